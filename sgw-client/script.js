@@ -4,14 +4,18 @@ document.getElementById('analysisForm').addEventListener('submit', async (e) =>
     const email = document.getElementById('email').value;
     const password = document.getElementById('password').value;
     const linkedinUrl = document.getElementById('linkedinUrl').value;
+    const jobUrl = document.getElementById('jobUrl').value;
     const errorMessage = document.getElementById('errorMessage');
     const loadingSpinner = document.getElementById('loadingSpinner');
     const resultsContainer = document.getElementById('results');
+    const verificationNotice = document.getElementById('verificationNotice');
 
     // Reset display
     errorMessage.style.display = 'none';
     loadingSpinner.style.display = 'block';
     resultsContainer.style.display = 'none';
+    verificationNotice.style.display = 'none';
+    const verificationPoll = setInterval(() => showVerification(email), 3000);
 
     try {
         const response = await fetch('http://localhost:3100/api/home', {
@@ -19,7 +23,7 @@ document.getElementById('analysisForm').addEventListener('submit', async (e) =>
             headers: {
                 'Content-Type': 'application/json'
             },
-            body: JSON.stringify({ email, password, linkedinUrl })
+            body: JSON.stringify({ email, password, linkedinUrl, jobUrl })
         });
 
         if (!response.ok) {
@@ -34,10 +38,34 @@ document.getElementById('analysisForm').addEventListener('submit', async (e) =>
         errorMessage.textContent = 'Analysis failed. Please try again.';
         console.error('Error:', error);
     } finally {
+        clearInterval(verificationPoll);
+        verificationNotice.style.display = 'none';
         loadingSpinner.style.display = 'none';
     }
 });
 
+// Links to a LinkedIn security check the login is waiting at, when the server streams it
+async function showVerification(email) {
+    const notice = document.getElementById('verificationNotice');
+    try {
+        const response = await fetch(`http://localhost:3100/api/verifications?email=${encodeURIComponent(email)}`);
+        if (!response.ok) return;
+        const data = await response.json();
+        if (data.verifications.length === 0) return;
+
+        notice.innerHTML = '';
+        const link = document.createElement('a');
+        link.href = data.verifications[0].url;
+        link.target = '_blank';
+        link.rel = 'noopener noreferrer';
+        link.textContent = 'solve it here';
+        notice.append('LinkedIn wants a security check before logging in, ', link, '.');
+        notice.style.display = 'block';
+    } catch (error) {
+        console.error('Error:', error);
+    }
+}
+
 function displayResults(data) {
     const resultsContainer = document.getElementById('results');
     const messageSection = document.getElementById('messageSection');
@@ -73,6 +101,7 @@ function displayResults(data) {
             postsSection.innerHTML += `
                 <div class="post-item">
                     <div class="post-content">${post.content}</div>
+                    <div class="post-meta">${postMeta(post)}</div>
                 </div>
             `;
         });
@@ -83,3 +112,16 @@ function displayResults(data) {
     // Show results container
     resultsContainer.style.display = 'block';
 }
+
+// Publish date, engagement and link of a post, as far as LinkedIn showed them
+function postMeta(post) {
+    const parts = [];
+    if (post.postedAt && !post.postedAt.startsWith('0001-')) {
+        parts.push(new Date(post.postedAt).toLocaleDateString());
+    }
+    parts.push(`${post.reactions || 0} reactions`, `${post.comments || 0} comments`);
+    if (post.url) {
+        parts.push(`<a href="${post.url}" target="_blank" rel="noopener">View on LinkedIn</a>`);
+    }
+    return parts.join(' · ');
+}
@@ -0,0 +1,243 @@
+// Command loadtest drives POST /api/home at increasing concurrency and reports latency
+// percentiles, throughput and the concurrency at which throughput stops scaling.
+//
+// By default it starts the server in-process with fake scraper and LLM backends, so no
+// browser, LinkedIn account or OpenAI key is needed:
+//
+//	go run ./cmd/loadtest -levels 1,2,4,8 -requests 20 -section 200ms -llm 300ms
+//
+// With -accounts N every request is sent as one of N warm LinkedIn accounts, which
+// serialises requests per account the way production warm sessions do.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/config"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+	"github.com/hemantsharma1498/segwise-assignment/server"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+// saturationGain is the throughput increase a doubling of concurrency must bring to count as still scaling.
+const saturationGain = 1.1
+
+type result struct {
+	latency time.Duration
+	ok      bool
+}
+
+type level struct {
+	concurrency int
+	p50         time.Duration
+	p95         time.Duration
+	p99         time.Duration
+	throughput  float64 // Successful requests per second
+	errors      int
+}
+
+func main() {
+	target := flag.String("target", "", "Base URL of a running server; empty starts one in-process with fake backends")
+	levels := flag.String("levels", "1,2,4,8", "Comma separated concurrency levels")
+	requests := flag.Int("requests", 20, "Requests sent per concurrency level")
+	accounts := flag.Int("accounts", 0, "Warm accounts requests are spread over, 0 logs in per request")
+	login := flag.Duration("login", 3*time.Second, "Fake LinkedIn login latency")
+	section := flag.Duration("section", 2*time.Second, "Fake latency per scraped profile section")
+	llm := flag.Duration("llm", 1500*time.Millisecond, "Fake OpenAI latency")
+	posts := flag.Int("posts", 5, "Posts on every fake profile, 0 keeps the canned ones, 2 or fewer also scrapes experience and education")
+	budget := flag.Duration("p95-budget", 30*time.Second, "p95 latency budget each level is checked against")
+	flag.Parse()
+
+	concurrencies, err := parseLevels(*levels)
+	if err != nil {
+		log.Fatalf("Invalid -levels, error: %s\n", err)
+	}
+
+	baseURL := *target
+	if baseURL == "" {
+		dir, err := os.MkdirTemp("", "segwise-loadtest")
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		ts, err := startServer(dir, fake.Backend{LoginLatency: *login, SectionLatency: *section, Posts: *posts}, &fake.LLM{Latency: *llm}, *accounts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer ts.Close()
+		baseURL = ts.URL
+	}
+
+	var report []level
+	for _, c := range concurrencies {
+		fmt.Fprintf(os.Stderr, "running %d requests at concurrency %d\n", *requests, c)
+		report = append(report, run(baseURL, c, *requests, *accounts))
+	}
+	if !printReport(report, *budget) {
+		os.Exit(1)
+	}
+}
+
+// startServer runs the API on a throwaway store with fake backends. Server logs are
+// discarded, they would drown the report.
+func startServer(dir string, backend fake.Backend, llm server.LLM, accounts int) (*httptest.Server, error) {
+	st, err := store.NewStore(filepath.Join(dir, "segwise.json"))
+	if err != nil {
+		return nil, err
+	}
+	log.SetOutput(io.Discard)
+
+	s := server.InitServer("loadtest", st)
+	s.NewScraper = func(email, password, url string) (server.Scraper, error) {
+		return backend.NewScraper(email, password, url)
+	}
+	s.LLM = llm
+	if accounts > 0 {
+		warm := make([]config.Account, 0, accounts)
+		for i := 0; i < accounts; i++ {
+			warm = append(warm, config.Account{Email: accountEmail(i), Password: "loadtest"})
+		}
+		s.WarmUp(warm, time.Hour)
+	}
+	return httptest.NewServer(s.Router), nil
+}
+
+func run(baseURL string, concurrency, requests, accounts int) level {
+	jobs := make(chan int)
+	results := make(chan result, requests)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results <- send(baseURL, i, accounts)
+			}
+		}()
+	}
+
+	start := time.Now()
+	for i := 0; i < requests; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	elapsed := time.Since(start)
+	close(results)
+
+	l := level{concurrency: concurrency}
+	latencies := make([]time.Duration, 0, requests)
+	for r := range results {
+		if !r.ok {
+			l.errors++
+			continue
+		}
+		latencies = append(latencies, r.latency)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	l.p50, l.p95, l.p99 = percentile(latencies, 50), percentile(latencies, 95), percentile(latencies, 99)
+	l.throughput = float64(len(latencies)) / elapsed.Seconds()
+	return l
+}
+
+func send(baseURL string, i, accounts int) result {
+	email := fmt.Sprintf("loadtest-%d@example.com", i)
+	if accounts > 0 {
+		email = accountEmail(i % accounts)
+	}
+	body, _ := json.Marshal(server.HomeReq{
+		Email:       email,
+		Password:    "loadtest",
+		LinkedinUrl: fmt.Sprintf("https://www.linkedin.com/in/load-test-%d/", i),
+	})
+
+	start := time.Now()
+	res, err := http.Post(baseURL+"/api/home", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return result{}
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+	return result{latency: time.Since(start), ok: res.StatusCode == http.StatusOK}
+}
+
+// printReport writes the report table and reports whether every level met the p95 budget.
+func printReport(report []level, budget time.Duration) bool {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "concurrency\tp50\tp95\tp99\treq/s\terrors\tp95 budget")
+	withinBudget := true
+	for _, l := range report {
+		verdict := "ok"
+		if l.p95 > budget || l.errors > 0 {
+			verdict = "FAIL"
+			withinBudget = false
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%.2f\t%d\t%s\n", l.concurrency, round(l.p50), round(l.p95), round(l.p99), l.throughput, l.errors, verdict)
+	}
+	tw.Flush()
+
+	if c, ok := saturation(report); ok {
+		fmt.Printf("\nthroughput stops scaling at concurrency %d\n", c)
+	} else {
+		fmt.Printf("\nthroughput still scaling at concurrency %d\n", report[len(report)-1].concurrency)
+	}
+	return withinBudget
+}
+
+// saturation returns the first concurrency level that did not raise throughput by saturationGain
+// over the previous one.
+func saturation(report []level) (int, bool) {
+	for i := 1; i < len(report); i++ {
+		if report[i].throughput < report[i-1].throughput*saturationGain {
+			return report[i].concurrency, true
+		}
+	}
+	return 0, false
+}
+
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := (len(sorted)*p+99)/100 - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+func parseLevels(s string) ([]int, error) {
+	var levels []int
+	for _, v := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid concurrency %q", v)
+		}
+		levels = append(levels, n)
+	}
+	return levels, nil
+}
+
+func accountEmail(i int) string {
+	return fmt.Sprintf("warm-%d@example.com", i)
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(time.Millisecond)
+}
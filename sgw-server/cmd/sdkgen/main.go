@@ -0,0 +1,40 @@
+// Command sdkgen writes the Go client of the server's API and the OpenAPI document it is
+// generated from, for clients in other languages. make sdk runs it.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/openapi"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/payload"
+	"github.com/hemantsharma1498/segwise-assignment/server"
+)
+
+func main() {
+	out := flag.String("o", "client", "Directory to write the client package to")
+	flag.Parse()
+
+	doc := server.OpenAPI()
+	src, err := openapi.GoClient(doc, openapi.GoOptions{Package: filepath.Base(*out), VersionHeader: payload.Header})
+	if err != nil {
+		log.Fatalf("Failed to generate the client, error: %s\n", err)
+	}
+	spec, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to write the OpenAPI document, error: %s\n", err)
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(*out, "client.go"), src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(*out, "openapi.json"), append(spec, '\n'), 0o644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Wrote %s and its OpenAPI document to %s\n", filepath.Join(*out, "client.go"), *out)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/client"
+	"github.com/hemantsharma1498/segwise-assignment/server"
+)
+
+const apiUsage = `Usage:
+  segwise api [-url URL] health                 Whether the server can read its store
+  segwise api [-url URL] ready                  Whether the server is warmed up and takes traffic
+  segwise api [-url URL] drain                  Stop the server taking on work and wait for its jobs, a preStop hook
+  segwise api [-url URL] profiles <email>       The user's prospects
+  segwise api [-url URL] batch <id> <email>     A batch and the prospects it scraped
+  segwise api [-url URL] cooldown <email>       The cooldown the user's LinkedIn account is in
+  segwise api [-url URL] end-cooldown <email>   Lift the account's cooldown early
+  segwise api [-url URL] reload <staff email>   Reload the selectors and message prompt files
+The server is at PUBLIC_BASE_URL unless -url says otherwise. Staff commands send SUPPORT_TOKEN.`
+
+// api calls a running server through the generated client, for operators scripting
+// against it without a browser. It needs no store, so it runs next to the server.
+func api(args []string, baseURL string) error {
+	flags := flag.NewFlagSet("api", flag.ContinueOnError)
+	flags.StringVar(&baseURL, "url", baseURL, "Where the server is")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), apiUsage)
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	c := client.New(baseURL)
+	if token := os.Getenv("SUPPORT_TOKEN"); token != "" {
+		c.HTTPClient = &http.Client{Transport: withHeader{server.SupportTokenHeader, token}}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	args = flags.Args()
+	var res any
+	var err error
+	switch {
+	case len(args) == 1 && args[0] == "health":
+		res, err = c.Health(ctx)
+	case len(args) == 1 && args[0] == "ready":
+		res, err = c.Ready(ctx)
+	case len(args) == 1 && args[0] == "drain":
+		res, err = c.Drain(ctx)
+	case len(args) == 2 && args[0] == "profiles":
+		res, err = c.ListProfiles(ctx, args[1])
+	case len(args) == 3 && args[0] == "batch":
+		res, err = c.GetBatch(ctx, args[1], args[2])
+	case len(args) == 2 && args[0] == "cooldown":
+		res, err = c.GetCooldown(ctx, args[1])
+	case len(args) == 2 && args[0] == "end-cooldown":
+		res, err = c.EndCooldown(ctx, args[1])
+	case len(args) == 2 && args[0] == "reload":
+		res, err = c.Reload(ctx, args[1])
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown api command %q", strings.Join(args, " "))
+	}
+	var apiErr *client.Error
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return fmt.Errorf("%w, retry in %s", err, apiErr.RetryAfter)
+	}
+	if err != nil {
+		return err
+	}
+	return printJSON(os.Stdout, res)
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	// Page scripts are full of =>, which would otherwise print as \u003e
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// withHeader sets a header on every request it sends.
+type withHeader struct{ name, value string }
+
+func (h withHeader) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(h.name, h.value)
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -1,24 +1,483 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/hemantsharma1498/segwise-assignment/config"
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/backup"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/breaker"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/enrich"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/ocr"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/parser"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/redact"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/sharelink"
 	"github.com/hemantsharma1498/segwise-assignment/server"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+	"io"
 	"log"
+	"net/url"
 	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
 )
 
 func main() {
+	// MIGRATE_ONLY lets a Kubernetes Job share the Deployment's ConfigMap and command
+	migrateOnly := flag.Bool("migrate-only", os.Getenv("MIGRATE_ONLY") == "true", "Migrate the store to the current schema version and exit, or set MIGRATE_ONLY=true")
+	flag.Parse()
+
+	cfg, err := config.Load(os.Getenv)
+	if err != nil {
+		log.Panicf("Invalid configuration:\n%s\n", err)
+	}
+
+	redactor := redact.New(append(redact.DefaultKeys, cfg.LogRedactKeys...), cfg.OpenAIApiKey, cfg.ShareLinkSecret, cfg.SupportToken)
+	for _, a := range cfg.Accounts {
+		redactor.AddSecrets(a.Password)
+	}
+	for _, token := range cfg.ReviewerTokens {
+		redactor.AddSecrets(token)
+	}
+	if cfg.GitHubToken != "" {
+		redactor.AddSecrets(cfg.GitHubToken)
+	}
+	if cfg.BackupPassphrase != "" {
+		redactor.AddSecrets(cfg.BackupPassphrase)
+	}
+	if dsn, err := url.Parse(cfg.StoreDSN); err == nil {
+		if password, ok := dsn.User.Password(); ok {
+			redactor.AddSecrets(password)
+		}
+	}
+	for _, proxy := range cfg.Proxies {
+		if password, ok := proxy.User.Password(); ok {
+			redactor.AddSecrets(password)
+		}
+	}
+	log.SetOutput(redactor.Writer(os.Stderr))
 	log.Printf("Initialising service")
+	if cfg.Env != "" {
+		log.Printf("Using %s profile\n", cfg.Env)
+	}
+
+	// segwise api talks to a running server, which holds the store
+	if args := flag.Args(); len(args) > 0 && args[0] == "api" {
+		if err := api(args[1:], cfg.PublicBaseURL); err != nil {
+			log.Panicf("api failed, error: %s\n", err)
+		}
+		return
+	}
+	// segwise parse reads saved pages alone
+	if args := flag.Args(); len(args) > 0 && args[0] == "parse" {
+		if err := parse(args[1:]); err != nil {
+			log.Panicf("parse failed, error: %s\n", err)
+		}
+		return
+	}
+	// segwise pages prints the built-in page scripts and selectors to start a SELECTORS_FILE from
+	if args := flag.Args(); len(args) > 0 && args[0] == "pages" {
+		if err := printJSON(os.Stdout, scraper.DefaultPages()); err != nil {
+			log.Panicf("pages failed, error: %s\n", err)
+		}
+		return
+	}
+
+	st, err := store.Open(cfg.StoreDSN)
+	if err != nil {
+		log.Panicf("Failed to open store, error: %s\n", err)
+	}
+	defer st.Close()
+	report, err := st.Migrate()
+	if err != nil {
+		log.Panicf("Failed to migrate store, error: %s\n", err)
+	}
+	for _, m := range report.Ran {
+		log.Printf("Ran store migration %d: %s\n", m.Version, m.Description)
+	}
+	if report.Profiles > 0 {
+		log.Printf("Upgraded %d stored profiles to schema version %d\n", report.Profiles, scraper.ProfileSchemaVersion)
+	}
+	if *migrateOnly {
+		log.Printf("Store is at schema version %d\n", report.To)
+		return
+	}
+
+	// segwise backup, segwise restore and segwise admin work on the store and exit
+	if args := flag.Args(); len(args) > 0 {
+		if err := command(args[0], args[1:], cfg, st); err != nil {
+			log.Panicf("%s failed, error: %s\n", args[0], err)
+		}
+		return
+	}
+
+	scraper.Headless = cfg.Headless
+	scraper.CaptureDir = cfg.FixtureCaptureDir
+	scraper.FailureDir = cfg.FailureDir
+	scraper.SnapshotDir = cfg.SnapshotDir
+	scraper.MaxListEntries = cfg.ScrapeMaxEntries
+	scraper.Rate = cfg.ScrapeRate
+	// Counted in the store so restarts and the other instances keep to the caps
+	scraper.ScrapeCounts = st
+	scraper.ExecPath = cfg.ChromePath
+	scraper.RemoteURL = cfg.ChromeRemoteURL
+	scraper.UserDataDir = cfg.ChromeUserDataDir
+	scraper.Limits.MaxMemoryMB = cfg.ChromeMaxMemoryMB
+	scraper.Limits.MaxRendererProcesses = cfg.ChromeRendererLimit
+	if !cfg.HumanBehavior {
+		// Fixed waits, for debugging selectors without the randomness
+		scraper.Human = scraper.Behavior{DwellMin: 2 * time.Second, DwellMax: 2 * time.Second}
+	}
+	scraper.Fingerprinting = cfg.Fingerprints
+	if len(cfg.Proxies) > 0 {
+		scraper.Proxies = scraper.NewProxyPool(cfg.Proxies)
+	}
+	if scraper.OCR, err = ocr.New(cfg.OCREngine, cfg.OCRLanguages); err != nil {
+		log.Panicf("Failed to set up OCR, error: %s\n", err)
+	}
+	stopReaper := scraper.StartReaper(30 * time.Second)
+	defer stopReaper()
+
+	s := server.InitServer(cfg.OpenAIApiKey, st)
+	if cfg.ScraperProvider == config.ScraperFake {
+		// Short delays keep the frontend's loading states visible
+		backend := fake.Backend{LoginLatency: time.Second, SectionLatency: 300 * time.Millisecond, Profiles: cfg.FixtureProfiles}
+		s.NewScraper = func(email, password, url string) (server.Scraper, error) {
+			return backend.NewScraper(email, password, url)
+		}
+		s.PublicScraper = func(url string) server.Scraper {
+			sc, _ := backend.NewScraper("", "", url)
+			return sc
+		}
+		s.RestoreScraper = func(_ *scraper.Session, email, password, url string) (server.Scraper, error) {
+			return backend.NewScraper(email, password, url)
+		}
+	}
+	if cfg.LLMProvider == config.LLMFake {
+		s.LLM = &fake.LLM{Latency: 500 * time.Millisecond}
+	}
+	s.PersonaLLMAssist = cfg.PersonaLLMAssist
+	s.ScoringWeights = cfg.ScoringWeights
+	if cfg.ScrapeBudget > 0 {
+		s.ScrapeBudget = cfg.ScrapeBudget
+	}
+	s.PromptBudget = cfg.PromptBudget
+	s.PostMaxAge, s.PostRanking = cfg.PostMaxAge, cfg.PostRanking
+	s.MinSignals = cfg.MinSignals
+	s.SaveSessions = cfg.SaveSessions
+	if cfg.ScrapeFallbacks != nil {
+		s.Degradation.Fallbacks = cfg.ScrapeFallbacks
+	}
+	s.PublicBaseURL = cfg.PublicBaseURL
+	if cfg.ShareLinkSecret != "" {
+		s.ShareLinks = sharelink.New([]byte(cfg.ShareLinkSecret))
+	}
+	if cfg.AccountCooldown > 0 {
+		s.AccountCooldown = cfg.AccountCooldown
+	}
+	if cfg.RateLimitCooldown > 0 {
+		s.RateLimitCooldown = cfg.RateLimitCooldown
+	}
+	breakerCooldown := breaker.DefaultCooldown
+	if cfg.BreakerCooldown > 0 {
+		breakerCooldown = cfg.BreakerCooldown
+	}
+	s.Breakers = breaker.NewSet(cfg.BreakerThreshold, breakerCooldown)
+	s.MaxQueueDepth, s.MaxBrowsers = cfg.MaxQueueDepth, cfg.MaxBrowsers
+	s.ValidateResponses = cfg.ValidateResponses
+	if cfg.ShareLinkTTL > 0 {
+		s.ShareLinkTTL = cfg.ShareLinkTTL
+	}
+	s.RequireApproval = cfg.RequireApproval
+	s.Reviewers, s.ReviewerTokens = cfg.Reviewers, cfg.ReviewerTokens
+	s.SupportStaff, s.SupportToken = cfg.SupportStaff, cfg.SupportToken
+	s.NativeLanguageMessages = cfg.NativeLanguage
+	s.LLMPrices = cfg.LLMPrices
+	// Websites are summarized by whichever LLM writes the messages
+	if s.Enrichment.Sources, err = enrich.New(cfg.EnrichSources, cfg.GitHubToken, s.SummarizeWebsite); err != nil {
+		log.Panicf("Failed to set up enrichment sources, error: %s\n", err)
+	}
+	s.Enrichment.Timeouts, s.Enrichment.Budget = cfg.EnrichTimeouts, cfg.EnrichBudget
+	s.Teams = cfg.Teams
+	s.WebhookURL = cfg.WebhookURL
+	s.SlackWebhookURL = cfg.SlackWebhookURL
+	s.AccountAlertURL = cfg.AccountAlertURL
+	s.WebhookPayloadVersion, s.AccountAlertPayloadVersion = cfg.WebhookVersion, cfg.AccountAlertVersion
+	if cfg.SMTPAddr != "" {
+		s.Notifiers[models.DestinationEmail] = &server.EmailNotifier{Addr: cfg.SMTPAddr, From: cfg.SMTPFrom, Username: cfg.SMTPUsername, Password: cfg.SMTPPassword}
+	}
+	if cfg.NotifyRoutes != nil {
+		s.NotifyRoutes = cfg.NotifyRoutes
+	}
+	s.TeamNotifyRoutes = cfg.TeamNotifyRoutes
+	if cfg.RemoteVerification {
+		scraper.RemoteVerification = func(v *scraper.Verification) {
+			s.StartVerification(v.Email, v.ExpiresAt, v)
+		}
+	}
+	if cfg.VerificationTimeout > 0 {
+		scraper.VerificationTimeout = cfg.VerificationTimeout
+	}
+	var inbox scraper.OTPProvider
+	if cfg.OTPInbox {
+		inbox = scraper.OTPFunc(s.OTPCode)
+	}
+	if len(cfg.OTPSecrets) > 0 || inbox != nil {
+		// Secrets answer without anyone's help, so they go first
+		scraper.OTP = scraper.OTPProviders(scraper.TOTP(cfg.OTPSecrets), inbox)
+	}
+	s.OTPSMSSecret, s.OTPPhones = cfg.OTPSMSSecret, cfg.OTPPhones
+	// Applied before the warm-up, whose logins and pings run the page scripts
+	s.SelectorsFile, s.PromptFile = cfg.SelectorsFile, cfg.PromptFile
+	if _, err := s.Reload(); err != nil {
+		log.Panicf("Failed to load selectors and prompt, error: %s\n", err)
+	}
+	stopReload := s.ReloadOnHangup()
+	defer stopReload()
+	if cfg.DrainTimeout > 0 {
+		s.DrainTimeout = cfg.DrainTimeout
+	}
+	// Listening through the warm-up answers liveness probes, /api/ready fails until MarkReady
+	stopped := make(chan struct{})
+	go func() {
+		if err := s.Start(cfg.Port); err != nil {
+			log.Panicf("Failed to initialise server at %s, error: %s\n", cfg.Port, err)
+		}
+		close(stopped)
+	}()
+	// Warmed up once the alert destinations and remote verification are set, so failed logins are reported
+	if len(cfg.Accounts) > 0 {
+		pingInterval := 10 * time.Minute
+		if cfg.WarmPingInterval > 0 {
+			pingInterval = cfg.WarmPingInterval
+		}
+		s.WarmUp(cfg.Accounts, pingInterval)
+	}
+	if err := s.RecoverInterrupted(); err != nil {
+		log.Panicf("Failed to recover interrupted batches, error: %s\n", err)
+	}
+	// The relay also runs without destinations so entries queued under an old config are drained
+	stopRelay := s.StartRelay(10 * time.Second)
+	defer stopRelay()
 
-	OpenAIApiKey := os.Getenv("OPENAI_API_KEY")
-	if OpenAIApiKey == "" {
-		log.Panic("Couldn't find OpenAI API key")
+	if cfg.DriftCheckURL != "" {
+		interval := 24 * time.Hour
+		if cfg.DriftCheckInterval > 0 {
+			interval = cfg.DriftCheckInterval
+		}
+		stopDriftCheck := s.StartDriftCheck(cfg.Accounts[0], cfg.DriftCheckURL, cfg.DriftExpectations, interval)
+		defer stopDriftCheck()
 	}
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "3100"
+
+	if cfg.BackupInterval > 0 {
+		stopBackups := s.StartBackups(cfg.BackupDir, cfg.BackupPassphrase, cfg.BackupInterval, cfg.BackupKeep)
+		defer stopBackups()
+	}
+
+	if cfg.MemoryPressurePercent > 0 {
+		stopPressureWatch := s.StartPressureWatch(float64(cfg.MemoryPressurePercent)/100, 15*time.Second)
+		defer stopPressureWatch()
+	}
+
+	s.MarkReady()
+	<-stopped
+}
+
+// parse prints the profile pkg/parser extracts from a profile's directory under SNAPSHOT_DIR.
+func parse(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: segwise parse <snapshot dir>")
+	}
+	profile, err := parser.ParseDir(args[0])
+	if err != nil {
+		// What could be parsed is still worth printing
+		log.Printf("Some pages could not be parsed: %v\n", err)
+	}
+	return printJSON(os.Stdout, profile)
+}
+
+// command runs the backup, restore or admin command with its arguments.
+func command(name string, args []string, cfg *config.Config, st *store.Store) error {
+	if name == "admin" {
+		return admin(args, cfg, st)
+	}
+	if name != "backup" && name != "restore" {
+		return fmt.Errorf("unknown command %q, expected backup, restore, admin, api, parse or pages", name)
+	}
+	if cfg.BackupPassphrase == "" {
+		return errors.New("set BACKUP_PASSPHRASE to the passphrase backups are encrypted with")
+	}
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	if name == "backup" {
+		out := flags.String("o", "", "File to write the backup to, instead of a new one in BACKUP_DIR")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		return backUp(cfg, st, *out)
+	}
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: segwise restore <backup file>")
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("restore needs the backup file to restore")
+	}
+	return restore(cfg, st, flags.Arg(0))
+}
+
+// backUp writes an encrypted backup of the store to out, or to a new file in BACKUP_DIR.
+func backUp(cfg *config.Config, st *store.Store, out string) error {
+	snapshot, err := st.Snapshot()
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		if out, err = backup.Write(cfg.BackupDir, snapshot, cfg.BackupPassphrase, time.Now()); err != nil {
+			return err
+		}
+	} else {
+		sealed, err := backup.Seal(snapshot, cfg.BackupPassphrase)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(out, sealed, 0o600); err != nil {
+			return err
+		}
+	}
+	log.Printf("Backed up store to %s\n", out)
+	return nil
+}
+
+// restore replaces the store with the backup at path, backing up what it replaces first
+// so a restore of the wrong file can be undone.
+func restore(cfg *config.Config, st *store.Store, path string) error {
+	sealed, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	snapshot, err := backup.Open(sealed, cfg.BackupPassphrase)
+	if err != nil {
+		return err
+	}
+	current, err := st.Snapshot()
+	if err != nil {
+		return err
+	}
+	saved, err := backup.Write(cfg.BackupDir, current, cfg.BackupPassphrase, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to back up the store before restoring: %w", err)
+	}
+	log.Printf("Backed up the current store to %s\n", saved)
+	if err := st.Restore(snapshot); err != nil {
+		return err
+	}
+	log.Printf("Restored store from %s\n", path)
+	return nil
+}
+
+const adminUsage = `Usage:
+  segwise admin list-linkedin-accounts  List the LinkedIn accounts the server knows, with their saved session, cooldown and use
+  segwise admin reset-password <email>  Forget the account's saved session and browser profile after its LinkedIn password was changed`
+
+// admin runs an admin subcommand, for operators managing accounts without the web UI.
+func admin(args []string, cfg *config.Config, st *store.Store) error {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, adminUsage)
+		return errors.New("admin needs a subcommand")
+	}
+	switch {
+	case args[0] == "list-linkedin-accounts" && len(args) == 1:
+		return listAccounts(os.Stdout, cfg, st)
+	case args[0] == "reset-password" && len(args) == 2:
+		return resetPassword(st, cfg.ChromeUserDataDir, args[1])
+	}
+	fmt.Fprintln(os.Stderr, adminUsage)
+	return fmt.Errorf("unknown admin command %q", strings.Join(args, " "))
+}
+
+// listAccounts writes a table of the accounts in LINKEDIN_ACCOUNTS and those with a saved
+// session: when the session was saved, until when the account is cooling down and whether
+// a request is using it. Passwords and cookies are never shown.
+func listAccounts(w io.Writer, cfg *config.Config, st *store.Store) error {
+	sessions, err := st.ListSessions()
+	if err != nil {
+		return err
+	}
+	saved := map[string]time.Time{}
+	var emails []string
+	for _, a := range cfg.Accounts {
+		emails = append(emails, strings.ToLower(a.Email))
+	}
+	for _, session := range sessions {
+		email := strings.ToLower(session.Email)
+		saved[email] = session.SavedAt
+		emails = append(emails, email)
+	}
+	slices.Sort(emails)
+	emails = slices.Compact(emails)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ACCOUNT\tCONFIGURED\tSESSION SAVED\tCOOLDOWN UNTIL\tIN USE")
+	for _, email := range emails {
+		configured := slices.ContainsFunc(cfg.Accounts, func(a config.Account) bool { return strings.EqualFold(a.Email, email) })
+		session := "-"
+		if at, ok := saved[email]; ok {
+			session = at.Local().Format(time.DateTime)
+		}
+		cooldown := "-"
+		c, err := st.ActiveCooldown(email)
+		if err == nil {
+			cooldown = c.Until.Local().Format(time.DateTime) + " (" + c.Reason + ")"
+		} else if !errors.Is(err, store.ErrNotFound) {
+			return err
+		}
+		// Requests hold the account's lease while they use it
+		_, err = st.ActiveLease("account:" + email)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			return err
+		}
+		inUse := err == nil
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", email, yesNo(configured), session, cooldown, yesNo(inUse))
+	}
+	return tw.Flush()
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// resetPassword forgets the account's saved session and its browser profile under
+// userDataDir. Passwords are never stored, but the
+// session is sealed with the one it was saved under: after a password change, e.g. because
+// the old one leaked, whoever knows the old password could still open it. The next request
+// logs in with the new password and saves a new session.
+func resetPassword(st *store.Store, userDataDir, email string) error {
+	// The browser profile is logged in as well
+	if dir := scraper.AccountDataDir(userDataDir, email); dir != "" {
+		if _, err := os.Stat(dir); err == nil {
+			if err := os.RemoveAll(dir); err != nil {
+				return err
+			}
+			log.Printf("Removed the browser profile of %s\n", email)
+		}
 	}
-	s := server.InitServer(OpenAIApiKey)
-	if err := s.Start(port); err != nil {
-		log.Panicf("Failed to initialise server at %s, error: %s\n", port, err)
+	if err := st.DeleteSession(email); errors.Is(err, store.ErrNotFound) {
+		log.Printf("%s has no saved session, nothing to reset\n", email)
+		return nil
+	} else if err != nil {
+		return err
 	}
+	log.Printf("Forgot the saved session of %s, its next request logs in with the new password\n", email)
+	return nil
 }
@@ -0,0 +1,283 @@
+package config
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/relevance"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestParseTeams(t *testing.T) {
+	teams, err := ParseTeams("growth=a@x.com, b@x.com;sales=c@x.com;")
+	if err != nil {
+		t.Fatalf("ParseTeams: %v", err)
+	}
+	if len(teams) != 2 || teams[0].Name != "growth" || len(teams[0].Members) != 2 || teams[1].Members[0] != "c@x.com" {
+		t.Fatalf("ParseTeams = %+v", teams)
+	}
+
+	for _, bad := range []string{"growth", "=a@x.com", "growth=", "growth=not-an-email", "growth=a@x.com;Growth=b@x.com"} {
+		if _, err := ParseTeams(bad); err == nil {
+			t.Errorf("ParseTeams(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestParseRoutes(t *testing.T) {
+	routes, err := ParseRoutes("account.*=slack, email; batch.done=none;*=webhook;")
+	if err != nil {
+		t.Fatalf("ParseRoutes: %v", err)
+	}
+	if len(routes) != 3 || len(routes[0].Channels) != 2 || routes[0].Channels[1] != models.DestinationEmail || routes[1].Channels != nil {
+		t.Fatalf("ParseRoutes = %+v", routes)
+	}
+	if !routes[0].Matches(models.EventAccountOTPRequired) || routes[0].Matches(models.EventBatchDone) || !routes[2].Matches(models.EventSelectorDrift) {
+		t.Errorf("routes match the wrong events: %+v", routes)
+	}
+
+	for _, bad := range []string{"slack", "=slack", "batch.started=slack", "batch.done=pager", "batch.done="} {
+		if _, err := ParseRoutes(bad); err == nil {
+			t.Errorf("ParseRoutes(%q) succeeded, want an error", bad)
+		}
+	}
+
+	teams, err := ParseTeamRoutes("Growth:account.*=slack;*=email | sales:*=none")
+	if err != nil {
+		t.Fatalf("ParseTeamRoutes: %v", err)
+	}
+	if len(teams) != 2 || len(teams["growth"]) != 2 || len(teams["sales"]) != 1 {
+		t.Fatalf("ParseTeamRoutes = %+v", teams)
+	}
+	for _, bad := range []string{"growth", ":*=slack", "growth:*=pager", "growth:*=slack|GROWTH:*=email"} {
+		if _, err := ParseTeamRoutes(bad); err == nil {
+			t.Errorf("ParseTeamRoutes(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestParseOTPSecrets(t *testing.T) {
+	secrets, err := ParseOTPSecrets("A@x.com=JBSW Y3DP EHPK 3PXP; b@x.com = gezdgnbvgy3tqojq;")
+	if err != nil {
+		t.Fatalf("ParseOTPSecrets: %v", err)
+	}
+	if len(secrets) != 2 || secrets["a@x.com"] != "JBSW Y3DP EHPK 3PXP" || secrets["b@x.com"] != "gezdgnbvgy3tqojq" {
+		t.Fatalf("ParseOTPSecrets = %v", secrets)
+	}
+
+	for _, bad := range []string{"a@x.com", "a@x.com=", "not-an-email=JBSWY3DPEHPK3PXP", "a@x.com=not base32!"} {
+		if _, err := ParseOTPSecrets(bad); err == nil {
+			t.Errorf("ParseOTPSecrets(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestParseEnrichTimeouts(t *testing.T) {
+	timeouts, err := ParseEnrichTimeouts("github=5s, news = 2s,")
+	if err != nil {
+		t.Fatalf("ParseEnrichTimeouts: %v", err)
+	}
+	if len(timeouts) != 2 || timeouts["github"] != 5*time.Second || timeouts["news"] != 2*time.Second {
+		t.Fatalf("ParseEnrichTimeouts = %v", timeouts)
+	}
+
+	for _, bad := range []string{"github", "myspace=5s", "github=soon", "website=-1s"} {
+		if _, err := ParseEnrichTimeouts(bad); err == nil {
+			t.Errorf("ParseEnrichTimeouts(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestAccounts(t *testing.T) {
+	got, err := accounts("a@x.com:one; b@x.com:two:three;")
+	if err != nil {
+		t.Fatalf("accounts: %v", err)
+	}
+	if len(got) != 2 || got[1].Email != "b@x.com" || got[1].Password != "two:three" {
+		t.Fatalf("accounts = %+v", got)
+	}
+
+	_, err = accounts("a@x.com;nope:secret")
+	if err == nil {
+		t.Fatal("accounts accepted entries without a password or a valid email")
+	}
+	if msg := err.Error(); msg != "LINKEDIN_ACCOUNTS entry 1 has no password, expected email:password\nLINKEDIN_ACCOUNTS entry 2 does not start with a valid email" {
+		t.Errorf("error = %q", msg)
+	}
+}
+
+func TestLoadFixtureDir(t *testing.T) {
+	env := map[string]string{
+		"DEMO_MODE":   "true",
+		"DATA_DIR":    t.TempDir(),
+		"FIXTURE_DIR": "../pkg/fake/testdata/fixtures",
+	}
+	cfg, err := Load(func(name string) string { return env[name] })
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(cfg.FixtureProfiles) != 1 || cfg.FixtureProfiles[0].Name != "Alex Sample" {
+		t.Fatalf("FixtureProfiles = %+v", cfg.FixtureProfiles)
+	}
+
+	env["FIXTURE_DIR"] = t.TempDir()
+	if _, err := Load(func(name string) string { return env[name] }); err == nil {
+		t.Error("Load accepted a FIXTURE_DIR without captured profiles")
+	}
+}
+
+func TestChromeRemoteURL(t *testing.T) {
+	env := map[string]string{
+		"DATA_DIR":          t.TempDir(),
+		"LLM_PROVIDER":      LLMFake,
+		"CHROME_REMOTE_URL": "ws://chrome:3000",
+	}
+	t.Setenv("PATH", "")
+	cfg, err := Load(func(name string) string { return env[name] })
+	if err != nil {
+		t.Fatalf("Load without a local Chrome: %v", err)
+	}
+	if cfg.ChromeRemoteURL != "ws://chrome:3000" || cfg.ChromePath != "" {
+		t.Errorf("ChromeRemoteURL = %q, ChromePath = %q", cfg.ChromeRemoteURL, cfg.ChromePath)
+	}
+
+	env["CHROME_REMOTE_URL"] = "chrome:3000"
+	if _, err := Load(func(name string) string { return env[name] }); err == nil {
+		t.Error("Load accepted a CHROME_REMOTE_URL without a scheme")
+	}
+}
+
+func TestFingerprints(t *testing.T) {
+	env := map[string]string{}
+	getenv := func(name string) string { return env[name] }
+	if f, err := fingerprints(getenv); f != nil || err != nil {
+		t.Errorf("without FINGERPRINTS = %+v, %v, want none", f, err)
+	}
+	env["FINGERPRINT_TIMEZONES"] = "Europe/London"
+	if _, err := fingerprints(getenv); err == nil {
+		t.Error("accepted a pool without FINGERPRINTS=true")
+	}
+
+	env["FINGERPRINTS"] = "true"
+	env["FINGERPRINT_USER_AGENTS"] = "Mozilla/5.0 (X11; Linux x86_64) Chrome/130.0.0.0, like this | Mozilla/5.0 (Macintosh) Chrome/129.0.0.0"
+	env["FINGERPRINT_VIEWPORTS"] = "1366x768, 1920x1080"
+	f, err := fingerprints(getenv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.UserAgents) != 2 || f.UserAgents[0] != "Mozilla/5.0 (X11; Linux x86_64) Chrome/130.0.0.0, like this" {
+		t.Errorf("UserAgents = %q, want them split on |", f.UserAgents)
+	}
+	if len(f.Viewports) != 2 || f.Viewports[0] != (scraper.Viewport{Width: 1366, Height: 768}) {
+		t.Errorf("Viewports = %+v", f.Viewports)
+	}
+	if len(f.Timezones) != 1 || len(f.Languages) != len(scraper.DefaultFingerprints.Languages) {
+		t.Errorf("Timezones = %q, Languages = %q, want the override and the defaults", f.Timezones, f.Languages)
+	}
+
+	for name, bad := range map[string]string{
+		"FINGERPRINT_VIEWPORTS": "1366*768",
+		"FINGERPRINT_LANGUAGES": "de-DE",
+		"FINGERPRINT_TIMEZONES": "Mars/Olympus",
+	} {
+		env := maps.Clone(env)
+		env[name] = bad
+		if _, err := fingerprints(func(name string) string { return env[name] }); err == nil {
+			t.Errorf("accepted %s=%s", name, bad)
+		}
+	}
+}
+
+func TestPostOptions(t *testing.T) {
+	env := map[string]string{
+		"DATA_DIR":          t.TempDir(),
+		"LLM_PROVIDER":      LLMFake,
+		"CHROME_REMOTE_URL": "ws://chrome:3000",
+	}
+	cfg, err := Load(func(name string) string { return env[name] })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.PostMaxAge != 0 || cfg.PostRanking != relevance.RankKeyword || cfg.MinSignals != 2 {
+		t.Errorf("defaults = %v, %q, %d, want every post ranked by keywords and two signals", cfg.PostMaxAge, cfg.PostRanking, cfg.MinSignals)
+	}
+
+	env["POST_MAX_AGE_DAYS"], env["POST_RANKING"], env["MIN_SIGNALS"] = "90", relevance.RankEmbedding, "0"
+	if cfg, err = Load(func(name string) string { return env[name] }); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.PostMaxAge != 90*24*time.Hour || cfg.PostRanking != relevance.RankEmbedding || cfg.MinSignals != 0 {
+		t.Errorf("PostMaxAge = %v, PostRanking = %q, MinSignals = %d", cfg.PostMaxAge, cfg.PostRanking, cfg.MinSignals)
+	}
+
+	env["POST_RANKING"] = "semantic"
+	if _, err := Load(func(name string) string { return env[name] }); err == nil {
+		t.Error("Load accepted POST_RANKING=semantic")
+	}
+}
+
+func TestChromeUserDataDir(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := filepath.Rel(wd, filepath.Join(t.TempDir(), "chrome-profiles"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{
+		"DATA_DIR":             t.TempDir(),
+		"LLM_PROVIDER":         LLMFake,
+		"SCRAPER_PROVIDER":     ScraperFake,
+		"CHROME_USER_DATA_DIR": dir,
+	}
+	cfg, err := Load(func(name string) string { return env[name] })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !filepath.IsAbs(cfg.ChromeUserDataDir) || filepath.Base(cfg.ChromeUserDataDir) != "chrome-profiles" {
+		t.Errorf("ChromeUserDataDir = %q, want it absolute", cfg.ChromeUserDataDir)
+	}
+
+	env["CHROME_REMOTE_URL"] = "ws://chrome:3000"
+	if _, err := Load(func(name string) string { return env[name] }); err == nil {
+		t.Error("Load accepted a CHROME_USER_DATA_DIR for a remote browser")
+	}
+}
+
+func TestSecretFiles(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "openai-api-key")
+	if err := os.WriteFile(secret, []byte("sk-from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{
+		"DATA_DIR":            t.TempDir(),
+		"SCRAPER_PROVIDER":    ScraperFake,
+		"OPENAI_API_KEY_FILE": secret,
+	}
+	cfg, err := Load(func(name string) string { return env[name] })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.OpenAIApiKey != "sk-from-file" || cfg.MemoryPressurePercent != 85 {
+		t.Errorf("OpenAIApiKey = %q, MemoryPressurePercent = %d", cfg.OpenAIApiKey, cfg.MemoryPressurePercent)
+	}
+
+	// The variable itself wins over its file
+	env["OPENAI_API_KEY"] = "sk-from-env"
+	if cfg, err = Load(func(name string) string { return env[name] }); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.OpenAIApiKey != "sk-from-env" {
+		t.Errorf("OpenAIApiKey = %q, want the variable's", cfg.OpenAIApiKey)
+	}
+	env["SHARE_LINK_SECRET_FILE"] = filepath.Join(t.TempDir(), "missing")
+	env["MEMORY_PRESSURE_PERCENT"] = "120"
+	if _, err := Load(func(name string) string { return env[name] }); err == nil {
+		t.Error("Load accepted a missing SHARE_LINK_SECRET_FILE and MEMORY_PRESSURE_PERCENT over 100")
+	}
+}
@@ -0,0 +1,300 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/enrich"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+// Account is a LinkedIn login the server authenticates at startup.
+type Account struct {
+	Email    string
+	Password string
+}
+
+// Team is a group of users who can see each other's activity.
+type Team struct {
+	Name    string
+	Members []string
+}
+
+// ParseTeams parses TEAMS, a ";" separated list of name=email,email entries.
+func ParseTeams(s string) ([]Team, error) {
+	var teams []Team
+	seen := map[string]bool{}
+	for i, entry := range strings.Split(s, ";") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		name, members, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("entry %d is not name=email,email", i+1)
+		}
+		if seen[strings.ToLower(name)] {
+			return nil, fmt.Errorf("team %q is listed twice", name)
+		}
+		seen[strings.ToLower(name)] = true
+
+		team := Team{Name: name}
+		for _, m := range strings.Split(members, ",") {
+			if m = strings.TrimSpace(m); m == "" {
+				continue
+			}
+			if !utils.ValidEmail(m) {
+				return nil, fmt.Errorf("team %q member %q is not a valid email", name, m)
+			}
+			team.Members = append(team.Members, m)
+		}
+		if len(team.Members) == 0 {
+			return nil, fmt.Errorf("team %q has no members", name)
+		}
+		teams = append(teams, team)
+	}
+	return teams, nil
+}
+
+// ParseEnrichTimeouts parses ENRICH_TIMEOUTS, e.g. "github=5s,website=10s". Sources
+// that are not listed get enrich.DefaultTimeout.
+func ParseEnrichTimeouts(s string) (map[string]time.Duration, error) {
+	timeouts := map[string]time.Duration{}
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		name := strings.TrimSpace(k)
+		if !ok || !slices.Contains(enrich.Names, name) {
+			return nil, fmt.Errorf("invalid timeout %q, expected source=duration with a source from %s", pair, strings.Join(enrich.Names, ", "))
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(v))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid timeout for %s, expected a positive duration", name)
+		}
+		timeouts[name] = d
+	}
+	return timeouts, nil
+}
+
+// FallbackRule fetches extra sections when a section came back with fewer than Below entries.
+type FallbackRule struct {
+	When  scraper.Section
+	Below int
+	Fetch []scraper.Section
+}
+
+/*
+ParseFallbacks parses SCRAPE_FALLBACKS, rules separated by ";" in the form
+section<below:section,section. For example "posts<3:experience,education"
+fetches experience and education for profiles with fewer than three posts; the
+default rule fetches every detail section instead.
+"none" turns fallbacks off.
+*/
+func ParseFallbacks(s string) ([]FallbackRule, error) {
+	rules := []FallbackRule{}
+	if strings.TrimSpace(s) == "none" {
+		return rules, nil
+	}
+	for _, raw := range strings.Split(s, ";") {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		cond, fetch, ok := strings.Cut(raw, ":")
+		when, below, ok2 := strings.Cut(cond, "<")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("invalid rule %q, expected section<count:section,section", raw)
+		}
+		rule := FallbackRule{When: scraper.Section(strings.TrimSpace(when))}
+		if !slices.Contains(scraper.PageOrder, rule.When) {
+			return nil, fmt.Errorf("unknown section %q", rule.When)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(below))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid count in rule %q", raw)
+		}
+		rule.Below = n
+		for _, f := range strings.Split(fetch, ",") {
+			section := scraper.Section(strings.TrimSpace(f))
+			if !slices.Contains(scraper.PageOrder, section) || section == scraper.SectionAbout {
+				// About can only be read right after NameAndLocation opened the profile page
+				return nil, fmt.Errorf("section %q can't be fetched as a fallback", section)
+			}
+			rule.Fetch = append(rule.Fetch, section)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Route sends the events it matches to Channels, none when it is empty. Event is an
+// event kind, e.g. "batch.done", a kind prefix ending in "*", e.g. "account.*", or "*".
+type Route struct {
+	Event    string
+	Channels []models.Destination
+}
+
+// Matches reports whether the route decides where events of kind go.
+func (r Route) Matches(kind models.EventKind) bool {
+	if prefix, ok := strings.CutSuffix(r.Event, "*"); ok {
+		return strings.HasPrefix(string(kind), prefix)
+	}
+	return r.Event == string(kind)
+}
+
+/*
+ParseRoutes parses NOTIFY_ROUTES, routes separated by ";" in the form
+event=channel,channel. For example "account.*=slack;batch.done=email;*=webhook"
+sends account events to Slack, finished batches by email and everything else to
+the webhook. The first route matching an event decides; "none" as the channels
+sends the events nowhere.
+*/
+func ParseRoutes(s string) ([]Route, error) {
+	routes := []Route{}
+	for _, raw := range strings.Split(s, ";") {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		event, channels, ok := strings.Cut(raw, "=")
+		route := Route{Event: strings.TrimSpace(event)}
+		if !ok || route.Event == "" {
+			return nil, fmt.Errorf("invalid route %q, expected event=channel,channel", raw)
+		}
+		if !slices.ContainsFunc(models.EventKinds, route.Matches) {
+			return nil, fmt.Errorf("route %q matches no event", route.Event)
+		}
+		if strings.TrimSpace(channels) == "none" {
+			routes = append(routes, route)
+			continue
+		}
+		for _, c := range strings.Split(channels, ",") {
+			channel := models.Destination(strings.TrimSpace(c))
+			if !slices.Contains(models.Destinations, channel) {
+				return nil, fmt.Errorf("unknown channel %q in route %q", channel, route.Event)
+			}
+			route.Channels = append(route.Channels, channel)
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// ParseTeamRoutes parses NOTIFY_TEAM_ROUTES, a "|" separated list of team:routes entries
+// with the routes in NOTIFY_ROUTES syntax, keyed by the lowercased team name.
+func ParseTeamRoutes(s string) (map[string][]Route, error) {
+	teams := map[string][]Route{}
+	for i, entry := range strings.Split(s, "|") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		name, raw, ok := strings.Cut(entry, ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" {
+			return nil, fmt.Errorf("entry %d is not team:routes", i+1)
+		}
+		if _, ok := teams[name]; ok {
+			return nil, fmt.Errorf("team %q is listed twice", name)
+		}
+		routes, err := ParseRoutes(raw)
+		if err != nil {
+			return nil, fmt.Errorf("team %q: %w", name, err)
+		}
+		teams[name] = routes
+	}
+	return teams, nil
+}
+
+// DefaultDriftExpectations expect the sections of the known-good profile to come back non-empty.
+var DefaultDriftExpectations = map[scraper.Section]int{
+	scraper.SectionNameAndLocation: 1,
+	scraper.SectionAbout:           1,
+	scraper.SectionPosts:           1,
+	scraper.SectionExperience:      1,
+	scraper.SectionEducation:       1,
+	scraper.SectionSkills:          1,
+	// Plenty of complete profiles list none, set a minimum when the drift profile has some
+	scraper.SectionCertifications:  0,
+	scraper.SectionRecommendations: 0,
+	scraper.SectionVolunteering:    0,
+	scraper.SectionPublications:    0,
+	scraper.SectionPatents:         0,
+	scraper.SectionLanguages:       0,
+	scraper.SectionArticles:        0,
+	scraper.SectionComments:        0,
+	scraper.SectionCompany:         0,
+	scraper.SectionContactInfo:     0,
+}
+
+// ParseDriftExpectations parses DRIFT_CHECK_EXPECT, e.g. "experience=3,education=1,posts=0".
+// Sections that are not listed keep their DefaultDriftExpectations minimum.
+func ParseDriftExpectations(s string) (map[scraper.Section]int, error) {
+	expect := map[scraper.Section]int{}
+	for section, min := range DefaultDriftExpectations {
+		expect[section] = min
+	}
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		section := scraper.Section(strings.TrimSpace(k))
+		if _, known := DefaultDriftExpectations[section]; !ok || !known {
+			return nil, fmt.Errorf("invalid expectation %q, expected section=minimum", pair)
+		}
+		min, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil || min < 0 {
+			return nil, fmt.Errorf("invalid minimum for %s", section)
+		}
+		expect[section] = min
+	}
+	return expect, nil
+}
+
+// ParseOTPSecrets parses OTP_TOTP_SECRETS, a ";" separated list of email=secret entries
+// with the base32 secrets authenticator apps are set up with.
+func ParseOTPSecrets(s string) (map[string]string, error) {
+	secrets, err := byAccount(s, "secret")
+	if err != nil {
+		return nil, err
+	}
+	for email, secret := range secrets {
+		if _, err := scraper.DecodeTOTPSecret(secret); err != nil {
+			return nil, fmt.Errorf("the secret of %s is not base32", email)
+		}
+	}
+	return secrets, nil
+}
+
+// ParseReviewerTokens parses REVIEWER_TOKENS, a ";" separated list of email=token entries
+// with the secrets reviewers prove who they are with.
+func ParseReviewerTokens(s string) (map[string]string, error) {
+	return byAccount(s, "token")
+}
+
+// ParseOTPPhones parses OTP_PHONES, a ";" separated list of email=phone entries.
+func ParseOTPPhones(s string) (map[string]string, error) {
+	return byAccount(s, "phone")
+}
+
+// byAccount parses a ";" separated list of email=value entries. Errors name the entry, never its value.
+func byAccount(s, value string) (map[string]string, error) {
+	values := map[string]string{}
+	for i, entry := range strings.Split(s, ";") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		email, v, ok := strings.Cut(entry, "=")
+		email, v = strings.TrimSpace(email), strings.TrimSpace(v)
+		if !ok || v == "" || !utils.ValidEmail(email) {
+			return nil, fmt.Errorf("entry %d is not email=%s", i+1, value)
+		}
+		values[strings.ToLower(email)] = v
+	}
+	return values, nil
+}
@@ -1 +1,342 @@
 package models
+
+import (
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/diff"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/enrich"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/icp"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/persona"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scoring"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// SenderRefreshInterval is how long a stored sender profile is trusted before it is scraped again.
+const SenderRefreshInterval = 30 * 24 * time.Hour
+
+// Sender is the user's own LinkedIn profile, used to personalise messages from their side.
+type Sender struct {
+	Email       string          `json:"email"`
+	LinkedinUrl string          `json:"linkedinUrl"`
+	Profile     scraper.Profile `json:"profile"`
+	// ProfileVersion is the scraper.ProfileSchemaVersion the profile was stored with
+	ProfileVersion int `json:"profileVersion"`
+	// Missing lists the sections that failed to scrape, left empty in Profile
+	Missing   []scraper.Section `json:"missing,omitempty"`
+	ScrapedAt time.Time         `json:"scrapedAt"`
+}
+
+func (s *Sender) NeedsRefresh() bool {
+	return time.Since(s.ScrapedAt) > SenderRefreshInterval
+}
+
+// Prospect is a scraped target profile together with what was generated for it.
+type Prospect struct {
+	ID          string          `json:"id"`
+	Owner       string          `json:"owner"`
+	BatchID     string          `json:"batchId,omitempty"`
+	LinkedinUrl string          `json:"linkedinUrl"`
+	Profile     scraper.Profile `json:"profile"`
+	// ProfileVersion is the scraper.ProfileSchemaVersion the profile was stored with
+	ProfileVersion int               `json:"profileVersion"`
+	Persona        persona.Persona   `json:"persona"`
+	Message        string            `json:"message"`
+	Score          scoring.Breakdown `json:"score"`
+	Error          string            `json:"error,omitempty"`
+	SkipReason     string            `json:"skipReason,omitempty"` // Set when the ICP filter rejected the profile
+	// Enrichment is what sources outside LinkedIn, such as GitHub, said at scrape time
+	Enrichment []enrich.Enrichment `json:"enrichment,omitempty"`
+	// Sources records which sources, LinkedIn sections included, succeeded at scrape time
+	Sources []enrich.Outcome `json:"sources,omitempty"`
+	// Job is the open role the message is about, when one was given
+	Job *scraper.Job `json:"job,omitempty"`
+	// Goal is what the message was written for, when one was given; regenerations keep it
+	Goal string `json:"goal,omitempty"`
+	// Approval is set when messages need a reviewer's approval before they may be sent
+	Approval *Approval `json:"approval,omitempty"`
+	// Draft is set on prospects of dry run batches, which are never shared, reviewed or
+	// listed with the user's prospects
+	Draft bool `json:"draft,omitempty"`
+	// Cost is what scraping and writing for the prospect took, nil for prospects stored
+	// before costs were recorded
+	Cost *Cost `json:"cost,omitempty"`
+	// Status is ProspectInsufficientPersonalization when the profile said too little to
+	// write from and Message is the generic fallback, empty otherwise
+	Status    ProspectStatus `json:"status,omitempty"`
+	ScrapedAt time.Time      `json:"scrapedAt"`
+}
+
+type ProspectStatus string
+
+// ProspectInsufficientPersonalization marks a generic fallback message, sent when the
+// profile gave too few details to personalise on.
+const ProspectInsufficientPersonalization ProspectStatus = "insufficient_personalization"
+
+// Cost is what a job, or the jobs of a batch or campaign added up, spent on the browser,
+// LinkedIn and the LLM. LLMDollars is estimated from the tokens at the configured prices.
+type Cost struct {
+	// BrowserSeconds is how long LinkedIn pages were being read, logging in excluded
+	BrowserSeconds float64 `json:"browserSeconds"`
+	// LinkedInActions counts the LinkedIn pages and sections read, which LinkedIn counts
+	// towards the account's limits
+	LinkedInActions  int     `json:"linkedinActions"`
+	LLMCalls         int     `json:"llmCalls"`
+	PromptTokens     int     `json:"promptTokens"`
+	CompletionTokens int     `json:"completionTokens"`
+	EmbeddingTokens  int     `json:"embeddingTokens"`
+	LLMDollars       float64 `json:"llmDollars"`
+}
+
+// Add adds o to c.
+func (c *Cost) Add(o Cost) {
+	c.BrowserSeconds += o.BrowserSeconds
+	c.LinkedInActions += o.LinkedInActions
+	c.LLMCalls += o.LLMCalls
+	c.PromptTokens += o.PromptTokens
+	c.CompletionTokens += o.CompletionTokens
+	c.EmbeddingTokens += o.EmbeddingTokens
+	c.LLMDollars += o.LLMDollars
+}
+
+type ApprovalStatus string
+
+const (
+	ApprovalPending  ApprovalStatus = "pending"
+	ApprovalApproved ApprovalStatus = "approved"
+	ApprovalRejected ApprovalStatus = "rejected"
+)
+
+// Approval is the review state of a prospect's current message. Reviewer, Note and
+// ReviewedAt are empty while it is pending.
+type Approval struct {
+	Status      ApprovalStatus `json:"status"`
+	Reviewer    string         `json:"reviewer,omitempty"`
+	Note        string         `json:"note,omitempty"`
+	RequestedAt time.Time      `json:"requestedAt,omitempty"`
+	ReviewedAt  time.Time      `json:"reviewedAt,omitempty"`
+}
+
+type BatchStatus string
+
+const (
+	BatchPending BatchStatus = "pending"
+	BatchRunning BatchStatus = "running"
+	BatchDone    BatchStatus = "done"
+	BatchFailed  BatchStatus = "failed"
+	// BatchPaused waits for its LinkedIn account's cooldown to end, until ResumeAt
+	BatchPaused BatchStatus = "paused"
+)
+
+// Batch is a set of profiles scraped with a single login and scored against the same criteria.
+type Batch struct {
+	ID           string           `json:"id"`
+	Owner        string           `json:"owner"`
+	LinkedinUrls []string         `json:"linkedinUrls"`
+	Criteria     scoring.Criteria `json:"criteria"`
+	ICPFilterID  string           `json:"icpFilterId,omitempty"`
+	JobURL       string           `json:"jobUrl,omitempty"`
+	CampaignID   string           `json:"campaignId,omitempty"` // Set for batches a campaign sourced
+	Goal         string           `json:"goal,omitempty"`       // What the messages are written for, posts are ranked by it
+	Status       BatchStatus      `json:"status"`
+	Error        string           `json:"error,omitempty"`
+	// Account is the LinkedIn account the batch scrapes with when the owner's is cooling off
+	Account     string    `json:"account,omitempty"`
+	ResumeAt    time.Time `json:"resumeAt,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	CompletedAt time.Time `json:"completedAt,omitempty"`
+	// DryRun batches scrape and generate as any other, but their prospects are drafts and
+	// their completion notifies nobody
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// Cooldown keeps a LinkedIn account idle after LinkedIn flagged it as automated or
+// restricted it. Nothing scrapes with the account before Until.
+type Cooldown struct {
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+	Until     time.Time `json:"until"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Session is the sealed cookie jar of a LinkedIn account's last logged in browser, which
+// later scrapes are logged in with instead of the login form. The jar is encrypted with a
+// key derived from the account's password and Salt, so only someone who could log in
+// anyway can use it.
+type Session struct {
+	Email   string    `json:"email"`
+	Salt    []byte    `json:"salt"`
+	Sealed  []byte    `json:"sealed"`
+	SavedAt time.Time `json:"savedAt"`
+}
+
+// Campaign is a saved people search that prospects are sourced from. Every sourcing run
+// reads the next search pages and queues the profiles it hasn't seen as a batch judged by
+// ICPFilterID and Criteria.
+type Campaign struct {
+	ID          string                `json:"id"`
+	Owner       string                `json:"owner"`
+	Name        string                `json:"name"`
+	Query       string                `json:"query"`
+	Filters     scraper.SearchFilters `json:"filters"`
+	ICPFilterID string                `json:"icpFilterId,omitempty"`
+	Criteria    scoring.Criteria      `json:"criteria"`
+	JobURL      string                `json:"jobUrl,omitempty"`
+	// Goal is what the campaign's messages are written for, e.g. "hiring data engineers"
+	Goal string `json:"goal,omitempty"`
+	// NextPage is the first search results page no sourcing run has read yet
+	NextPage int `json:"nextPage"`
+	// Exhausted is set once the search has no pages left
+	Exhausted bool `json:"exhausted,omitempty"`
+	// Sourced lists the profile URLs sourcing runs have already considered, so later runs skip them
+	Sourced   []string  `json:"sourced,omitempty"`
+	BatchIDs  []string  `json:"batchIds,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ICPFilter is a saved ideal customer profile that batches are evaluated against.
+type ICPFilter struct {
+	ID        string     `json:"id"`
+	Owner     string     `json:"owner"`
+	Name      string     `json:"name"`
+	Filter    icp.Filter `json:"filter"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// Settings are a user's overrides of the server's generation settings; unset fields keep
+// the server's value.
+type Settings struct {
+	Owner          string           `json:"owner"`
+	ScoringWeights *scoring.Weights `json:"scoringWeights,omitempty"`
+	// Fallbacks replace the degradation fallbacks, in SCRAPE_FALLBACKS syntax
+	Fallbacks        string `json:"fallbacks,omitempty"`
+	NativeLanguage   *bool  `json:"nativeLanguage,omitempty"`
+	PersonaLLMAssist *bool  `json:"personaLlmAssist,omitempty"`
+	// NotifyRoutes decide where the user's events go before their team's and the
+	// server's routes, in NOTIFY_ROUTES syntax
+	NotifyRoutes string    `json:"notifyRoutes,omitempty"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// Regeneration re-runs generation for stored prospects after a prompt change. New
+// messages are kept next to the old ones until the owner applies them.
+type Regeneration struct {
+	ID          string             `json:"id"`
+	Owner       string             `json:"owner"`
+	BatchID     string             `json:"batchId,omitempty"`
+	CampaignID  string             `json:"campaignId,omitempty"`
+	Filter      persona.Filter     `json:"filter"`
+	Status      BatchStatus        `json:"status"`
+	Items       []RegenerationItem `json:"items"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	CompletedAt time.Time          `json:"completedAt,omitempty"`
+	// Cost is what the new messages took, nothing is scraped
+	Cost Cost `json:"cost"`
+}
+
+// RegenerationItem is one prospect's old and new message. Applied is set once the new
+// message replaced the stored one.
+type RegenerationItem struct {
+	ProspectID  string    `json:"prospectId"`
+	LinkedinUrl string    `json:"linkedinUrl"`
+	OldMessage  string    `json:"oldMessage"`
+	NewMessage  string    `json:"newMessage"`
+	Diff        []diff.Op `json:"diff"`
+	Error       string    `json:"error,omitempty"`
+	// Status is the new message's, see Prospect.Status
+	Status    ProspectStatus `json:"status,omitempty"`
+	Applied   bool           `json:"applied"`
+	AppliedAt time.Time      `json:"appliedAt,omitempty"`
+}
+
+type EventKind string
+
+const (
+	EventBatchDone   EventKind = "batch.done"
+	EventBatchFailed EventKind = "batch.failed"
+	// EventSelectorDrift reports a known-good profile scraping with less coverage than expected
+	EventSelectorDrift EventKind = "scraper.drift"
+	// EventAccountCheckpoint and EventAccountRestricted warn that a LinkedIn account needs its owner
+	EventAccountCheckpoint EventKind = "account.checkpoint"
+	EventAccountRestricted EventKind = "account.restricted"
+	// EventAccountBotDetected warns that LinkedIn challenged a logged in session as automated
+	EventAccountBotDetected EventKind = "account.bot-detected"
+	// EventAccountOTPRequired asks an account's owner for the verification code LinkedIn sent its login
+	EventAccountOTPRequired EventKind = "account.otp-required"
+)
+
+// EventKinds lists every kind of event, the ones notification routes can name.
+var EventKinds = []EventKind{
+	EventBatchDone, EventBatchFailed, EventSelectorDrift,
+	EventAccountCheckpoint, EventAccountRestricted, EventAccountBotDetected, EventAccountOTPRequired,
+}
+
+// PriorityHigh marks events someone should act on right away.
+const PriorityHigh = "high"
+
+// Event is a notification payload, sent as is to webhook destinations. Error carries
+// the failure or drift details. Job names what was running for account events, e.g.
+// "home", "batch" (with BatchID) or "keep-alive". VerifyURL links to a checkpoint that
+// can be solved from the browser until VerifyBy. SchemaVersion is only set on delivery,
+// to the payload version the destination receives.
+type Event struct {
+	SchemaVersion int       `json:"schemaVersion,omitempty"`
+	ID            string    `json:"id"`
+	Kind          EventKind `json:"kind"`
+	Priority      string    `json:"priority,omitempty"`
+	Owner         string    `json:"owner"`
+	Job           string    `json:"job,omitempty"`
+	BatchID       string    `json:"batchId,omitempty"`
+	Prospects     int       `json:"prospects"`
+	Error         string    `json:"error,omitempty"`
+	VerifyURL     string    `json:"verifyUrl,omitempty"`
+	VerifyBy      time.Time `json:"verifyBy,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type Destination string
+
+const (
+	DestinationWebhook Destination = "webhook"
+	DestinationSlack   Destination = "slack"
+	// DestinationAccountAlert only receives account events unless routed others
+	DestinationAccountAlert Destination = "account-alert"
+	// DestinationEmail mails the owner of the event
+	DestinationEmail Destination = "email"
+)
+
+// Destinations lists the notification channels routes can send events to.
+var Destinations = []Destination{DestinationWebhook, DestinationSlack, DestinationAccountAlert, DestinationEmail}
+
+// OutboxEntry is an event waiting to be delivered to one destination. Entries are
+// stored together with the change that caused them and removed once delivered.
+// DeadAt is set when delivery was given up on; dead entries are kept for
+// inspection but never retried.
+type OutboxEntry struct {
+	ID            string      `json:"id"`
+	Destination   Destination `json:"destination"`
+	Event         Event       `json:"event"`
+	Attempts      int         `json:"attempts"`
+	NextAttemptAt time.Time   `json:"nextAttemptAt"`
+	LastError     string      `json:"lastError,omitempty"`
+	DeadAt        time.Time   `json:"deadAt,omitempty"`
+}
+
+// Lease gives one server instance exclusive use of a named resource, such as a batch or
+// a LinkedIn account, until ExpiresAt. Holders renew it while they work; a lease whose
+// holder died simply expires.
+type Lease struct {
+	Name      string    `json:"name"`
+	Holder    string    `json:"holder"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// AuditEntry records a look at or change to a user's data made by someone else, such as
+// support staff viewing it, for the user and operators to review.
+type AuditEntry struct {
+	ID      string    `json:"id"`
+	Actor   string    `json:"actor"`   // Who did it
+	Action  string    `json:"action"`  // What they did, e.g. "support.view"
+	Subject string    `json:"subject"` // Whose data it was
+	Reason  string    `json:"reason"`
+	At      time.Time `json:"at"`
+}
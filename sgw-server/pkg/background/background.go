@@ -0,0 +1,218 @@
+/*
+	Package background detects shared background between the sender and a prospect.
+
+Overlaps such as a common school, a common former employer, the same city or
+time spent at the same company in the same years are the strongest hooks for a
+connect note. They are computed here explicitly so the model does not have to
+spot them on its own.
+
+Basic usage:
+
+	hooks := background.Shared(senderProfile, *scraper.Profile)
+	for _, h := range hooks {
+	    fmt.Println(h.Kind, h.Detail)
+	}
+*/
+package background
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// Kind identifies the type of overlap a Hook describes.
+type Kind string
+
+const (
+	KindSameSchool        Kind = "same_school"
+	KindSameEmployer      Kind = "same_employer"
+	KindSameCity          Kind = "same_city"
+	KindOverlappingTenure Kind = "overlapping_tenure"
+)
+
+/*
+	Hook is a single piece of shared background between sender and prospect.
+
+Detail is a short human readable description that is passed to the model as is.
+*/
+type Hook struct {
+	Kind   Kind   `json:"kind"`   // Type of overlap
+	Detail string `json:"detail"` // Human readable description, e.g. "Both worked at Google"
+}
+
+/*
+	Shared computes every overlap between the sender's and the prospect's profiles.
+
+Overlapping tenure is reported instead of same employer when both durations
+can be parsed and intersect, since it is the stronger hook.
+
+Parameters:
+  - sender: The sender's own profile
+  - prospect: The target profile
+
+Returns:
+  - []Hook: Overlaps ordered by strength, empty if there are none
+*/
+func Shared(sender, prospect scraper.Profile) []Hook {
+	hooks := make([]Hook, 0)
+	hooks = append(hooks, sharedEmployers(sender.Experience, prospect.Experience)...)
+	hooks = append(hooks, sharedSchools(sender.Education, prospect.Education)...)
+
+	if city := City(sender.Location); city != "" && strings.EqualFold(city, City(prospect.Location)) {
+		hooks = append(hooks, Hook{Kind: KindSameCity, Detail: "Both based in " + City(prospect.Location)})
+	}
+	return hooks
+}
+
+func sharedEmployers(sender, prospect []scraper.Experience) []Hook {
+	var tenure, employers []Hook
+	seen := map[string]bool{}
+	for _, p := range prospect {
+		company := normalizeOrg(p.Company)
+		if company == "" || seen[company] {
+			continue
+		}
+		for _, s := range sender {
+			if normalizeOrg(s.Company) != company {
+				continue
+			}
+			seen[company] = true
+			name := displayOrg(p.Company)
+			if from, to, ok := overlap(s.Duration, p.Duration); ok {
+				tenure = append(tenure, Hook{
+					Kind:   KindOverlappingTenure,
+					Detail: fmt.Sprintf("Both worked at %s %s", name, yearRange(from, to)),
+				})
+			} else {
+				employers = append(employers, Hook{Kind: KindSameEmployer, Detail: "Both worked at " + name})
+			}
+			break
+		}
+	}
+	return append(tenure, employers...)
+}
+
+func sharedSchools(sender, prospect []scraper.Education) []Hook {
+	var hooks []Hook
+	seen := map[string]bool{}
+	for _, p := range prospect {
+		school := normalizeOrg(p.Institute)
+		if school == "" || seen[school] {
+			continue
+		}
+		for _, s := range sender {
+			if normalizeOrg(s.Institute) != school {
+				continue
+			}
+			seen[school] = true
+			detail := "Both studied at " + displayOrg(p.Institute)
+			if from, to, ok := overlap(s.Duration, p.Duration); ok {
+				detail += " " + yearRange(from, to)
+			}
+			hooks = append(hooks, Hook{Kind: KindSameSchool, Detail: detail})
+			break
+		}
+	}
+	return hooks
+}
+
+// City returns the first component of a LinkedIn location such as "Bengaluru, Karnataka, India".
+func City(location string) string {
+	city, _, _ := strings.Cut(location, ",")
+	return strings.TrimSpace(city)
+}
+
+// displayOrg strips the employment type LinkedIn appends to company names ("Google · Full-time").
+func displayOrg(org string) string {
+	name, _, _ := strings.Cut(org, "·")
+	return strings.TrimSpace(name)
+}
+
+var orgSuffixes = []string{" private limited", " limited", " inc", " ltd", " llc", " pvt", " corp", " corporation", " gmbh"}
+
+func normalizeOrg(org string) string {
+	name := strings.ToLower(displayOrg(org))
+	name = strings.TrimRight(name, ". ")
+	for _, suffix := range orgSuffixes {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	return strings.TrimSpace(strings.TrimRight(name, ","))
+}
+
+var (
+	dateRe    = regexp.MustCompile(`(?i)(?:(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\s+)?(\d{4})`)
+	presentRe = regexp.MustCompile(`(?i)present`)
+	months    = map[string]time.Month{
+		"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+		"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+		"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+	}
+)
+
+/*
+	ParseDuration parses a LinkedIn duration such as "Jan 2019 - Present · 5 yrs" or "2015 - 2019".
+
+Returns:
+  - time.Time: Start of the period
+  - time.Time: End of the period, now for "Present"
+  - bool: Whether a start date could be found
+*/
+func ParseDuration(duration string) (time.Time, time.Time, bool) {
+	period, _, _ := strings.Cut(duration, "·")
+	matches := dateRe.FindAllStringSubmatch(period, 2)
+	if len(matches) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	start := toDate(matches[0], time.January)
+	end := start.AddDate(1, 0, 0)
+	switch {
+	case presentRe.MatchString(period):
+		end = time.Now()
+	case len(matches) > 1:
+		end = toDate(matches[1], time.December)
+	case matches[0][1] != "":
+		end = start.AddDate(0, 1, 0)
+	}
+	return start, end, true
+}
+
+func toDate(match []string, defaultMonth time.Month) time.Time {
+	year, _ := strconv.Atoi(match[2])
+	month := defaultMonth
+	if m, ok := months[strings.ToLower(match[1])]; ok {
+		month = m
+	}
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func overlap(a, b string) (time.Time, time.Time, bool) {
+	aStart, aEnd, okA := ParseDuration(a)
+	bStart, bEnd, okB := ParseDuration(b)
+	if !okA || !okB {
+		return time.Time{}, time.Time{}, false
+	}
+	from, to := aStart, aEnd
+	if bStart.After(from) {
+		from = bStart
+	}
+	if bEnd.Before(to) {
+		to = bEnd
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+func yearRange(from, to time.Time) string {
+	if from.Year() == to.Year() {
+		return "in " + strconv.Itoa(from.Year())
+	}
+	return "between " + strconv.Itoa(from.Year()) + " and " + strconv.Itoa(to.Year())
+}
@@ -0,0 +1,204 @@
+/*
+	Package backup seals store snapshots into encrypted backup files and opens them again.
+
+A backup is the gzipped snapshot encrypted with AES-GCM under a key derived
+from a passphrase with Argon2id, so a copy kept off the server, on a shared
+drive or in a bucket, shows nothing of the prospects, messages or saved
+LinkedIn sessions it holds to whoever comes across it. Losing the passphrase
+loses the backups.
+
+Basic usage:
+
+	snapshot, err := st.Snapshot()
+	// ...
+	path, err := backup.Write(dir, snapshot, passphrase, time.Now())
+
+	sealed, err := os.ReadFile(path)
+	// ...
+	snapshot, err = backup.Open(sealed, passphrase)
+	err = st.Restore(snapshot)
+*/
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+	"golang.org/x/crypto/argon2"
+)
+
+var (
+	ErrNotBackup       = errors.New("not a segwise backup")
+	ErrWrongPassphrase = errors.New("wrong backup passphrase, or the backup is damaged")
+)
+
+// MinPassphraseLength is the shortest passphrase backups should be sealed with; a backup
+// can be attacked offline for as long as its holder likes.
+const MinPassphraseLength = 12
+
+// Ext is the extension of backup files, which Write names after when they were taken.
+const Ext = ".sgwbak"
+
+const (
+	// magic starts every backup, followed by the key's salt and the sealed snapshot
+	magic    = "SGWBAK1\n"
+	saltSize = 16
+)
+
+/*
+	Seal compresses and encrypts a snapshot with passphrase.
+
+Parameters:
+  - snapshot: A store snapshot
+  - passphrase: What Open needs to read the backup
+
+Returns:
+  - []byte: The backup
+  - error: Any error compressing or encrypting
+*/
+func Seal(snapshot []byte, passphrase string) ([]byte, error) {
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(snapshot); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	sealed, err := utils.Seal(key(passphrase, salt), compressed.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat([]byte(magic), salt, sealed), nil
+}
+
+/*
+	Open decrypts and decompresses a backup made by Seal.
+
+Parameters:
+  - sealed: The backup
+  - passphrase: The passphrase it was sealed with
+
+Returns:
+  - []byte: The snapshot
+  - error: ErrNotBackup for other files, ErrWrongPassphrase when it doesn't decrypt
+*/
+func Open(sealed []byte, passphrase string) ([]byte, error) {
+	if !bytes.HasPrefix(sealed, []byte(magic)) || len(sealed) < len(magic)+saltSize {
+		return nil, ErrNotBackup
+	}
+	salt, sealed := sealed[len(magic):len(magic)+saltSize], sealed[len(magic)+saltSize:]
+	compressed, err := utils.Open(key(passphrase, salt), sealed)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress backup: %w", err)
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+/*
+	Write seals a snapshot into a new backup file in dir, named after taken.
+
+The file is written under a temporary name and renamed, so a backup in dir is
+never half written.
+
+Parameters:
+  - dir: Directory for the backup, created if needed
+  - snapshot: A store snapshot
+  - passphrase: What Open needs to read the backup
+  - taken: When the snapshot was taken
+
+Returns:
+  - string: Path of the backup file
+  - error: Any error sealing or writing
+*/
+func Write(dir string, snapshot []byte, passphrase string, taken time.Time) (string, error) {
+	sealed, err := Seal(snapshot, passphrase)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "segwise-"+taken.UTC().Format("20060102T150405Z")+Ext)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, sealed, 0o600); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	return path, nil
+}
+
+/*
+	Prune deletes all but the keep latest backups in dir.
+
+Only files named by Write are considered, so other files in dir are safe.
+
+Parameters:
+  - dir: Directory holding the backups
+  - keep: How many backups to keep, 0 keeps all
+
+Returns:
+  - error: Any error listing or deleting
+*/
+func Prune(dir string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	backups, err := List(dir)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, path := range backups[min(keep, len(backups)):] {
+		errs = append(errs, os.Remove(path))
+	}
+	return errors.Join(errs...)
+}
+
+// List returns the backups Write made in dir, latest first.
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var backups []string
+	for _, e := range entries {
+		if name := e.Name(); !e.IsDir() && strings.HasPrefix(name, "segwise-") && strings.HasSuffix(name, Ext) {
+			backups = append(backups, filepath.Join(dir, name))
+		}
+	}
+	// Names sort by when the backup was taken
+	slices.Sort(backups)
+	slices.Reverse(backups)
+	return backups, nil
+}
+
+// key derives a backup key with Argon2id at the parameters RFC 9106 recommends for memory-constrained hosts.
+func key(passphrase string, salt []byte) []byte {
+	return argon2.IDKey([]byte(passphrase), salt, 3, 64*1024, 4, 32)
+}
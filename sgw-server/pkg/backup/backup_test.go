@@ -0,0 +1,69 @@
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSealOpen(t *testing.T) {
+	snapshot := []byte(`{"prospects": {"p1": {"id": "p1", "message": "Hi Priya"}}}`)
+	sealed, err := Seal(snapshot, "correct horse battery")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(sealed, []byte("Priya")) {
+		t.Error("the backup holds the snapshot in the clear")
+	}
+	got, err := Open(sealed, "correct horse battery")
+	if err != nil || !bytes.Equal(got, snapshot) {
+		t.Fatalf("Open = %q, %v; want the snapshot", got, err)
+	}
+	if _, err := Open(sealed, "wrong horse battery"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Open with the wrong passphrase = %v, want ErrWrongPassphrase", err)
+	}
+	sealed[len(sealed)-1] ^= 1
+	if _, err := Open(sealed, "correct horse battery"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Open of a damaged backup = %v, want ErrWrongPassphrase", err)
+	}
+	if _, err := Open(snapshot, "correct horse battery"); !errors.Is(err, ErrNotBackup) {
+		t.Errorf("Open of a snapshot = %v, want ErrNotBackup", err)
+	}
+}
+
+func TestWritePrune(t *testing.T) {
+	dir := t.TempDir()
+	taken := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	var paths []string
+	for i := range 4 {
+		path, err := Write(dir, []byte("{}"), "correct horse battery", taken.Add(time.Duration(i)*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	if want := filepath.Join(dir, "segwise-20261014T090000Z.sgwbak"); paths[0] != want {
+		t.Errorf("Write path = %s, want %s", paths[0], want)
+	}
+	other := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(other, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Prune(dir, 2); err != nil {
+		t.Fatal(err)
+	}
+	left, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) != 2 || left[0] != paths[3] || left[1] != paths[2] {
+		t.Errorf("after Prune(2): %v, want the 2 latest of %v", left, paths)
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("Prune removed a file it didn't write: %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+/*
+	Package breaker provides circuit breakers that make calls to a failing dependency fail fast.
+
+A breaker counts consecutive failures. After Threshold of them it opens, and
+calls fail with an *OpenError at once instead of waiting on the dependency.
+Once Cooldown has passed a single call is let through as a probe: if it
+succeeds the breaker closes, otherwise it stays open for another Cooldown.
+
+Basic usage:
+
+	breakers := breaker.NewSet(breaker.DefaultThreshold, breaker.DefaultCooldown)
+	err := breakers.Get("openai/message").Do(func() error {
+	    msg, _, err = openai.GetMessage(prospect, apiKey)
+	    return err
+	})
+	var open *breaker.OpenError
+	if errors.As(err, &open) {
+	    // Answer 503, retry after open.Until
+	}
+*/
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultThreshold and DefaultCooldown are the settings of a breaker nothing else was configured for.
+const (
+	DefaultThreshold = 5
+	DefaultCooldown  = 30 * time.Second
+)
+
+// ErrOpen is what every *OpenError is, for callers that only care whether a breaker refused the call.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// OpenError is returned for calls an open breaker refused.
+type OpenError struct {
+	Name  string
+	Until time.Time // When the next probe is let through
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("%s keeps failing, not retrying until %s", e.Name, e.Until.Format(time.RFC3339))
+}
+
+func (e *OpenError) Is(target error) bool {
+	return target == ErrOpen
+}
+
+// State is where a breaker is in its cycle.
+type State string
+
+const (
+	Closed   State = "closed"    // Calls go through
+	Open     State = "open"      // Calls fail fast until the cooldown ends
+	HalfOpen State = "half-open" // A probe is deciding whether to close
+)
+
+// Stats is a snapshot of a breaker.
+type Stats struct {
+	State    State     `json:"state"`
+	Failures int       `json:"failures"`        // Consecutive failures so far
+	Until    time.Time `json:"until,omitempty"` // When an open breaker lets the next probe through
+	Opened   uint64    `json:"opened"`          // Times the breaker opened
+	Refused  uint64    `json:"refused"`         // Calls failed fast while open
+}
+
+/*
+	Breaker guards calls to one dependency. It is safe for concurrent use.
+
+The zero value is not usable, create one with New or through a Set.
+*/
+type Breaker struct {
+	mu        sync.Mutex
+	name      string
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	until     time.Time // Set while open
+	probing   bool      // A probe is in flight
+	opened    uint64
+	refused   uint64
+	now       func() time.Time
+}
+
+/*
+	New creates a closed breaker.
+
+Parameters:
+  - name: The dependency, as errors and stats name it
+  - threshold: Consecutive failures that open the breaker, 0 never opens it
+  - cooldown: How long the breaker stays open before a probe is let through
+
+Returns:
+  - *Breaker: The breaker
+*/
+func New(name string, threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{name: name, threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+/*
+	Allow asks to make a call.
+
+Returns:
+  - func(error): Reports how the call went, exactly once; pass nil for errors that
+    say nothing about the dependency, such as a rejected password
+  - error: An *OpenError when the breaker is open or its probe is still in flight
+*/
+func (b *Breaker) Allow() (func(error), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	probe := false
+	if !b.until.IsZero() {
+		if b.probing || b.now().Before(b.until) {
+			b.refused++
+			return nil, &OpenError{Name: b.name, Until: b.until}
+		}
+		b.probing, probe = true, true
+	}
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() { b.done(probe, err) })
+	}, nil
+}
+
+// Do runs fn when the breaker allows it, counting any error fn returns as a failure.
+func (b *Breaker) Do(fn func() error) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+	err = fn()
+	done(err)
+	return err
+}
+
+// Err returns the *OpenError Allow would fail with, without taking the probe. Callers use
+// it to give up before waiting on anything else.
+func (b *Breaker) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.until.IsZero() && (b.probing || b.now().Before(b.until)) {
+		return &OpenError{Name: b.name, Until: b.until}
+	}
+	return nil
+}
+
+// Stats returns a snapshot of the breaker.
+func (b *Breaker) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := Stats{State: Closed, Failures: b.failures, Opened: b.opened, Refused: b.refused}
+	switch {
+	case b.probing:
+		stats.State, stats.Until = HalfOpen, b.until
+	case !b.until.IsZero():
+		stats.State, stats.Until = Open, b.until
+	}
+	return stats
+}
+
+func (b *Breaker) done(probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if probe {
+		b.probing = false
+	}
+	if err == nil {
+		b.failures, b.until = 0, time.Time{}
+		return
+	}
+	b.failures++
+	// A failed probe reopens at once, whatever the count
+	if probe || (b.threshold > 0 && b.failures >= b.threshold && b.until.IsZero()) {
+		b.until = b.now().Add(b.cooldown)
+		b.opened++
+	}
+}
+
+// Set keeps one breaker per name, created on first use with the same settings. It is safe for concurrent use.
+type Set struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	breakers  map[string]*Breaker
+}
+
+// NewSet returns an empty set creating breakers with New(name, threshold, cooldown).
+func NewSet(threshold int, cooldown time.Duration) *Set {
+	return &Set{threshold: threshold, cooldown: cooldown, breakers: map[string]*Breaker{}}
+}
+
+// Get returns the breaker for name, creating it closed on first use.
+func (s *Set) Get(name string) *Breaker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.breakers[name]
+	if !ok {
+		b = New(name, s.threshold, s.cooldown)
+		s.breakers[name] = b
+	}
+	return b
+}
+
+// Stats returns a snapshot of every breaker used so far, by name.
+func (s *Set) Stats() map[string]Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := make(map[string]Stats, len(s.breakers))
+	for name, b := range s.breakers {
+		stats[name] = b.Stats()
+	}
+	return stats
+}
@@ -0,0 +1,83 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	b := New("openai/message", 3, time.Minute)
+	b.now = func() time.Time { return now }
+	failing := errors.New("503 from upstream")
+
+	for i := 0; i < 3; i++ {
+		if err := b.Do(func() error { return failing }); !errors.Is(err, failing) {
+			t.Fatalf("call %d = %v, want the dependency's error", i, err)
+		}
+	}
+	called := false
+	err := b.Do(func() error { called = true; return nil })
+	var open *OpenError
+	if !errors.As(err, &open) || !errors.Is(err, ErrOpen) || called || !open.Until.Equal(now.Add(time.Minute)) {
+		t.Fatalf("call after 3 failures = %v, called %v, want it refused until the cooldown ends", err, called)
+	}
+
+	// One probe at a time once the cooldown is over, and a failed one reopens
+	now = now.Add(time.Minute)
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("probe refused: %v", err)
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("second call during the probe = %v, want it refused", err)
+	}
+	if got := b.Stats().State; got != HalfOpen {
+		t.Errorf("state during the probe = %s", got)
+	}
+	done(failing)
+	if err := b.Err(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("after a failed probe = %v, want open again", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := b.Do(func() error { return nil }); err != nil {
+		t.Fatalf("probe = %v", err)
+	}
+	if stats := b.Stats(); stats.State != Closed || stats.Failures != 0 || stats.Opened != 2 || stats.Refused != 2 {
+		t.Errorf("stats after a good probe = %+v", stats)
+	}
+}
+
+func TestBreakerIgnoresReportedSuccesses(t *testing.T) {
+	b := New("linkedin/a@x.com", 2, time.Minute)
+	for i := 0; i < 5; i++ {
+		done, err := b.Allow()
+		if err != nil {
+			t.Fatalf("call %d refused: %v", i, err)
+		}
+		// A rejected password says nothing about the dependency
+		done(nil)
+	}
+	if err := b.Do(func() error { return errors.New("timeout") }); err == nil || errors.Is(err, ErrOpen) {
+		t.Fatalf("first failure = %v", err)
+	}
+	if got := b.Stats().Failures; got != 1 {
+		t.Errorf("failures = %d, want 1", got)
+	}
+}
+
+func TestSetSharesBreakersByName(t *testing.T) {
+	s := NewSet(1, time.Minute)
+	s.Get("openai/message").Do(func() error { return errors.New("down") })
+	if err := s.Get("openai/message").Err(); !errors.Is(err, ErrOpen) {
+		t.Errorf("openai/message = %v, want open", err)
+	}
+	if err := s.Get("openai/persona").Err(); err != nil {
+		t.Errorf("openai/persona = %v, want closed", err)
+	}
+	if got := s.Stats(); len(got) != 2 || got["openai/message"].State != Open {
+		t.Errorf("stats = %+v", got)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+	Package cache provides a size-bounded, thread-safe LRU cache that counts its hits and misses.
+
+It is meant for results that are expensive to recompute (LLM calls, network
+lookups) and safe to reuse for the lifetime of the process.
+
+Basic usage:
+
+	c := cache.New[string, persona.Persona](1024)
+	if p, ok := c.Get(title); ok {
+	    return p
+	}
+	c.Add(title, p)
+	fmt.Println(c.Stats().HitRate)
+*/
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+/*
+	Stats is a snapshot of a cache's counters since it was created.
+
+HitRate is Hits / (Hits + Misses), zero before the first lookup.
+*/
+type Stats struct {
+	Size      int     `json:"size"`
+	Capacity  int     `json:"capacity"`
+	Hits      uint64  `json:"hits"`
+	Misses    uint64  `json:"misses"`
+	Evictions uint64  `json:"evictions"`
+	HitRate   float64 `json:"hitRate"`
+}
+
+// LRU is a least recently used cache. The zero value is not usable, create one with New.
+type LRU[K comparable, V any] struct {
+	mu        sync.Mutex
+	capacity  int
+	items     map[K]*list.Element
+	order     *list.List // Front is the most recently used entry
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+/*
+	New creates an LRU cache holding at most capacity entries.
+
+Parameters:
+  - capacity: Maximum number of entries, values below 1 are treated as 1
+
+Returns:
+  - *LRU[K, V]: An empty cache
+*/
+func New[K comparable, V any](capacity int) *LRU[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRU[K, V]{capacity: capacity, items: map[K]*list.Element{}, order: list.New()}
+}
+
+// Get returns the value stored for key and marks it as recently used.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.hits++
+		c.order.MoveToFront(el)
+		return el.Value.(*entry[K, V]).value, true
+	}
+	c.misses++
+	var zero V
+	return zero, false
+}
+
+// Add stores value for key, evicting the least recently used entry when the cache is full.
+func (c *LRU[K, V]) Add(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		el.Value.(*entry[K, V]).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*entry[K, V]).key)
+		c.evictions++
+	}
+}
+
+// Stats returns the cache's current size and counters.
+func (c *LRU[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	st := Stats{Size: c.order.Len(), Capacity: c.capacity, Hits: c.hits, Misses: c.misses, Evictions: c.evictions}
+	if total := c.hits + c.misses; total > 0 {
+		st.HitRate = float64(c.hits) / float64(total)
+	}
+	return st
+}
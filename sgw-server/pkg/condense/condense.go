@@ -0,0 +1,199 @@
+/*
+	Package condense shrinks long LinkedIn profiles before they are handed to the model.
+
+Most profiles fit the prompt as they are. Long ones, with an About section that
+runs for pages or twenty jobs behind them, are condensed in passes that give up
+the least relevant detail first: the About is cut where a sentence ends, older
+roles are folded into one summary entry, long posts and recommendations are
+trimmed, and long lists keep their strongest entries. Passes stop as soon as the
+profile fits, so a profile over budget because of its About keeps every role.
+
+Basic usage:
+
+	prospect.Profile = condense.Profile(profile, condense.DefaultBudget)
+*/
+package condense
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// DefaultBudget is the size of profile JSON, in bytes, Profile condenses to by default; about 4,000 tokens.
+const DefaultBudget = 16000
+
+const (
+	// aboutMax is how much of a long About section is kept.
+	aboutMax = 1200
+	// recentRoles is how many of the latest roles are kept as they are.
+	recentRoles = 6
+	// foldedRoles is how many older roles the summary entry names.
+	foldedRoles = 5
+	// textMax is how much of a post, article excerpt, comment or recommendation is kept.
+	textMax = 500
+	// listMax is how many entries long lists such as skills keep.
+	listMax = 8
+)
+
+var (
+	// sentenceEndRe matches where a sentence ends, for cutting text without leaving half of one.
+	sentenceEndRe = regexp.MustCompile(`[.!?…](?:["”')\]]*)(?:\s|$)`)
+	// yearRe matches the years in a duration such as "Mar 2015 - Jun 2018 · 3 yrs 4 mos".
+	yearRe = regexp.MustCompile(`\b(?:19|20)\d{2}\b`)
+)
+
+/*
+	Profile returns a copy of profile condensed to fit budget bytes of JSON.
+
+The profile is returned as it is when it already fits or budget is 0. Otherwise
+the passes run in order until it fits; a profile that still does not fit after
+every pass is returned as condensed as they make it.
+
+Parameters:
+  - profile: The scraped profile
+  - budget: Size of the profile's JSON to fit in, in bytes
+
+Returns:
+  - scraper.Profile: The condensed copy, sharing nothing with profile
+*/
+func Profile(profile scraper.Profile, budget int) scraper.Profile {
+	p := profile.Clone()
+	if budget <= 0 || size(p) <= budget {
+		return p
+	}
+	for _, pass := range []func(*scraper.Profile){trimAbout, foldRoles, trimWriting, trimLists} {
+		pass(&p)
+		if size(p) <= budget {
+			break
+		}
+	}
+	return p
+}
+
+func size(p scraper.Profile) int {
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return 0
+	}
+	return len(raw)
+}
+
+// trimAbout keeps the opening of a long About, which is where people say what they do.
+func trimAbout(p *scraper.Profile) {
+	p.About = cut(p.About, aboutMax)
+}
+
+/*
+	foldRoles keeps the latest roles and folds older ones into a single summary entry.
+
+Roles still held ("Present") are kept wherever they are listed, since a board
+seat or side project may sit below older full-time jobs. The summary names the
+first few folded roles and spans their years, e.g. "Earlier roles" at "Product
+Lead at Nazara; Analyst at Grab and 9 more" for "2008 - 2019".
+*/
+func foldRoles(p *scraper.Profile) {
+	if len(p.Experience) <= recentRoles+1 {
+		return
+	}
+	kept := append([]scraper.Experience(nil), p.Experience[:recentRoles]...)
+	var folded []scraper.Experience
+	for _, e := range p.Experience[recentRoles:] {
+		if strings.Contains(e.Duration, "Present") {
+			kept = append(kept, e)
+		} else {
+			folded = append(folded, e)
+		}
+	}
+	if len(folded) < 2 {
+		return
+	}
+
+	var names, years []string
+	for i, e := range folded {
+		years = append(years, yearRe.FindAllString(e.Duration, -1)...)
+		if i < foldedRoles {
+			company, _, _ := strings.Cut(e.Company, "·")
+			names = append(names, strings.TrimSpace(e.Title+" at "+strings.TrimSpace(company)))
+		}
+	}
+	summary := strings.Join(names, "; ")
+	if more := len(folded) - len(names); more > 0 {
+		summary += fmt.Sprintf(" and %d more", more)
+	}
+	var duration string
+	if len(years) > 0 {
+		sort.Strings(years)
+		duration = years[0] + " - " + years[len(years)-1]
+	}
+	p.Experience = append(kept, scraper.Experience{Title: "Earlier roles", Company: summary, Duration: duration})
+}
+
+// trimWriting shortens long posts, articles, comments and recommendations, keeping every one of them.
+func trimWriting(p *scraper.Profile) {
+	for i := range p.Posts {
+		p.Posts[i].Content = cut(p.Posts[i].Content, textMax)
+		p.Posts[i].MediaText = cut(p.Posts[i].MediaText, textMax/2)
+	}
+	for i := range p.Articles {
+		p.Articles[i].Excerpt = cut(p.Articles[i].Excerpt, textMax)
+	}
+	for i := range p.Comments {
+		p.Comments[i].Content = cut(p.Comments[i].Content, textMax)
+		p.Comments[i].PostExcerpt = cut(p.Comments[i].PostExcerpt, textMax/2)
+	}
+	for i := range p.Recommendations {
+		p.Recommendations[i].Text = cut(p.Recommendations[i].Text, textMax)
+	}
+	if p.Company != nil {
+		p.Company.About = cut(p.Company.About, textMax)
+		for i := range p.Company.Posts {
+			p.Company.Posts[i].Content = cut(p.Company.Posts[i].Content, textMax)
+		}
+	}
+}
+
+// trimLists keeps the most endorsed skills and the first entries of the other long lists,
+// which LinkedIn shows latest first.
+func trimLists(p *scraper.Profile) {
+	// Stable, so equally endorsed skills keep the order the owner gave them
+	sort.SliceStable(p.Skills, func(i, j int) bool { return p.Skills[i].Endorsements > p.Skills[j].Endorsements })
+	p.Skills = first(p.Skills, listMax)
+	p.Certifications = first(p.Certifications, listMax)
+	p.Volunteering = first(p.Volunteering, listMax/2)
+	p.Publications = first(p.Publications, listMax/2)
+	p.Patents = first(p.Patents, listMax/2)
+	p.Education = first(p.Education, listMax/2)
+}
+
+func first[T any](list []T, n int) []T {
+	if len(list) <= n {
+		return list
+	}
+	return list[:n]
+}
+
+// cut shortens text to about max bytes at the last sentence end, or else the last space,
+// marking the cut with "...".
+func cut(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+	head := strings.ToValidUTF8(text[:max], "")
+	end := 0
+	for _, m := range sentenceEndRe.FindAllStringIndex(head, -1) {
+		end = m[1]
+	}
+	if end >= max/2 {
+		return strings.TrimSpace(head[:end]) + " ..."
+	}
+	// One long sentence, or a list without full stops
+	if space := strings.LastIndex(head, " "); space > 0 {
+		head = head[:space]
+	}
+	return strings.TrimSpace(head) + "..."
+}
@@ -0,0 +1,86 @@
+package condense
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestProfileKeepsProfilesThatFit(t *testing.T) {
+	p := scraper.Profile{Name: "Priya", About: strings.Repeat("Builds data platforms. ", 100)}
+	if got := Profile(p, 1<<20); !reflect.DeepEqual(got, p) {
+		t.Errorf("profile within budget was changed: %+v", got)
+	}
+	if got := Profile(p, 0); got.About != p.About {
+		t.Error("budget 0 condensed the profile")
+	}
+}
+
+func TestProfileCondensesLongProfiles(t *testing.T) {
+	p := scraper.Profile{
+		Name:  "Priya",
+		About: "I lead data platform teams. " + strings.Repeat("Previously I built pipelines at many companies. ", 60),
+		Experience: []scraper.Experience{
+			{Title: "Engineering Manager", Company: "Moonfrog Labs · Full-time", Duration: "Mar 2021 - Present"},
+		},
+	}
+	for i := 0; i < 24; i++ {
+		p.Experience = append(p.Experience, scraper.Experience{Title: fmt.Sprintf("Engineer %d", i), Company: fmt.Sprintf("Company %d", i), Duration: fmt.Sprintf("%d - %d", 2020-i, 2021-i)})
+	}
+	// A role still held, listed below the older ones
+	p.Experience = append(p.Experience, scraper.Experience{Title: "Advisor", Company: "DataTalks", Duration: "2015 - Present"})
+
+	got := Profile(p, 2500)
+	if size(got) > 2500 {
+		t.Errorf("condensed to %d bytes, want at most 2500", size(got))
+	}
+	if !strings.HasPrefix(got.About, "I lead data platform teams.") || !strings.HasSuffix(got.About, "companies. ...") || len(got.About) > aboutMax+4 {
+		t.Errorf("About = %q, want its opening cut after a sentence", got.About)
+	}
+	if len(got.Experience) != recentRoles+2 {
+		t.Fatalf("kept %d roles, want %d: %+v", len(got.Experience), recentRoles+2, got.Experience)
+	}
+	if got.Experience[0].Title != "Engineering Manager" || got.Experience[recentRoles].Title != "Advisor" {
+		t.Errorf("roles = %+v, want the latest and the ones still held first", got.Experience)
+	}
+	summary := got.Experience[recentRoles+1]
+	if summary.Title != "Earlier roles" || summary.Duration != "1997 - 2016" || !strings.HasPrefix(summary.Company, "Engineer 5 at Company 5; Engineer 6 at Company 6") || !strings.HasSuffix(summary.Company, " and 14 more") {
+		t.Errorf("summary = %+v", summary)
+	}
+	if len(p.Experience) != 26 || len(p.About) < 2000 {
+		t.Error("Profile changed the profile it was given")
+	}
+}
+
+func TestProfileTrimsWritingAndLists(t *testing.T) {
+	p := scraper.Profile{Name: "Priya"}
+	for i := 0; i < 5; i++ {
+		p.Posts = append(p.Posts, scraper.Post{Content: strings.Repeat("Streaming beats batch for live-ops analytics. ", 40)})
+	}
+	for i := 0; i < 20; i++ {
+		p.Skills = append(p.Skills, scraper.Skill{Name: fmt.Sprintf("Skill %d", i), Endorsements: i % 4})
+	}
+
+	got := Profile(p, 3500)
+	if len(got.Posts) != 5 || len(got.Posts[0].Content) > textMax+4 {
+		t.Errorf("posts = %d, first %d bytes, want all 5 trimmed", len(got.Posts), len(got.Posts[0].Content))
+	}
+	if len(got.Skills) != listMax || got.Skills[0].Name != "Skill 3" || got.Skills[1].Name != "Skill 7" {
+		t.Errorf("skills = %+v, want the %d most endorsed", got.Skills, listMax)
+	}
+}
+
+func TestCut(t *testing.T) {
+	for _, c := range []struct{ text, want string }{
+		{"Short.", "Short."},
+		{"First sentence here. Second one runs on", "First sentence here. ..."},
+		{"one long run of words without any stop", "one long run of..."},
+	} {
+		if got := cut(c.text, 20); got != c.want {
+			t.Errorf("cut(%q) = %q, want %q", c.text, got, c.want)
+		}
+	}
+}
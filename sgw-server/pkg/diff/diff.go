@@ -0,0 +1,94 @@
+/*
+	Package diff computes word-level differences between two short texts.
+
+It is meant for connect messages, a couple of lines each, where a word diff
+reads better than a line diff and the quadratic LCS is cheap.
+
+Basic usage:
+
+	for _, op := range diff.Words(oldMsg, newMsg) {
+	    fmt.Println(op.Kind, op.Text)
+	}
+*/
+package diff
+
+import "strings"
+
+// Kind says whether an Op's text is in both texts, only the new one or only the old one.
+type Kind string
+
+const (
+	KindEqual  Kind = "equal"
+	KindInsert Kind = "insert"
+	KindDelete Kind = "delete"
+)
+
+/*
+	Op is a run of consecutive words with the same Kind.
+
+Words inside Text are separated by single spaces; the original spacing is not kept.
+*/
+type Op struct {
+	Kind Kind   `json:"kind"`
+	Text string `json:"text"`
+}
+
+/*
+	Words diffs old against new word by word.
+
+Parameters:
+  - old: The previous text
+  - new: The replacement text
+
+Returns:
+  - []Op: Runs that rebuild old from the equal and delete ops, and new from
+    the equal and insert ops. Deletes come before inserts where both apply.
+*/
+func Words(old, new string) []Op {
+	a, b := strings.Fields(old), strings.Fields(new)
+
+	// lcs[i][j] is the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []Op
+	add := func(kind Kind, word string) {
+		if n := len(ops); n > 0 && ops[n-1].Kind == kind {
+			ops[n-1].Text += " " + word
+			return
+		}
+		ops = append(ops, Op{Kind: kind, Text: word})
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			add(KindEqual, a[i])
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			add(KindDelete, a[i])
+			i++
+		default:
+			add(KindInsert, b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		add(KindDelete, a[i])
+	}
+	for ; j < len(b); j++ {
+		add(KindInsert, b[j])
+	}
+	return ops
+}
@@ -0,0 +1,101 @@
+/*
+	Package enrich gathers what professional sources outside LinkedIn say about a prospect.
+
+LinkedIn stays the primary record. Each Source looks at the scraped profile,
+typically the websites from its contact info or its current employer, and
+contributes Enrichments that are stored with the prospect and handed to the
+model next to the profile. A Pipeline runs the sources within their timeouts
+and records how each fared, which together with the profile makes up the
+ProspectContext generation writes from.
+
+Basic usage:
+
+	sources, err := enrich.New([]string{"github", "website", "news"}, githubToken, summarize)
+	if err != nil {
+	    log.Fatal(err)
+	}
+	pipeline := &enrich.Pipeline{Sources: sources, Timeouts: map[string]time.Duration{"github": 5 * time.Second}}
+	enrichments, outcomes, err := pipeline.Run(ctx, profile)
+*/
+package enrich
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// Names lists the sources New knows, in the order their results are reported.
+var Names = []string{"github", "website", "news"}
+
+/*
+	Enrichment is what one source found about a prospect.
+
+Summary and Facts are short human readable texts passed to the model as is.
+*/
+type Enrichment struct {
+	Source  string   `json:"source"`            // Source name, e.g. "github"
+	URL     string   `json:"url"`               // Page the data was taken from
+	Summary string   `json:"summary,omitempty"` // Bio, description or summary, empty when the page has none
+	Facts   []string `json:"facts,omitempty"`   // e.g. "Maintains kafka-lag (Go, 320 stars)"
+}
+
+/*
+	Source contributes enrichment data about a prospect from outside LinkedIn.
+
+Enrich returns nothing, and no error, for profiles the source has nothing on.
+*/
+type Source interface {
+	Name() string
+	Enrich(ctx context.Context, profile scraper.Profile) ([]Enrichment, error)
+}
+
+/*
+	New builds the named sources.
+
+Parameters:
+  - names: Source names from Names
+  - githubToken: Optional GitHub token, raising the API rate limit
+  - summarize: Optional summarizer for the website source, see Website
+
+Returns:
+  - []Source: The sources in the order named
+  - error: An error naming the first unknown source
+*/
+func New(names []string, githubToken string, summarize Summarize) ([]Source, error) {
+	sources := make([]Source, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case "github":
+			sources = append(sources, &GitHub{Token: githubToken})
+		case "website":
+			sources = append(sources, &Website{Summarize: summarize})
+		case "news":
+			sources = append(sources, &News{})
+		default:
+			return nil, fmt.Errorf("unknown enrichment source %q, expected one of %s", name, strings.Join(Names, ", "))
+		}
+	}
+	return sources, nil
+}
+
+// websites returns the profile's websites that parse as http(s) URLs.
+func websites(profile scraper.Profile) []*url.URL {
+	var urls []*url.URL
+	for _, site := range profile.ContactInfo.Websites {
+		u, err := url.Parse(site)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			continue
+		}
+		urls = append(urls, u)
+	}
+	return urls
+}
+
+// host returns u's host without a www. prefix.
+func host(u *url.URL) string {
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
@@ -0,0 +1,286 @@
+package enrich
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestGitHub(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/priya":
+			fmt.Fprint(w, `{"login": "priya", "bio": "Streaming data at Moonfrog", "company": "@moonfrog", "public_repos": 12, "followers": 80, "html_url": "https://github.com/priya"}`)
+		case "/users/priya/repos":
+			fmt.Fprint(w, `[{"name": "forked", "language": "C", "stargazers_count": 900, "fork": true},
+				{"name": "small", "language": "Python", "stargazers_count": 2},
+				{"name": "kafka-lag", "description": "Consumer lag alerts", "language": "Go", "stargazers_count": 320},
+				{"name": "dotfiles", "stargazers_count": 1},
+				{"name": "topic-gc", "language": "Go", "stargazers_count": 40}]`)
+		case "/graphql":
+			if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer secret" {
+				t.Errorf("%s /graphql with Authorization %q", r.Method, r.Header.Get("Authorization"))
+			}
+			var req struct{ Variables map[string]string }
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Variables["login"] != "priya" {
+				t.Errorf("graphql variables = %v, %v", req.Variables, err)
+			}
+			fmt.Fprint(w, `{"data": {"user": {"pinnedItems": {"nodes": [
+				{"name": "flink-notes", "description": "Talk notes", "stargazerCount": 15},
+				{"name": "topic-gc", "stargazerCount": 40, "primaryLanguage": {"name": "Go"}}]}}}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer api.Close()
+
+	facts := []string{
+		"12 public repositories, 80 followers",
+		"Company on GitHub: @moonfrog",
+		"Writes mostly Go, Python",
+	}
+	tests := []struct {
+		name    string
+		token   string
+		profile scraper.Profile
+		repos   []string
+	}{
+		{
+			name:    "pinned repositories with a token",
+			token:   "secret",
+			profile: scraper.Profile{ContactInfo: scraper.ContactInfo{Websites: []string{"https://github.com/other/some-repo", "https://www.github.com/priya/"}}},
+			repos:   []string{"Pinned flink-notes (15 stars): Talk notes", "Pinned topic-gc (Go, 40 stars)"},
+		},
+		{
+			name:    "starred repositories without one, linked from the About section",
+			profile: scraper.Profile{About: "Code at github.com/priya/kafka-lag and more on https://github.com/priya."},
+			repos:   []string{"Maintains kafka-lag (Go, 320 stars): Consumer lag alerts", "Maintains topic-gc (Go, 40 stars)", "Maintains small (Python, 2 stars)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &GitHub{Token: tt.token, BaseURL: api.URL, Client: api.Client()}
+			got, err := g.Enrich(context.Background(), tt.profile)
+			if err != nil {
+				t.Fatalf("Enrich: %v", err)
+			}
+			want := []Enrichment{{Source: "github", URL: "https://github.com/priya", Summary: "Streaming data at Moonfrog", Facts: append(slices.Clone(facts), tt.repos...)}}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("enrichments = %+v\nwant %+v", got, want)
+			}
+		})
+	}
+
+	for _, profile := range []scraper.Profile{
+		{ContactInfo: scraper.ContactInfo{Websites: []string{"https://example.com", "https://github.com/orgs/moonfrog"}}},
+		{About: "I read github.com/trending and mygithub.com/priya"},
+	} {
+		if got, err := (&GitHub{BaseURL: api.URL}).Enrich(context.Background(), profile); got != nil || err != nil {
+			t.Errorf("profile without a GitHub user page %+v = %+v, %v", profile, got, err)
+		}
+	}
+}
+
+func TestGitHubKeepsStarredRepositoriesWhenPinnedFail(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/priya":
+			fmt.Fprint(w, `{"login": "priya"}`)
+		case "/users/priya/repos":
+			fmt.Fprint(w, `[{"name": "kafka-lag", "stargazers_count": 320}]`)
+		case "/graphql":
+			fmt.Fprint(w, `{"errors": [{"message": "Your token has not been granted the required scopes"}]}`)
+		}
+	}))
+	defer api.Close()
+
+	g := &GitHub{Token: "secret", BaseURL: api.URL, Client: api.Client()}
+	got, err := g.Enrich(context.Background(), scraper.Profile{ContactInfo: scraper.ContactInfo{Websites: []string{"https://github.com/priya"}}})
+	if err == nil {
+		t.Error("the GraphQL error was not reported")
+	}
+	if len(got) != 1 || !slices.Contains(got[0].Facts, "Maintains kafka-lag (320 stars)") {
+		t.Errorf("enrichments = %+v, want the starred repositories", got)
+	}
+}
+
+func TestWebsite(t *testing.T) {
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, `<html><head><title>Priya Raman &amp; friends</title>
+				<meta content='Notes on streaming
+				data platforms' property="og:description"></head><body></body></html>`)
+		case "/cv.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+		}
+	}))
+	defer site.Close()
+
+	w := &Website{Client: site.Client()}
+	profile := scraper.Profile{ContactInfo: scraper.ContactInfo{Websites: []string{"https://github.com/priya", site.URL + "/", site.URL + "/cv.pdf", site.URL + "/not-fetched"}}}
+	got, err := w.Enrich(context.Background(), profile)
+	want := []Enrichment{{Source: "website", URL: site.URL + "/", Summary: "Notes on streaming data platforms", Facts: []string{"Page title: Priya Raman & friends"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("enrichments = %+v\nwant %+v", got, want)
+	}
+	if err == nil {
+		t.Error("a PDF website was not reported")
+	}
+}
+
+func TestWebsiteSummarizesAboutPageAndBlog(t *testing.T) {
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html><head><title>Priya Raman</title></head><body>
+				<nav><a href="/about-me">About</a> <a href="/blog/">Blog</a> <a href="https://elsewhere.com/blog/x">Elsewhere post</a></nav>
+				<main><p>I build <b>streaming</b> data platforms for mobile games.</p><p>Hi!</p></main>
+				<footer><p>Copyright 2024 Priya Raman, all rights reserved</p></footer></body></html>`)
+		case "/about-me":
+			fmt.Fprint(w, `<html><body><article><h1>About me</h1><p>Data engineer at Moonfrog, ten years on Kafka and Flink.</p></article></body></html>`)
+		case "/blog/":
+			fmt.Fprint(w, `<html><body><script>var p = "<a href='/blog/fake'>Fake post</a>";</script>
+				<article><h2><a href="/blog/kafka-lag">Alerting on Kafka consumer lag</a></h2></article>
+				<article><h2><a href="/blog/kafka-lag#comments">Alerting on Kafka consumer lag</a></h2></article>
+				<article><h2><a href="/blog/flink">Why we moved to Flink</a></h2></article></body></html>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer site.Close()
+
+	var summarized string
+	w := &Website{Client: site.Client(), Summarize: func(ctx context.Context, s, text string) (string, error) {
+		summarized = text
+		return " Builds streaming data platforms at Moonfrog and blogs about Kafka. ", nil
+	}}
+	got, err := w.Enrich(context.Background(), scraper.Profile{ContactInfo: scraper.ContactInfo{Websites: []string{site.URL}}})
+	if err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	want := []Enrichment{{Source: "website", URL: site.URL, Summary: "Builds streaming data platforms at Moonfrog and blogs about Kafka.", Facts: []string{
+		"Page title: Priya Raman",
+		`Blog post: "Alerting on Kafka consumer lag"`,
+		`Blog post: "Why we moved to Flink"`,
+	}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("enrichments = %+v\nwant %+v", got, want)
+	}
+	wantText := "Title: Priya Raman\nHomepage:\nI build streaming data platforms for mobile games.\n" +
+		"About page:\nData engineer at Moonfrog, ten years on Kafka and Flink.\n" +
+		"Blog posts:\n- Alerting on Kafka consumer lag\n- Why we moved to Flink"
+	if summarized != wantText {
+		t.Errorf("summarized %q\nwant %q", summarized, wantText)
+	}
+
+	// Without a summary the about page stands in for the missing description
+	w.Summarize = func(ctx context.Context, s, text string) (string, error) { return "", errors.New("rate limited") }
+	got, err = w.Enrich(context.Background(), scraper.Profile{ContactInfo: scraper.ContactInfo{Websites: []string{site.URL}}})
+	if err == nil || len(got) != 1 || got[0].Summary != "Data engineer at Moonfrog, ten years on Kafka and Flink." {
+		t.Errorf("failed summary: %+v, %v", got, err)
+	}
+}
+
+func TestWebsiteRefusesPrivateAddresses(t *testing.T) {
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the default client reached a loopback address")
+	}))
+	defer site.Close()
+
+	_, err := (&Website{}).Enrich(context.Background(), scraper.Profile{ContactInfo: scraper.ContactInfo{Websites: []string{site.URL}}})
+	if !errors.Is(err, errPrivateAddress) {
+		t.Errorf("err = %v, want errPrivateAddress", err)
+	}
+}
+
+func TestPipelineKeepsWhatWorkingSourcesFound(t *testing.T) {
+	sources, err := New([]string{"website", "github", "news"}, "", nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if sources[0].Name() != "website" || sources[1].Name() != "github" || sources[2].Name() != "news" {
+		t.Errorf("sources = %v, want the order named", sources)
+	}
+	if _, err := New([]string{"myspace"}, "", nil); err == nil {
+		t.Error("unknown source accepted")
+	}
+
+	failing := &GitHub{BaseURL: "http://127.0.0.1:1"}
+	found := fakeSource{{Source: "fake", URL: "https://example.com"}}
+	p := &Pipeline{Sources: []Source{failing, found, slowSource{}}, Timeouts: map[string]time.Duration{"slow": 10 * time.Millisecond}}
+	start := time.Now()
+	got, outcomes, err := p.Run(context.Background(), scraper.Profile{ContactInfo: scraper.ContactInfo{Websites: []string{"https://github.com/priya"}}})
+	if len(got) != 1 || got[0].Source != "fake" || err == nil {
+		t.Errorf("Run = %+v, %v; want the working source's result and the failures", got, err)
+	}
+	if len(outcomes) != 3 || outcomes[0].OK || outcomes[0].Error == "" || !outcomes[1].OK || outcomes[2].OK {
+		t.Errorf("outcomes = %+v, want github and slow failed", outcomes)
+	}
+	if took := time.Since(start); took > time.Second {
+		t.Errorf("Run took %s, the slow source's timeout is 10ms", took)
+	}
+
+	// The budget bounds sources without a timeout of their own
+	p = &Pipeline{Sources: []Source{slowSource{}}, Budget: 10 * time.Millisecond}
+	if _, outcomes, _ := p.Run(context.Background(), scraper.Profile{}); outcomes[0].OK || time.Since(start) > 2*time.Second {
+		t.Errorf("outcomes = %+v, want the slow source stopped by the budget", outcomes)
+	}
+}
+
+func TestNews(t *testing.T) {
+	recent := time.Now().Add(-48 * time.Hour).UTC()
+	feed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("q"); r.URL.Path != "/rss/search" || q != `"Moonfrog Labs"` {
+			t.Errorf("searched %s for %q", r.URL.Path, q)
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprintf(w, `<?xml version="1.0"?><rss><channel>
+			<item><title>Moonfrog Labs raises $20M for live-ops - TechCrunch</title><link>https://news.example/1</link><pubDate>%s</pubDate><source url="https://techcrunch.com">TechCrunch</source></item>
+			<item><title>Moonfrog launches Teen Patti Gold 2 - Inc42</title><pubDate>Mon, 02 Jan 2006 15:04:05 GMT</pubDate><source>Inc42</source></item>
+		</channel></rss>`, recent.Format(time.RFC1123))
+	}))
+	defer feed.Close()
+
+	n := &News{BaseURL: feed.URL, Client: feed.Client()}
+	got, err := n.Enrich(context.Background(), scraper.Profile{Experience: []scraper.Experience{{Company: "Moonfrog Labs · Full-time"}}})
+	if err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	want := "Moonfrog Labs raises $20M for live-ops (TechCrunch, " + recent.Format("Jan 2006") + ")"
+	if len(got) != 1 || got[0].Source != "news" || !reflect.DeepEqual(got[0].Facts, []string{want}) {
+		t.Errorf("enrichments = %+v, want only the recent headline", got)
+	}
+	if got, err := n.Enrich(context.Background(), scraper.Profile{}); got != nil || err != nil {
+		t.Errorf("profile without an employer = %+v, %v", got, err)
+	}
+}
+
+type fakeSource []Enrichment
+
+func (f fakeSource) Name() string { return "fake" }
+
+func (f fakeSource) Enrich(ctx context.Context, profile scraper.Profile) ([]Enrichment, error) {
+	return f, nil
+}
+
+// slowSource finds nothing until its context is done.
+type slowSource struct{}
+
+func (slowSource) Name() string { return "slow" }
+
+func (slowSource) Enrich(ctx context.Context, profile scraper.Profile) ([]Enrichment, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
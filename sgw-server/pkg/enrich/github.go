@@ -0,0 +1,285 @@
+package enrich
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+const (
+	// githubRepos caps how many repositories a GitHub enrichment lists.
+	githubRepos = 3
+	// githubLanguages caps how many languages a GitHub enrichment lists.
+	githubLanguages = 3
+)
+
+var (
+	// githubLinkRe finds github.com/<login> links in free text such as the About section.
+	githubLinkRe = regexp.MustCompile(`(?i)(?:^|[^\w.-])(?:https?://)?(?:www\.)?github\.com/([a-z0-9][a-z0-9-]{0,38})\b/?(?:[^/\w-]|$)`)
+	// githubLoginRe matches valid GitHub logins.
+	githubLoginRe = regexp.MustCompile(`(?i)^[a-z0-9][a-z0-9-]{0,38}$`)
+)
+
+// githubReserved lists top level github.com paths that are not user pages.
+var githubReserved = map[string]bool{
+	"about": true, "apps": true, "collections": true, "enterprise": true, "events": true, "explore": true, "features": true,
+	"issues": true, "join": true, "login": true, "marketplace": true, "new": true, "notifications": true, "orgs": true,
+	"pricing": true, "pulls": true, "search": true, "security": true, "settings": true, "site": true, "sponsors": true,
+	"topics": true, "trending": true,
+}
+
+// githubPinnedQuery asks the GraphQL API for a user's pinned repositories, which the REST API doesn't have.
+const githubPinnedQuery = `query($login: String!) {
+  user(login: $login) {
+    pinnedItems(first: 6, types: REPOSITORY) {
+      nodes { ... on Repository { name description stargazerCount primaryLanguage { name } } }
+    }
+  }
+}`
+
+/*
+	GitHub enriches prospects that link a GitHub profile with their bio, languages and projects.
+
+The profile is found among the contact info websites, or else as a github.com
+link in the About section. Pinned repositories are listed when Token is set,
+since the GraphQL API they come from requires one; otherwise, or when nothing
+is pinned, the best starred own repositories are listed instead.
+
+BaseURL and Client default to the public API and a client with a 10 second
+timeout. Unauthenticated requests are limited to 60 an hour, set Token for more.
+*/
+type GitHub struct {
+	Token   string
+	BaseURL string
+	Client  *http.Client
+}
+
+type githubUser struct {
+	Login       string `json:"login"`
+	Name        string `json:"name"`
+	Bio         string `json:"bio"`
+	Company     string `json:"company"`
+	PublicRepos int    `json:"public_repos"`
+	Followers   int    `json:"followers"`
+	HTMLURL     string `json:"html_url"`
+}
+
+type githubRepo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Language    string `json:"language"`
+	Stars       int    `json:"stargazers_count"`
+	Fork        bool   `json:"fork"`
+}
+
+type githubPinned struct {
+	Data struct {
+		User struct {
+			PinnedItems struct {
+				Nodes []struct {
+					Name            string `json:"name"`
+					Description     string `json:"description"`
+					StargazerCount  int    `json:"stargazerCount"`
+					PrimaryLanguage *struct {
+						Name string `json:"name"`
+					} `json:"primaryLanguage"`
+				} `json:"nodes"`
+			} `json:"pinnedItems"`
+		} `json:"user"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func (g *GitHub) Name() string { return "github" }
+
+/*
+	Enrich looks up the GitHub profile the prospect links.
+
+Parameters:
+  - ctx: Context bounding the API requests
+  - profile: The scraped LinkedIn profile
+
+Returns:
+  - []Enrichment: One enrichment, or none when no GitHub profile is linked
+  - error: Any error calling the GitHub API, returned with the enrichment when only the pinned repositories failed
+*/
+func (g *GitHub) Enrich(ctx context.Context, profile scraper.Profile) ([]Enrichment, error) {
+	login := githubLogin(profile)
+	if login == "" {
+		return nil, nil
+	}
+
+	var user githubUser
+	if err := g.get(ctx, "/users/"+login, &user); err != nil {
+		return nil, err
+	}
+	var repos []githubRepo
+	if err := g.get(ctx, "/users/"+login+"/repos?type=owner&sort=pushed&per_page=100", &repos); err != nil {
+		return nil, err
+	}
+
+	e := Enrichment{Source: g.Name(), URL: user.HTMLURL, Summary: strings.TrimSpace(user.Bio)}
+	if e.URL == "" {
+		e.URL = "https://github.com/" + login
+	}
+	e.Facts = append(e.Facts, fmt.Sprintf("%d public repositories, %d followers", user.PublicRepos, user.Followers))
+	if company := strings.TrimSpace(user.Company); company != "" {
+		e.Facts = append(e.Facts, "Company on GitHub: "+company)
+	}
+
+	// Their own work, best known first
+	own := repos[:0]
+	for _, r := range repos {
+		if !r.Fork {
+			own = append(own, r)
+		}
+	}
+	sort.SliceStable(own, func(i, j int) bool { return own[i].Stars > own[j].Stars })
+	if languages := topLanguages(own); len(languages) > 0 {
+		e.Facts = append(e.Facts, "Writes mostly "+strings.Join(languages, ", "))
+	}
+
+	// A token without access to the GraphQL API still gets the starred repositories
+	var pinned []githubRepo
+	var err error
+	if g.Token != "" {
+		pinned, err = g.pinned(ctx, login)
+	}
+	if len(pinned) > 0 {
+		for _, r := range pinned {
+			e.Facts = append(e.Facts, repoFact("Pinned", r))
+		}
+	} else {
+		for _, r := range own[:min(len(own), githubRepos)] {
+			e.Facts = append(e.Facts, repoFact("Maintains", r))
+		}
+	}
+	return []Enrichment{e}, err
+}
+
+// githubLogin returns the login of the first GitHub user page in the profile's
+// websites or About section, or "" when there is none.
+func githubLogin(profile scraper.Profile) string {
+	for _, u := range websites(profile) {
+		if host(u) != "github.com" {
+			continue
+		}
+		// Only user pages, not links to a single repository or gist
+		if parts := strings.Split(strings.Trim(u.Path, "/"), "/"); len(parts) == 1 && isGitHubLogin(parts[0]) {
+			return parts[0]
+		}
+	}
+	for _, m := range githubLinkRe.FindAllStringSubmatch(profile.About, -1) {
+		if isGitHubLogin(m[1]) {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+func isGitHubLogin(login string) bool {
+	return githubLoginRe.MatchString(login) && !githubReserved[strings.ToLower(login)]
+}
+
+// topLanguages returns the most used primary languages of repos, most used first.
+func topLanguages(repos []githubRepo) []string {
+	counts := map[string]int{}
+	var languages []string
+	for _, r := range repos {
+		if r.Language == "" {
+			continue
+		}
+		if counts[r.Language] == 0 {
+			languages = append(languages, r.Language)
+		}
+		counts[r.Language]++
+	}
+	// Stable, so ties keep the order of the best starred repository using them
+	sort.SliceStable(languages, func(i, j int) bool { return counts[languages[i]] > counts[languages[j]] })
+	return languages[:min(len(languages), githubLanguages)]
+}
+
+// repoFact describes r, e.g. "Maintains kafka-lag (Go, 320 stars): Consumer lag alerts".
+func repoFact(verb string, r githubRepo) string {
+	fact := fmt.Sprintf("%s %s (", verb, r.Name)
+	if r.Language != "" {
+		fact += r.Language + ", "
+	}
+	fact += fmt.Sprintf("%d stars)", r.Stars)
+	if r.Description != "" {
+		fact += ": " + r.Description
+	}
+	return fact
+}
+
+// pinned returns the repositories login pinned to their profile, in their order.
+func (g *GitHub) pinned(ctx context.Context, login string) ([]githubRepo, error) {
+	body, err := json.Marshal(map[string]any{"query": githubPinnedQuery, "variables": map[string]string{"login": login}})
+	if err != nil {
+		return nil, err
+	}
+	var res githubPinned
+	if err := g.do(ctx, http.MethodPost, "/graphql", bytes.NewReader(body), &res); err != nil {
+		return nil, err
+	}
+	if len(res.Errors) > 0 {
+		return nil, fmt.Errorf("github graphql: %s", res.Errors[0].Message)
+	}
+
+	var repos []githubRepo
+	for _, n := range res.Data.User.PinnedItems.Nodes {
+		r := githubRepo{Name: n.Name, Description: n.Description, Stars: n.StargazerCount}
+		if n.PrimaryLanguage != nil {
+			r.Language = n.PrimaryLanguage.Name
+		}
+		repos = append(repos, r)
+	}
+	return repos, nil
+}
+
+func (g *GitHub) get(ctx context.Context, path string, out any) error {
+	return g.do(ctx, http.MethodGet, path, nil, out)
+}
+
+func (g *GitHub) do(ctx context.Context, method, path string, body io.Reader, out any) error {
+	base := g.BaseURL
+	if base == "" {
+		base = "https://api.github.com"
+	}
+	req, err := http.NewRequestWithContext(ctx, method, base+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if g.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.Token)
+	}
+
+	client := g.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("github responded to %s with %s", path, res.Status)
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
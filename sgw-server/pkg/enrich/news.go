@@ -0,0 +1,118 @@
+package enrich
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+const (
+	// newsItems caps how many headlines a news enrichment lists.
+	newsItems = 3
+	// newsMaxAge drops headlines older than this, they no longer make a timely opener.
+	newsMaxAge = 90 * 24 * time.Hour
+)
+
+/*
+	News enriches prospects with recent headlines about their current employer,
+
+searched for by name on Google News. Prospects without a current employer are
+left alone.
+
+BaseURL and Client default to https://news.google.com and a client with a 10
+second timeout.
+*/
+type News struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+type newsFeed struct {
+	Items []struct {
+		Title   string `xml:"title"`
+		Link    string `xml:"link"`
+		PubDate string `xml:"pubDate"`
+		Source  string `xml:"source"`
+	} `xml:"channel>item"`
+}
+
+func (n *News) Name() string { return "news" }
+
+/*
+	Enrich searches the news for the profile's current employer.
+
+Parameters:
+  - ctx: Context bounding the request
+  - profile: The scraped LinkedIn profile
+
+Returns:
+  - []Enrichment: One enrichment listing the latest headlines, none when there are no recent ones
+  - error: Any error encountered while searching
+*/
+func (n *News) Enrich(ctx context.Context, profile scraper.Profile) ([]Enrichment, error) {
+	company := currentCompany(profile)
+	if company == "" {
+		return nil, nil
+	}
+	base, client := n.BaseURL, n.Client
+	if base == "" {
+		base = "https://news.google.com"
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	query := url.Values{"q": {`"` + company + `"`}, "hl": {"en-US"}, "gl": {"US"}, "ceid": {"US:en"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/rss/search?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("news search for %q responded with %s", company, res.Status)
+	}
+	var feed newsFeed
+	if err := xml.NewDecoder(res.Body).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("news search for %q: %w", company, err)
+	}
+
+	var facts []string
+	for _, item := range feed.Items {
+		published, err := time.Parse(time.RFC1123, item.PubDate)
+		if err != nil || time.Since(published) > newsMaxAge {
+			continue
+		}
+		// Google News titles end in " - <publisher>", the publisher is added back below
+		title := clean(strings.TrimSuffix(item.Title, " - "+item.Source))
+		facts = append(facts, fmt.Sprintf("%s (%s, %s)", title, clean(item.Source), published.Format("Jan 2006")))
+		if len(facts) == newsItems {
+			break
+		}
+	}
+	if len(facts) == 0 {
+		return nil, nil
+	}
+	return []Enrichment{{Source: n.Name(), URL: "https://news.google.com/search?" + query.Encode(), Summary: "Recent news about " + company, Facts: facts}}, nil
+}
+
+// currentCompany returns the name of the profile's current employer, "" when it lists none.
+func currentCompany(profile scraper.Profile) string {
+	if profile.Company != nil && profile.Company.Name != "" {
+		return profile.Company.Name
+	}
+	if len(profile.Experience) == 0 {
+		return ""
+	}
+	company, _, _ := strings.Cut(profile.Experience[0].Company, "·")
+	return strings.TrimSpace(company)
+}
@@ -0,0 +1,128 @@
+package enrich
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+const (
+	// DefaultBudget bounds all the sources of a prospect together.
+	DefaultBudget = 15 * time.Second
+	// DefaultTimeout bounds a single source without a timeout of its own.
+	DefaultTimeout = 10 * time.Second
+)
+
+/*
+	ProspectContext is everything gathered about a prospect that generation writes from.
+
+Profile is what LinkedIn showed; Enrichment is what the other sources found
+about it; Job is the open role the message is about and Goal what the sender
+is reaching out for, if any. Sources records how every source fared, LinkedIn
+sections included, so a thin message can be traced back to what failed.
+*/
+type ProspectContext struct {
+	Profile    scraper.Profile
+	Enrichment []Enrichment
+	Job        *scraper.Job
+	Goal       string
+	Sources    []Outcome
+}
+
+/*
+	Outcome records how one source fared for a prospect.
+
+LinkedIn sections are reported as "linkedin:<section>", e.g. "linkedin:posts".
+A source that failed may still have contributed what it found before failing.
+*/
+type Outcome struct {
+	Source  string `json:"source"`
+	OK      bool   `json:"ok"`
+	Skipped bool   `json:"skipped,omitempty"` // Not run, the budget ran out first
+	Error   string `json:"error,omitempty"`
+}
+
+// LinkedInOutcomes reports the sections of a budgeted scrape as outcomes.
+func LinkedInOutcomes(results []scraper.SectionResult) []Outcome {
+	outcomes := make([]Outcome, 0, len(results))
+	for _, r := range results {
+		o := Outcome{Source: "linkedin:" + string(r.Section), OK: !r.Skipped && r.Err == nil, Skipped: r.Skipped}
+		if r.Err != nil {
+			o.Error = r.Err.Error()
+		}
+		outcomes = append(outcomes, o)
+	}
+	return outcomes
+}
+
+/*
+	Pipeline runs the configured sources for a prospect at once.
+
+Each source gets its own timeout, from Timeouts by source name or else
+DefaultTimeout, and all of them together get Budget, DefaultBudget when zero.
+A source still running when its time is up is cancelled and reported as failed;
+the rest keep what they found.
+*/
+type Pipeline struct {
+	Sources  []Source
+	Timeouts map[string]time.Duration
+	Budget   time.Duration
+}
+
+/*
+	Run asks every source about profile at once.
+
+Parameters:
+  - ctx: Context bounding the whole run, on top of Budget
+  - profile: The scraped LinkedIn profile
+
+Returns:
+  - []Enrichment: Results in source order
+  - []Outcome: One outcome per source, in source order
+  - error: The errors of the sources that failed, joined
+*/
+func (p *Pipeline) Run(ctx context.Context, profile scraper.Profile) ([]Enrichment, []Outcome, error) {
+	budget := p.Budget
+	if budget <= 0 {
+		budget = DefaultBudget
+	}
+	ctx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	found := make([][]Enrichment, len(p.Sources))
+	errs := make([]error, len(p.Sources))
+	var wg sync.WaitGroup
+	for i, source := range p.Sources {
+		timeout := p.Timeouts[source.Name()]
+		if timeout <= 0 {
+			timeout = DefaultTimeout
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			found[i], errs[i] = source.Enrich(ctx, profile)
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("%s: %w", source.Name(), errs[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	var enrichments []Enrichment
+	outcomes := make([]Outcome, 0, len(p.Sources))
+	for i, source := range p.Sources {
+		enrichments = append(enrichments, found[i]...)
+		o := Outcome{Source: source.Name(), OK: errs[i] == nil}
+		if errs[i] != nil {
+			o.Error = errs[i].Error()
+		}
+		outcomes = append(outcomes, o)
+	}
+	return enrichments, outcomes, errors.Join(errs...)
+}
@@ -0,0 +1,375 @@
+package enrich
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+const (
+	// websitePages caps how many of a profile's websites are fetched.
+	websitePages = 2
+	// websiteMaxBytes caps how much of a page is read.
+	websiteMaxBytes = 512 << 10
+	// websiteMaxText caps the page text handed to Summarize.
+	websiteMaxText = 6000
+	// websitePosts caps how many blog post titles are reported per website.
+	websitePosts = 5
+)
+
+var (
+	titleRe     = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	metaRe      = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attrRe      = regexp.MustCompile(`(?is)([a-z][a-z:-]*)\s*=\s*("[^"]*"|'[^']*')`)
+	linkRe      = regexp.MustCompile(`(?is)<a\s([^>]*)>(.*?)</a>`)
+	contentRe   = regexp.MustCompile(`(?is)<(?:article|main)\b[^>]*>(.*)</(?:article|main)>`)
+	paragraphRe = regexp.MustCompile(`(?is)<(?:p|h[1-3]|li)\b[^>]*>(.*?)</(?:p|h[1-3]|li)>`)
+	tagRe       = regexp.MustCompile(`(?s)<[^>]*>`)
+	// hiddenRes match elements that aren't rendered and furnitureRes the page furniture around
+	// the content, one regexp per element since RE2 has no backreferences to pair the closing tag
+	hiddenRes    = elementRes("script", "style", "noscript", "svg", "template")
+	furnitureRes = elementRes("nav", "header", "footer", "aside", "form")
+)
+
+// blogSegments name the path segments blogs are kept under.
+var blogSegments = []string{"blog", "posts", "writing", "articles", "notes"}
+
+// errPrivateAddress is returned for websites resolving to an address that isn't public.
+var errPrivateAddress = errors.New("website resolves to a private address")
+
+// sourceHosts names the sources of known professional sites; other sites are "website".
+var sourceHosts = map[string]string{
+	"wellfound.com": "wellfound",
+	"angel.co":      "wellfound",
+}
+
+/*
+	Summarize condenses the text read from a prospect's website into a short summary.
+
+It is handed the context of the enrichment, the website URL and its text,
+homepage and about page, with the titles of its blog posts, and returns what the
+site says about its owner.
+*/
+type Summarize func(ctx context.Context, site, text string) (string, error)
+
+/*
+	Website enriches prospects with what the websites they list say about them,
+
+such as a personal site, a blog or a Wellfound profile. GitHub profiles are left
+to the GitHub source.
+
+Besides the homepage it reads the site's about page and the titles of its blog
+posts. With Summarize set the text of those pages is summarized; without it, or
+when it fails, the summary is the site's own description.
+
+Websites come from a page anyone can edit, so the default Client refuses to
+connect to loopback, private and link-local addresses; only set Client to reach
+those on purpose, e.g. in tests.
+*/
+type Website struct {
+	Client    *http.Client
+	Summarize Summarize
+}
+
+func (w *Website) Name() string { return "website" }
+
+/*
+	Enrich fetches the first websites of the profile and reads what they say about the prospect.
+
+Parameters:
+  - ctx: Context bounding the requests
+  - profile: The scraped LinkedIn profile
+
+Returns:
+  - []Enrichment: One enrichment per website that described itself
+  - error: The errors of the pages that could not be read or summarized, joined
+*/
+func (w *Website) Enrich(ctx context.Context, profile scraper.Profile) ([]Enrichment, error) {
+	client := w.Client
+	if client == nil {
+		client = publicClient
+	}
+
+	var enrichments []Enrichment
+	var errs []error
+	fetched := 0
+	for _, u := range websites(profile) {
+		if host(u) == "github.com" || fetched == websitePages {
+			continue
+		}
+		fetched++
+		e, err := w.read(ctx, client, u)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if e == nil {
+			continue
+		}
+		if name, ok := sourceHosts[host(u)]; ok {
+			e.Source = name
+		}
+		enrichments = append(enrichments, *e)
+	}
+	return enrichments, errors.Join(errs...)
+}
+
+// read reads the website at u: its homepage, about page and blog post titles. The
+// enrichment is nil when the homepage can't be read or says nothing; failures of the
+// other pages and of Summarize are returned with what was read.
+func (w *Website) read(ctx context.Context, client *http.Client, u *url.URL) (*Enrichment, error) {
+	home, err := readPage(ctx, client, u)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", u, err)
+	}
+
+	var errs []error
+	var about *page
+	if link := aboutLink(home); link != nil {
+		if about, err = readPage(ctx, client, link); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", link, err))
+		}
+	}
+	posts := blogPosts(home)
+	if index := blogIndex(home); len(posts) == 0 && index != nil {
+		blog, err := readPage(ctx, client, index)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", index, err))
+		} else {
+			posts = blogPosts(blog)
+		}
+	}
+
+	e := &Enrichment{Source: w.Name(), URL: u.String(), Summary: home.description}
+	if home.title != "" {
+		e.Facts = append(e.Facts, "Page title: "+home.title)
+	}
+	for _, post := range posts {
+		e.Facts = append(e.Facts, fmt.Sprintf("Blog post: %q", post))
+	}
+	if text := siteText(home, about, posts); w.Summarize != nil && text != "" {
+		summary, err := w.Summarize(ctx, u.String(), text)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: summarizing: %w", u, err))
+		} else if summary = strings.TrimSpace(summary); summary != "" {
+			e.Summary = summary
+		}
+	}
+	if e.Summary == "" && about != nil && about.text != "" {
+		e.Summary = excerpt(about.text, 300)
+	}
+	if e.Summary == "" && len(e.Facts) == 0 {
+		return nil, errors.Join(errs...)
+	}
+	return e, errors.Join(errs...)
+}
+
+// page is what is read from an HTML page.
+type page struct {
+	url         *url.URL
+	title       string
+	description string
+	text        string // Readable text of the main content, a line per paragraph
+	links       []link // Links to other pages of the same site
+}
+
+type link struct {
+	url  *url.URL
+	text string
+}
+
+// readPage fetches the HTML page at u and reads its title, description, text and same-site links.
+func readPage(ctx context.Context, client *http.Client, u *url.URL) (*page, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html")
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("responded with %s", res.Status)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type")); mediaType != "text/html" {
+		return nil, fmt.Errorf("is %q, not an HTML page", mediaType)
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, websiteMaxBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	doc := string(body)
+	p := &page{url: res.Request.URL}
+	if m := titleRe.FindStringSubmatch(doc); m != nil {
+		p.title = clean(m[1])
+	}
+	for _, tag := range metaRe.FindAllString(doc, -1) {
+		attrs := attributes(tag)
+		name := strings.ToLower(attrs["name"] + attrs["property"])
+		if (name == "description" || name == "og:description") && p.description == "" {
+			p.description = attrs["content"]
+		}
+	}
+
+	for _, re := range hiddenRes {
+		doc = re.ReplaceAllString(doc, " ")
+	}
+	for _, m := range linkRe.FindAllStringSubmatch(doc, -1) {
+		href, err := p.url.Parse(attributes(m[1])["href"])
+		if err != nil || host(href) != host(p.url) || (href.Scheme != "http" && href.Scheme != "https") {
+			continue
+		}
+		href.Fragment = ""
+		p.links = append(p.links, link{url: href, text: clean(tagRe.ReplaceAllString(m[2], " "))})
+	}
+	for _, re := range furnitureRes {
+		doc = re.ReplaceAllString(doc, " ")
+	}
+	// Readability in short: the article or main element when there is one, and only its paragraphs
+	if m := contentRe.FindStringSubmatch(doc); m != nil {
+		doc = m[1]
+	}
+	var paragraphs []string
+	for _, m := range paragraphRe.FindAllStringSubmatch(doc, -1) {
+		if text := clean(tagRe.ReplaceAllString(m[1], " ")); len(strings.Fields(text)) >= 4 {
+			paragraphs = append(paragraphs, text)
+		}
+	}
+	p.text = strings.Join(paragraphs, "\n")
+	return p, nil
+}
+
+// elementRes returns regexps matching the named HTML elements with their content.
+func elementRes(tags ...string) []*regexp.Regexp {
+	res := make([]*regexp.Regexp, 0, len(tags))
+	for _, tag := range tags {
+		res = append(res, regexp.MustCompile(`(?is)<`+tag+`\b[^>]*>.*?</`+tag+`>`))
+	}
+	return res
+}
+
+// attributes returns the attributes of an HTML tag by lowercased name.
+func attributes(tag string) map[string]string {
+	attrs := map[string]string{}
+	for _, a := range attrRe.FindAllStringSubmatch(tag, -1) {
+		attrs[strings.ToLower(a[1])] = clean(strings.Trim(a[2], `"'`))
+	}
+	return attrs
+}
+
+// aboutLink returns the first link to an about page other than p itself, e.g. /about or /about-me.html.
+func aboutLink(p *page) *url.URL {
+	for _, l := range p.links {
+		if strings.HasPrefix(strings.ToLower(path.Base(l.url.Path)), "about") && l.url.Path != p.url.Path {
+			return l.url
+		}
+	}
+	return nil
+}
+
+// blogIndex returns the first link to a blog's list of posts, e.g. /blog/.
+func blogIndex(p *page) *url.URL {
+	for _, l := range p.links {
+		segments := strings.Split(strings.Trim(l.url.Path, "/"), "/")
+		if len(segments) == 1 && slices.Contains(blogSegments, strings.ToLower(segments[0])) && l.url.Path != p.url.Path {
+			return l.url
+		}
+	}
+	return nil
+}
+
+// blogPosts returns the titles of the first blog posts p links to, taken from the link texts.
+func blogPosts(p *page) []string {
+	var posts []string
+	seen := map[string]bool{}
+	for _, l := range p.links {
+		segments := strings.Split(strings.Trim(l.url.Path, "/"), "/")
+		title := excerpt(l.text, 120)
+		if len(segments) < 2 || !slices.Contains(blogSegments, strings.ToLower(segments[0])) ||
+			len(strings.Fields(title)) < 2 || seen[l.url.Path] {
+			continue
+		}
+		seen[l.url.Path] = true
+		posts = append(posts, title)
+		if len(posts) == websitePosts {
+			break
+		}
+	}
+	return posts
+}
+
+// siteText is the text handed to Summarize, capped at websiteMaxText.
+func siteText(home, about *page, posts []string) string {
+	var b strings.Builder
+	if home.title != "" {
+		fmt.Fprintf(&b, "Title: %s\n", home.title)
+	}
+	if home.description != "" {
+		fmt.Fprintf(&b, "Description: %s\n", home.description)
+	}
+	if home.text != "" {
+		fmt.Fprintf(&b, "Homepage:\n%s\n", home.text)
+	}
+	if about != nil && about.text != "" {
+		fmt.Fprintf(&b, "About page:\n%s\n", about.text)
+	}
+	if len(posts) > 0 {
+		fmt.Fprintf(&b, "Blog posts:\n- %s\n", strings.Join(posts, "\n- "))
+	}
+	return excerpt(strings.TrimSpace(b.String()), websiteMaxText)
+}
+
+// excerpt cuts s to at most n bytes on a word boundary.
+func excerpt(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	if i := strings.LastIndexAny(s[:n], " \n"); i > 0 {
+		return s[:i] + "..."
+	}
+	return s[:n] + "..."
+}
+
+// clean unescapes HTML entities and collapses whitespace.
+func clean(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
+
+// publicClient only connects to public addresses, checked after DNS resolution.
+var publicClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				ip := net.ParseIP(host)
+				if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+					return errPrivateAddress
+				}
+				return nil
+			},
+		}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+	},
+}
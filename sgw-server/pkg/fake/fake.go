@@ -0,0 +1,480 @@
+/*
+	Package fake provides canned stand-ins for the LinkedIn scraper and OpenAI.
+
+Scrapers return one of Profiles (or of the profiles captured from real scrapes,
+see LoadFixtures), picked by profile URL, and the LLM fills a
+message template from the profile, persona and shared background and embeds
+text as a bag of words, each after a configurable delay. They satisfy server.Scraper and server.LLM so the API can
+be exercised without a browser, LinkedIn credentials or OpenAI spend, by
+cmd/loadtest and by the server's demo mode.
+
+Basic usage:
+
+	s := server.InitServer("unused", st)
+	backend := fake.Backend{SectionLatency: 2 * time.Second}
+	s.NewScraper = func(email, password, url string) (server.Scraper, error) {
+	    return backend.NewScraper(email, password, url)
+	}
+	s.LLM = &fake.LLM{Latency: time.Second}
+*/
+package fake
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/openai"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/persona"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/topics"
+)
+
+/*
+	Backend configures the scrapers created by its NewScraper method.
+
+LoginLatency is spent once per NewScraper call, SectionLatency once per
+scraped section. When Posts is above zero every profile gets exactly that
+many posts instead of its canned ones; with two or fewer the server also
+scrapes experience and education. Profiles replaces the canned Profiles, e.g.
+with captured ones from LoadFixtures.
+*/
+type Backend struct {
+	LoginLatency   time.Duration
+	SectionLatency time.Duration
+	Posts          int
+	Profiles       []scraper.Profile
+}
+
+// NewScraper returns a logged in fake scraper after LoginLatency.
+func (b Backend) NewScraper(email, password, linkedInURL string) (*Scraper, error) {
+	time.Sleep(b.LoginLatency)
+	return &Scraper{backend: b, linkedInURL: linkedInURL}, nil
+}
+
+// Scraper fills profiles from the canned profile matching the profile URL.
+type Scraper struct {
+	backend     Backend
+	mu          sync.Mutex
+	linkedInURL string
+	profile     scraper.Profile
+}
+
+func (s *Scraper) SetProfileURL(linkedInURL string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.linkedInURL = linkedInURL
+	s.profile = scraper.Profile{}
+}
+
+// ScrapeWithBudget skips sections that would not fit in the remaining budget, like the real one.
+func (s *Scraper) ScrapeWithBudget(ctx context.Context, budget time.Duration, sections ...scraper.Section) []scraper.SectionResult {
+	deadline := time.Now().Add(budget)
+	results := make([]scraper.SectionResult, 0, len(sections))
+	for _, section := range sections {
+		if time.Until(deadline) < s.backend.SectionLatency {
+			results = append(results, scraper.SectionResult{Section: section, Skipped: true})
+			continue
+		}
+		start := time.Now()
+		err := s.scrape(ctx, section)
+		results = append(results, scraper.SectionResult{Section: section, Err: err, Took: time.Since(start)})
+	}
+	return results
+}
+
+// ScrapeAll scrapes every section, ending when ctx does, like the real one.
+func (s *Scraper) ScrapeAll(ctx context.Context, sections ...scraper.Section) (scraper.Profile, error) {
+	results := make([]scraper.SectionResult, len(sections))
+	for i, section := range sections {
+		start := time.Now()
+		results[i] = scraper.SectionResult{Section: section, Err: s.scrape(ctx, section), Took: time.Since(start)}
+	}
+	return s.Profile(), scraper.Errors(results)
+}
+
+func (s *Scraper) GetNameAndLocation(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionNameAndLocation)
+}
+
+func (s *Scraper) GetAbout(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionAbout)
+}
+
+func (s *Scraper) GetExperiences(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionExperience)
+}
+
+func (s *Scraper) GetEducation(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionEducation)
+}
+
+func (s *Scraper) GetSkills(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionSkills)
+}
+
+func (s *Scraper) GetCertifications(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionCertifications)
+}
+
+func (s *Scraper) GetRecommendations(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionRecommendations)
+}
+
+func (s *Scraper) GetVolunteering(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionVolunteering)
+}
+
+func (s *Scraper) GetPublications(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionPublications)
+}
+
+func (s *Scraper) GetPatents(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionPatents)
+}
+
+func (s *Scraper) GetCompany(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionCompany)
+}
+
+func (s *Scraper) GetContactInfo(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionContactInfo)
+}
+
+func (s *Scraper) GetArticles(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionArticles)
+}
+
+func (s *Scraper) GetRecentComments(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionComments)
+}
+
+// GetJob returns the canned job posted at jobURL, or else the one it hashes to under
+// jobURL, after SectionLatency.
+func (s *Scraper) GetJob(ctx context.Context, jobURL string) (*scraper.Job, error) {
+	page := scraper.JobPage(jobURL)
+	if page == "" {
+		return nil, fmt.Errorf("not a LinkedIn job posting: %q", jobURL)
+	}
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	for _, job := range Jobs {
+		if job.URL == page {
+			return job.Clone(), nil
+		}
+	}
+	h := fnv.New32a()
+	h.Write([]byte(page))
+	job := Jobs[int(h.Sum32()%uint32(len(Jobs)))].Clone()
+	job.URL = page
+	return job, nil
+}
+
+func (s *Scraper) GetLanguages(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionLanguages)
+}
+
+func (s *Scraper) GetRecentPosts(ctx context.Context) error {
+	return s.scrape(ctx, scraper.SectionPosts)
+}
+
+// wait takes SectionLatency, like a page loading, or until ctx ends.
+func (s *Scraper) wait(ctx context.Context) error {
+	t := time.NewTimer(s.backend.SectionLatency)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// scrape copies section from the canned profile after SectionLatency, nothing when ctx ends first.
+func (s *Scraper) scrape(ctx context.Context, section scraper.Section) error {
+	if err := s.wait(ctx); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	canned := s.backend.profile(s.linkedInURL)
+	switch section {
+	case scraper.SectionNameAndLocation:
+		s.profile.Name = canned.Name
+		s.profile.Location = canned.Location
+		s.profile.Headline = canned.Headline
+		s.profile.Pronouns = canned.Pronouns
+		s.profile.PhotoURL = canned.PhotoURL
+		s.profile.OpenToWork = canned.OpenToWork
+		s.profile.JobPreferences = canned.JobPreferences
+		s.profile.CompanyURL = canned.CompanyURL
+		s.profile.Connections = canned.Connections
+		s.profile.Followers = canned.Followers
+	case scraper.SectionAbout:
+		s.profile.About = canned.About
+	case scraper.SectionPosts:
+		s.profile.Posts = canned.Posts
+		if s.backend.Posts > 0 {
+			s.profile.Posts = make([]scraper.Post, 0, s.backend.Posts)
+			for i := 0; i < s.backend.Posts; i++ {
+				s.profile.Posts = append(s.profile.Posts, scraper.Post{Content: fmt.Sprintf("Post %d by %s about scaling analytics pipelines", i+1, canned.Name)})
+			}
+		}
+	case scraper.SectionExperience:
+		s.profile.Experience = canned.Experience
+	case scraper.SectionEducation:
+		s.profile.Education = canned.Education
+	case scraper.SectionSkills:
+		s.profile.Skills = canned.Skills
+	case scraper.SectionCertifications:
+		s.profile.Certifications = canned.Certifications
+	case scraper.SectionRecommendations:
+		s.profile.Recommendations = canned.Recommendations
+	case scraper.SectionVolunteering:
+		s.profile.Volunteering = canned.Volunteering
+	case scraper.SectionPublications:
+		s.profile.Publications = canned.Publications
+	case scraper.SectionPatents:
+		s.profile.Patents = canned.Patents
+	case scraper.SectionArticles:
+		s.profile.Articles = canned.Articles
+	case scraper.SectionComments:
+		s.profile.Comments = canned.Comments
+	case scraper.SectionContactInfo:
+		s.profile.ContactInfo = canned.ContactInfo
+	case scraper.SectionCompany:
+		if s.profile.CompanyURL != "" {
+			s.profile.Company = canned.Company
+		}
+	case scraper.SectionLanguages:
+		s.profile.Languages = canned.Languages
+	}
+	return nil
+}
+
+func (s *Scraper) Profile() scraper.Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.profile.Clone()
+}
+
+func (s *Scraper) Renew(lease time.Duration) {}
+
+func (s *Scraper) Ping() error {
+	return nil
+}
+
+func (s *Scraper) Relogin() error {
+	time.Sleep(s.backend.LoginLatency)
+	return nil
+}
+
+func (s *Scraper) Close() {}
+
+// searchPageSize is how many people a page of fake search results lists, like LinkedIn.
+const searchPageSize = 10
+
+// SearchPeople lists the profiles whose name, headline and current role contain every word
+// of query and that match the title, company and school filters, paged like LinkedIn, after
+// SectionLatency. Network and location filters are accepted but not applied. The URLs listed
+// scrape back to the same profiles.
+func (s *Scraper) SearchPeople(ctx context.Context, query string, filters scraper.SearchFilters) (scraper.SearchPage, error) {
+	if _, err := scraper.SearchURL(query, filters); err != nil {
+		return scraper.SearchPage{}, err
+	}
+	if err := s.wait(ctx); err != nil {
+		return scraper.SearchPage{}, err
+	}
+
+	matches := []scraper.SearchResult{}
+	for _, p := range s.backend.profiles() {
+		if p.Name == "" || !matchesSearch(p, query, filters) {
+			continue
+		}
+		matches = append(matches, scraper.SearchResult{URL: ProfileURL(p), Name: p.Name, Headline: p.Headline, Location: p.Location})
+	}
+	page := scraper.SearchPage{Page: max(filters.Page, 1), Results: []scraper.SearchResult{}}
+	start := (page.Page - 1) * searchPageSize
+	if start < len(matches) {
+		page.Results = matches[start:min(len(matches), start+searchPageSize)]
+	}
+	page.HasMore = start+searchPageSize < len(matches)
+	return page, nil
+}
+
+func matchesSearch(p scraper.Profile, query string, filters scraper.SearchFilters) bool {
+	var title, company string
+	if len(p.Experience) > 0 {
+		title, company = p.Experience[0].Title, p.Experience[0].Company
+	}
+	var schools []string
+	for _, e := range p.Education {
+		schools = append(schools, e.Institute)
+	}
+	contains := func(text, part string) bool {
+		return strings.Contains(strings.ToLower(text), strings.ToLower(strings.TrimSpace(part)))
+	}
+	for _, word := range strings.Fields(query) {
+		if !contains(strings.Join([]string{p.Name, p.Headline, title, company}, " "), word) {
+			return false
+		}
+	}
+	return contains(title+" "+p.Headline, filters.Title) && contains(company, filters.Company) && contains(strings.Join(schools, " "), filters.School)
+}
+
+// ProfileURL returns the URL fake search results list p under, which fake scrapers map back to p.
+func ProfileURL(p scraper.Profile) string {
+	return "https://www.linkedin.com/in/" + strings.Join(strings.Fields(strings.ToLower(p.Name)), "-") + "/"
+}
+
+func (b Backend) profiles() []scraper.Profile {
+	if len(b.Profiles) > 0 {
+		return b.Profiles
+	}
+	return Profiles
+}
+
+// profile returns a copy of the profile listed under linkedInURL by SearchPeople, or else
+// the one it hashes to.
+func (b Backend) profile(linkedInURL string) scraper.Profile {
+	profiles := b.profiles()
+	for _, p := range profiles {
+		if p.Name != "" && strings.EqualFold(ProfileURL(p), linkedInURL) {
+			return p.Clone()
+		}
+	}
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(strings.TrimSuffix(linkedInURL, "/"))))
+	return profiles[int(h.Sum32()%uint32(len(profiles)))].Clone()
+}
+
+// LLM writes template messages after Latency. Its usage counts a token per four bytes,
+// about what OpenAI bills for English text and JSON.
+type LLM struct {
+	Latency time.Duration
+}
+
+// GetMessage opens with the strongest hook available, like the real prompt asks for:
+// shared background, then a recent post, then a recent comment, then the current role, then the employer's latest post.
+func (l *LLM) GetMessage(prospect openai.Prospect) (string, openai.Usage, error) {
+	time.Sleep(l.Latency)
+	profile := prospect.Profile
+	first, _, _ := strings.Cut(strings.TrimSpace(profile.Name), " ")
+	if first == "" {
+		first = "there"
+	}
+
+	// What dormant prospects wrote is stale, their work is the better hook
+	dormant := prospect.Activity == openai.ActivityDormant && len(profile.Experience) > 0
+	var hook string
+	switch {
+	case len(prospect.SharedBackground) > 0:
+		hook = strings.Replace(prospect.SharedBackground[0].Detail, "Both", "we both", 1) + ", so I had to say hello."
+	case dormant:
+		company, _, _ := strings.Cut(profile.Experience[0].Company, "·")
+		hook = fmt.Sprintf("your work as %s at %s caught my eye.", profile.Experience[0].Title, strings.TrimSpace(company))
+	case len(profile.Posts) > 0:
+		// Image and document posts may only have the text read off their media
+		hook = fmt.Sprintf("your post \"%s\" stuck with me.", excerpt(cmp.Or(profile.Posts[0].Content, profile.Posts[0].MediaText), 8))
+	case len(profile.Comments) > 0:
+		hook = fmt.Sprintf("your comment \"%s\" stuck with me.", excerpt(profile.Comments[0].Content, 8))
+	case len(profile.Experience) > 0:
+		company, _, _ := strings.Cut(profile.Experience[0].Company, "·")
+		hook = fmt.Sprintf("your work as %s at %s caught my eye.", profile.Experience[0].Title, strings.TrimSpace(company))
+	case profile.Company != nil && len(profile.Company.Posts) > 0:
+		hook = fmt.Sprintf("%s's post \"%s\" caught my eye.", profile.Company.Name, excerpt(profile.Company.Posts[0].Content, 8))
+	default:
+		hook = "I came across your profile and liked what I saw."
+	}
+
+	closing := "Would love to connect and swap notes."
+	if job := prospect.Job; job != nil {
+		// Pitch the role to outsiders, ask about it those hiring for it
+		closing = fmt.Sprintf("Would love to connect and tell you about the %s role at %s.", job.Title, job.Company)
+		if hiresFor(profile, job) {
+			closing = fmt.Sprintf("I'm keen on the %s role at %s and would love to connect.", job.Title, job.Company)
+		}
+	}
+	msg := fmt.Sprintf("Hi %s, %s %s", first, hook, closing)
+	if prospect.Sender != nil && prospect.Sender.Name != "" {
+		msg += " - " + prospect.Sender.Name
+	}
+	return msg, usage(prospect, msg), nil
+}
+
+// ClassifyPersona returns the rule-based classification, defaulting what the rules leave unknown.
+func (l *LLM) ClassifyPersona(profile scraper.Profile) (persona.Persona, openai.Usage, error) {
+	time.Sleep(l.Latency)
+	p := persona.Classify(profile)
+	if p.Seniority == persona.SeniorityUnknown {
+		p.Seniority = persona.SeniorityIC
+	}
+	if p.Function == persona.FunctionUnknown {
+		p.Function = persona.FunctionEngineering
+	}
+	return p, usage(profile, `{"seniority": "`+string(p.Seniority)+`", "function": "`+string(p.Function)+`"}`), nil
+}
+
+// SummarizeWebsite returns the first words of the site's text after Latency.
+func (l *LLM) SummarizeWebsite(site, text string) (string, openai.Usage, error) {
+	time.Sleep(l.Latency)
+	summary := excerpt(text, 25)
+	return summary, usage(site+"\n\n"+text, summary), nil
+}
+
+// embeddingSize is the length of the fake embeddings.
+const embeddingSize = 64
+
+// Embed returns a bag of words embedding of each text after Latency: texts sharing keywords
+// point the same way, which is enough to rank posts against a goal.
+func (l *LLM) Embed(texts []string) ([][]float64, openai.Usage, error) {
+	time.Sleep(l.Latency)
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = make([]float64, embeddingSize)
+		for _, word := range topics.Keywords(text) {
+			h := fnv.New32a()
+			h.Write([]byte(word))
+			vectors[i][h.Sum32()%embeddingSize]++
+		}
+	}
+	u := usage(strings.Join(texts, "\n"), "")
+	u.PromptTokens, u.EmbeddingTokens = 0, u.PromptTokens
+	return vectors, u, nil
+}
+
+// usage estimates the tokens a call with prompt, marshalled unless it is a string, and
+// completion would be billed for.
+func usage(prompt any, completion string) openai.Usage {
+	text, ok := prompt.(string)
+	if !ok {
+		raw, _ := json.Marshal(prompt)
+		text = string(raw)
+	}
+	return openai.Usage{PromptTokens: (len(text) + 3) / 4, CompletionTokens: (len(completion) + 3) / 4, Calls: 1}
+}
+
+// hiresFor reports whether profile is on the job's hiring team or works at its company.
+func hiresFor(profile scraper.Profile, job *scraper.Job) bool {
+	for _, m := range job.HiringTeam {
+		if strings.EqualFold(m.Name, profile.Name) {
+			return true
+		}
+	}
+	return profile.Company != nil && job.CompanyURL != "" && profile.Company.URL == job.CompanyURL
+}
+
+func excerpt(s string, words int) string {
+	fields := strings.Fields(s)
+	if len(fields) <= words {
+		return strings.Join(fields, " ")
+	}
+	return strings.Join(fields[:words], " ") + "..."
+}
@@ -0,0 +1,108 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestScraperReturnsTheWholeCannedProfile(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; len(seen) < len(Profiles) && i < 1000; i++ {
+		url := fmt.Sprintf("https://www.linkedin.com/in/someone-%d/", i)
+		want := Backend{}.profile(url)
+		if seen[want.Name] {
+			continue
+		}
+		seen[want.Name] = true
+
+		s, err := Backend{}.NewScraper("a@x.com", "secret", url)
+		if err != nil {
+			t.Fatalf("NewScraper: %v", err)
+		}
+		s.ScrapeWithBudget(context.Background(), time.Minute, scraper.PageOrder...)
+		if got := s.Profile(); !reflect.DeepEqual(got, want) {
+			t.Errorf("scraped profile = %+v\nwant %+v", got, want)
+		}
+	}
+	if len(seen) < len(Profiles) {
+		t.Fatalf("only %d of %d profiles were picked", len(seen), len(Profiles))
+	}
+}
+
+func TestLoadFixtures(t *testing.T) {
+	profiles, err := LoadFixtures("testdata/fixtures")
+	if err != nil {
+		t.Fatalf("LoadFixtures: %v", err)
+	}
+	if len(profiles) != 1 {
+		t.Fatalf("loaded %d profiles, want 1", len(profiles))
+	}
+	p := profiles[0]
+	if p.Name != "Alex Sample" || !p.OpenToWork || p.Headline != "Product Analyst at Example Corp" || p.Pronouns != "They/Them" || p.About == "" || len(p.Posts) != 1 || p.Experience[0].Title != "Product Analyst" {
+		t.Errorf("profile = %+v", p)
+	}
+	if len(p.Recommendations) != 2 || p.Recommendations[0].Given || !p.Recommendations[1].Given {
+		t.Errorf("recommendations = %+v, want received then given", p.Recommendations)
+	}
+	if p.Languages == nil || len(p.Languages) != 0 {
+		t.Errorf("languages = %#v, want scraped and empty", p.Languages)
+	}
+
+	// Scrapers serve the captured profile in place of the canned ones
+	s, err := Backend{Profiles: profiles}.NewScraper("a@x.com", "secret", "https://www.linkedin.com/in/anyone/")
+	if err != nil {
+		t.Fatalf("NewScraper: %v", err)
+	}
+	s.ScrapeWithBudget(context.Background(), time.Minute, scraper.PageOrder...)
+	if got := s.Profile(); got.Name != "Alex Sample" {
+		t.Errorf("scraped %q, want the captured profile", got.Name)
+	}
+}
+
+func TestLoadFixturesNamesTheBrokenFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "posts"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "posts", "abc.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFixtures(dir); err == nil || !strings.Contains(err.Error(), "abc.json") {
+		t.Fatalf("LoadFixtures error = %v, want it to name abc.json", err)
+	}
+}
+
+func TestSearchPeoplePages(t *testing.T) {
+	var profiles []scraper.Profile
+	for i := 0; i < searchPageSize+3; i++ {
+		profiles = append(profiles, scraper.Profile{Name: fmt.Sprintf("Person %d", i), Headline: "Data Engineer"})
+	}
+	profiles = append(profiles, scraper.Profile{Name: "Someone Else", Headline: "Designer"})
+	s, err := Backend{Profiles: profiles}.NewScraper("a@x.com", "secret", "")
+	if err != nil {
+		t.Fatalf("NewScraper: %v", err)
+	}
+
+	first, err := s.SearchPeople(context.Background(), "data engineer", scraper.SearchFilters{})
+	if err != nil || len(first.Results) != searchPageSize || !first.HasMore || first.Page != 1 {
+		t.Fatalf("page 1 = %d results, hasMore %v, page %d, %v", len(first.Results), first.HasMore, first.Page, err)
+	}
+	second, err := s.SearchPeople(context.Background(), "data engineer", scraper.SearchFilters{Page: 2})
+	if err != nil || len(second.Results) != 3 || second.HasMore {
+		t.Fatalf("page 2 = %+v, %v", second, err)
+	}
+
+	s.SetProfileURL(second.Results[2].URL)
+	s.ScrapeWithBudget(context.Background(), time.Minute, scraper.SectionNameAndLocation)
+	if got := s.Profile().Name; got != "Person 12" {
+		t.Errorf("result scraped back to %q, want Person 12", got)
+	}
+}
@@ -0,0 +1,148 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+/*
+	LoadFixtures reads the profiles captured with scraper.CaptureDir.
+
+Every <dir>/<section>/<id>.json file becomes that section of profile <id>;
+the HTML next to it is left alone. Recommendation tabs are merged back
+received first, like the scraper does. Profiles whose name was not captured
+are dropped.
+
+Parameters:
+  - dir: The capture directory
+
+Returns:
+  - []scraper.Profile: One profile per captured id, sorted by id
+  - error: Unreadable or malformed fixtures, naming the file
+*/
+func LoadFixtures(dir string) ([]scraper.Profile, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	// Received before given, as the scraper appends them
+	for _, tab := range []string{"received", "given"} {
+		matches, err := filepath.Glob(filepath.Join(dir, string(scraper.SectionRecommendations), tab, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+
+	profiles := map[string]*scraper.Profile{}
+	for _, file := range files {
+		section, _ := filepath.Rel(dir, filepath.Dir(file))
+		section = filepath.ToSlash(section)
+		id := strings.TrimSuffix(filepath.Base(file), ".json")
+		if profiles[id] == nil {
+			profiles[id] = &scraper.Profile{}
+		}
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := decodeFixture(profiles[id], section, raw); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+
+	ids := make([]string, 0, len(profiles))
+	for id, p := range profiles {
+		if p.Name != "" {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	loaded := make([]scraper.Profile, 0, len(ids))
+	for _, id := range ids {
+		loaded = append(loaded, *profiles[id])
+	}
+	return loaded, nil
+}
+
+// decodeFixture sets the section of profile a fixture was captured for.
+func decodeFixture(profile *scraper.Profile, section string, raw []byte) error {
+	switch scraper.Section(section) {
+	case scraper.SectionNameAndLocation:
+		var top struct {
+			Name        string `json:"name"`
+			Location    string `json:"location"`
+			Headline    string `json:"headline"`
+			Pronouns    string `json:"pronouns"`
+			PhotoURL    string `json:"photoUrl"`
+			OpenToWork  bool   `json:"openToWork"`
+			CompanyURL  string `json:"companyUrl"`
+			Connections int    `json:"connections"`
+			Followers   int    `json:"followers"`
+			// Nil unless the open-to-work card showed them
+			JobPreferences *scraper.JobPreferences `json:"jobPreferences"`
+		}
+		if err := json.Unmarshal(raw, &top); err != nil {
+			return err
+		}
+		profile.Name, profile.Location, profile.OpenToWork = top.Name, top.Location, top.OpenToWork
+		profile.Headline, profile.Pronouns, profile.PhotoURL = top.Headline, top.Pronouns, top.PhotoURL
+		profile.CompanyURL, profile.Connections, profile.Followers = top.CompanyURL, top.Connections, top.Followers
+		profile.JobPreferences = top.JobPreferences
+		return nil
+	case scraper.SectionAbout:
+		var about struct {
+			About string `json:"about"`
+		}
+		if err := json.Unmarshal(raw, &about); err != nil {
+			return err
+		}
+		profile.About = about.About
+		return nil
+	case scraper.SectionPosts:
+		return json.Unmarshal(raw, &profile.Posts)
+	case scraper.SectionExperience:
+		return json.Unmarshal(raw, &profile.Experience)
+	case scraper.SectionEducation:
+		return json.Unmarshal(raw, &profile.Education)
+	case scraper.SectionSkills:
+		return json.Unmarshal(raw, &profile.Skills)
+	case scraper.SectionCertifications:
+		return json.Unmarshal(raw, &profile.Certifications)
+	case scraper.SectionVolunteering:
+		return json.Unmarshal(raw, &profile.Volunteering)
+	case scraper.SectionPublications:
+		return json.Unmarshal(raw, &profile.Publications)
+	case scraper.SectionPatents:
+		return json.Unmarshal(raw, &profile.Patents)
+	case scraper.SectionArticles:
+		return json.Unmarshal(raw, &profile.Articles)
+	case scraper.SectionComments:
+		return json.Unmarshal(raw, &profile.Comments)
+	case scraper.SectionCompany:
+		return json.Unmarshal(raw, &profile.Company)
+	case scraper.SectionContactInfo:
+		// Fixtures captured before the email, Twitter handle and birthday were read list the websites only
+		if json.Unmarshal(raw, &profile.ContactInfo.Websites) == nil {
+			return nil
+		}
+		return json.Unmarshal(raw, &profile.ContactInfo)
+	case scraper.SectionLanguages:
+		return json.Unmarshal(raw, &profile.Languages)
+	}
+	if strings.HasPrefix(section, string(scraper.SectionRecommendations)+"/") {
+		var tab []scraper.Recommendation
+		if err := json.Unmarshal(raw, &tab); err != nil {
+			return err
+		}
+		profile.Recommendations = append(profile.Recommendations, tab...)
+		return nil
+	}
+	return fmt.Errorf("unknown section %q", section)
+}
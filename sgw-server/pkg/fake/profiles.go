@@ -0,0 +1,169 @@
+package fake
+
+import (
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// Profiles are the canned profiles fake scrapers return, picked by profile URL so the same URL
+// always shows the same person. They cover an active poster, a sparse profile that makes the
+// server fall back to experience and education, a founder who only writes articles, and a few
+// personas for ICP filters.
+var Profiles = []scraper.Profile{
+	{
+		Name:       "Priya Raman",
+		Location:   "Bengaluru, Karnataka, India",
+		Headline:   "Engineering Manager, Data Platform at Moonfrog Labs | Streaming, Kafka, live-ops analytics",
+		Pronouns:   "She/Her",
+		PhotoURL:   "https://media.licdn.com/dms/image/fake/priya-raman.jpg",
+		CompanyURL: "https://www.linkedin.com/company/moonfrog-labs/",
+		Company: &scraper.Company{
+			Name: "Moonfrog Labs", URL: "https://www.linkedin.com/company/moonfrog-labs/", Industry: "Computer Games", Size: "201-500 employees",
+			About: "Moonfrog makes mobile games played by millions across India every day.",
+			Posts: []scraper.Post{{Content: "Teen Patti Gold just crossed 10 million monthly players. Thank you to every player and every Moonfrogger who made it happen.", Reactions: 1204, Comments: 88}},
+		},
+		About: "Engineering manager building the data platform behind live-ops for mobile games. Previously scaled ad-tech pipelines to billions of events a day.",
+		Experience: []scraper.Experience{
+			{Title: "Engineering Manager, Data Platform", Company: "Moonfrog Labs · Full-time", Duration: "Mar 2021 - Present · 3 yrs 7 mos"},
+			{Title: "Senior Software Engineer", Company: "InMobi", Duration: "Jul 2016 - Feb 2021 · 4 yrs 8 mos"},
+		},
+		Education: []scraper.Education{
+			{Institute: "National Institute of Technology Karnataka", Major: "B.Tech, Computer Science", Duration: "2012 - 2016"},
+		},
+		Skills:         []scraper.Skill{{Name: "Data Engineering", Endorsements: 41}, {Name: "Apache Kafka", Endorsements: 23}, {Name: "Team Leadership", Endorsements: 12}},
+		Volunteering:   []scraper.VolunteerEntry{{Organization: "Women Who Code Bengaluru", Role: "Speaker and Mentor", Cause: "Science and Technology", Duration: "2019 - Present"}},
+		Certifications: []scraper.Certification{{Name: "Google Cloud Professional Data Engineer", Issuer: "Google Cloud", IssuedAt: "Jun 2022"}},
+		Languages:      []scraper.Language{{Name: "Tamil", Proficiency: "Native or bilingual proficiency"}, {Name: "English", Proficiency: "Full professional proficiency"}},
+		Posts: []scraper.Post{
+			{Content: "We cut our daily batch window from 6 hours to 40 minutes by moving event enrichment to streaming. Notes on what broke along the way.", Reactions: 312, Comments: 41},
+			{Content: "Hiring two senior data engineers in Bengaluru. You'll own the pipeline that decides which offer a player sees next.", Reactions: 88, Comments: 12},
+			{Content: "Hot take: most churn models fail because the events feeding them are late, not because the model is wrong."},
+			{Content: "Great turnout at the Bengaluru Data Engineering meetup yesterday, slides from my talk on late-arriving events are up."},
+			{Content: "Three years at Moonfrog today. Grateful for a team that ships on Fridays without fear."},
+		},
+		Connections: 500, Followers: 3400,
+	},
+	{
+		Name:       "Daniel Okafor",
+		Location:   "London, England, United Kingdom",
+		Headline:   "VP Marketing at Calmly | Subscription growth",
+		Pronouns:   "He/Him",
+		About:      "Marketing leader for consumer subscription apps.",
+		CompanyURL: "https://www.linkedin.com/company/calmly-app/",
+		Company: &scraper.Company{
+			Name: "Calmly", URL: "https://www.linkedin.com/company/calmly-app/", Industry: "Wellness and Fitness Services", Size: "51-200 employees",
+			About: "Calmly is the meditation app for people who think they can't meditate.",
+		},
+		Experience: []scraper.Experience{
+			{Title: "VP Marketing", Company: "Calmly · Full-time", Duration: "Jan 2022 - Present · 2 yrs 10 mos"},
+			{Title: "Head of Growth", Company: "Fitbod", Duration: "2018 - 2021"},
+			{Title: "Growth Marketing Manager", Company: "Deliveroo", Duration: "2015 - 2018"},
+		},
+		Education: []scraper.Education{
+			{Institute: "London School of Economics", Major: "MSc, Management", Duration: "2013 - 2014"},
+		},
+		Skills: []scraper.Skill{{Name: "Growth Marketing", Endorsements: 67}, {Name: "Subscription Pricing", Endorsements: 18}},
+		Recommendations: []scraper.Recommendation{
+			{Name: "Hannah Lee", Relationship: "June 2, 2021, Hannah reported directly to Daniel", Text: "Daniel turned our paywall from guesswork into a weekly experiment cadence. He gives his team room to be wrong quickly."},
+		},
+		Posts: []scraper.Post{
+			{Content: "Paywall tests are only as good as your cohort definitions. Ours were wrong for a year.", Reactions: 57, Comments: 9},
+		},
+		Articles: []scraper.Article{
+			{Title: "The Annual Plan Discount Is Lying to You", URL: "https://www.linkedin.com/pulse/annual-plan-discount-lying-you-daniel-okafor/", PublishedAt: time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC), Excerpt: "Why a 40% annual discount grew revenue in month one and cost us a quarter of renewals a year later."},
+		},
+	},
+	{
+		Name:       "Mei Lin Chen",
+		Location:   "Singapore",
+		Headline:   "Senior Data Scientist | Player LTV and pricing",
+		PhotoURL:   "https://media.licdn.com/dms/image/fake/mei-lin-chen.jpg",
+		OpenToWork: true,
+		About:      "Data scientist working on player lifetime value and pricing.",
+		CompanyURL: "https://www.linkedin.com/company/garena/",
+		Company: &scraper.Company{
+			Name: "Garena", URL: "https://www.linkedin.com/company/garena/", Industry: "Entertainment Providers", Size: "10,001+ employees",
+			Posts: []scraper.Post{{Content: "Free Fire World Series returns to Bangkok this November. Registrations for regional qualifiers open today.", Reactions: 3400, Comments: 210}},
+		},
+		Experience: []scraper.Experience{
+			{Title: "Senior Data Scientist", Company: "Garena", Duration: "Aug 2020 - Present · 4 yrs 3 mos"},
+			{Title: "Data Analyst", Company: "Grab", Duration: "2017 - 2020"},
+		},
+		Education: []scraper.Education{
+			{Institute: "National University of Singapore", Major: "BSc, Statistics", Duration: "2013 - 2017"},
+		},
+		Skills: []scraper.Skill{{Name: "Python", Endorsements: 35}, {Name: "A/B Testing", Endorsements: 14}, {Name: "Survival Analysis", Endorsements: 9}},
+		Recommendations: []scraper.Recommendation{
+			{Name: "Rahul Nair", Relationship: "January 14, 2020, Rahul managed Mei Lin directly", Text: "Mei Lin rebuilt our LTV model end to end and explained every trade-off to the business in plain language."},
+			{Name: "Sara Kim", Relationship: "March 3, 2022, Mei Lin worked with Sara on the same team", Text: "Sara is the analyst you want next to you when a launch metric looks too good to be true.", Given: true},
+		},
+		Publications: []scraper.Publication{{Title: "Predicting Player Lifetime Value with Survival Models", Venue: "IEEE Conference on Games", Date: "Aug 2023"}},
+		Languages:    []scraper.Language{{Name: "English", Proficiency: "Native or bilingual proficiency"}, {Name: "Mandarin", Proficiency: "Native or bilingual proficiency"}},
+		Posts: []scraper.Post{
+			{Content: "LTV predictions at day 3 are now within 8% of day 90 actuals for our top titles. Write-up coming soon."},
+			{Content: "If your A/B test needs a PhD to explain, you probably ran the wrong test."},
+			{Content: "Speaking at PyCon APAC next month on survival models for churn."},
+		},
+		Connections: 500, Followers: 52000,
+		JobPreferences: &scraper.JobPreferences{
+			Titles:    []string{"Lead Data Scientist", "Data Science Manager"},
+			Locations: []string{"Singapore", "Remote"},
+			StartDate: "Flexible, I'm casually browsing",
+		},
+	},
+	{
+		Name:     "Arjun Mehta",
+		Location: "Mumbai, Maharashtra, India",
+		Headline: "Product at a gaming studio",
+		About:    "Second-time founder. Building tools for indie game studios.",
+		Experience: []scraper.Experience{
+			{Title: "Co-Founder & CEO", Company: "Pixelforge", Duration: "Apr 2023 - Present · 1 yr 7 mos"},
+			{Title: "Product Lead", Company: "Nazara Technologies", Duration: "2019 - 2023"},
+		},
+		Education: []scraper.Education{
+			{Institute: "IIT Bombay", Major: "B.Tech, Mechanical Engineering", Duration: "2011 - 2015"},
+		},
+		Skills:         []scraper.Skill{{Name: "Product Management", Endorsements: 52}, {Name: "Unity", Endorsements: 7}},
+		Volunteering:   []scraper.VolunteerEntry{{Organization: "Game Dev Mumbai", Role: "Mentor", Cause: "Education", Duration: "2020 - Present"}},
+		Certifications: []scraper.Certification{{Name: "Certified Scrum Product Owner", Issuer: "Scrum Alliance", IssuedAt: "Nov 2019"}, {Name: "Unity Certified Programmer", Issuer: "Unity Technologies", IssuedAt: "Feb 2021"}},
+		Patents:        []scraper.Patent{{Title: "Automated playtest session clustering", Office: "US 11,482,310", Date: "Oct 25, 2022"}},
+		Articles: []scraper.Article{
+			{Title: "What Indie Studios Get Wrong About Playtesting", URL: "https://www.linkedin.com/pulse/what-indie-studios-get-wrong-playtesting-arjun-mehta/", PublishedAt: time.Date(2024, time.August, 19, 0, 0, 0, 0, time.UTC), Excerpt: "Forty playtest sessions taught us that players rarely quit where designers expect them to."},
+			{Title: "Leaving a Big Studio to Build Tools for Small Ones", URL: "https://www.linkedin.com/pulse/leaving-big-studio-build-tools-small-ones-arjun-mehta/", PublishedAt: time.Date(2023, time.May, 2, 0, 0, 0, 0, time.UTC), Excerpt: "Notes from my first year running Pixelforge."},
+		},
+		Comments: []scraper.Comment{
+			{Content: "We saw the same with our playtests: players forgive hard levels, never unclear ones.", PostAuthor: "Ishita Rao", PostExcerpt: "Difficulty spikes are not why players churn in week one.", CommentedAt: time.Date(2024, time.October, 2, 0, 0, 0, 0, time.UTC)},
+			{Content: "Kudos on the launch! Curious how you handled save migration across engines.", PostAuthor: "Kabir Shah", PostExcerpt: "After 18 months, Lantern is out on Steam."},
+		},
+	},
+}
+
+// Jobs are the canned postings fake scrapers return, picked by job URL like Profiles. One is
+// posted by a canned profile's employer with that profile on its hiring team.
+var Jobs = []scraper.Job{
+	{
+		Title:      "Senior Data Engineer",
+		URL:        "https://www.linkedin.com/jobs/view/4012345678/",
+		Company:    "Moonfrog Labs",
+		CompanyURL: "https://www.linkedin.com/company/moonfrog-labs/",
+		Location:   "Bengaluru, Karnataka, India (Hybrid)",
+		Highlights: []string{
+			"Own the Kafka and Flink pipelines behind live-ops analytics for 10M+ monthly players",
+			"Cut end-to-end event latency from minutes to seconds",
+			"4+ years building streaming data systems in Go, Java or Scala",
+		},
+		HiringTeam: []scraper.HiringTeamMember{{Name: "Priya Raman", Title: "Engineering Manager, Data Platform at Moonfrog Labs", ProfileURL: "https://www.linkedin.com/in/priya-raman/"}},
+	},
+	{
+		Title:      "Lifecycle Marketing Manager",
+		URL:        "https://www.linkedin.com/jobs/view/4023456789/",
+		Company:    "Calmly",
+		CompanyURL: "https://www.linkedin.com/company/calmly-app/",
+		Location:   "London, England, United Kingdom (Remote)",
+		Highlights: []string{
+			"Design onboarding and win-back journeys across push, email and in-app",
+			"Run weekly retention experiments with the growth team",
+		},
+	},
+}
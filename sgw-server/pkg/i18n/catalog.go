@@ -0,0 +1,381 @@
+package i18n
+
+// catalog holds the translations of every language but Default, keyed by the English message.
+var catalog = map[string]map[string]string{
+	"es": es,
+	"de": de,
+	"fr": fr,
+	"hi": hi,
+}
+
+var es = map[string]string{
+	// API responses
+	"server encountered an error, please try again later":               "el servidor encontró un error, inténtalo de nuevo más tarde",
+	"Encountered an error. Please try again":                            "Se produjo un error. Inténtalo de nuevo",
+	"invalid email":                                                     "correo electrónico no válido",
+	"invalid secret":                                                    "secreto no válido",
+	"invalid form":                                                      "formulario no válido",
+	"name is required":                                                  "el nombre es obligatorio",
+	"at least one linkedinUrl is required":                              "se requiere al menos un linkedinUrl",
+	"reason is required, it is recorded in the audit log":               "el motivo es obligatorio, se registra en el registro de auditoría",
+	"goal is longer than %d characters":                                 "el objetivo supera los %d caracteres",
+	"batchId and campaignId can't both be set":                          "batchId y campaignId no pueden indicarse a la vez",
+	"pages must be between 1 and %d":                                    "pages debe estar entre 1 y %d",
+	"x and y must be between 0 and 1":                                   "x e y deben estar entre 0 y 1",
+	"text must be between 1 and 256 bytes":                              "text debe tener entre 1 y 256 bytes",
+	`type must be one of "click", "type" or "key"`:                      `type debe ser "click", "type" o "key"`,
+	"code must be 4 to 8 digits":                                        "el código debe tener entre 4 y 8 dígitos",
+	"body is larger than 5MB":                                           "el cuerpo supera los 5MB",
+	"body is not a JSON document: %s":                                   "el cuerpo no es un documento JSON: %s",
+	"bundle settings: %s":                                               "ajustes del paquete: %s",
+	"unsupported bundle version %d, this server reads version %d":       "versión de paquete %d no compatible, este servidor lee la versión %d",
+	"icp filter %d has no name":                                         "el filtro ICP %d no tiene nombre",
+	"campaign %d: %s":                                                   "campaña %d: %s",
+	"icp filter %q is neither in the bundle nor saved":                  "el filtro ICP %q no está en el paquete ni guardado",
+	"%s, nothing was reloaded":                                          "%s, no se recargó nada",
+	"streaming is not supported":                                        "el streaming no es compatible",
+	"prospect not found":                                                "prospecto no encontrado",
+	"batch not found":                                                   "lote no encontrado",
+	"campaign not found":                                                "campaña no encontrada",
+	"icp filter not found":                                              "filtro ICP no encontrado",
+	"schema not found":                                                  "esquema no encontrado",
+	"team not found":                                                    "equipo no encontrado",
+	"regeneration not found":                                            "regeneración no encontrada",
+	"verification not found":                                            "verificación no encontrada",
+	"verification code request not found":                               "solicitud de código de verificación no encontrada",
+	"no prospects match":                                                "ningún prospecto coincide",
+	"no login is waiting for a code sent to this number":                "ningún inicio de sesión espera un código enviado a este número",
+	"the text has no verification code":                                 "el texto no contiene ningún código de verificación",
+	"prospect has no generated message":                                 "el prospecto no tiene ningún mensaje generado",
+	"prospect is a draft of a dry run batch, drafts can't be shared":    "el prospecto es un borrador de un lote de prueba, los borradores no se pueden compartir",
+	"message is not pending approval":                                   "el mensaje no está pendiente de aprobación",
+	"regeneration is still running":                                     "la regeneración sigue en curso",
+	"this campaign is already being sourced":                            "ya se están buscando prospectos para esta campaña",
+	"the campaign's icp filter could not be loaded":                     "no se pudo cargar el filtro ICP de la campaña",
+	"jobUrl is not a LinkedIn job posting":                              "jobUrl no es una oferta de empleo de LinkedIn",
+	"jobUrl needs the LinkedIn account's password":                      "jobUrl necesita la contraseña de la cuenta de LinkedIn",
+	"could not read the job posting, please check jobUrl and try again": "no se pudo leer la oferta de empleo, revisa jobUrl e inténtalo de nuevo",
+	"only reviewers can approve messages":                               "solo los revisores pueden aprobar mensajes",
+	"only reviewers can see the approval queue":                         "solo los revisores pueden ver la cola de aprobación",
+	"reviewers can't approve their own messages":                        "los revisores no pueden aprobar sus propios mensajes",
+	"only team members can see the team's activity":                     "solo los miembros del equipo pueden ver la actividad del equipo",
+	"only support staff can view other users":                           "solo el personal de soporte puede ver a otros usuarios",
+	"only support staff can reload the configuration":                   "solo el personal de soporte puede recargar la configuración",
+	"could not log in to LinkedIn, please try again later":              "no se pudo iniciar sesión en LinkedIn, inténtalo de nuevo más tarde",
+	"could not search LinkedIn, please try again later":                 "no se pudo buscar en LinkedIn, inténtalo de nuevo más tarde",
+	"this LinkedIn account is busy, please try again shortly":           "esta cuenta de LinkedIn está ocupada, inténtalo de nuevo en breve",
+	"the server is busy with %d queued profiles":                        "el servidor está ocupado con %d perfiles en cola",
+	"all %d browsers are in use":                                        "los %d navegadores están en uso",
+	"the server is low on memory with %d browsers in use":               "el servidor tiene poca memoria con %d navegadores en uso",
+	"this instance is shutting down, please try again":                  "esta instancia se está apagando, inténtalo de nuevo",
+	"only the instance itself can drain it":                             "solo la propia instancia puede vaciarse",
+	"%s, please try again in about %s":                                  "%s, inténtalo de nuevo en unos %s",
+	"%s, please try again after %s":                                     "%s, inténtalo de nuevo después de %s",
+	"%s keeps failing, please try again after %s":                       "%s sigue fallando, inténtalo de nuevo después de %s",
+	"logging in to this LinkedIn account":                               "el inicio de sesión en esta cuenta de LinkedIn",
+	"writing messages":                                                  "la redacción de mensajes",
+	"LinkedIn is rate limiting this account":                            "LinkedIn está limitando la frecuencia de esta cuenta",
+	"LinkedIn flagged this account as automated":                        "LinkedIn marcó esta cuenta como automatizada",
+	"LinkedIn restricted this account":                                  "LinkedIn restringió esta cuenta",
+	"LinkedIn wants a security checkpoint solved for this account":      "LinkedIn pide resolver un control de seguridad para esta cuenta",
+	"LinkedIn did not accept the email and password":                    "LinkedIn no aceptó el correo electrónico y la contraseña",
+	"this LinkedIn account is cooling off after LinkedIn flagged it, please try again after %s": "esta cuenta de LinkedIn está en pausa porque LinkedIn la marcó, inténtalo de nuevo después de %s",
+	"this LinkedIn account reached its profile scrape limit, please try again after %s":         "esta cuenta de LinkedIn alcanzó su límite de perfiles extraídos, inténtalo de nuevo después de %s",
+	"account is not cooling off": "la cuenta no está en pausa",
+	"cooldown for %s ended":      "la pausa de %s ha terminado",
+
+	// Pages
+	"Share link not found.":                           "Enlace compartido no encontrado.",
+	"This share link has expired, ask for a new one.": "Este enlace compartido ha caducado, pide uno nuevo.",
+	"Suggested message for %s":                        "Mensaje sugerido para %s",
+	"Why this message":                                "Por qué este mensaje",
+	"Recent post: \"%s\"":                             "Publicación reciente: \"%s\"",
+	"Persona: %s, %s":                                 "Perfil: %s, %s",
+	"View profile on LinkedIn":                        "Ver perfil en LinkedIn",
+	"This link expires %s.":                           "Este enlace caduca el %s.",
+	"Verification not found.":                         "Verificación no encontrada.",
+	"LinkedIn security check for %s":                  "Control de seguridad de LinkedIn para %s",
+	"LinkedIn security checkpoint":                    "Control de seguridad de LinkedIn",
+	"Click and type on the page below as you would on LinkedIn. Once LinkedIn lets the account through, the scrape carries on by itself.": "Haz clic y escribe en la página de abajo como lo harías en LinkedIn. Cuando LinkedIn deje pasar la cuenta, el scraping continuará por sí solo.",
+	"This check gives up %s.": "Este control se cancela el %s.",
+	"This security check has ended. If LinkedIn accepted it, the scrape carries on by itself.": "Este control de seguridad ha terminado. Si LinkedIn lo aceptó, el scraping continuará por sí solo.",
+}
+
+var de = map[string]string{
+	// API responses
+	"server encountered an error, please try again later":               "beim Server ist ein Fehler aufgetreten, bitte versuchen Sie es später erneut",
+	"Encountered an error. Please try again":                            "Es ist ein Fehler aufgetreten. Bitte versuchen Sie es erneut",
+	"invalid email":                                                     "ungültige E-Mail-Adresse",
+	"invalid secret":                                                    "ungültiges Secret",
+	"invalid form":                                                      "ungültiges Formular",
+	"name is required":                                                  "ein Name ist erforderlich",
+	"at least one linkedinUrl is required":                              "mindestens eine linkedinUrl ist erforderlich",
+	"reason is required, it is recorded in the audit log":               "ein Grund ist erforderlich, er wird im Audit-Log festgehalten",
+	"goal is longer than %d characters":                                 "das Ziel ist länger als %d Zeichen",
+	"batchId and campaignId can't both be set":                          "batchId und campaignId können nicht beide gesetzt werden",
+	"pages must be between 1 and %d":                                    "pages muss zwischen 1 und %d liegen",
+	"x and y must be between 0 and 1":                                   "x und y müssen zwischen 0 und 1 liegen",
+	"text must be between 1 and 256 bytes":                              "text muss zwischen 1 und 256 Bytes lang sein",
+	`type must be one of "click", "type" or "key"`:                      `type muss "click", "type" oder "key" sein`,
+	"code must be 4 to 8 digits":                                        "der Code muss 4 bis 8 Ziffern haben",
+	"body is larger than 5MB":                                           "der Body ist größer als 5MB",
+	"body is not a JSON document: %s":                                   "der Body ist kein JSON-Dokument: %s",
+	"bundle settings: %s":                                               "Bundle-Einstellungen: %s",
+	"unsupported bundle version %d, this server reads version %d":       "nicht unterstützte Bundle-Version %d, dieser Server liest Version %d",
+	"icp filter %d has no name":                                         "ICP-Filter %d hat keinen Namen",
+	"campaign %d: %s":                                                   "Kampagne %d: %s",
+	"icp filter %q is neither in the bundle nor saved":                  "ICP-Filter %q ist weder im Bundle noch gespeichert",
+	"%s, nothing was reloaded":                                          "%s, es wurde nichts neu geladen",
+	"streaming is not supported":                                        "Streaming wird nicht unterstützt",
+	"prospect not found":                                                "Interessent nicht gefunden",
+	"batch not found":                                                   "Batch nicht gefunden",
+	"campaign not found":                                                "Kampagne nicht gefunden",
+	"icp filter not found":                                              "ICP-Filter nicht gefunden",
+	"schema not found":                                                  "Schema nicht gefunden",
+	"team not found":                                                    "Team nicht gefunden",
+	"regeneration not found":                                            "Neugenerierung nicht gefunden",
+	"verification not found":                                            "Verifizierung nicht gefunden",
+	"verification code request not found":                               "Anfrage für den Bestätigungscode nicht gefunden",
+	"no prospects match":                                                "keine Interessenten gefunden",
+	"no login is waiting for a code sent to this number":                "keine Anmeldung wartet auf einen an diese Nummer gesendeten Code",
+	"the text has no verification code":                                 "der Text enthält keinen Bestätigungscode",
+	"prospect has no generated message":                                 "für den Interessenten wurde keine Nachricht generiert",
+	"prospect is a draft of a dry run batch, drafts can't be shared":    "der Interessent ist ein Entwurf eines Testlaufs, Entwürfe können nicht geteilt werden",
+	"message is not pending approval":                                   "die Nachricht wartet nicht auf Freigabe",
+	"regeneration is still running":                                     "die Neugenerierung läuft noch",
+	"this campaign is already being sourced":                            "für diese Kampagne werden bereits Interessenten gesucht",
+	"the campaign's icp filter could not be loaded":                     "der ICP-Filter der Kampagne konnte nicht geladen werden",
+	"jobUrl is not a LinkedIn job posting":                              "jobUrl ist keine LinkedIn-Stellenanzeige",
+	"jobUrl needs the LinkedIn account's password":                      "jobUrl benötigt das Passwort des LinkedIn-Kontos",
+	"could not read the job posting, please check jobUrl and try again": "die Stellenanzeige konnte nicht gelesen werden, bitte prüfen Sie jobUrl und versuchen Sie es erneut",
+	"only reviewers can approve messages":                               "nur Prüfer können Nachrichten freigeben",
+	"only reviewers can see the approval queue":                         "nur Prüfer können die Freigabewarteschlange sehen",
+	"reviewers can't approve their own messages":                        "Prüfer können ihre eigenen Nachrichten nicht freigeben",
+	"only team members can see the team's activity":                     "nur Teammitglieder können die Aktivität des Teams sehen",
+	"only support staff can view other users":                           "nur Support-Mitarbeiter können andere Benutzer ansehen",
+	"only support staff can reload the configuration":                   "nur Support-Mitarbeiter können die Konfiguration neu laden",
+	"could not log in to LinkedIn, please try again later":              "Anmeldung bei LinkedIn fehlgeschlagen, bitte versuchen Sie es später erneut",
+	"could not search LinkedIn, please try again later":                 "LinkedIn-Suche fehlgeschlagen, bitte versuchen Sie es später erneut",
+	"this LinkedIn account is busy, please try again shortly":           "dieses LinkedIn-Konto ist beschäftigt, bitte versuchen Sie es gleich noch einmal",
+	"the server is busy with %d queued profiles":                        "der Server ist mit %d wartenden Profilen ausgelastet",
+	"all %d browsers are in use":                                        "alle %d Browser sind belegt",
+	"the server is low on memory with %d browsers in use":               "der Server hat wenig Speicher bei %d belegten Browsern",
+	"this instance is shutting down, please try again":                  "diese Instanz wird heruntergefahren, bitte versuchen Sie es erneut",
+	"only the instance itself can drain it":                             "nur die Instanz selbst kann sich leeren",
+	"%s, please try again in about %s":                                  "%s, bitte versuchen Sie es in etwa %s erneut",
+	"%s, please try again after %s":                                     "%s, bitte versuchen Sie es nach %s erneut",
+	"%s keeps failing, please try again after %s":                       "%s schlägt wiederholt fehl, bitte versuchen Sie es nach %s erneut",
+	"logging in to this LinkedIn account":                               "die Anmeldung bei diesem LinkedIn-Konto",
+	"writing messages":                                                  "das Schreiben von Nachrichten",
+	"LinkedIn is rate limiting this account":                            "LinkedIn drosselt die Anfragen dieses Kontos",
+	"LinkedIn flagged this account as automated":                        "LinkedIn hat dieses Konto als automatisiert markiert",
+	"LinkedIn restricted this account":                                  "LinkedIn hat dieses Konto eingeschränkt",
+	"LinkedIn wants a security checkpoint solved for this account":      "LinkedIn verlangt für dieses Konto eine Sicherheitsprüfung",
+	"LinkedIn did not accept the email and password":                    "LinkedIn hat E-Mail-Adresse und Passwort nicht akzeptiert",
+	"this LinkedIn account is cooling off after LinkedIn flagged it, please try again after %s": "dieses LinkedIn-Konto pausiert, weil LinkedIn es markiert hat, bitte versuchen Sie es nach %s erneut",
+	"this LinkedIn account reached its profile scrape limit, please try again after %s":         "dieses LinkedIn-Konto hat sein Limit an gelesenen Profilen erreicht, bitte versuchen Sie es nach %s erneut",
+	"account is not cooling off": "das Konto pausiert nicht",
+	"cooldown for %s ended":      "Pause für %s beendet",
+
+	// Pages
+	"Share link not found.":                           "Freigabelink nicht gefunden.",
+	"This share link has expired, ask for a new one.": "Dieser Freigabelink ist abgelaufen, fordern Sie einen neuen an.",
+	"Suggested message for %s":                        "Vorgeschlagene Nachricht für %s",
+	"Why this message":                                "Warum diese Nachricht",
+	"Recent post: \"%s\"":                             "Letzter Beitrag: „%s“",
+	"Persona: %s, %s":                                 "Profiltyp: %s, %s",
+	"View profile on LinkedIn":                        "Profil auf LinkedIn ansehen",
+	"This link expires %s.":                           "Dieser Link läuft am %s ab.",
+	"Verification not found.":                         "Verifizierung nicht gefunden.",
+	"LinkedIn security check for %s":                  "LinkedIn-Sicherheitsprüfung für %s",
+	"LinkedIn security checkpoint":                    "LinkedIn-Sicherheitsprüfung",
+	"Click and type on the page below as you would on LinkedIn. Once LinkedIn lets the account through, the scrape carries on by itself.": "Klicken und tippen Sie auf der Seite unten wie auf LinkedIn. Sobald LinkedIn das Konto durchlässt, setzt sich das Scraping von selbst fort.",
+	"This check gives up %s.": "Diese Prüfung endet am %s.",
+	"This security check has ended. If LinkedIn accepted it, the scrape carries on by itself.": "Diese Sicherheitsprüfung ist beendet. Wenn LinkedIn sie akzeptiert hat, setzt sich das Scraping von selbst fort.",
+}
+
+var fr = map[string]string{
+	// API responses
+	"server encountered an error, please try again later":               "le serveur a rencontré une erreur, veuillez réessayer plus tard",
+	"Encountered an error. Please try again":                            "Une erreur s'est produite. Veuillez réessayer",
+	"invalid email":                                                     "adresse e-mail invalide",
+	"invalid secret":                                                    "secret invalide",
+	"invalid form":                                                      "formulaire invalide",
+	"name is required":                                                  "le nom est obligatoire",
+	"at least one linkedinUrl is required":                              "au moins un linkedinUrl est requis",
+	"reason is required, it is recorded in the audit log":               "un motif est obligatoire, il est consigné dans le journal d'audit",
+	"goal is longer than %d characters":                                 "l'objectif dépasse %d caractères",
+	"batchId and campaignId can't both be set":                          "batchId et campaignId ne peuvent pas être définis ensemble",
+	"pages must be between 1 and %d":                                    "pages doit être compris entre 1 et %d",
+	"x and y must be between 0 and 1":                                   "x et y doivent être compris entre 0 et 1",
+	"text must be between 1 and 256 bytes":                              "text doit faire entre 1 et 256 octets",
+	`type must be one of "click", "type" or "key"`:                      `type doit valoir "click", "type" ou "key"`,
+	"code must be 4 to 8 digits":                                        "le code doit comporter de 4 à 8 chiffres",
+	"body is larger than 5MB":                                           "le corps dépasse 5MB",
+	"body is not a JSON document: %s":                                   "le corps n'est pas un document JSON : %s",
+	"bundle settings: %s":                                               "paramètres du bundle : %s",
+	"unsupported bundle version %d, this server reads version %d":       "version de bundle %d non prise en charge, ce serveur lit la version %d",
+	"icp filter %d has no name":                                         "le filtre ICP %d n'a pas de nom",
+	"campaign %d: %s":                                                   "campagne %d : %s",
+	"icp filter %q is neither in the bundle nor saved":                  "le filtre ICP %q n'est ni dans le paquet ni enregistré",
+	"%s, nothing was reloaded":                                          "%s, rien n'a été rechargé",
+	"streaming is not supported":                                        "le streaming n'est pas pris en charge",
+	"prospect not found":                                                "prospect introuvable",
+	"batch not found":                                                   "lot introuvable",
+	"campaign not found":                                                "campagne introuvable",
+	"icp filter not found":                                              "filtre ICP introuvable",
+	"schema not found":                                                  "schéma introuvable",
+	"team not found":                                                    "équipe introuvable",
+	"regeneration not found":                                            "régénération introuvable",
+	"verification not found":                                            "vérification introuvable",
+	"verification code request not found":                               "demande de code de vérification introuvable",
+	"no prospects match":                                                "aucun prospect ne correspond",
+	"no login is waiting for a code sent to this number":                "aucune connexion n'attend de code envoyé à ce numéro",
+	"the text has no verification code":                                 "le texte ne contient aucun code de vérification",
+	"prospect has no generated message":                                 "le prospect n'a aucun message généré",
+	"prospect is a draft of a dry run batch, drafts can't be shared":    "le prospect est un brouillon d'un lot de test, les brouillons ne peuvent pas être partagés",
+	"message is not pending approval":                                   "le message n'est pas en attente d'approbation",
+	"regeneration is still running":                                     "la régénération est toujours en cours",
+	"this campaign is already being sourced":                            "la recherche de prospects pour cette campagne est déjà en cours",
+	"the campaign's icp filter could not be loaded":                     "le filtre ICP de la campagne n'a pas pu être chargé",
+	"jobUrl is not a LinkedIn job posting":                              "jobUrl n'est pas une offre d'emploi LinkedIn",
+	"jobUrl needs the LinkedIn account's password":                      "jobUrl nécessite le mot de passe du compte LinkedIn",
+	"could not read the job posting, please check jobUrl and try again": "impossible de lire l'offre d'emploi, veuillez vérifier jobUrl et réessayer",
+	"only reviewers can approve messages":                               "seuls les relecteurs peuvent approuver des messages",
+	"only reviewers can see the approval queue":                         "seuls les relecteurs peuvent voir la file d'approbation",
+	"reviewers can't approve their own messages":                        "les relecteurs ne peuvent pas approuver leurs propres messages",
+	"only team members can see the team's activity":                     "seuls les membres de l'équipe peuvent voir l'activité de l'équipe",
+	"only support staff can view other users":                           "seule l'équipe support peut consulter d'autres utilisateurs",
+	"only support staff can reload the configuration":                   "seule l'équipe support peut recharger la configuration",
+	"could not log in to LinkedIn, please try again later":              "impossible de se connecter à LinkedIn, veuillez réessayer plus tard",
+	"could not search LinkedIn, please try again later":                 "impossible de rechercher sur LinkedIn, veuillez réessayer plus tard",
+	"this LinkedIn account is busy, please try again shortly":           "ce compte LinkedIn est occupé, veuillez réessayer dans un instant",
+	"the server is busy with %d queued profiles":                        "le serveur est occupé avec %d profils en file d'attente",
+	"all %d browsers are in use":                                        "les %d navigateurs sont tous utilisés",
+	"the server is low on memory with %d browsers in use":               "le serveur manque de mémoire avec %d navigateurs utilisés",
+	"this instance is shutting down, please try again":                  "cette instance s'arrête, veuillez réessayer",
+	"only the instance itself can drain it":                             "seule l'instance elle-même peut se vider",
+	"%s, please try again in about %s":                                  "%s, veuillez réessayer dans environ %s",
+	"%s, please try again after %s":                                     "%s, veuillez réessayer après %s",
+	"%s keeps failing, please try again after %s":                       "%s échoue sans cesse, veuillez réessayer après %s",
+	"logging in to this LinkedIn account":                               "la connexion à ce compte LinkedIn",
+	"writing messages":                                                  "la rédaction des messages",
+	"LinkedIn is rate limiting this account":                            "LinkedIn limite le débit de ce compte",
+	"LinkedIn flagged this account as automated":                        "LinkedIn a signalé ce compte comme automatisé",
+	"LinkedIn restricted this account":                                  "LinkedIn a restreint ce compte",
+	"LinkedIn wants a security checkpoint solved for this account":      "LinkedIn demande de résoudre un contrôle de sécurité pour ce compte",
+	"LinkedIn did not accept the email and password":                    "LinkedIn n'a pas accepté l'e-mail et le mot de passe",
+	"this LinkedIn account is cooling off after LinkedIn flagged it, please try again after %s": "ce compte LinkedIn est en pause car LinkedIn l'a signalé, veuillez réessayer après %s",
+	"this LinkedIn account reached its profile scrape limit, please try again after %s":         "ce compte LinkedIn a atteint sa limite de profils extraits, veuillez réessayer après %s",
+	"account is not cooling off": "le compte n'est pas en pause",
+	"cooldown for %s ended":      "pause de %s terminée",
+
+	// Pages
+	"Share link not found.":                           "Lien de partage introuvable.",
+	"This share link has expired, ask for a new one.": "Ce lien de partage a expiré, demandez-en un nouveau.",
+	"Suggested message for %s":                        "Message suggéré pour %s",
+	"Why this message":                                "Pourquoi ce message",
+	"Recent post: \"%s\"":                             "Publication récente : « %s »",
+	"Persona: %s, %s":                                 "Profil type : %s, %s",
+	"View profile on LinkedIn":                        "Voir le profil sur LinkedIn",
+	"This link expires %s.":                           "Ce lien expire le %s.",
+	"Verification not found.":                         "Vérification introuvable.",
+	"LinkedIn security check for %s":                  "Contrôle de sécurité LinkedIn pour %s",
+	"LinkedIn security checkpoint":                    "Contrôle de sécurité LinkedIn",
+	"Click and type on the page below as you would on LinkedIn. Once LinkedIn lets the account through, the scrape carries on by itself.": "Cliquez et tapez sur la page ci-dessous comme vous le feriez sur LinkedIn. Dès que LinkedIn laisse passer le compte, le scraping reprend tout seul.",
+	"This check gives up %s.": "Ce contrôle expire le %s.",
+	"This security check has ended. If LinkedIn accepted it, the scrape carries on by itself.": "Ce contrôle de sécurité est terminé. Si LinkedIn l'a accepté, le scraping reprend tout seul.",
+}
+
+var hi = map[string]string{
+	// API responses
+	"server encountered an error, please try again later":               "सर्वर में एक त्रुटि हुई, कृपया बाद में फिर से प्रयास करें",
+	"Encountered an error. Please try again":                            "एक त्रुटि हुई। कृपया फिर से प्रयास करें",
+	"invalid email":                                                     "अमान्य ईमेल",
+	"invalid secret":                                                    "अमान्य सीक्रेट",
+	"invalid form":                                                      "अमान्य फ़ॉर्म",
+	"name is required":                                                  "नाम आवश्यक है",
+	"at least one linkedinUrl is required":                              "कम से कम एक linkedinUrl आवश्यक है",
+	"reason is required, it is recorded in the audit log":               "कारण आवश्यक है, यह ऑडिट लॉग में दर्ज किया जाता है",
+	"goal is longer than %d characters":                                 "लक्ष्य %d अक्षरों से लंबा है",
+	"batchId and campaignId can't both be set":                          "batchId और campaignId दोनों एक साथ सेट नहीं किए जा सकते",
+	"pages must be between 1 and %d":                                    "pages 1 और %d के बीच होना चाहिए",
+	"x and y must be between 0 and 1":                                   "x और y 0 और 1 के बीच होने चाहिए",
+	"text must be between 1 and 256 bytes":                              "text 1 से 256 बाइट के बीच होना चाहिए",
+	`type must be one of "click", "type" or "key"`:                      `type "click", "type" या "key" में से एक होना चाहिए`,
+	"code must be 4 to 8 digits":                                        "कोड 4 से 8 अंकों का होना चाहिए",
+	"body is larger than 5MB":                                           "बॉडी 5MB से बड़ी है",
+	"body is not a JSON document: %s":                                   "बॉडी JSON दस्तावेज़ नहीं है: %s",
+	"bundle settings: %s":                                               "बंडल सेटिंग्स: %s",
+	"unsupported bundle version %d, this server reads version %d":       "बंडल संस्करण %d समर्थित नहीं है, यह सर्वर संस्करण %d पढ़ता है",
+	"icp filter %d has no name":                                         "ICP फ़िल्टर %d का कोई नाम नहीं है",
+	"campaign %d: %s":                                                   "अभियान %d: %s",
+	"icp filter %q is neither in the bundle nor saved":                  "ICP फ़िल्टर %q न तो बंडल में है और न ही सहेजा गया है",
+	"%s, nothing was reloaded":                                          "%s, कुछ भी फिर से लोड नहीं किया गया",
+	"streaming is not supported":                                        "स्ट्रीमिंग समर्थित नहीं है",
+	"prospect not found":                                                "प्रॉस्पेक्ट नहीं मिला",
+	"batch not found":                                                   "बैच नहीं मिला",
+	"campaign not found":                                                "कैंपेन नहीं मिला",
+	"icp filter not found":                                              "ICP फ़िल्टर नहीं मिला",
+	"schema not found":                                                  "स्कीमा नहीं मिला",
+	"team not found":                                                    "टीम नहीं मिली",
+	"regeneration not found":                                            "रीजनरेशन नहीं मिला",
+	"verification not found":                                            "सत्यापन नहीं मिला",
+	"verification code request not found":                               "सत्यापन कोड अनुरोध नहीं मिला",
+	"no prospects match":                                                "कोई प्रॉस्पेक्ट मेल नहीं खाता",
+	"no login is waiting for a code sent to this number":                "इस नंबर पर भेजे गए कोड की प्रतीक्षा में कोई लॉगिन नहीं है",
+	"the text has no verification code":                                 "टेक्स्ट में कोई सत्यापन कोड नहीं है",
+	"prospect has no generated message":                                 "प्रॉस्पेक्ट के लिए कोई संदेश नहीं बनाया गया है",
+	"prospect is a draft of a dry run batch, drafts can't be shared":    "प्रॉस्पेक्ट एक ड्राई रन बैच का ड्राफ़्ट है, ड्राफ़्ट साझा नहीं किए जा सकते",
+	"message is not pending approval":                                   "संदेश अनुमोदन के लिए लंबित नहीं है",
+	"regeneration is still running":                                     "रीजनरेशन अभी चल रहा है",
+	"this campaign is already being sourced":                            "इस कैंपेन के लिए पहले से प्रॉस्पेक्ट खोजे जा रहे हैं",
+	"the campaign's icp filter could not be loaded":                     "कैंपेन का ICP फ़िल्टर लोड नहीं हो सका",
+	"jobUrl is not a LinkedIn job posting":                              "jobUrl LinkedIn की नौकरी पोस्टिंग नहीं है",
+	"jobUrl needs the LinkedIn account's password":                      "jobUrl के लिए LinkedIn खाते का पासवर्ड चाहिए",
+	"could not read the job posting, please check jobUrl and try again": "नौकरी पोस्टिंग पढ़ी नहीं जा सकी, कृपया jobUrl जाँचें और फिर से प्रयास करें",
+	"only reviewers can approve messages":                               "केवल समीक्षक संदेश स्वीकृत कर सकते हैं",
+	"only reviewers can see the approval queue":                         "केवल समीक्षक अनुमोदन कतार देख सकते हैं",
+	"reviewers can't approve their own messages":                        "समीक्षक अपने स्वयं के संदेश स्वीकृत नहीं कर सकते",
+	"only team members can see the team's activity":                     "केवल टीम के सदस्य टीम की गतिविधि देख सकते हैं",
+	"only support staff can view other users":                           "केवल सपोर्ट स्टाफ़ अन्य उपयोगकर्ताओं को देख सकते हैं",
+	"only support staff can reload the configuration":                   "केवल सपोर्ट स्टाफ़ कॉन्फ़िगरेशन फिर से लोड कर सकते हैं",
+	"could not log in to LinkedIn, please try again later":              "LinkedIn में लॉग इन नहीं हो सका, कृपया बाद में फिर से प्रयास करें",
+	"could not search LinkedIn, please try again later":                 "LinkedIn पर खोज नहीं हो सकी, कृपया बाद में फिर से प्रयास करें",
+	"this LinkedIn account is busy, please try again shortly":           "यह LinkedIn खाता व्यस्त है, कृपया थोड़ी देर में फिर से प्रयास करें",
+	"the server is busy with %d queued profiles":                        "सर्वर कतार में लगी %d प्रोफ़ाइलों में व्यस्त है",
+	"all %d browsers are in use":                                        "सभी %d ब्राउज़र उपयोग में हैं",
+	"the server is low on memory with %d browsers in use":               "%d ब्राउज़र उपयोग में होने से सर्वर की मेमोरी कम है",
+	"this instance is shutting down, please try again":                  "यह इंस्टेंस बंद हो रहा है, कृपया फिर से कोशिश करें",
+	"only the instance itself can drain it":                             "केवल इंस्टेंस स्वयं ही खुद को ड्रेन कर सकता है",
+	"%s, please try again in about %s":                                  "%s, कृपया लगभग %s में फिर से प्रयास करें",
+	"%s, please try again after %s":                                     "%s, कृपया %s के बाद फिर से प्रयास करें",
+	"%s keeps failing, please try again after %s":                       "%s बार-बार विफल हो रहा है, कृपया %s के बाद फिर से प्रयास करें",
+	"logging in to this LinkedIn account":                               "इस LinkedIn खाते में लॉग इन करना",
+	"writing messages":                                                  "संदेश लिखना",
+	"LinkedIn is rate limiting this account":                            "LinkedIn इस खाते की दर सीमित कर रहा है",
+	"LinkedIn flagged this account as automated":                        "LinkedIn ने इस खाते को स्वचालित के रूप में फ़्लैग किया",
+	"LinkedIn restricted this account":                                  "LinkedIn ने इस खाते को प्रतिबंधित किया",
+	"LinkedIn wants a security checkpoint solved for this account":      "LinkedIn इस खाते के लिए एक सुरक्षा जाँच हल करवाना चाहता है",
+	"LinkedIn did not accept the email and password":                    "LinkedIn ने ईमेल और पासवर्ड स्वीकार नहीं किए",
+	"this LinkedIn account is cooling off after LinkedIn flagged it, please try again after %s": "LinkedIn द्वारा फ़्लैग किए जाने के बाद यह LinkedIn खाता कूलडाउन में है, कृपया %s के बाद फिर से प्रयास करें",
+	"this LinkedIn account reached its profile scrape limit, please try again after %s":         "यह LinkedIn खाता प्रोफ़ाइल स्क्रैप करने की अपनी सीमा तक पहुँच गया है, कृपया %s के बाद फिर से प्रयास करें",
+	"account is not cooling off": "खाता कूलडाउन में नहीं है",
+	"cooldown for %s ended":      "%s का कूलडाउन समाप्त हुआ",
+
+	// Pages
+	"Share link not found.":                           "शेयर लिंक नहीं मिला।",
+	"This share link has expired, ask for a new one.": "यह शेयर लिंक समाप्त हो गया है, नया लिंक माँगें।",
+	"Suggested message for %s":                        "%s के लिए सुझाया गया संदेश",
+	"Why this message":                                "यह संदेश क्यों",
+	"Recent post: \"%s\"":                             "हाल की पोस्ट: \"%s\"",
+	"Persona: %s, %s":                                 "पर्सोना: %s, %s",
+	"View profile on LinkedIn":                        "LinkedIn पर प्रोफ़ाइल देखें",
+	"This link expires %s.":                           "यह लिंक %s को समाप्त होगा।",
+	"Verification not found.":                         "सत्यापन नहीं मिला।",
+	"LinkedIn security check for %s":                  "%s के लिए LinkedIn सुरक्षा जाँच",
+	"LinkedIn security checkpoint":                    "LinkedIn सुरक्षा जाँच",
+	"Click and type on the page below as you would on LinkedIn. Once LinkedIn lets the account through, the scrape carries on by itself.": "नीचे दिए गए पेज पर वैसे ही क्लिक और टाइप करें जैसे आप LinkedIn पर करते हैं। LinkedIn के खाते को आगे जाने देने पर स्क्रेप अपने आप जारी रहेगा।",
+	"This check gives up %s.": "यह जाँच %s को समाप्त होगी।",
+	"This security check has ended. If LinkedIn accepted it, the scrape carries on by itself.": "यह सुरक्षा जाँच समाप्त हो गई है। अगर LinkedIn ने इसे स्वीकार किया, तो स्क्रेप अपने आप जारी रहेगा।",
+}
@@ -0,0 +1,109 @@
+/*
+	Package i18n translates the messages the server answers with and the pages it renders.
+
+Messages are keyed by their English text, so code keeps writing English and
+a message missing from a catalog is still answered, in English. Messages
+with values in them are kept as a format and its arguments until the
+language is known, so the format is what gets translated:
+
+	lang := i18n.Negotiate(r.Header.Get("Accept-Language"))
+	i18n.T(lang, "invalid email")                                   // "correo electrónico no válido" for "es"
+	i18n.Format("goal is longer than %d characters", 500).In(lang) // "el objetivo supera los 500 caracteres"
+
+Translations keep the verbs of their format in the same order. Field names
+and values such as jobUrl or "click" are left as they are, clients send them
+in English whatever the language.
+*/
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Default is the language of the messages themselves, answered when no other is accepted.
+const Default = "en"
+
+// Languages are the languages messages are translated to, Default first.
+var Languages = []string{Default, "es", "de", "fr", "hi"}
+
+/*
+	Negotiate picks the language to answer a request in from its Accept-Language header.
+
+It is the supported language the header weighs highest, matched on the
+primary subtag so "es-MX" picks "es"; ties go to the one listed first.
+Default is picked when the header accepts none of Languages, or is empty.
+*/
+func Negotiate(acceptLanguage string) string {
+	best, bestQ := Default, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q > bestQ && supported(base) {
+			best, bestQ = base, q
+		}
+	}
+	return best
+}
+
+func supported(lang string) bool {
+	for _, l := range Languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// T translates msg into lang, returning msg itself when lang has no translation of it.
+func T(lang, msg string) string {
+	if t, ok := catalog[lang][msg]; ok {
+		return t
+	}
+	return msg
+}
+
+// Message is a message with values in it, translated once the language is known.
+type Message struct {
+	format string
+	args   []any
+}
+
+// Format returns the message format fills with args, as fmt.Sprintf would.
+func Format(format string, args ...any) Message {
+	return Message{format: format, args: args}
+}
+
+// In returns the message in lang. Arguments that are Messages themselves are translated too.
+func (m Message) In(lang string) string {
+	args := make([]any, len(m.args))
+	for i, arg := range m.args {
+		if msg, ok := arg.(Message); ok {
+			arg = msg.In(lang)
+		}
+		args[i] = arg
+	}
+	return fmt.Sprintf(T(lang, m.format), args...)
+}
+
+// String returns the message in English.
+func (m Message) String() string {
+	return m.In(Default)
+}
+
+// Funcs are the functions of a page template rendered in lang: "t" translates a message
+// format and fills it with the arguments given, "lang" returns lang for the html element.
+func Funcs(lang string) map[string]any {
+	return map[string]any{
+		"t":    func(format string, args ...any) string { return Format(format, args...).In(lang) },
+		"lang": func() string { return lang },
+	}
+}
@@ -0,0 +1,64 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]string{
+		"":                                "en",
+		"es":                              "es",
+		"es-MX,es;q=0.9,en;q=0.8":         "es",
+		"ja, de;q=0.5":                    "de",
+		"en;q=0.4, FR-ca;q=0.8, hi;q=0.8": "fr",
+		"hi;q=0, de;q=0.1":                "de",
+		"*, pt-BR":                        "en",
+		"es;q=nonsense, fr;q=0.2":         "fr",
+	}
+	for header, want := range cases {
+		if got := Negotiate(header); got != want {
+			t.Errorf("Negotiate(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	msg := Format("%s keeps failing, please try again after %s", Format("writing messages"), "10:00")
+	if got := msg.In("de"); got != "das Schreiben von Nachrichten schlägt wiederholt fehl, bitte versuchen Sie es nach 10:00 erneut" {
+		t.Errorf("In(de) = %q", got)
+	}
+	if got := msg.String(); got != "writing messages keeps failing, please try again after 10:00" {
+		t.Errorf("String = %q", got)
+	}
+	if got := T("es", "not in the catalog"); got != "not in the catalog" {
+		t.Errorf("T of an unknown message = %q, want it in English", got)
+	}
+}
+
+var verbs = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+// Every language translates the same messages, and keeps the verbs of each in order.
+func TestCatalog(t *testing.T) {
+	for _, lang := range Languages[1:] {
+		if catalog[lang] == nil {
+			t.Fatalf("no catalog for %q", lang)
+		}
+	}
+	for lang, messages := range catalog {
+		for msg, translation := range messages {
+			if translation == msg {
+				t.Errorf("%s: %q is not translated", lang, msg)
+			}
+			if !slices.Equal(verbs.FindAllString(msg, -1), verbs.FindAllString(translation, -1)) {
+				t.Errorf("%s: %q changes the verbs of %q", lang, translation, msg)
+			}
+			for other, them := range catalog {
+				if _, ok := them[msg]; !ok {
+					t.Errorf("%s translates %q, %s doesn't", lang, msg, other)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+	Package icp evaluates scraped profiles against an ideal customer profile.
+
+A Filter is made of independent criteria (titles, locations, keywords, topics
+and persona). A profile matches when it satisfies every non-empty criterion, and a
+criterion is satisfied when any of its values is found. Matching is a plain,
+case-insensitive substring check so it is cheap enough to run before any
+LLM spend.
+
+Basic usage:
+
+	f := icp.Filter{Titles: []string{"engineering manager"}, Locations: []string{"Bengaluru"}}
+	if ok, reason := f.Match(*scraper.Profile, p); !ok {
+	    log.Printf("skipping: %s", reason)
+	}
+*/
+package icp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/persona"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/topics"
+)
+
+/*
+	Filter is an ideal customer profile definition.
+
+Titles are matched against every experience title, Locations against the
+profile location, Keywords against the about section and experience
+entries, and Topics against the topics of the posts, articles and comments
+as topics.Match does.
+*/
+type Filter struct {
+	Titles    []string       `json:"titles,omitempty"`
+	Locations []string       `json:"locations,omitempty"`
+	Keywords  []string       `json:"keywords,omitempty"`
+	Topics    []string       `json:"topics,omitempty"`
+	Persona   persona.Filter `json:"persona"`
+}
+
+// NeedsDetails reports whether the filter reads sections beyond name, location and posts.
+func (f Filter) NeedsDetails() bool {
+	return len(f.Titles) > 0 || len(f.Keywords) > 0 || len(f.Persona.Seniorities) > 0 || len(f.Persona.Functions) > 0
+}
+
+/*
+	Match evaluates a profile against the filter.
+
+Parameters:
+  - profile: The scraped profile
+  - p: The profile's persona classification
+
+Returns:
+  - bool: Whether the profile matches every criterion
+  - string: Why the profile does not match, empty when it does
+*/
+func (f Filter) Match(profile scraper.Profile, p persona.Persona) (bool, string) {
+	if len(f.Titles) > 0 {
+		titles := make([]string, 0, len(profile.Experience))
+		for _, exp := range profile.Experience {
+			titles = append(titles, exp.Title)
+		}
+		if !containsAny(titles, f.Titles) {
+			return false, fmt.Sprintf("no title matches %s", strings.Join(f.Titles, ", "))
+		}
+	}
+	if ok, reason := f.MatchLocation(profile.Location); !ok {
+		return false, reason
+	}
+	if len(f.Keywords) > 0 {
+		text := []string{profile.About}
+		for _, exp := range profile.Experience {
+			text = append(text, exp.Title, exp.Company)
+		}
+		if !containsAny(text, f.Keywords) {
+			return false, fmt.Sprintf("about and experience mention none of %s", strings.Join(f.Keywords, ", "))
+		}
+	}
+	if len(f.Topics) > 0 && !topics.Match(profile.Topics, f.Topics) {
+		return false, fmt.Sprintf("posted about none of %s", strings.Join(f.Topics, ", "))
+	}
+	if !f.Persona.Match(p) {
+		return false, fmt.Sprintf("persona %s/%s does not match", p.Seniority, p.Function)
+	}
+	return true, ""
+}
+
+/*
+	MatchLocation evaluates only the filter's locations.
+
+It suits search results, which list nothing but a location and headline.
+
+Parameters:
+  - location: The profile location
+
+Returns:
+  - bool: Whether the location matches, always true when the filter has no locations
+  - string: Why the location does not match, empty when it does
+*/
+func (f Filter) MatchLocation(location string) (bool, string) {
+	if len(f.Locations) > 0 && !containsAny([]string{location}, f.Locations) {
+		return false, fmt.Sprintf("location %q is not one of %s", location, strings.Join(f.Locations, ", "))
+	}
+	return true, ""
+}
+
+func containsAny(haystacks, needles []string) bool {
+	for _, h := range haystacks {
+		h = strings.ToLower(h)
+		for _, n := range needles {
+			if n = strings.ToLower(strings.TrimSpace(n)); n != "" && strings.Contains(h, n) {
+				return true
+			}
+		}
+	}
+	return false
+}
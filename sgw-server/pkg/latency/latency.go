@@ -0,0 +1,208 @@
+/*
+	Package latency keeps percentiles of how long jobs and their stages take, for setting and watching SLOs.
+
+Every job and stage keeps its latest observations, so percentiles follow recent
+traffic rather than everything since startup, and counts and sums since the
+recorder was created, as Prometheus summaries report them.
+
+Basic usage:
+
+	r := latency.New(latency.DefaultWindow)
+	r.Observe("batch", latency.Scrape, time.Since(start))
+	fmt.Println(r.Stats()["batch"][latency.Scrape].P95Ms)
+	r.WritePrometheus(w, "segwise_job_latency_seconds", "How long jobs take, by stage")
+*/
+package latency
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Stage is a part of a job's end-to-end latency.
+type Stage string
+
+const (
+	Queue    Stage = "queue"    // From submission until scraping starts, waiting for the account and logging in included
+	Scrape   Stage = "scrape"   // Reading the profile, enrichment included
+	Generate Stage = "generate" // Classifying the persona and writing the message
+	Total    Stage = "total"    // From submission until the message is ready
+)
+
+// DefaultWindow is how many of the latest observations percentiles are computed from.
+const DefaultWindow = 1024
+
+// quantiles are the percentiles Stats and WritePrometheus report.
+var quantiles = []float64{0.5, 0.9, 0.95, 0.99}
+
+/*
+	Stats is a snapshot of one job's stage.
+
+Count is since the recorder was created, the rest are over the latest
+observations only.
+*/
+type Stats struct {
+	Count  uint64  `json:"count"`
+	MeanMs float64 `json:"meanMs"`
+	P50Ms  float64 `json:"p50Ms"`
+	P90Ms  float64 `json:"p90Ms"`
+	P95Ms  float64 `json:"p95Ms"`
+	P99Ms  float64 `json:"p99Ms"`
+	MaxMs  float64 `json:"maxMs"`
+}
+
+type key struct {
+	job   string
+	stage Stage
+}
+
+// series is a ring of the latest observations of a job's stage.
+type series struct {
+	samples []time.Duration
+	next    int // Where the next observation goes once samples is full
+	count   uint64
+	sum     time.Duration
+}
+
+// Recorder keeps the observations of every job and stage. It is safe for concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	window int
+	series map[key]*series
+}
+
+/*
+	New creates an empty recorder.
+
+Parameters:
+  - window: How many of the latest observations of a job's stage percentiles are computed from, DefaultWindow when not positive
+
+Returns:
+  - *Recorder: The recorder
+*/
+func New(window int) *Recorder {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	return &Recorder{window: window, series: map[key]*series{}}
+}
+
+// Observe records that a stage of job took d.
+func (r *Recorder) Observe(job string, stage Stage, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := key{job, stage}
+	s, ok := r.series[k]
+	if !ok {
+		s = &series{samples: make([]time.Duration, 0, r.window)}
+		r.series[k] = s
+	}
+	if len(s.samples) < r.window {
+		s.samples = append(s.samples, d)
+	} else {
+		s.samples[s.next] = d
+		s.next = (s.next + 1) % r.window
+	}
+	s.count++
+	s.sum += d
+}
+
+// Stats returns a snapshot of every job and stage observed so far, by job and stage.
+func (r *Recorder) Stats() map[string]map[Stage]Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := map[string]map[Stage]Stats{}
+	for k, s := range r.series {
+		if stats[k.job] == nil {
+			stats[k.job] = map[Stage]Stats{}
+		}
+		sorted := s.sorted()
+		var sum time.Duration
+		for _, d := range sorted {
+			sum += d
+		}
+		stats[k.job][k.stage] = Stats{
+			Count:  s.count,
+			MeanMs: ms(sum / time.Duration(len(sorted))),
+			P50Ms:  ms(percentile(sorted, 0.5)),
+			P90Ms:  ms(percentile(sorted, 0.9)),
+			P95Ms:  ms(percentile(sorted, 0.95)),
+			P99Ms:  ms(percentile(sorted, 0.99)),
+			MaxMs:  ms(sorted[len(sorted)-1]),
+		}
+	}
+	return stats
+}
+
+/*
+	WritePrometheus writes every job and stage as a Prometheus summary in the text exposition format.
+
+Parameters:
+  - w: Where to write
+  - name: The metric name, e.g. segwise_job_latency_seconds
+  - help: The metric's HELP line
+
+Returns:
+  - error: Any error writing to w
+*/
+func (r *Recorder) WritePrometheus(w io.Writer, name, help string) error {
+	r.mu.Lock()
+	keys := make([]key, 0, len(r.series))
+	for k := range r.series {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].job != keys[j].job {
+			return keys[i].job < keys[j].job
+		}
+		return keys[i].stage < keys[j].stage
+	})
+	type snapshot struct {
+		sorted []time.Duration
+		count  uint64
+		sum    time.Duration
+	}
+	snapshots := make([]snapshot, len(keys))
+	for i, k := range keys {
+		s := r.series[k]
+		snapshots[i] = snapshot{sorted: s.sorted(), count: s.count, sum: s.sum}
+	}
+	r.mu.Unlock()
+
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s summary\n", name, help, name); err != nil {
+		return err
+	}
+	for i, k := range keys {
+		labels := fmt.Sprintf("job=%q,stage=%q", k.job, k.stage)
+		for _, q := range quantiles {
+			if _, err := fmt.Fprintf(w, "%s{%s,quantile=\"%g\"} %g\n", name, labels, q, percentile(snapshots[i].sorted, q).Seconds()); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s_sum{%s} %g\n%s_count{%s} %d\n", name, labels, snapshots[i].sum.Seconds(), name, labels, snapshots[i].count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *series) sorted() []time.Duration {
+	sorted := slices.Clone(s.samples)
+	slices.Sort(sorted)
+	return sorted
+}
+
+// percentile returns the nearest-rank q percentile of sorted, which is not empty.
+func percentile(sorted []time.Duration, q float64) time.Duration {
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+	return sorted[max(rank, 0)]
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,71 @@
+package latency
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	r := New(100)
+	for i := 1; i <= 100; i++ {
+		r.Observe("batch", Scrape, time.Duration(i)*time.Millisecond)
+	}
+	r.Observe("home", Total, time.Second)
+
+	got := r.Stats()
+	scrape := got["batch"][Scrape]
+	if scrape.Count != 100 || scrape.P50Ms != 50 || scrape.P90Ms != 90 || scrape.P99Ms != 99 || scrape.MaxMs != 100 || scrape.MeanMs != 50.5 {
+		t.Errorf("batch scrape = %+v", scrape)
+	}
+	if home := got["home"][Total]; home.Count != 1 || home.P50Ms != 1000 || home.P99Ms != 1000 {
+		t.Errorf("home total = %+v", home)
+	}
+	if _, ok := got["batch"][Generate]; ok {
+		t.Error("a stage that was never observed has stats")
+	}
+}
+
+func TestWindowKeepsLatestObservations(t *testing.T) {
+	r := New(10)
+	for i := 0; i < 10; i++ {
+		r.Observe("batch", Queue, time.Hour)
+	}
+	// A slow start rolls out of the window, the count still has it
+	for i := 0; i < 10; i++ {
+		r.Observe("batch", Queue, time.Second)
+	}
+	if got := r.Stats()["batch"][Queue]; got.Count != 20 || got.MaxMs != 1000 {
+		t.Errorf("batch queue = %+v, want 20 observations and the latest 10 of a second", got)
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	r := New(0)
+	r.Observe("home", Scrape, 2*time.Second)
+	r.Observe("home", Scrape, 4*time.Second)
+	r.Observe("batch", Total, 30*time.Second)
+
+	var out strings.Builder
+	if err := r.WritePrometheus(&out, "jobs_seconds", "How long jobs take"); err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP jobs_seconds How long jobs take
+# TYPE jobs_seconds summary
+jobs_seconds{job="batch",stage="total",quantile="0.5"} 30
+jobs_seconds{job="batch",stage="total",quantile="0.9"} 30
+jobs_seconds{job="batch",stage="total",quantile="0.95"} 30
+jobs_seconds{job="batch",stage="total",quantile="0.99"} 30
+jobs_seconds_sum{job="batch",stage="total"} 30
+jobs_seconds_count{job="batch",stage="total"} 1
+jobs_seconds{job="home",stage="scrape",quantile="0.5"} 2
+jobs_seconds{job="home",stage="scrape",quantile="0.9"} 4
+jobs_seconds{job="home",stage="scrape",quantile="0.95"} 4
+jobs_seconds{job="home",stage="scrape",quantile="0.99"} 4
+jobs_seconds_sum{job="home",stage="scrape"} 6
+jobs_seconds_count{job="home",stage="scrape"} 2
+`
+	if out.String() != want {
+		t.Errorf("WritePrometheus wrote\n%s\nwant\n%s", out.String(), want)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+	Package ocr reads the text off images, such as the slides of LinkedIn document posts.
+
+An Engine turns a PNG screenshot into text. Tesseract, the only engine for now,
+runs the tesseract command line tool; other engines, e.g. a cloud vision API,
+only need to implement Engine.
+
+Basic usage:
+
+	engine, err := ocr.New("tesseract", "eng")
+	if err != nil {
+	    log.Fatal(err)
+	}
+	text, err := engine.Text(ctx, screenshot)
+*/
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Names lists the engines New knows.
+var Names = []string{"tesseract"}
+
+/*
+	Engine reads the text in an image.
+
+Text returns "" and no error for images without text.
+*/
+type Engine interface {
+	Text(ctx context.Context, image []byte) (string, error)
+}
+
+/*
+	New returns the named engine.
+
+Parameters:
+  - name: Engine name from Names, "" for none
+  - languages: Languages to read, in the engine's notation (e.g. "eng+deu" for tesseract); "" for its default
+
+Returns:
+  - Engine: The engine, nil when name is ""
+  - error: An error for unknown engines or engines that can't run here
+*/
+func New(name, languages string) (Engine, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case "tesseract":
+		path, err := exec.LookPath("tesseract")
+		if err != nil {
+			return nil, errors.New("tesseract is not on PATH, install it (e.g. apt install tesseract-ocr)")
+		}
+		return &Tesseract{Path: path, Languages: languages}, nil
+	default:
+		return nil, fmt.Errorf("unknown OCR engine %q, expected one of %s", name, strings.Join(Names, ", "))
+	}
+}
+
+/*
+	Tesseract reads text with the tesseract command line tool.
+
+Path defaults to tesseract on PATH; Languages is passed as -l when set and
+needs the matching traineddata installed.
+*/
+type Tesseract struct {
+	Path      string
+	Languages string
+}
+
+/*
+	Text runs tesseract on image and returns the text it read.
+
+Parameters:
+  - ctx: Context bounding the run, the process is killed when it is done
+  - image: The image, in any format tesseract reads (PNG for screenshots)
+
+Returns:
+  - string: The text, a line per line read with blank lines dropped
+  - error: Any error running tesseract, with its output
+*/
+func (t *Tesseract) Text(ctx context.Context, image []byte) (string, error) {
+	path := t.Path
+	if path == "" {
+		path = "tesseract"
+	}
+	args := []string{"stdin", "stdout"}
+	if t.Languages != "" {
+		args = append(args, "-l", t.Languages)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin = bytes.NewReader(image)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("tesseract failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return Clean(stdout.String()), nil
+}
+
+// Clean collapses the whitespace of OCR output, keeping a line per line read.
+func Clean(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,46 @@
+package ocr
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestTesseract(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the stand-in tesseract is a shell script")
+	}
+	// Echoes its arguments and the image back, as tesseract would print the text it read
+	path := filepath.Join(t.TempDir(), "tesseract")
+	script := "#!/bin/sh\necho \"args: $*\"\necho\ncat\n"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := (&Tesseract{Path: path, Languages: "eng+deu"}).Text(context.Background(), []byte("  Five  lessons \n\n\f from   scaling Kafka\n"))
+	if err != nil {
+		t.Fatalf("Text: %v", err)
+	}
+	if want := "args: stdin stdout -l eng+deu\nFive lessons\nfrom scaling Kafka"; got != want {
+		t.Errorf("Text = %q, want %q", got, want)
+	}
+
+	failing := filepath.Join(t.TempDir(), "tesseract")
+	if err := os.WriteFile(failing, []byte("#!/bin/sh\necho 'Error opening data file' >&2\nexit 1\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&Tesseract{Path: failing}).Text(context.Background(), nil); err == nil {
+		t.Error("a failing tesseract was not reported")
+	}
+}
+
+func TestNew(t *testing.T) {
+	if engine, err := New("", ""); engine != nil || err != nil {
+		t.Errorf("New(\"\") = %v, %v; want no engine", engine, err)
+	}
+	if _, err := New("abbyy", ""); err == nil {
+		t.Error("unknown engine accepted")
+	}
+}
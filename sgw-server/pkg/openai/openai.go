@@ -13,11 +13,11 @@ Basic usage:
 	    Posts: []scraper.Post{...},
 	}
 
-	message, err := openai.GetMessage(profile, "your-api-key")
+	message, usage, err := openai.GetMessage(openai.Prospect{Profile: profile}, "your-api-key")
 	if err != nil {
 	    log.Fatal(err)
 	}
-	fmt.Println(message)
+	fmt.Println(message, usage.Dollars(openai.DefaultPrices))
 */
 package openai
 
@@ -26,10 +26,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/hemantsharma1498/segwise-assignment/pkg/background"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/enrich"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/persona"
 	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
 )
 
+const (
+	apiURL = "https://api.openai.com/v1/chat/completions"
+	model  = "gpt-4o-mini"
+
+	embeddingURL   = "https://api.openai.com/v1/embeddings"
+	embeddingModel = "text-embedding-3-small"
+)
+
 /*
 	OpenAIReq represents the request structure for OpenAI's chat completion API.
 
@@ -57,6 +71,45 @@ It contains an array of choices, each containing a message.
 */
 type OpenAIResponse struct {
 	Choices []Choice `json:"choices"` // Array of possible responses
+	Usage   Usage    `json:"usage"`   // Tokens the request was billed for
+}
+
+/*
+	Usage is the tokens one or more requests to OpenAI's API were billed for.
+
+Calls counts the requests, including those that failed after being sent. The
+tokens of embedding requests are priced apart from those of chat completions.
+*/
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	Calls            int `json:"-"`
+	EmbeddingTokens  int `json:"-"`
+}
+
+// Add returns the usage of both u's and o's requests.
+func (u Usage) Add(o Usage) Usage {
+	return Usage{
+		PromptTokens:     u.PromptTokens + o.PromptTokens,
+		CompletionTokens: u.CompletionTokens + o.CompletionTokens,
+		Calls:            u.Calls + o.Calls,
+		EmbeddingTokens:  u.EmbeddingTokens + o.EmbeddingTokens,
+	}
+}
+
+// Prices are what a million tokens cost, in US dollars.
+type Prices struct {
+	PromptPerMillion     float64
+	CompletionPerMillion float64
+	EmbeddingPerMillion  float64
+}
+
+// DefaultPrices are OpenAI's list prices of the models messages are written and posts embedded with.
+var DefaultPrices = Prices{PromptPerMillion: 0.15, CompletionPerMillion: 0.60, EmbeddingPerMillion: 0.02}
+
+// Dollars estimates what the usage cost at prices.
+func (u Usage) Dollars(prices Prices) float64 {
+	return (float64(u.PromptTokens)*prices.PromptPerMillion + float64(u.CompletionTokens)*prices.CompletionPerMillion + float64(u.EmbeddingTokens)*prices.EmbeddingPerMillion) / 1e6
 }
 
 /*
@@ -78,19 +131,172 @@ type Message struct {
 	Content string `json:"content"` // Content of the generated message
 }
 
+/*
+	Prospect is everything known about the target that is handed to the model.
+
+Profile holds the scraped LinkedIn data; the remaining fields are derived
+signals that help the model pick the right angle for the message.
+*/
+type Prospect struct {
+	Profile scraper.Profile  `json:"profile"`           // Scraped LinkedIn profile
+	Persona *persona.Persona `json:"persona,omitempty"` // Seniority and function classification
+	// Background shared between the sender and the prospect
+	SharedBackground []background.Hook `json:"sharedBackground,omitempty"`
+	Sender           *SenderPersona    `json:"sender,omitempty"` // Who the message is written from
+	// Language to write the message in, English when empty
+	Language string `json:"language,omitempty"`
+	// What sources outside LinkedIn, such as GitHub or a personal website, say about the prospect
+	Enrichment []enrich.Enrichment `json:"enrichment,omitempty"`
+	// Open role the message is about, if any
+	Job *scraper.Job `json:"job,omitempty"`
+	// How recently the prospect was active, ActivityFresh or ActivityDormant; empty in between
+	Activity string `json:"activity,omitempty"`
+	// What the sender is reaching out for, such as hiring data engineers; posts are ranked by relevance to it
+	Goal string `json:"goal,omitempty"`
+}
+
+const (
+	// ActivityFresh marks a prospect who posted, published or commented within activityFresh.
+	ActivityFresh = "fresh"
+	// ActivityDormant marks a prospect whose latest dated activity is older than activityDormant.
+	ActivityDormant = "dormant"
+
+	activityFresh   = 7 * 24 * time.Hour
+	activityDormant = 180 * 24 * time.Hour
+)
+
+/*
+	Recency tells how recently a profile's owner was last active on LinkedIn.
+
+Parameters:
+  - profile: The scraped profile, with LastActiveAt derived
+  - now: The time the message is written
+
+Returns:
+  - string: ActivityFresh, ActivityDormant, or "" when the activity is neither or not dated
+*/
+func Recency(profile scraper.Profile, now time.Time) string {
+	switch since := now.Sub(profile.LastActiveAt); {
+	case profile.LastActiveAt.IsZero():
+		return ""
+	case since <= activityFresh:
+		return ActivityFresh
+	case since > activityDormant:
+		return ActivityDormant
+	}
+	return ""
+}
+
+/*
+	SenderPersona describes the user the message is written on behalf of.
+
+It is taken from the sender's own stored LinkedIn profile.
+*/
+type SenderPersona struct {
+	Name    string `json:"name"`    // Sender's full name
+	Title   string `json:"title"`   // Sender's current job title
+	Company string `json:"company"` // Sender's current employer
+}
+
+/*
+	NewSenderPersona builds a SenderPersona from the sender's scraped profile,
+
+using the most recent experience entry for title and company.
+*/
+func NewSenderPersona(profile scraper.Profile) *SenderPersona {
+	sender := &SenderPersona{Name: profile.Name}
+	if len(profile.Experience) > 0 {
+		sender.Title = profile.Experience[0].Title
+		company, _, _ := strings.Cut(profile.Experience[0].Company, "·")
+		sender.Company = strings.TrimSpace(company)
+	}
+	return sender
+}
+
+/*
+	NativeLanguage returns the first language the profile lists at native or bilingual proficiency.
+
+Parameters:
+  - profile: The prospect's scraped profile
+
+Returns:
+  - string: The language as listed, or "" when none is marked native
+*/
+func NativeLanguage(profile scraper.Profile) string {
+	for _, l := range profile.Languages {
+		if strings.Contains(strings.ToLower(l.Proficiency), "native") {
+			return l.Name
+		}
+	}
+	return ""
+}
+
+// DefaultMessagePrompt is the system prompt GetMessage writes with until SetMessagePrompt replaces it.
+const DefaultMessagePrompt = "You will be provided with a JSON containing a LinkedIn user's profile (slices and strings of posts with the text read off their images and slides (mediaText), articles, comments they left on other people's posts with the post's author and opening, topics (their hashtags and recurring themes, most mentioned first), experience, company (the current employer's page with its industry, size, about and recent posts), education, skills with endorsement counts, certifications, recommendations received and given, volunteering, publications, patents, languages, about, name, geography, and connection and follower counts) " +
+	"and optionally their persona (seniority and function), the sender writing the message (sender), the background they share with the sender (sharedBackground), what their own pages outside LinkedIn say about them (enrichment, e.g. GitHub or a personal website, and recent news about their employer), an open role the message is about (job: title, company, location, highlights of the description and hiring team), how recently they were active (activity) and what the sender is reaching out for (goal). " +
+	"Create a connect message of maximum two lines. Prioritize the content of the message by posts and articles, recommendations, experience, company, publications and patents, skills, certifications, education, volunteering, about, name, and geography. " +
+	"Comments show what the user engages with when they rarely post: they rank just below posts and articles, and the post commented on is someone else's, so never attribute it to the user or name its author. " +
+	"If activity is fresh, the user was active in the last week: open on their latest post or comment, as the freshest thing on their mind. If activity is dormant, nothing they wrote is recent: hook on their experience instead, and never present a post or comment as recent. " +
+	"If a goal is present, posts are ordered by how relevant they are to it, most relevant first: reference the first post that fits rather than the latest one, even when activity is fresh, and let the goal shape the message without pitching it outright. " +
+	"ContactInfo holds ways to reach the user (websites, email, Twitter handle, birthday): never put the email, handle or birthday in the message. " +
+	"JobPreferences are the titles, locations and start date the user said they are open to on their open-to-work card: they are looking for a new role, so reference what they want in plain words and, if a job is present, say how it fits them; never mention the badge or that they are looking. " +
+	"Topics are what the user writes about most: when no single post stands out, hook on the first topic that fits, in plain words and never as a hashtag. " +
+	"A post's mediaText is read by OCR and may be garbled: use it as what the post is about when the caption says little, never quote its broken parts. " +
+	"Recommendations are written by or for other people: use what they say about the user, never quote them or name the other person. " +
+	"Enrichment is the user's own work outside LinkedIn: it ranks with their posts, may be named by where it is (their GitHub, their blog) and is never pasted as a link. " +
+	"The exception is news enrichment, which is about the user's employer: treat it like the company's posts. " +
+	"The company is the user's employer, not the user: refer to it as where they work and never present its posts as the user's own. " +
+	"Prefer the most endorsed skills, and only mention a skill when it fits the rest of the message. " +
+	"If a job is present, anchor the message on that role: when the user is on its hiring team or works at its company, write as a candidate interested in it; otherwise pitch it to the user, picking the highlights that fit their background. Name the role and company, never list every highlight. " +
+	"If sharedBackground is present, open with the strongest shared hook (the first one) since it outweighs everything else. " +
+	"If a sender is present, write in the first person as the sender and never invent facts about them. " +
+	"Use the follower and connection counts to pitch the tone: people with a large following (10,000 followers or more) get many requests, so be brief and specific about their work and never flatter their reach; people with a small network (under 300 connections) are best approached warmly and plainly. Never mention the counts. " +
+	"If a persona is present, match the tone to it: concise and outcome-focused for directors, VPs and C-level, peer-to-peer and practical for individual contributors and managers. " +
+	"If a language is present, write the whole message in that language. " +
+	"If nothing is present, send a sample connect message."
+
+// messagePrompt is the prompt SetMessagePrompt set, nil for DefaultMessagePrompt.
+var messagePrompt atomic.Pointer[string]
+
+/*
+	SetMessagePrompt replaces the system prompt GetMessage writes connect messages with.
+
+Messages being written when it is called keep the prompt they started with.
+
+Parameters:
+  - prompt: The new system prompt, empty for DefaultMessagePrompt
+*/
+func SetMessagePrompt(prompt string) {
+	if prompt == "" {
+		messagePrompt.Store(nil)
+		return
+	}
+	messagePrompt.Store(&prompt)
+}
+
+// MessagePrompt returns the system prompt GetMessage writes with.
+func MessagePrompt() string {
+	if p := messagePrompt.Load(); p != nil {
+		return *p
+	}
+	return DefaultMessagePrompt
+}
+
 /*
 	GetMessage generates a personalized LinkedIn connection message based on a user's profile data.
 
 It processes the profile information and uses OpenAI's GPT model to create a contextual
 connection request. The function prioritizes different aspects of the profile in the following order:
-posts, experience, education, about section, name, and geography.
+posts and articles, comments, recommendations, experience, company, publications and patents, skills, certifications, education,
+volunteering, about section, name, and geography.
 
 Parameters:
-  - userData: A scraper.Profile struct containing the LinkedIn profile information
+  - prospect: A Prospect containing the scraped profile and derived signals
   - apiKey: OpenAI API key for authentication
 
 Returns:
   - string: The generated connection message
+  - Usage: The tokens the request was billed for
   - error: Any error encountered during the API request or response processing
 
 Example:
@@ -101,41 +307,214 @@ Example:
 	        {Company: "Tech Corp", Title: "Software Engineer"},
 	    },
 	}
-	message, err := GetMessage(profile, "your-api-key")
+	message, usage, err := GetMessage(Prospect{Profile: profile}, "your-api-key")
+*/
+func GetMessage(prospect Prospect, apiKey string) (string, Usage, error) {
+	jsonProspect, err := json.Marshal(prospect)
+	if err != nil {
+		return "", Usage{}, err
+	}
+
+	systemMessage := OpenAIRole{
+		Role:    "system",
+		Content: MessagePrompt(),
+	}
+	userMessage := OpenAIRole{
+		Role:    "user",
+		Content: string(jsonProspect),
+	}
+
+	return chatCompletion([]OpenAIRole{systemMessage, userMessage}, apiKey)
+}
+
+/*
+	ClassifyPersona asks the model for the seniority and function of a profile.
+
+It is meant to be used as a persona.Assist when the rule-based classifier
+cannot decide; unknown or malformed answers are returned as persona.SeniorityUnknown
+and persona.FunctionUnknown.
+
+Parameters:
+  - userData: A scraper.Profile struct containing the LinkedIn profile information
+  - apiKey: OpenAI API key for authentication
+
+Returns:
+  - persona.Persona: The model's classification
+  - Usage: The tokens the request was billed for
+  - error: Any error encountered during the API request or response processing
 */
-func GetMessage(userData scraper.Profile, apiKey string) (string, error) {
-	apiURL := "https://api.openai.com/v1/chat/completions"
+func ClassifyPersona(userData scraper.Profile, apiKey string) (persona.Persona, Usage, error) {
+	result := persona.Persona{Seniority: persona.SeniorityUnknown, Function: persona.FunctionUnknown}
 	jsonProfile, err := json.Marshal(userData)
 	if err != nil {
-		return "", err
+		return result, Usage{}, err
+	}
+
+	seniorities := make([]string, 0, len(persona.Seniorities))
+	for _, s := range persona.Seniorities {
+		seniorities = append(seniorities, string(s))
+	}
+	functions := make([]string, 0, len(persona.Functions))
+	for _, f := range persona.Functions {
+		functions = append(functions, string(f))
 	}
 
 	systemMessage := OpenAIRole{
 		Role: "system",
-		Content: "You will be provided with a JSON containing slices and strings of posts, experience, education, about, name, and geography for a LinkedIn user. " +
-			"Create a connect message of maximum two lines. Prioritize the content of the message by posts, experience, education, about, name, and geography. " +
-			"If nothing is present, send a sample connect message.",
+		Content: "You will be provided with a JSON containing a LinkedIn user's profile. " +
+			"Reply with only a JSON object of the form {\"seniority\": \"...\", \"function\": \"...\"}. " +
+			"seniority must be one of: " + strings.Join(seniorities, ", ") + ". " +
+			"function must be one of: " + strings.Join(functions, ", ") + ". " +
+			"Use \"unknown\" if the profile does not say.",
 	}
 	userMessage := OpenAIRole{
 		Role:    "user",
 		Content: string(jsonProfile),
 	}
 
+	content, usage, err := chatCompletion([]OpenAIRole{systemMessage, userMessage}, apiKey)
+	if err != nil {
+		return result, usage, err
+	}
+
+	var answer persona.Persona
+	content = strings.TrimSpace(strings.Trim(strings.TrimSpace(content), "`"))
+	content = strings.TrimPrefix(content, "json")
+	if err := json.Unmarshal([]byte(content), &answer); err != nil {
+		return result, usage, fmt.Errorf("failed to parse persona answer: %w", err)
+	}
+	if persona.ValidSeniority(answer.Seniority) {
+		result.Seniority = answer.Seniority
+	}
+	if persona.ValidFunction(answer.Function) {
+		result.Function = answer.Function
+	}
+	return result, usage, nil
+}
+
+/*
+	SummarizeWebsite asks the model what a prospect's own website says about them.
+
+It is meant to be used as an enrich.Summarize for the website source.
+
+Parameters:
+  - site: The website URL
+  - text: Text read from the site: its homepage, about page and blog post titles
+  - apiKey: OpenAI API key for authentication
+
+Returns:
+  - string: A summary of at most two sentences
+  - Usage: The tokens the request was billed for
+  - error: Any error encountered during the API request or response processing
+*/
+func SummarizeWebsite(site, text, apiKey string) (string, Usage, error) {
+	systemMessage := OpenAIRole{
+		Role: "system",
+		Content: "You will be provided with the URL and the text of a person's own website: its title, homepage, about page and the titles of its blog posts. " +
+			"Summarize in at most two sentences, in the third person, what they work on, write about and care about professionally. " +
+			"Only use what the text says, never quote it at length or include links. " +
+			"Reply with only the summary, or nothing if the text says nothing about its owner.",
+	}
+	userMessage := OpenAIRole{
+		Role:    "user",
+		Content: site + "\n\n" + text,
+	}
+
+	summary, usage, err := chatCompletion([]OpenAIRole{systemMessage, userMessage}, apiKey)
+	return strings.TrimSpace(summary), usage, err
+}
+
+// EmbeddingReq is the request body of OpenAI's embeddings API.
+type EmbeddingReq struct {
+	Model string   `json:"model"` // The embedding model to be used
+	Input []string `json:"input"` // Texts to embed
+}
+
+// EmbeddingResponse is the response of OpenAI's embeddings API, one embedding per input.
+type EmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`     // Position of the input embedded
+		Embedding []float64 `json:"embedding"` // The input's vector
+	} `json:"data"`
+	Usage Usage `json:"usage"` // Tokens the request was billed for, as prompt tokens
+}
+
+/*
+	Embed asks OpenAI for the embeddings of texts, to compare what they are about.
+
+Unlike the chat completions, a non-200 response is an error: there is nothing to
+compare without the vectors.
+
+Parameters:
+  - texts: The texts to embed, e.g. a campaign's goal followed by a prospect's posts
+  - apiKey: OpenAI API key for authentication
+
+Returns:
+  - [][]float64: One embedding per text, in their order
+  - Usage: The tokens the request was billed for, as EmbeddingTokens
+  - error: Any error encountered during the API request or response processing
+*/
+func Embed(texts []string, apiKey string) ([][]float64, Usage, error) {
+	jsonData, err := json.Marshal(EmbeddingReq{Model: embeddingModel, Input: texts})
+	if err != nil {
+		return nil, Usage{}, err
+	}
+
+	req, err := http.NewRequest("POST", embeddingURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, Usage{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, Usage{}, err
+	}
+	defer resp.Body.Close()
+
+	usage := Usage{Calls: 1}
+	if resp.StatusCode != http.StatusOK {
+		return nil, usage, fmt.Errorf("embeddings request failed with status code %d", resp.StatusCode)
+	}
+	response := &EmbeddingResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, usage, err
+	}
+	usage.EmbeddingTokens = response.Usage.PromptTokens
+	vectors := make([][]float64, len(texts))
+	for _, d := range response.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, usage, fmt.Errorf("embeddings response has an embedding for input %d of %d", d.Index, len(texts))
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, usage, nil
+}
+
+/*
+	chatCompletion sends messages to OpenAI's chat completion API and returns the content
+
+of the first choice with the tokens it was billed for. A non-200 response is logged and
+yields an empty message.
+*/
+func chatCompletion(messages []OpenAIRole, apiKey string) (string, Usage, error) {
 	reqBody := OpenAIReq{
-		Model:    "gpt-4o-mini",
-		Messages: []OpenAIRole{systemMessage, userMessage},
+		Model:    model,
+		Messages: messages,
 	}
 
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
 		fmt.Println("Error marshalling JSON:", err)
-		return "", err
+		return "", Usage{}, err
 	}
 
 	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		fmt.Println("Error creating request:", err)
-		return "", err
+		return "", Usage{}, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -145,21 +524,24 @@ func GetMessage(userData scraper.Profile, apiKey string) (string, error) {
 	resp, err := client.Do(req)
 	if err != nil {
 		fmt.Println("Error making request:", err)
-		return "", err
+		return "", Usage{}, err
 	}
 	defer resp.Body.Close()
 
+	usage := Usage{Calls: 1}
 	var message string
 	if resp.StatusCode == http.StatusOK {
 		response := &OpenAIResponse{}
 		err := json.NewDecoder(resp.Body).Decode(&response)
 		if err != nil {
 			fmt.Println("Error decoding response:", err)
-			return "", err
+			return "", usage, err
 		}
+		usage = response.Usage
+		usage.Calls = 1
 		message = response.Choices[0].Message.Content
 	} else {
 		fmt.Printf("Request failed with status code: %d\n", resp.StatusCode)
 	}
-	return message, nil
+	return message, usage, nil
 }
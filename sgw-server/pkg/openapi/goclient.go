@@ -0,0 +1,446 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/schema"
+)
+
+// GoOptions configures the client GoClient generates.
+type GoOptions struct {
+	Package       string // The client's package name
+	VersionHeader string // Pins the version of responses to the document's Info.Version, not sent when empty
+}
+
+/*
+	GoClient generates a Go client for doc: a type per component schema and a method per operation.
+
+Methods are named after operation IDs and take the context, the path and then
+query parameters as strings, empty query parameters being left out, and the
+request body. Responses outside 2xx are returned as an *Error.
+
+Parameters:
+  - doc: The document, as New builds them
+  - opts: The package to generate
+
+Returns:
+  - []byte: The gofmt'ed source of a single file
+  - error: An error when doc uses schemas or names the generator can't map to Go
+*/
+func GoClient(doc *Document, opts GoOptions) ([]byte, error) {
+	g := &goGen{doc: doc, names: map[string]string{}}
+	if err := g.nameTypes(); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by sdkgen from the OpenAPI document of %s %s. DO NOT EDIT.\n\n", doc.Info.Title, doc.Info.Version)
+	fmt.Fprintf(&b, "// Package %s is a typed client of the %s API.\n", opts.Package, doc.Info.Title)
+	fmt.Fprintf(&b, "package %s\n\n", opts.Package)
+	b.WriteString(clientImports)
+	fmt.Fprintf(&b, "// SchemaVersion is the payload version responses are pinned to, the one the client was generated for.\nconst SchemaVersion = %q\n\n", doc.Info.Version)
+	fmt.Fprintf(&b, "const versionHeader = %q\n\n", opts.VersionHeader)
+	b.WriteString(clientCore)
+
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		if name == ErrorSchema {
+			continue
+		}
+		if err := g.writeType(&b, name, doc.Components.Schemas[name]); err != nil {
+			return nil, err
+		}
+	}
+	for _, path := range sortedKeys(doc.Paths) {
+		for _, method := range sortedKeys(doc.Paths[path]) {
+			if err := g.writeMethod(&b, method, path, doc.Paths[path][method]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated client does not parse: %w", err)
+	}
+	return src, nil
+}
+
+type goGen struct {
+	doc   *Document
+	names map[string]string // Go type names, by component
+}
+
+// nameTypes names a component's type after its Go type, e.g. Profile for scraper.Profile,
+// with the package in front when two packages have a type of that name.
+func (g *goGen) nameTypes() error {
+	taken := map[string]int{}
+	for name := range g.doc.Components.Schemas {
+		taken[baseName(name)]++
+	}
+	used := map[string]string{}
+	for _, name := range sortedKeys(g.doc.Components.Schemas) {
+		goName := exported(baseName(name))
+		if taken[baseName(name)] > 1 {
+			goName = exported(strings.ReplaceAll(name, ".", "_"))
+		}
+		if other, ok := used[goName]; ok {
+			return fmt.Errorf("schemas %q and %q would both be the Go type %s", other, name, goName)
+		}
+		if (goName == "Client" || goName == "Error") && name != ErrorSchema {
+			return fmt.Errorf("schema %q would be the Go type %s, which the client defines", name, goName)
+		}
+		used[goName] = name
+		g.names[name] = goName
+	}
+	return nil
+}
+
+func baseName(component string) string {
+	return component[strings.LastIndex(component, ".")+1:]
+}
+
+func (g *goGen) writeType(b *bytes.Buffer, name string, s *schema.Schema) error {
+	if len(s.Type) != 1 || s.Type[0] != "object" || s.Properties == nil {
+		t, err := g.goType(s)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		fmt.Fprintf(b, "// %s is the %s schema.\ntype %s %s\n\n", g.names[name], name, g.names[name], t)
+		return nil
+	}
+	fmt.Fprintf(b, "// %s is the %s schema.\ntype %s struct {\n", g.names[name], name, g.names[name])
+	fields := map[string]string{}
+	for _, prop := range sortedKeys(s.Properties) {
+		t, err := g.goType(s.Properties[prop])
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", name, prop, err)
+		}
+		field := exported(prop)
+		if other, ok := fields[field]; ok {
+			return fmt.Errorf("%s: properties %q and %q would both be the field %s", name, other, prop, field)
+		}
+		fields[field] = prop
+		tag := prop
+		if !slices.Contains(s.Required, prop) {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(b, "\t%s %s `json:%q`\n", field, t, tag)
+	}
+	b.WriteString("}\n\n")
+	return nil
+}
+
+// goType maps a schema as package schema generates them onto a Go type.
+func (g *goGen) goType(s *schema.Schema) (string, error) {
+	if s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, schemaPrefix)
+		if !ok || g.names[name] == "" {
+			return "", fmt.Errorf("refers to %s, which the document does not define", s.Ref)
+		}
+		if name == ErrorSchema {
+			return "string", nil
+		}
+		return g.names[name], nil
+	}
+	if len(s.AnyOf) > 0 {
+		// A pointer to a struct: the struct or null
+		if len(s.AnyOf) == 2 && s.AnyOf[0].Ref != "" && slices.Equal(s.AnyOf[1].Type, schema.Types{"null"}) {
+			t, err := g.goType(s.AnyOf[0])
+			return "*" + t, err
+		}
+		return "any", nil
+	}
+
+	types := slices.DeleteFunc(slices.Clone(s.Type), func(t string) bool { return t == "null" })
+	nullable := len(types) < len(s.Type)
+	if len(types) != 1 {
+		return "any", nil
+	}
+	var t string
+	switch types[0] {
+	case "array":
+		if s.Items == nil {
+			return "[]any", nil
+		}
+		item, err := g.goType(s.Items)
+		return "[]" + item, err
+	case "object":
+		if s.AdditionalProperties == nil || s.AdditionalProperties == schema.False {
+			return "map[string]any", nil
+		}
+		value, err := g.goType(s.AdditionalProperties)
+		return "map[string]" + value, err
+	case "string":
+		t = "string"
+		if s.Format == "date-time" {
+			t = "time.Time"
+		}
+	case "integer":
+		t = "int"
+	case "number":
+		t = "float64"
+	case "boolean":
+		t = "bool"
+	default:
+		return "", fmt.Errorf("has unknown type %q", types[0])
+	}
+	if nullable {
+		return "*" + t, nil
+	}
+	return t, nil
+}
+
+func (g *goGen) writeMethod(b *bytes.Buffer, method, path string, op *Operation) error {
+	name := exported(op.OperationID)
+	if !token.IsIdentifier(name) {
+		return fmt.Errorf("operation %s %s: ID %q is not a Go identifier", method, path, op.OperationID)
+	}
+
+	args := []string{"ctx context.Context"}
+	taken := []string{"ctx", "req", "res", "query", "err"}
+	pathExpr := fmt.Sprintf("%q", path)
+	var queryLines []string
+	for _, p := range op.Parameters {
+		arg := unexported(p.Name)
+		if slices.Contains(taken, arg) || token.IsKeyword(arg) || !token.IsIdentifier(arg) {
+			return fmt.Errorf("operation %s: parameter %q can't be a Go argument", op.OperationID, p.Name)
+		}
+		taken = append(taken, arg)
+		args = append(args, arg+" string")
+		switch p.In {
+		case "path":
+			pathExpr = strings.Replace(pathExpr, "{"+p.Name+"}", `"+url.PathEscape(`+arg+`)+"`, 1)
+		case "query":
+			queryLines = append(queryLines, fmt.Sprintf("\tif %s != \"\" {\n\t\tquery.Set(%q, %s)\n\t}\n", arg, p.Name, arg))
+		}
+	}
+	pathExpr = strings.ReplaceAll(pathExpr, `+""`, "")
+	body := "nil"
+	if op.RequestBody != nil {
+		t, err := g.goType(op.RequestBody.Content["application/json"].Schema)
+		if err != nil {
+			return fmt.Errorf("operation %s request: %w", op.OperationID, err)
+		}
+		args = append(args, "req "+pointerTo(t))
+		body = "req"
+	}
+
+	var resType string
+	for code, r := range op.Responses {
+		if len(code) == 3 && code[0] == '2' && r.Content != nil {
+			t, err := g.goType(r.Content["application/json"].Schema)
+			if err != nil {
+				return fmt.Errorf("operation %s response: %w", op.OperationID, err)
+			}
+			resType = t
+		}
+	}
+
+	summary := op.Summary
+	if summary == "" {
+		summary = "calls " + strings.ToUpper(method) + " " + path
+	}
+	fmt.Fprintf(b, "// %s %s.\n", name, lowerFirst(summary))
+	results := "error"
+	if resType != "" {
+		results = "(" + pointerTo(resType) + ", error)"
+	}
+	fmt.Fprintf(b, "func (c *Client) %s(%s) %s {\n", name, strings.Join(args, ", "), results)
+	b.WriteString("\tquery := url.Values{}\n")
+	for _, line := range queryLines {
+		b.WriteString(line)
+	}
+	call := fmt.Sprintf("c.do(ctx, http.Method%s, %s, query, %s, ", methodName(method), pathExpr, body)
+	switch {
+	case resType == "":
+		fmt.Fprintf(b, "\treturn %snil)\n", call)
+	case pointerTo(resType) != resType:
+		fmt.Fprintf(b, "\tres := &%s{}\n\tif err := %sres); err != nil {\n\t\treturn nil, err\n\t}\n\treturn res, nil\n", resType, call)
+	default:
+		fmt.Fprintf(b, "\tvar res %s\n\terr := %s&res)\n\treturn res, err\n", resType, call)
+	}
+	b.WriteString("}\n\n")
+	return nil
+}
+
+// pointerTo passes the generated struct types by pointer, and everything else as is.
+func pointerTo(t string) string {
+	if t != "" && unicode.IsUpper(rune(t[0])) && t != "Error" {
+		return "*" + t
+	}
+	return t
+}
+
+func methodName(method string) string {
+	for _, m := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		if strings.EqualFold(m, method) {
+			return m[:1] + strings.ToLower(m[1:])
+		}
+	}
+	return "Get"
+}
+
+// initialisms are spelled in capitals in Go names.
+var initialisms = []string{"IDs", "ID", "URL", "URN", "ICP", "API", "OTP", "HTTP", "JSON"}
+
+// exported turns a JSON or schema name into an exported Go name, e.g. linkedinUrl into LinkedinURL.
+func exported(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	out := b.String()
+	for _, in := range initialisms {
+		mixed := in[:1] + strings.ToLower(in[1:])
+		if strings.HasSuffix(out, mixed) {
+			out = strings.TrimSuffix(out, mixed) + in
+		}
+	}
+	if out != "" && unicode.IsDigit(rune(out[0])) {
+		out = "X" + out
+	}
+	return out
+}
+
+// unexported turns a parameter name into a Go argument name, e.g. email or teamID.
+func unexported(name string) string {
+	out := exported(name)
+	for _, in := range initialisms {
+		if out == in {
+			return strings.ToLower(in)
+		}
+	}
+	return lowerFirst(out)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+const clientImports = `import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+`
+
+// clientCore is the part of every client that doesn't depend on the document.
+const clientCore = `// Client calls the API of a server.
+type Client struct {
+	BaseURL    string       // Where the server is, e.g. "http://localhost:3100"
+	HTTPClient *http.Client // http.DefaultClient when nil
+}
+
+// New returns a client of the server at baseURL.
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Error is a response outside 2xx.
+type Error struct {
+	StatusCode int
+	Message    string        // What went wrong, as the server said it
+	RetryAfter time.Duration // When to retry, from the Retry-After header; 0 when there was none
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	u := c.BaseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if versionHeader != "" {
+		req.Header.Set(versionHeader, SchemaVersion)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		e := &Error{StatusCode: resp.StatusCode}
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		// A JSON string, a busy body or, from the mux, plain text
+		var busy struct {
+			Error string ` + "`json:\"error\"`" + `
+		}
+		if json.Unmarshal(data, &e.Message) != nil {
+			if json.Unmarshal(data, &busy) == nil && busy.Error != "" {
+				e.Message = busy.Error
+			} else {
+				e.Message = strings.TrimSpace(string(data))
+			}
+		}
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			e.RetryAfter = time.Duration(seconds) * time.Second
+		}
+		return e
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+`
@@ -0,0 +1,183 @@
+/*
+	Package openapi describes a JSON API as an OpenAPI 3.1 document and generates Go clients from it.
+
+The document is built from a table of routes naming the Go types of their
+request and response bodies, whose schemas come from package schema, so it
+follows what the handlers actually read and write. Errors are described once:
+a JSON string saying what went wrong, or a busy body for the routes that turn
+work away with a 429.
+
+Basic usage:
+
+	doc := openapi.New(openapi.Info{Title: "Segwise", Version: "2"}, routes)
+	src, err := openapi.GoClient(doc, openapi.GoOptions{Package: "client"})
+*/
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/schema"
+)
+
+// Version is the OpenAPI version documents declare.
+const Version = "3.1.0"
+
+// ErrorSchema names the component error responses have: a JSON string.
+const ErrorSchema = "Error"
+
+// schemaPrefix is where component schemas are referred to.
+const schemaPrefix = "#/components/schemas/"
+
+// Document is an OpenAPI document, as New builds them.
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Servers    []Server                         `json:"servers,omitempty"`
+	Paths      map[string]map[string]*Operation `json:"paths"` // By path, then lower case method
+	Components Components                       `json:"components"`
+}
+
+// Info names the API and its version.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Server is where the API is served.
+type Server struct {
+	URL string `json:"url"`
+}
+
+// Components holds the schemas operations refer to, by name.
+type Components struct {
+	Schemas map[string]*schema.Schema `json:"schemas"`
+}
+
+// Operation is a method on a path.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *Body                `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"` // By status code, or "default"
+}
+
+// Parameter is a path or query parameter.
+type Parameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"` // "path" or "query"
+	Required    bool           `json:"required,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Schema      *schema.Schema `json:"schema"`
+}
+
+// Body is a JSON request body.
+type Body struct {
+	Required bool             `json:"required"`
+	Content  map[string]Media `json:"content"`
+}
+
+// Response is a response an operation may answer with.
+type Response struct {
+	Description string             `json:"description"`
+	Headers     map[string]*Header `json:"headers,omitempty"`
+	Content     map[string]Media   `json:"content,omitempty"`
+}
+
+// Header is a response header.
+type Header struct {
+	Description string         `json:"description,omitempty"`
+	Schema      *schema.Schema `json:"schema"`
+}
+
+// Media is the schema of a body.
+type Media struct {
+	Schema *schema.Schema `json:"schema"`
+}
+
+// Route is an operation of the API, as New describes it.
+type Route struct {
+	Method   string       // e.g. http.MethodGet
+	Path     string       // As the server's mux registers it, e.g. "/api/batches/{id}"
+	ID       string       // The operationId, lower camel case; clients name their method after it
+	Summary  string       // What the operation does
+	Query    []Param      // Query parameters
+	Request  reflect.Type // The JSON request body, nil when there is none
+	Response reflect.Type // The JSON body of the success response, nil when there is none
+	Status   int          // The success status, 200 when zero
+	Busy     reflect.Type // The 429 body when the route turns work away, nil when it doesn't
+}
+
+// Param is a query parameter.
+type Param struct {
+	Name        string
+	Required    bool
+	Description string
+}
+
+// pathParams finds the {name} parameters of a path.
+var pathParams = regexp.MustCompile(`\{([A-Za-z]+)\}`)
+
+/*
+	New describes routes as an OpenAPI document.
+
+Parameters:
+  - info: The API's title and version
+  - routes: The operations, each with a unique ID
+
+Returns:
+  - *Document: The document, its schemas under components
+*/
+func New(info Info, routes []Route) *Document {
+	c := schema.NewComponents(schemaPrefix)
+	errorRef := c.Define(ErrorSchema, &schema.Schema{Type: schema.Types{"string"}, Title: "What went wrong, for people"})
+	doc := &Document{OpenAPI: Version, Info: info, Paths: map[string]map[string]*Operation{}}
+
+	for _, route := range routes {
+		op := &Operation{OperationID: route.ID, Summary: route.Summary, Responses: map[string]*Response{}}
+		for _, m := range pathParams.FindAllStringSubmatch(route.Path, -1) {
+			op.Parameters = append(op.Parameters, Parameter{Name: m[1], In: "path", Required: true, Schema: &schema.Schema{Type: schema.Types{"string"}}})
+		}
+		for _, p := range route.Query {
+			op.Parameters = append(op.Parameters, Parameter{Name: p.Name, In: "query", Required: p.Required, Description: p.Description, Schema: &schema.Schema{Type: schema.Types{"string"}}})
+		}
+		if route.Request != nil {
+			op.RequestBody = &Body{Required: true, Content: jsonContent(c.For(route.Request))}
+		}
+
+		status := route.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		ok := &Response{Description: http.StatusText(status)}
+		if route.Response != nil {
+			ok.Content = jsonContent(c.For(route.Response))
+		}
+		op.Responses[strconv.Itoa(status)] = ok
+		if route.Busy != nil {
+			op.Responses[strconv.Itoa(http.StatusTooManyRequests)] = &Response{
+				Description: "The server is too busy to take the work",
+				Headers:     map[string]*Header{"Retry-After": {Description: "Seconds to wait before retrying", Schema: &schema.Schema{Type: schema.Types{"integer"}}}},
+				Content:     jsonContent(c.For(route.Busy)),
+			}
+		}
+		op.Responses["default"] = &Response{Description: "An error", Content: jsonContent(errorRef)}
+
+		if doc.Paths[route.Path] == nil {
+			doc.Paths[route.Path] = map[string]*Operation{}
+		}
+		doc.Paths[route.Path][strings.ToLower(route.Method)] = op
+	}
+	doc.Components.Schemas = c.Defs()
+	return doc
+}
+
+func jsonContent(s *schema.Schema) map[string]Media {
+	return map[string]Media{"application/json": {Schema: s}}
+}
@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type widget struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name,omitempty"`
+	Parts   []part    `json:"parts"`
+	Parent  *widget   `json:"parent,omitempty"`
+	Tags    []string  `json:"tags"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+type part struct {
+	Count int `json:"count"`
+}
+
+type busyRes struct {
+	Error string `json:"error"`
+}
+
+var routes = []Route{
+	{Method: http.MethodGet, Path: "/api/widgets/{id}", ID: "getWidget", Summary: "returns a widget", Query: []Param{{Name: "owner", Required: true}}, Response: reflect.TypeFor[widget]()},
+	{Method: http.MethodPost, Path: "/api/widgets", ID: "createWidget", Request: reflect.TypeFor[widget](), Response: reflect.TypeFor[widget](), Status: http.StatusCreated, Busy: reflect.TypeFor[busyRes]()},
+	{Method: http.MethodDelete, Path: "/api/widgets/{id}", ID: "deleteWidget", Status: http.StatusNoContent},
+}
+
+func TestNew(t *testing.T) {
+	doc := New(Info{Title: "Widgets", Version: "1"}, routes)
+
+	get := doc.Paths["/api/widgets/{id}"]["get"]
+	if get == nil || get.OperationID != "getWidget" {
+		t.Fatalf("paths = %v", doc.Paths)
+	}
+	if len(get.Parameters) != 2 || get.Parameters[0].In != "path" || get.Parameters[0].Name != "id" || get.Parameters[1].In != "query" || !get.Parameters[1].Required {
+		t.Errorf("parameters = %+v", get.Parameters)
+	}
+	if ref := get.Responses["200"].Content["application/json"].Schema.Ref; ref != "#/components/schemas/openapi.widget" {
+		t.Errorf("200 refers to %q", ref)
+	}
+	if ref := get.Responses["default"].Content["application/json"].Schema.Ref; ref != "#/components/schemas/"+ErrorSchema {
+		t.Errorf("errors refer to %q", ref)
+	}
+
+	create := doc.Paths["/api/widgets"]["post"]
+	if create.RequestBody == nil || create.Responses["201"] == nil || create.Responses["429"].Headers["Retry-After"] == nil {
+		t.Errorf("create = %+v", create)
+	}
+	if del := doc.Paths["/api/widgets/{id}"]["delete"]; del.Responses["204"].Content != nil {
+		t.Error("204 has a body")
+	}
+	for _, name := range []string{"openapi.widget", "openapi.part", "openapi.busyRes", ErrorSchema} {
+		if doc.Components.Schemas[name] == nil {
+			t.Errorf("no %s component", name)
+		}
+	}
+	if parent := doc.Components.Schemas["openapi.widget"].Properties["parent"]; len(parent.AnyOf) != 2 || parent.AnyOf[0].Ref != "#/components/schemas/openapi.widget" {
+		t.Errorf("a widget's parent should refer back to the widget, got %+v", parent)
+	}
+}
+
+func TestGoClient(t *testing.T) {
+	src, err := GoClient(New(Info{Title: "Widgets", Version: "1"}, routes), GoOptions{Package: "widgets", VersionHeader: "X-Version"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := string(src)
+	for _, want := range []string{
+		"package widgets",
+		`const SchemaVersion = "1"`,
+		"type Widget struct {",
+		"\tParent  *Widget   `json:\"parent,omitempty\"`",
+		"\tAddedAt time.Time `json:\"addedAt\"`",
+		"func (c *Client) GetWidget(ctx context.Context, id string, owner string) (*Widget, error) {",
+		`c.do(ctx, http.MethodGet, "/api/widgets/"+url.PathEscape(id), query, nil, res)`,
+		"func (c *Client) CreateWidget(ctx context.Context, req *Widget) (*Widget, error) {",
+		"func (c *Client) DeleteWidget(ctx context.Context, id string) error {",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("client has no %q", want)
+		}
+	}
+}
+
+func TestExported(t *testing.T) {
+	for name, want := range map[string]string{
+		"linkedinUrl":  "LinkedinURL",
+		"batchIds":     "BatchIDs",
+		"id":           "ID",
+		"p50Ms":        "P50Ms",
+		"created_at":   "CreatedAt",
+		"valid":        "Valid",
+		"icp.Filter":   "IcpFilter",
+		"2fa":          "X2fa",
+		"share-link":   "ShareLink",
+		"profileURNId": "ProfileURNID",
+	} {
+		if got := exported(name); got != want {
+			t.Errorf("exported(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
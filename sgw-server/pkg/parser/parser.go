@@ -0,0 +1,311 @@
+/*
+	Package parser extracts profile sections from saved LinkedIn pages, without a browser.
+
+It reads the pages scraper.SnapshotDir saves, and the fixtures of
+scraper.CaptureDir, with the selectors the scraper's page scripts use, so
+extraction can be worked on against pages saved earlier and old snapshots
+parsed again when the selectors improve:
+
+	profile, err := parser.ParseDir("snapshots/3f2a9c1e7b04")
+
+The top card, About and the sections read from a profile's details pages
+(experience, education, skills, certifications, recommendations, volunteering,
+publications, patents and languages) are supported. The feed sections, posts,
+articles and comments, contact info, company pages and Sales Navigator lead
+pages are built by the scraper across several pages and interactions and fail
+with ErrUnsupported.
+*/
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// ErrUnsupported is returned for sections that can't be extracted from a saved page.
+var ErrUnsupported = errors.New("section can't be parsed from a saved page")
+
+var (
+	yearEndRe = regexp.MustCompile(`\d{4}$`)
+	issuedRe  = regexp.MustCompile(`(?i)^Issued\s*`)
+	patentRe  = regexp.MustCompile(`(?i)^(Issued|Filed)\s*`)
+	digitsRe  = regexp.MustCompile(`[^0-9]`)
+	spaceRe   = regexp.MustCompile(`\s+`)
+	photoRe   = regexp.MustCompile(`(?i)open_?to_?work`)
+	countRe   = map[string]*regexp.Regexp{
+		"connection": regexp.MustCompile(`^[0-9][0-9.,]*[KM]?\+? connections?$`),
+		"follower":   regexp.MustCompile(`^[0-9][0-9.,]*[KM]?\+? followers?$`),
+	}
+)
+
+/*
+	Parse extracts a section from a saved page into profile.
+
+Only the section's own fields are set, as when the scraper reads it. A page
+that isn't the section's, such as a login page, leaves them empty; the top
+card fails instead, since a profile without a name is of no use.
+
+Parameters:
+  - profile: The profile to set the section of
+  - section: The section the page was saved for, recommendations/received and recommendations/given for the two tabs
+  - page: The page's HTML
+
+Returns:
+  - error: ErrUnsupported for sections that can't be parsed offline, or any error reading the page
+*/
+func Parse(profile *scraper.Profile, section scraper.Section, page io.Reader) error {
+	doc, err := goquery.NewDocumentFromReader(page)
+	if err != nil {
+		return err
+	}
+	switch section {
+	case scraper.SectionNameAndLocation:
+		return topCard(profile, doc)
+	case scraper.SectionAbout:
+		profile.About = firstText(doc.Find(`div[class*="display-flex full-width"] span[aria-hidden="true"]`))
+	case scraper.SectionExperience:
+		profile.Experience = entries(doc, func(e *goquery.Selection) (scraper.Experience, bool) {
+			title := text(e, scraper.Selector("entry.title"))
+			if title == "" {
+				title = text(e, `div.display-flex.align-items-center.mr1.t-bold span.visually-hidden`)
+			}
+			return scraper.Experience{Title: title, Company: text(e, scraper.Selector("entry.subtitle")), Duration: text(e, scraper.Selector("entry.caption"))}, true
+		})
+	case scraper.SectionEducation:
+		profile.Education = entries(doc, func(e *goquery.Selection) (scraper.Education, bool) {
+			return scraper.Education{Institute: text(e, scraper.Selector("entry.linked-title")), Major: text(e, scraper.Selector("entry.subtitle")), Duration: text(e, scraper.Selector("entry.caption"))}, true
+		})
+	case scraper.SectionSkills:
+		seen := map[string]bool{}
+		profile.Skills = entries(doc, func(e *goquery.Selection) (scraper.Skill, bool) {
+			name := text(e, scraper.Selector("entry.linked-title"))
+			// The skills page lists a skill once per tab, keep the first
+			if name == "" || seen[name] {
+				return scraper.Skill{}, false
+			}
+			seen[name] = true
+			skill := scraper.Skill{Name: name}
+			e.Find(`span[aria-hidden="true"]`).EachWithBreak(func(_ int, span *goquery.Selection) bool {
+				t := strings.TrimSpace(span.Text())
+				if !strings.Contains(strings.ToLower(t), "endorsement") {
+					return true
+				}
+				skill.Endorsements, _ = strconv.Atoi(digitsRe.ReplaceAllString(t, ""))
+				return false
+			})
+			return skill, true
+		})
+	case scraper.SectionCertifications:
+		profile.Certifications = entries(doc, func(e *goquery.Selection) (scraper.Certification, bool) {
+			name := text(e, scraper.Selector("entry.linked-title"))
+			// Shown as "Issued Mar 2023 · Expires Mar 2026", keep the issue date only
+			issued, _, _ := strings.Cut(text(e, scraper.Selector("entry.caption")), "·")
+			return scraper.Certification{Name: name, Issuer: text(e, scraper.Selector("entry.subtitle")), IssuedAt: strings.TrimSpace(issuedRe.ReplaceAllString(issued, ""))}, name != ""
+		})
+	case scraper.SectionRecommendations + "/received", scraper.SectionRecommendations + "/given":
+		given := section == scraper.SectionRecommendations+"/given"
+		profile.Recommendations = append(profile.Recommendations, entries(doc, func(e *goquery.Selection) (scraper.Recommendation, bool) {
+			r := scraper.Recommendation{Name: text(e, scraper.Selector("entry.linked-title")), Relationship: text(e, scraper.Selector("entry.caption")), Given: given}
+			// The text has no stable class of its own, it is the longest block in the entry
+			e.Find(`span[aria-hidden="true"]`).Each(func(_ int, span *goquery.Selection) {
+				if t := strings.TrimSpace(span.Text()); t != r.Name && t != r.Relationship && len(t) > len(r.Text) {
+					r.Text = t
+				}
+			})
+			return r, r.Name != ""
+		})...)
+	case scraper.SectionVolunteering:
+		profile.Volunteering = entries(doc, func(e *goquery.Selection) (scraper.VolunteerEntry, bool) {
+			// Duration and cause are consecutive light lines, the cause is left out when not set
+			light := texts(e, scraper.Selector("entry.caption"))
+			return scraper.VolunteerEntry{Role: text(e, scraper.Selector("entry.title")), Organization: text(e, scraper.Selector("entry.subtitle")), Duration: at(light, 0), Cause: at(light, 1)}, true
+		})
+	case scraper.SectionPublications:
+		profile.Publications = entries(doc, func(e *goquery.Selection) (scraper.Publication, bool) {
+			title := text(e, scraper.Selector("entry.title"))
+			// Shown as "IEEE Transactions on Games · Mar 12, 2021", either part may be missing
+			parts := split(text(e, scraper.Selector("entry.subtitle")))
+			date := ""
+			if len(parts) > 1 || yearEndRe.MatchString(parts[0]) {
+				date, parts = parts[len(parts)-1], parts[:len(parts)-1]
+			}
+			return scraper.Publication{Title: title, Venue: strings.Join(parts, " · "), Date: date}, title != ""
+		})
+	case scraper.SectionPatents:
+		profile.Patents = entries(doc, func(e *goquery.Selection) (scraper.Patent, bool) {
+			title := text(e, scraper.Selector("entry.title"))
+			// Shown as "US 10,946,520 · Issued Mar 16, 2021" or "US 17/123,456 · Filed Jan 5, 2022"
+			parts := split(text(e, scraper.Selector("entry.subtitle")))
+			return scraper.Patent{Title: title, Office: parts[0], Date: patentRe.ReplaceAllString(at(parts, 1), "")}, title != ""
+		})
+	case scraper.SectionLanguages:
+		profile.Languages = entries(doc, func(e *goquery.Selection) (scraper.Language, bool) {
+			name := text(e, scraper.Selector("entry.title"))
+			return scraper.Language{Name: name, Proficiency: text(e, scraper.Selector("entry.caption"))}, name != ""
+		})
+	default:
+		return fmt.Errorf("%s: %w", section, ErrUnsupported)
+	}
+	return nil
+}
+
+/*
+	ParseDir extracts a profile from a directory of saved pages, one per section.
+
+The pages are named as scraper.SnapshotDir saves them, <section>.html, with the
+recommendation tabs in recommendations/received.html and
+recommendations/given.html. Sections without a page, and those that can't be
+parsed offline, are left empty.
+
+Parameters:
+  - dir: A profile's directory under scraper.SnapshotDir
+
+Returns:
+  - scraper.Profile: Everything the pages had, also when error is not nil
+  - error: The pages that couldn't be read or parsed, joined
+*/
+func ParseDir(dir string) (scraper.Profile, error) {
+	var profile scraper.Profile
+	var errs []error
+	for _, section := range []scraper.Section{
+		scraper.SectionNameAndLocation, scraper.SectionAbout, scraper.SectionExperience, scraper.SectionEducation,
+		scraper.SectionSkills, scraper.SectionCertifications,
+		// Received before given, as the scraper appends them
+		scraper.SectionRecommendations + "/received", scraper.SectionRecommendations + "/given",
+		scraper.SectionVolunteering, scraper.SectionPublications, scraper.SectionPatents, scraper.SectionLanguages,
+	} {
+		file := filepath.Join(dir, filepath.FromSlash(string(section))+".html")
+		page, err := os.Open(file)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		err = Parse(&profile, section, page)
+		page.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", file, err))
+		}
+	}
+	return profile, errors.Join(errs...)
+}
+
+// topCard sets the fields GetNameAndLocation reads from the top of the profile page. The
+// job preferences are read from a dialog that isn't part of the page, and are left nil.
+func topCard(profile *scraper.Profile, doc *goquery.Document) error {
+	name := text(doc.Selection, scraper.Selector("top-card.name"))
+	if name == "" {
+		return errors.New("not a profile page, it has no name")
+	}
+	profile.Name = name
+	profile.Location = text(doc.Selection, scraper.Selector("top-card.location"))
+	profile.Headline = text(doc.Selection, `.mt2.relative .text-body-medium.break-words`)
+	profile.Pronouns = text(doc.Selection, `.mt2.relative .text-body-small.v-align-middle.break-words.t-black--light`)
+
+	// The open-to-work badge is a frame drawn into the photo, named in its alt text, or an
+	// "Open to work" card under the top card. Ghost placeholders aren't worth keeping
+	photo := doc.Find(`.pv-top-card-profile-picture__image, .pv-top-card-profile-picture img`).First()
+	profile.PhotoURL = photo.AttrOr("src", "")
+	if !strings.HasPrefix(profile.PhotoURL, "http") || strings.Contains(strings.ToLower(profile.PhotoURL+" "+photo.AttrOr("class", "")), "ghost") {
+		profile.PhotoURL = ""
+	}
+	profile.OpenToWork = photo.Length() > 0 && photoRe.MatchString(photo.AttrOr("alt", "")+" "+photo.AttrOr("title", ""))
+	doc.Find(`[class*="open-to-carousel"]`).EachWithBreak(func(_ int, card *goquery.Selection) bool {
+		if strings.Contains(strings.ToLower(card.Text()), "open to work") {
+			profile.OpenToWork = true
+		}
+		return !profile.OpenToWork
+	})
+
+	// The first experience entry is the current role, its logo links to the employer
+	profile.CompanyURL = scraper.CompanyPage(absolute(doc.Find(`a[data-field="experience_company_logo"]`).First().AttrOr("href", "")))
+	// Counts sit under the top card or, for followers, atop the activity section
+	counts := map[string]string{}
+	doc.Find(`main li, main span, main p`).Each(func(_ int, el *goquery.Selection) {
+		t := strings.TrimSpace(spaceRe.ReplaceAllString(el.Text(), " "))
+		for noun, re := range countRe {
+			if counts[noun] == "" && re.MatchString(t) {
+				counts[noun] = t
+			}
+		}
+	})
+	profile.Connections = scraper.ParseCount(counts["connection"])
+	profile.Followers = scraper.ParseCount(counts["follower"])
+	profile.JobPreferences = nil
+	return nil
+}
+
+// entries extracts the entries of a details page with entry, which reports whether to keep
+// each. A page without entries is an empty list, as the scraper reads it.
+func entries[T any](doc *goquery.Document, entry func(*goquery.Selection) (T, bool)) []T {
+	list := []T{}
+	doc.Find(scraper.Selector("list.entry")).Each(func(_ int, item *goquery.Selection) {
+		entity := item.Find(scraper.Selector("entry")).First()
+		if entity.Length() == 0 {
+			return
+		}
+		if v, ok := entry(entity); ok {
+			list = append(list, v)
+		}
+	})
+	return list
+}
+
+// text returns the trimmed text of the first element under sel matching selector.
+func text(sel *goquery.Selection, selector string) string {
+	return strings.TrimSpace(sel.Find(selector).First().Text())
+}
+
+// texts returns the trimmed text of every element under sel matching selector.
+func texts(sel *goquery.Selection, selector string) []string {
+	return sel.Find(selector).Map(func(_ int, el *goquery.Selection) string {
+		return strings.TrimSpace(el.Text())
+	})
+}
+
+// firstText returns the first non-empty trimmed text of sel's elements.
+func firstText(sel *goquery.Selection) string {
+	for _, t := range sel.Map(func(_ int, el *goquery.Selection) string { return strings.TrimSpace(el.Text()) }) {
+		if t != "" {
+			return t
+		}
+	}
+	return ""
+}
+
+// split splits a "·" separated subtitle into its trimmed parts, at least one.
+func split(subtitle string) []string {
+	parts := strings.Split(subtitle, "·")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+func at(list []string, i int) string {
+	if i < len(list) {
+		return list[i]
+	}
+	return ""
+}
+
+// absolute resolves a link of a saved page against linkedin.com, as the browser did.
+func absolute(href string) string {
+	u, err := url.Parse(href)
+	if err != nil || href == "" {
+		return href
+	}
+	return (&url.URL{Scheme: "https", Host: "www.linkedin.com"}).ResolveReference(u).String()
+}
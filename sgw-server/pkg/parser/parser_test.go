@@ -0,0 +1,169 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// entity renders a details page entry as LinkedIn does, bold or linked title first.
+func entity(titleClass, title, subtitle string, light ...string) string {
+	var b strings.Builder
+	b.WriteString(`<li class="pvs-list__paged-list-item"><div data-view-name="profile-component-entity">`)
+	b.WriteString(`<div class="display-flex align-items-center mr1 ` + titleClass + ` t-bold"><span aria-hidden="true">` + title + `</span><span class="visually-hidden">` + title + `</span></div>`)
+	if subtitle != "" {
+		b.WriteString(`<span class="t-14 t-normal"><span aria-hidden="true">` + subtitle + `</span></span>`)
+	}
+	for _, l := range light {
+		b.WriteString(`<span class="t-14 t-normal t-black--light"><span aria-hidden="true">` + l + `</span></span>`)
+	}
+	b.WriteString(`</div></li>`)
+	return b.String()
+}
+
+func page(entries ...string) string {
+	return `<html><body><main><ul>` + strings.Join(entries, "") + `</ul></main></body></html>`
+}
+
+func parse(t *testing.T, section scraper.Section, html string) scraper.Profile {
+	t.Helper()
+	var p scraper.Profile
+	if err := Parse(&p, section, strings.NewReader(html)); err != nil {
+		t.Fatalf("Parse(%s): %v", section, err)
+	}
+	return p
+}
+
+func TestParseDetails(t *testing.T) {
+	experience := parse(t, scraper.SectionExperience, page(
+		entity("", "Head of Data Platform", "Moonfrog Labs · Full-time", "2021 - Present · 3 yrs"),
+		`<li class="pvs-list__paged-list-item"><div>A section header</div></li>`,
+		// Roles grouped under one company only keep the screen reader copy of the title
+		`<li class="pvs-list__paged-list-item"><div data-view-name="profile-component-entity"><div class="display-flex align-items-center mr1 t-bold"><span class="visually-hidden">Data Engineer</span></div></div></li>`,
+	)).Experience
+	if want := []scraper.Experience{{Title: "Head of Data Platform", Company: "Moonfrog Labs · Full-time", Duration: "2021 - Present · 3 yrs"}, {Title: "Data Engineer"}}; !reflect.DeepEqual(experience, want) {
+		t.Errorf("experience = %+v", experience)
+	}
+
+	skills := parse(t, scraper.SectionSkills, page(
+		entity("hoverable-link-text", "Go", ""),
+		entity("hoverable-link-text", "Go", ""),
+		`<li class="pvs-list__paged-list-item"><div data-view-name="profile-component-entity"><div class="display-flex align-items-center mr1 hoverable-link-text t-bold"><span aria-hidden="true">SQL</span></div><span aria-hidden="true">1,204 endorsements</span></div></li>`,
+	)).Skills
+	if want := []scraper.Skill{{Name: "Go"}, {Name: "SQL", Endorsements: 1204}}; !reflect.DeepEqual(skills, want) {
+		t.Errorf("skills = %+v, want each skill once with its endorsements", skills)
+	}
+
+	certifications := parse(t, scraper.SectionCertifications, page(entity("hoverable-link-text", "CKA", "CNCF", "Issued Mar 2023 · Expires Mar 2026"))).Certifications
+	if want := []scraper.Certification{{Name: "CKA", Issuer: "CNCF", IssuedAt: "Mar 2023"}}; !reflect.DeepEqual(certifications, want) {
+		t.Errorf("certifications = %+v", certifications)
+	}
+
+	publications := parse(t, scraper.SectionPublications, page(
+		entity("", "Late, not wrong", "IEEE Transactions on Games · Mar 12, 2021"),
+		entity("", "Undated", "Self-published"),
+		entity("", "Venueless", "2019"),
+	)).Publications
+	if want := []scraper.Publication{{Title: "Late, not wrong", Venue: "IEEE Transactions on Games", Date: "Mar 12, 2021"}, {Title: "Undated", Venue: "Self-published"}, {Title: "Venueless", Date: "2019"}}; !reflect.DeepEqual(publications, want) {
+		t.Errorf("publications = %+v", publications)
+	}
+
+	patents := parse(t, scraper.SectionPatents, page(entity("", "Sharded queues", "US 10,946,520 · Issued Mar 16, 2021"))).Patents
+	if want := []scraper.Patent{{Title: "Sharded queues", Office: "US 10,946,520", Date: "Mar 16, 2021"}}; !reflect.DeepEqual(patents, want) {
+		t.Errorf("patents = %+v", patents)
+	}
+
+	volunteering := parse(t, scraper.SectionVolunteering, page(entity("", "Mentor", "Code.org", "2019 - Present", "Education"))).Volunteering
+	if want := []scraper.VolunteerEntry{{Role: "Mentor", Organization: "Code.org", Duration: "2019 - Present", Cause: "Education"}}; !reflect.DeepEqual(volunteering, want) {
+		t.Errorf("volunteering = %+v", volunteering)
+	}
+
+	// Both tabs append, the text is the longest line that isn't the name or relationship
+	var p scraper.Profile
+	received := page(`<li class="pvs-list__paged-list-item"><div data-view-name="profile-component-entity"><div class="display-flex align-items-center mr1 hoverable-link-text t-bold"><span aria-hidden="true">Sam Lee</span></div><span class="t-14 t-normal t-black--light"><span aria-hidden="true">March 5, 2022, Sam managed Priya directly</span></span><span aria-hidden="true">Priya rebuilt our pipeline.</span></div></li>`)
+	for _, tab := range []string{"received", "given"} {
+		if err := Parse(&p, scraper.SectionRecommendations+"/"+scraper.Section(tab), strings.NewReader(received)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []scraper.Recommendation{
+		{Name: "Sam Lee", Relationship: "March 5, 2022, Sam managed Priya directly", Text: "Priya rebuilt our pipeline."},
+		{Name: "Sam Lee", Relationship: "March 5, 2022, Sam managed Priya directly", Text: "Priya rebuilt our pipeline.", Given: true},
+	}
+	if !reflect.DeepEqual(p.Recommendations, want) {
+		t.Errorf("recommendations = %+v", p.Recommendations)
+	}
+}
+
+func TestParseTopCard(t *testing.T) {
+	html := `<html><body><main>
+<div class="mt2 relative">
+  <h1 class="inline t-24 v-align-middle break-words"> Priya Raman </h1>
+  <span class="text-body-small v-align-middle break-words t-black--light">She/Her</span>
+  <div class="text-body-medium break-words">Head of Data Platform at Moonfrog</div>
+  <span class="text-body-small inline t-black--light break-words">Bengaluru, Karnataka, India</span>
+</div>
+<img class="pv-top-card-profile-picture__image" src="https://media.licdn.com/priya.jpg" alt="Priya Raman, #OPEN_TO_WORK">
+<a data-field="experience_company_logo" href="/company/moonfrog-labs/?trk=profile"></a>
+<ul><li><span>500+</span> connections</li></ul>
+<p>2,380 followers</p>
+</main></body></html>`
+	p := parse(t, scraper.SectionNameAndLocation, html)
+	want := scraper.Profile{
+		Name: "Priya Raman", Location: "Bengaluru, Karnataka, India", Headline: "Head of Data Platform at Moonfrog", Pronouns: "She/Her",
+		PhotoURL: "https://media.licdn.com/priya.jpg", OpenToWork: true, CompanyURL: "https://www.linkedin.com/company/moonfrog-labs/",
+		Connections: 500, Followers: 2380,
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("top card = %+v\nwant %+v", p, want)
+	}
+
+	if err := Parse(&scraper.Profile{}, scraper.SectionNameAndLocation, strings.NewReader(`<html><body>Sign in</body></html>`)); err == nil {
+		t.Error("a page without a name parsed as a top card")
+	}
+	if err := Parse(&scraper.Profile{}, scraper.SectionPosts, strings.NewReader(html)); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("posts: %v, want ErrUnsupported", err)
+	}
+}
+
+func TestParseDir(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, html string) {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(html), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("nameAndLocation.html", `<html><body><h1 class="inline t-24 v-align-middle break-words">Priya Raman</h1></body></html>`)
+	write("about.html", `<html><body><div class="display-flex full-width"><span aria-hidden="true"> </span><span aria-hidden="true">I build data platforms.</span></div></body></html>`)
+	write("languages.html", page(entity("", "Tamil", "", "Native or bilingual proficiency")))
+	write("recommendations/given.html", page(entity("hoverable-link-text", "Sam Lee", "", "Priya managed Sam")))
+	write("posts.html", page())
+
+	p, err := ParseDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "Priya Raman" || p.About != "I build data platforms." || len(p.Languages) != 1 || len(p.Recommendations) != 1 || !p.Recommendations[0].Given || p.Posts != nil || p.Experience != nil {
+		t.Errorf("profile = %+v", p)
+	}
+
+	// Fixtures are saved pages too
+	fixture, err := os.Open("../fake/testdata/fixtures/languages/3f2a9c1e7b04.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fixture.Close()
+	var fromFixture scraper.Profile
+	if err := Parse(&fromFixture, scraper.SectionLanguages, fixture); err != nil || fromFixture.Languages == nil || len(fromFixture.Languages) != 0 {
+		t.Errorf("fixture languages = %#v, %v, want an empty list", fromFixture.Languages, err)
+	}
+}
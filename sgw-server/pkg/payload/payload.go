@@ -0,0 +1,146 @@
+/*
+	Package payload versions the JSON webhook payloads and API responses the server writes.
+
+Every payload is written in the Current version and downgraded to older ones
+clients or webhook destinations pinned, one version at a time, so a change to
+the contract only has to say how to undo itself. Old versions are served until
+their sunset, with Deprecation and Sunset headers telling integrators to move.
+
+Basic usage:
+
+	v, err := payload.Parse(r.Header.Get(payload.Header), time.Now())
+	doc, err = payload.Downgrade(doc, v)
+	payload.SetHeaders(w.Header(), v)
+*/
+package payload
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Current is the version payloads are written in.
+const Current = 2
+
+// Header names the version of a payload, and pins the version of the responses to a request.
+const Header = "X-Segwise-Schema-Version"
+
+// Version is a payload version and, for old ones, when it was deprecated and stops being served.
+type Version struct {
+	Number     int
+	Deprecated time.Time // Zero while the version is current
+	Sunset     time.Time // Zero while the version is current
+
+	// downgrade turns a payload of the next version into this one
+	downgrade func(doc map[string]json.RawMessage)
+}
+
+/*
+	Versions are the versions payloads can be written in, by number.
+
+Version 1 are the payloads as they were before versioning, without a
+schemaVersion.
+*/
+var Versions = map[int]Version{
+	1: {
+		Number:     1,
+		Deprecated: time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC),
+		Sunset:     time.Date(2027, 4, 14, 0, 0, 0, 0, time.UTC),
+		downgrade: func(doc map[string]json.RawMessage) {
+			delete(doc, "schemaVersion")
+		},
+	},
+	Current: {Number: Current},
+}
+
+/*
+	Parse reads a requested version.
+
+Parameters:
+  - v: The version number, Current when empty
+  - now: When it is requested, versions past their sunset are no longer supported
+
+Returns:
+  - int: The version
+  - error: An error naming the supported versions when v isn't one
+*/
+func Parse(v string, now time.Time) (int, error) {
+	if v = strings.TrimSpace(v); v == "" {
+		return Current, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err == nil {
+		err = Supported(n, now)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("schema version %q is not supported, use one of %s", v, supported(now))
+	}
+	return n, nil
+}
+
+// Supported returns an error when payloads can't be written in version v at now.
+func Supported(v int, now time.Time) error {
+	version, ok := Versions[v]
+	if !ok {
+		return fmt.Errorf("schema version %d does not exist", v)
+	}
+	if !version.Sunset.IsZero() && !now.Before(version.Sunset) {
+		return fmt.Errorf("schema version %d was sunset %s", v, version.Sunset.Format("Jan 2, 2006"))
+	}
+	return nil
+}
+
+func supported(now time.Time) string {
+	var numbers []string
+	for n := range Versions {
+		if Supported(n, now) == nil {
+			numbers = append(numbers, strconv.Itoa(n))
+		}
+	}
+	sort.Strings(numbers)
+	return strings.Join(numbers, ", ")
+}
+
+/*
+	Downgrade rewrites a Current payload as version v.
+
+Parameters:
+  - doc: The payload, a JSON object with schemaVersion set to Current
+  - v: A supported version
+
+Returns:
+  - []byte: The payload in version v, doc itself when v is Current
+  - error: An error when doc is not a JSON object
+*/
+func Downgrade(doc []byte, v int) ([]byte, error) {
+	if v == Current {
+		return doc, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &fields); err != nil {
+		return nil, fmt.Errorf("payload is not a JSON object: %w", err)
+	}
+	for n := Current - 1; n >= v; n-- {
+		Versions[n].downgrade(fields)
+	}
+	if _, ok := fields["schemaVersion"]; ok {
+		fields["schemaVersion"] = json.RawMessage(strconv.Itoa(v))
+	}
+	return json.Marshal(fields)
+}
+
+// SetHeaders names version v in h, with RFC 9745 Deprecation and RFC 8594 Sunset headers when v is deprecated.
+func SetHeaders(h http.Header, v int) {
+	h.Set(Header, strconv.Itoa(v))
+	version := Versions[v]
+	if version.Deprecated.IsZero() {
+		return
+	}
+	h.Set("Deprecation", "@"+strconv.FormatInt(version.Deprecated.Unix(), 10))
+	h.Set("Sunset", version.Sunset.Format(http.TimeFormat))
+}
@@ -0,0 +1,50 @@
+package payload
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	for in, want := range map[string]int{"": Current, "2": 2, " 1 ": 1} {
+		if got, err := Parse(in, now); err != nil || got != want {
+			t.Errorf("Parse(%q) = %d, %v, want %d", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"0", "3", "v2"} {
+		if _, err := Parse(bad, now); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", bad)
+		}
+	}
+	if _, err := Parse("1", Versions[1].Sunset); err == nil {
+		t.Error("version 1 is still served after its sunset")
+	}
+}
+
+func TestDowngrade(t *testing.T) {
+	doc := []byte(`{"schemaVersion":2,"id":"ev1","owner":"a@x.com"}`)
+	if got, err := Downgrade(doc, Current); err != nil || string(got) != string(doc) {
+		t.Errorf("Downgrade to Current = %s, %v", got, err)
+	}
+	if got, err := Downgrade(doc, 1); err != nil || string(got) != `{"id":"ev1","owner":"a@x.com"}` {
+		t.Errorf("Downgrade to 1 = %s, %v", got, err)
+	}
+	if _, err := Downgrade([]byte(`[1]`), 1); err == nil {
+		t.Error("Downgrade accepted a payload that is not an object")
+	}
+}
+
+func TestSetHeaders(t *testing.T) {
+	h := http.Header{}
+	SetHeaders(h, Current)
+	if h.Get(Header) != "2" || h.Get("Deprecation") != "" || h.Get("Sunset") != "" {
+		t.Errorf("current version headers = %v", h)
+	}
+	h = http.Header{}
+	SetHeaders(h, 1)
+	if h.Get(Header) != "1" || h.Get("Deprecation") != "@1791936000" || h.Get("Sunset") != "Wed, 14 Apr 2027 00:00:00 GMT" {
+		t.Errorf("version 1 headers = %v", h)
+	}
+}
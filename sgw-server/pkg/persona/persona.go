@@ -0,0 +1,283 @@
+/*
+	Package persona classifies LinkedIn profiles by seniority and job function.
+
+Classification is rule-based and works off the job titles scraped into a
+scraper.Profile, or its headline when no experience was scraped. When the rules cannot decide, an optional Assist function
+(for example openai.ClassifyPersona) can be supplied to resolve the remaining
+fields with an LLM.
+
+Basic usage:
+
+	p := persona.Classify(*scraper.Profile)
+	fmt.Println(p.Seniority, p.Function)
+
+Filtering:
+
+	f := persona.Filter{Seniorities: []persona.Seniority{persona.SeniorityDirector, persona.SeniorityVP}}
+	if f.Match(p) {
+	    ...
+	}
+*/
+package persona
+
+import (
+	"strings"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// Seniority is the level of a profile's current role.
+type Seniority string
+
+const (
+	SeniorityUnknown  Seniority = "unknown"
+	SeniorityIC       Seniority = "ic"
+	SeniorityManager  Seniority = "manager"
+	SeniorityDirector Seniority = "director"
+	SeniorityVP       Seniority = "vp"
+	SeniorityCLevel   Seniority = "c-level"
+)
+
+// Function is the business function of a profile's current role.
+type Function string
+
+const (
+	FunctionUnknown     Function = "unknown"
+	FunctionEngineering Function = "engineering"
+	FunctionData        Function = "data"
+	FunctionProduct     Function = "product"
+	FunctionDesign      Function = "design"
+	FunctionSales       Function = "sales"
+	FunctionMarketing   Function = "marketing"
+	FunctionHR          Function = "hr"
+	FunctionFinance     Function = "finance"
+	FunctionOperations  Function = "operations"
+	FunctionSupport     Function = "support"
+	FunctionLegal       Function = "legal"
+	FunctionExecutive   Function = "executive"
+)
+
+// Seniorities lists every known seniority, ordered from junior to senior.
+var Seniorities = []Seniority{SeniorityIC, SeniorityManager, SeniorityDirector, SeniorityVP, SeniorityCLevel}
+
+// Functions lists every known function.
+var Functions = []Function{
+	FunctionEngineering, FunctionData, FunctionProduct, FunctionDesign, FunctionSales, FunctionMarketing,
+	FunctionHR, FunctionFinance, FunctionOperations, FunctionSupport, FunctionLegal, FunctionExecutive,
+}
+
+/*
+	Persona is the classification result for a single profile.
+
+Title is the job title the classification was derived from.
+*/
+type Persona struct {
+	Seniority Seniority `json:"seniority"`
+	Function  Function  `json:"function"`
+	Title     string    `json:"title,omitempty"`
+}
+
+/*
+	Assist resolves a persona for a profile that the rules could not fully classify.
+
+Implementations are expected to call out to an LLM and may be slow or fail;
+Classify only uses the fields of the result that are still unknown.
+*/
+type Assist func(profile scraper.Profile) (Persona, error)
+
+// seniorityRules are checked in order, so more senior markers win over junior ones.
+var seniorityRules = []struct {
+	seniority Seniority
+	keywords  []string
+}{
+	{SeniorityCLevel, []string{"chief", "ceo", "cto", "cfo", "coo", "cmo", "cio", "ciso", "cpo", "cro", "founder", "co-founder", "cofounder", "president", "owner", "managing partner"}},
+	{SeniorityVP, []string{"vp", "svp", "evp", "vice president"}},
+	{SeniorityDirector, []string{"director", "head of", "head"}},
+	{SeniorityManager, []string{"manager", "team lead", "supervisor", "lead"}},
+	{SeniorityIC, []string{"engineer", "developer", "analyst", "scientist", "designer", "specialist", "consultant", "associate", "executive", "representative", "recruiter", "architect", "intern", "coordinator", "administrator", "accountant", "officer", "researcher", "writer", "student"}},
+}
+
+var functionRules = []struct {
+	function Function
+	keywords []string
+}{
+	{FunctionExecutive, []string{"ceo", "founder", "co-founder", "cofounder", "owner", "managing director", "general manager", "president", "managing partner"}},
+	{FunctionEngineering, []string{"engineer", "engineering", "developer", "software", "sde", "devops", "sre", "architect", "programmer", "qa", "cto", "technology", "infrastructure", "security", "ciso", "it"}},
+	{FunctionData, []string{"data", "analytics", "scientist", "machine learning", "ml", "ai", "bi"}},
+	{FunctionProduct, []string{"product", "cpo"}},
+	{FunctionDesign, []string{"design", "designer", "ux", "ui", "creative"}},
+	{FunctionSales, []string{"sales", "account executive", "business development", "bdr", "sdr", "account manager", "partnerships", "cro", "revenue"}},
+	{FunctionMarketing, []string{"marketing", "growth", "seo", "content", "brand", "cmo", "communications", "pr"}},
+	{FunctionHR, []string{"hr", "human resources", "recruiter", "recruiting", "talent", "people"}},
+	{FunctionFinance, []string{"finance", "financial", "accountant", "accounting", "cfo", "controller", "treasury"}},
+	{FunctionOperations, []string{"operations", "coo", "supply chain", "logistics", "procurement"}},
+	{FunctionSupport, []string{"customer success", "support", "customer service", "customer experience"}},
+	{FunctionLegal, []string{"legal", "counsel", "lawyer", "attorney", "compliance"}},
+}
+
+/*
+	Classify tags a profile with a seniority and function using keyword rules.
+
+The most recent experience title is used; name-only profiles come back as
+SeniorityUnknown and FunctionUnknown.
+
+Parameters:
+  - profile: The scraped profile to classify
+
+Returns:
+  - Persona: The classification result
+*/
+func Classify(profile scraper.Profile) Persona {
+	title := currentTitle(profile)
+	return Persona{
+		Seniority: ClassifySeniority(title),
+		Function:  ClassifyFunction(title),
+		Title:     title,
+	}
+}
+
+/*
+	ClassifyWithAssist classifies a profile with the rules and falls back to assist
+
+for any field the rules left unknown. A nil assist behaves like Classify.
+
+Parameters:
+  - profile: The scraped profile to classify
+  - assist: Optional LLM-backed classifier
+
+Returns:
+  - Persona: The classification result
+  - error: Any error returned by assist; the rule-based result is still returned
+*/
+func ClassifyWithAssist(profile scraper.Profile, assist Assist) (Persona, error) {
+	p := Classify(profile)
+	if assist == nil || (p.Seniority != SeniorityUnknown && p.Function != FunctionUnknown) {
+		return p, nil
+	}
+
+	assisted, err := assist(profile)
+	if err != nil {
+		return p, err
+	}
+	if p.Seniority == SeniorityUnknown && ValidSeniority(assisted.Seniority) {
+		p.Seniority = assisted.Seniority
+	}
+	if p.Function == FunctionUnknown && ValidFunction(assisted.Function) {
+		p.Function = assisted.Function
+	}
+	return p, nil
+}
+
+// ClassifySeniority returns the seniority implied by a job title.
+func ClassifySeniority(title string) Seniority {
+	normalized := normalize(title)
+	if normalized == "" {
+		return SeniorityUnknown
+	}
+	for _, rule := range seniorityRules {
+		if containsAny(normalized, rule.keywords) {
+			return rule.seniority
+		}
+	}
+	return SeniorityIC
+}
+
+// ClassifyFunction returns the business function implied by a job title.
+func ClassifyFunction(title string) Function {
+	normalized := normalize(title)
+	for _, rule := range functionRules {
+		if containsAny(normalized, rule.keywords) {
+			return rule.function
+		}
+	}
+	return FunctionUnknown
+}
+
+// ValidSeniority reports whether s is one of the known seniorities.
+func ValidSeniority(s Seniority) bool {
+	for _, known := range Seniorities {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidFunction reports whether f is one of the known functions.
+func ValidFunction(f Function) bool {
+	for _, known := range Functions {
+		if f == known {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+	Filter selects personas by seniority and function.
+
+Empty slices match everything, so the zero Filter matches every persona.
+*/
+type Filter struct {
+	Seniorities []Seniority `json:"seniorities,omitempty"`
+	Functions   []Function  `json:"functions,omitempty"`
+}
+
+// Match reports whether p satisfies the filter.
+func (f Filter) Match(p Persona) bool {
+	if len(f.Seniorities) > 0 && !containsSeniority(f.Seniorities, p.Seniority) {
+		return false
+	}
+	if len(f.Functions) > 0 && !containsFunction(f.Functions, p.Function) {
+		return false
+	}
+	return true
+}
+
+// currentTitle is the latest experience title, or the headline when no experience was scraped.
+func currentTitle(profile scraper.Profile) string {
+	for _, exp := range profile.Experience {
+		if strings.TrimSpace(exp.Title) != "" {
+			return strings.TrimSpace(exp.Title)
+		}
+	}
+	return strings.TrimSpace(profile.Headline)
+}
+
+// normalize lowercases a title and pads it with spaces so keywords can be
+// matched on word boundaries.
+func normalize(title string) string {
+	replacer := strings.NewReplacer(",", " ", "/", " ", "|", " ", "&", " ", "(", " ", ")", " ", ".", " ", "@", " ", ":", " ")
+	fields := strings.Fields(strings.ToLower(replacer.Replace(title)))
+	if len(fields) == 0 {
+		return ""
+	}
+	return " " + strings.Join(fields, " ") + " "
+}
+
+func containsAny(normalized string, keywords []string) bool {
+	for _, k := range keywords {
+		if strings.Contains(normalized, " "+k+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSeniority(list []Seniority, s Seniority) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFunction(list []Function, f Function) bool {
+	for _, v := range list {
+		if v == f {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package persona
+
+import (
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestClassifyFallsBackToHeadline(t *testing.T) {
+	headline := scraper.Profile{Headline: "VP Marketing at Calmly | Subscription growth"}
+	if p := Classify(headline); p.Seniority != SeniorityVP || p.Function != FunctionMarketing {
+		t.Errorf("Classify(headline only) = %+v, want vp marketing", p)
+	}
+
+	// The latest experience wins over a vaguer headline
+	both := scraper.Profile{Headline: "Helping studios grow", Experience: []scraper.Experience{{Title: "Senior Data Scientist"}}}
+	if p := Classify(both); p.Title != "Senior Data Scientist" || p.Function != FunctionData {
+		t.Errorf("Classify(experience and headline) = %+v, want the experience title", p)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+	Package redact masks credentials in log output.
+
+A Redactor replaces the values of sensitive JSON fields and key=value pairs
+(password, li_at, apiKey, ...), Authorization headers, OpenAI style API keys
+and any literal secret it was given with "[REDACTED]". It can wrap the
+standard logger's output so every log line is scrubbed before it is written.
+
+Basic usage:
+
+	r := redact.New(redact.DefaultKeys, os.Getenv("OPENAI_API_KEY"))
+	log.SetOutput(r.Writer(os.Stderr))
+*/
+package redact
+
+import (
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mask is what redacted values are replaced with.
+const Mask = "[REDACTED]"
+
+// DefaultKeys are the field names whose values are always redacted, matched case-insensitively.
+var DefaultKeys = []string{"password", "li_at", "jsessionid", "apiKey", "api_key", "token", "secret", "authorization", "cookie"}
+
+// minSecretLen keeps short literals (an empty or one letter password) from masking unrelated text.
+const minSecretLen = 4
+
+var (
+	bearerRe = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`)
+	apiKeyRe = regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{16,}`)
+)
+
+// Redactor scrubs text. It is safe for concurrent use.
+type Redactor struct {
+	mu       sync.RWMutex
+	quotedRe *regexp.Regexp // "key": "value"
+	bareRe   *regexp.Regexp // key=value, key: value and Go's %+v Key:value
+	secrets  []string
+}
+
+/*
+	New creates a Redactor for the given field names and literal secrets.
+
+Parameters:
+  - keys: Field names whose values are masked, e.g. DefaultKeys
+  - secrets: Exact values to mask wherever they appear, e.g. configured API keys
+
+Returns:
+  - *Redactor: The configured redactor
+*/
+func New(keys []string, secrets ...string) *Redactor {
+	r := &Redactor{}
+	if alternation := keyAlternation(keys); alternation != "" {
+		r.quotedRe = regexp.MustCompile(`(?i)(["']?\b(?:` + alternation + `)["']?\s*[:=]\s*")(?:[^"\\]|\\.)*"`)
+		r.bareRe = regexp.MustCompile(`(?i)(\b(?:` + alternation + `)["']?\s*[:=]\s*)[^\s,;&}"'\[]+`)
+	}
+	r.AddSecrets(secrets...)
+	return r
+}
+
+// AddSecrets registers more literal values to mask, such as passwords of configured accounts.
+func (r *Redactor) AddSecrets(secrets ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range secrets {
+		if len(s) >= minSecretLen {
+			r.secrets = append(r.secrets, s)
+		}
+	}
+	// Longest first, so a secret containing another one is masked whole
+	sort.Slice(r.secrets, func(i, j int) bool { return len(r.secrets[i]) > len(r.secrets[j]) })
+}
+
+// String returns s with every sensitive value masked.
+func (r *Redactor) String(s string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, Mask)
+	}
+	// Schemes first, otherwise "Authorization: Bearer x" would only lose "Bearer"
+	s = bearerRe.ReplaceAllString(s, "${1} "+Mask)
+	s = apiKeyRe.ReplaceAllString(s, Mask)
+	if r.quotedRe != nil {
+		s = r.quotedRe.ReplaceAllString(s, "${1}"+Mask+`"`)
+		s = r.bareRe.ReplaceAllString(s, "${1}"+Mask)
+	}
+	return s
+}
+
+// Writer returns a writer that redacts every write before passing it to w. The standard
+// logger issues one write per line, so values are never split across writes.
+func (r *Redactor) Writer(w io.Writer) io.Writer {
+	return writer{r: r, w: w}
+}
+
+type writer struct {
+	r *Redactor
+	w io.Writer
+}
+
+func (w writer) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.w, w.r.String(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func keyAlternation(keys []string) string {
+	quoted := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k = strings.TrimSpace(k); k != "" {
+			quoted = append(quoted, regexp.QuoteMeta(k))
+		}
+	}
+	return strings.Join(quoted, "|")
+}
@@ -0,0 +1,188 @@
+/*
+	Package relevance picks the posts a connect message should be written from.
+
+Messages hook on a prospect's posts, and the latest one is often the wrong one:
+a work anniversary from last year, or a repost about something the campaign has
+nothing to do with. Recent leaves out posts older than a cutoff, and the rankers
+score what is left against the campaign's goal, by the goal's keywords or by the
+similarity of embeddings, so Rank can put the posts worth referencing first.
+
+Basic usage:
+
+	posts := relevance.Recent(profile.Posts, 90*24*time.Hour, time.Now())
+	posts = relevance.Rank(posts, relevance.KeywordScores(posts, "hiring data engineers"))
+*/
+package relevance
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/topics"
+)
+
+// How posts are ranked against a goal.
+const (
+	RankOff       = "off"
+	RankKeyword   = "keyword"
+	RankEmbedding = "embedding"
+)
+
+// suffixes are cut off words before they are compared, so "hiring" matches "hire" and
+// "engineers" matches "engineering".
+var suffixes = []string{"ings", "ing", "ers", "er", "ed", "es", "s", "e"}
+
+/*
+	Recent returns the posts published within maxAge of now, in their order.
+
+Posts without a date are kept, there is no telling their age.
+
+Parameters:
+  - posts: The scraped posts
+  - maxAge: How old a post may be, 0 to keep every post
+  - now: The time the message is written
+
+Returns:
+  - []scraper.Post: The posts kept, a new slice unless maxAge is 0
+*/
+func Recent(posts []scraper.Post, maxAge time.Duration, now time.Time) []scraper.Post {
+	if maxAge <= 0 {
+		return posts
+	}
+	var kept []scraper.Post
+	for _, p := range posts {
+		if p.PostedAt.IsZero() || now.Sub(p.PostedAt) <= maxAge {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// Text is what a post says, as ranked: its caption and the text read off its media.
+func Text(p scraper.Post) string {
+	return strings.TrimSpace(p.Content + "\n" + p.MediaText)
+}
+
+/*
+	KeywordScores scores each post by the share of the goal's keywords it mentions.
+
+Words are compared by their stem, so a post about "hiring engineers" mentions
+both keywords of "hire an engineer".
+
+Parameters:
+  - posts: The posts to score
+  - goal: What the message is meant to achieve, e.g. "hiring data engineers"
+
+Returns:
+  - []float64: One score per post between 0 and 1, all 0 when the goal has no keywords
+*/
+func KeywordScores(posts []scraper.Post, goal string) []float64 {
+	var terms []string
+	for _, k := range topics.Keywords(goal) {
+		terms = append(terms, stem(k))
+	}
+	scores := make([]float64, len(posts))
+	if len(terms) == 0 {
+		return scores
+	}
+	for i, p := range posts {
+		var words []string
+		for _, k := range topics.Keywords(Text(p)) {
+			words = append(words, stem(k))
+		}
+		matched := 0
+		for _, t := range terms {
+			for _, w := range words {
+				if sameStem(t, w) {
+					matched++
+					break
+				}
+			}
+		}
+		scores[i] = float64(matched) / float64(len(terms))
+	}
+	return scores
+}
+
+/*
+	SimilarityScores scores each post by the cosine similarity of its embedding to the goal's.
+
+Parameters:
+  - goal: The embedding of the goal
+  - posts: The embeddings of the posts' Text, in their order
+
+Returns:
+  - []float64: One score per post, 0 for embeddings that are empty or of another size
+*/
+func SimilarityScores(goal []float64, posts [][]float64) []float64 {
+	scores := make([]float64, len(posts))
+	for i, p := range posts {
+		scores[i] = cosine(goal, p)
+	}
+	return scores
+}
+
+/*
+	Rank orders posts by score, highest first.
+
+Posts scoring the same keep their order, which is newest first as scraped.
+
+Parameters:
+  - posts: The posts to rank
+  - scores: One score per post, from KeywordScores or SimilarityScores
+
+Returns:
+  - []scraper.Post: The posts ranked, a new slice; posts unchanged when the scores don't match them
+*/
+func Rank(posts []scraper.Post, scores []float64) []scraper.Post {
+	if len(scores) != len(posts) {
+		return posts
+	}
+	order := make([]int, len(posts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return scores[order[i]] > scores[order[j]] })
+	ranked := make([]scraper.Post, len(posts))
+	for i, o := range order {
+		ranked[i] = posts[o]
+	}
+	return ranked
+}
+
+func stem(word string) string {
+	for _, s := range suffixes {
+		if len(word)-len(s) >= 3 && strings.HasSuffix(word, s) {
+			return strings.TrimSuffix(word, s)
+		}
+	}
+	return word
+}
+
+// sameStem reports whether two stems are of the same word; one may still carry a suffix
+// the other lost, as "engineer" of "engineering" does against "engine" of "engineers".
+func sameStem(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return a == b || (len(a) >= 4 && strings.HasPrefix(b, a))
+}
+
+func cosine(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
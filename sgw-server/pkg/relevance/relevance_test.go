@@ -0,0 +1,55 @@
+package relevance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestRecent(t *testing.T) {
+	now := time.Date(2024, time.November, 1, 0, 0, 0, 0, time.UTC)
+	posts := []scraper.Post{
+		{Content: "last week", PostedAt: now.AddDate(0, 0, -7)},
+		{Content: "undated"},
+		{Content: "work anniversary", PostedAt: now.AddDate(-1, 0, 0)},
+	}
+	kept := Recent(posts, 30*24*time.Hour, now)
+	if len(kept) != 2 || kept[0].Content != "last week" || kept[1].Content != "undated" {
+		t.Errorf("Recent = %+v, want the dated post within 30 days and the undated one", kept)
+	}
+	if all := Recent(posts, 0, now); len(all) != 3 {
+		t.Errorf("Recent without a cutoff kept %d posts, want 3", len(all))
+	}
+}
+
+func TestKeywordScores(t *testing.T) {
+	posts := []scraper.Post{
+		{Content: "Three years at Moonfrog today. Grateful for the team."},
+		{Content: "We're hiring! Two senior data engineers in Bengaluru."},
+		{Content: "Slides from my data talk are up."},
+	}
+	scores := KeywordScores(posts, "Hire data engineering leads")
+	if !(scores[1] > scores[2] && scores[2] > scores[0] && scores[0] == 0) {
+		t.Errorf("scores = %v, want the hiring post first and the anniversary last", scores)
+	}
+	ranked := Rank(posts, scores)
+	if ranked[0].Content != posts[1].Content || ranked[2].Content != posts[0].Content || posts[0].Content != "Three years at Moonfrog today. Grateful for the team." {
+		t.Errorf("Rank = %+v, want a new slice by score", ranked)
+	}
+	if none := KeywordScores(posts, "the"); none[0] != 0 || none[1] != 0 {
+		t.Errorf("scores without keywords = %v", none)
+	}
+}
+
+func TestSimilarityScores(t *testing.T) {
+	scores := SimilarityScores([]float64{1, 0}, [][]float64{{0, 1}, {2, 0}, {1, 1}, nil})
+	if scores[0] != 0 || scores[1] != 1 || scores[2] <= 0.7 || scores[2] >= 0.71 || scores[3] != 0 {
+		t.Errorf("scores = %v", scores)
+	}
+	// Ties keep the scraped order
+	posts := []scraper.Post{{Content: "a"}, {Content: "b"}, {Content: "c"}}
+	if ranked := Rank(posts, []float64{0, 1, 0}); ranked[0].Content != "b" || ranked[1].Content != "a" || ranked[2].Content != "c" {
+		t.Errorf("Rank = %+v", ranked)
+	}
+}
@@ -0,0 +1,430 @@
+/*
+	Package schema describes Go types as JSON Schemas and validates JSON documents against them.
+
+Schemas are generated from a type's encoding/json layout, so they follow what
+the server actually writes: property names and omitempty come from the json
+tags, time.Time is a date-time string, nil slices, maps and pointers are null,
+and every struct is described once under $defs. Validate implements the part of
+JSON Schema 2020-12 the generated schemas use.
+
+Basic usage:
+
+	s := schema.For(reflect.TypeFor[scraper.Profile](), "LinkedIn profile")
+	errs, err := s.Validate(data)
+	for _, e := range errs {
+	    fmt.Println(e.Path, e.Message)
+	}
+*/
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect generated schemas declare.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+/*
+	Schema is a JSON Schema, as For generates them.
+
+The zero value accepts any document.
+*/
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+	never                bool               // The false schema, nothing is valid against it
+}
+
+// False is the schema no value is valid against, the additionalProperties of structs.
+var False = &Schema{never: true}
+
+// MarshalJSON writes False as false.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.never {
+		return []byte("false"), nil
+	}
+	type plain Schema
+	return json.Marshal((*plain)(s))
+}
+
+// Types are the JSON types a value may have: "object", "array", "string", "number", "integer", "boolean" or "null".
+type Types []string
+
+// MarshalJSON writes a single type as a string.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// Error is where a document doesn't match its schema.
+type Error struct {
+	Path    string `json:"path"` // JSON Pointer to the value, empty for the document itself
+	Message string `json:"message"`
+}
+
+func (e Error) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+/*
+	For generates the schema of t as encoding/json writes it.
+
+Parameters:
+  - t: The Go type, usually a struct
+  - title: What the documents are, for people reading the schema
+
+Returns:
+  - *Schema: The root schema, with the structs t contains under $defs
+*/
+func For(t reflect.Type, title string) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	g := &generator{root: t, defs: map[string]*Schema{}, prefix: "#/$defs/"}
+	var root *Schema
+	if t.Kind() == reflect.Struct {
+		root = g.object(t)
+	} else {
+		root = g.schema(t)
+	}
+	root.Schema, root.Title = Draft, title
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+	return root
+}
+
+type generator struct {
+	root   reflect.Type // Referred to as "#", nil for Components
+	defs   map[string]*Schema
+	prefix string // Where refs find defs
+}
+
+/*
+	Components describes several Go types with one set of definitions, such as the
+
+components/schemas of an OpenAPI document.
+
+Basic usage:
+
+	c := schema.NewComponents("#/components/schemas/")
+	res := c.For(reflect.TypeFor[HomeRes]())
+	defs := c.Defs()
+*/
+type Components struct {
+	g *generator
+}
+
+// NewComponents starts a set of definitions refs find at prefix followed by their name.
+func NewComponents(prefix string) *Components {
+	return &Components{g: &generator{defs: map[string]*Schema{}, prefix: prefix}}
+}
+
+// For returns the schema of t, referring to the structs it contains, t included, in the definitions.
+func (c *Components) For(t reflect.Type) *Schema {
+	return c.g.schema(t)
+}
+
+// Define adds a schema that isn't generated from a Go type, and returns a reference to it.
+func (c *Components) Define(name string, s *Schema) *Schema {
+	c.g.defs[name] = s
+	return &Schema{Ref: c.g.prefix + name}
+}
+
+// Defs returns the definitions, by name: the package and name of the Go type, e.g. "scraper.Profile".
+func (c *Components) Defs() map[string]*Schema {
+	return c.g.defs
+}
+
+func (g *generator) schema(t reflect.Type) *Schema {
+	if t == timeType {
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Pointer:
+		return nullable(g.schema(t.Elem()))
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Base64
+			return &Schema{Type: Types{"string", "null"}}
+		}
+		return nullable(&Schema{Type: Types{"array"}, Items: g.schema(t.Elem())})
+	case reflect.Array:
+		return &Schema{Type: Types{"array"}, Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return nullable(&Schema{Type: Types{"object"}, AdditionalProperties: g.schema(t.Elem())})
+	case reflect.Struct:
+		if g.root != nil && t == g.root {
+			return &Schema{Ref: "#"}
+		}
+		name := path.Base(t.PkgPath()) + "." + t.Name()
+		if _, ok := g.defs[name]; !ok {
+			// Set first, so a struct that contains itself refers to its definition
+			g.defs[name] = &Schema{}
+			*g.defs[name] = *g.object(t)
+		}
+		return &Schema{Ref: g.prefix + name}
+	}
+	// Interfaces and anything else encoding/json can write
+	return &Schema{}
+}
+
+// object describes a struct's fields, promoting those of embedded structs as encoding/json does.
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: Types{"object"}, Properties: map[string]*Schema{}, AdditionalProperties: False}
+	g.fields(t, s)
+	return s
+}
+
+func (g *generator) fields(t reflect.Type, s *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.fields(embedded, s)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s.Properties[name] = g.schema(f.Type)
+		if !slices.Contains(strings.Split(opts, ","), "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// nullable lets s also be null.
+func nullable(s *Schema) *Schema {
+	switch {
+	case s.Ref != "":
+		return &Schema{AnyOf: []*Schema{s, {Type: Types{"null"}}}}
+	case len(s.Type) == 0 || slices.Contains(s.Type, "null"):
+		return s
+	}
+	s.Type = append(s.Type, "null")
+	return s
+}
+
+/*
+	Validate checks a JSON document against the schema.
+
+Parameters:
+  - data: The document
+
+Returns:
+  - []Error: Where the document doesn't match, nil when it does
+  - error: An error when data is not JSON
+*/
+func (s *Schema) Validate(data []byte) ([]Error, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after the document")
+	}
+	var errs []Error
+	s.validate(s, doc, "", &errs)
+	return errs, nil
+}
+
+func (s *Schema) validate(root *Schema, v any, at string, errs *[]Error) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, Error{Path: at, Message: fmt.Sprintf(format, args...)})
+	}
+	if s.never {
+		fail("is not allowed")
+		return
+	}
+	if s.Ref != "" {
+		ref, ok := root.resolve(s.Ref)
+		if !ok {
+			fail("refers to %s, which the schema does not define", s.Ref)
+			return
+		}
+		ref.validate(root, v, at, errs)
+		return
+	}
+	got := typeOf(v)
+	if len(s.AnyOf) > 0 {
+		// Only the options of the value's type say what is wrong with it
+		var wanted Types
+		var first []Error
+		for _, option := range s.AnyOf {
+			types := root.types(option)
+			if !allows(types, got) {
+				wanted = append(wanted, types...)
+				continue
+			}
+			var optionErrs []Error
+			option.validate(root, v, at, &optionErrs)
+			if len(optionErrs) == 0 {
+				return
+			}
+			if first == nil {
+				first = optionErrs
+			}
+		}
+		if first == nil {
+			fail("is %s, want %s", article(got), strings.Join(wanted, " or "))
+		}
+		*errs = append(*errs, first...)
+		return
+	}
+
+	if !allows(s.Type, got) {
+		fail("is %s, want %s", article(got), strings.Join(s.Type, " or "))
+		return
+	}
+	switch v := v.(type) {
+	case string:
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				fail("is not an RFC 3339 date-time")
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(root, item, at+"/"+strconv.Itoa(i), errs)
+			}
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				fail("is missing %q", name)
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			property, ok := s.Properties[name]
+			if !ok {
+				property = s.AdditionalProperties
+			}
+			if property == nil {
+				continue
+			}
+			if property.never {
+				fail("has unknown property %q", name)
+				continue
+			}
+			property.validate(root, v[name], at+"/"+pointerEscape(name), errs)
+		}
+	}
+}
+
+// resolve returns the schema ref points to: the root or one of its $defs.
+func (s *Schema) resolve(ref string) (*Schema, bool) {
+	if ref == "#" {
+		return s, true
+	}
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil, false
+	}
+	def, ok := s.Defs[name]
+	return def, ok
+}
+
+// types returns the JSON types option allows, following its $ref into s; none means any.
+func (s *Schema) types(option *Schema) Types {
+	if option.Ref != "" {
+		if ref, ok := s.resolve(option.Ref); ok {
+			return s.types(ref)
+		}
+	}
+	return option.Type
+}
+
+// allows reports whether a value of type got has one of types, any type when there are none.
+func allows(types Types, got string) bool {
+	return len(types) == 0 || slices.Contains(types, got) || (got == "integer" && slices.Contains(types, "number"))
+}
+
+func typeOf(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	}
+	return "object"
+}
+
+func article(typ string) string {
+	switch typ {
+	case "null":
+		return "null"
+	case "array", "integer", "object":
+		return "an " + typ
+	}
+	return "a " + typ
+}
+
+// pointerEscape escapes a property name for a JSON Pointer.
+func pointerEscape(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
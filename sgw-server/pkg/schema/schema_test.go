@@ -0,0 +1,111 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type team struct {
+	Name string `json:"name"`
+}
+
+type base struct {
+	ID string `json:"id"`
+}
+
+type member struct {
+	base
+	Email   string            `json:"email"`
+	Nick    string            `json:"nick,omitempty"`
+	Age     int               `json:"age"`
+	Team    *team             `json:"team"`
+	Past    []team            `json:"past"`
+	Tags    map[string]string `json:"tags,omitempty"`
+	Joined  time.Time         `json:"joined"`
+	Score   float64           `json:"score"`
+	Manager *member           `json:"manager,omitempty"`
+	Secret  string            `json:"-"`
+	Level   int
+	private int
+}
+
+func TestFor(t *testing.T) {
+	s := For(reflect.TypeFor[member](), "Member")
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	json.Unmarshal(data, &got)
+
+	props := got["properties"].(map[string]any)
+	for _, name := range []string{"id", "email", "nick", "age", "team", "past", "tags", "joined", "score", "manager", "Level"} {
+		if _, ok := props[name]; !ok {
+			t.Errorf("no property %q in %s", name, data)
+		}
+	}
+	for _, name := range []string{"Secret", "private", "base"} {
+		if _, ok := props[name]; ok {
+			t.Errorf("property %q should not be described", name)
+		}
+	}
+	if got["additionalProperties"] != false || got["$schema"] != Draft || got["title"] != "Member" {
+		t.Errorf("root = %s", data)
+	}
+	if req := strings.Join(s.Required, ","); req != "id,email,age,team,past,joined,score,Level" {
+		t.Errorf("required = %s", req)
+	}
+	if joined := s.Properties["joined"]; joined.Format != "date-time" {
+		t.Errorf("joined = %+v", joined)
+	}
+	if manager := s.Properties["manager"]; len(manager.AnyOf) != 2 || manager.AnyOf[0].Ref != "#" {
+		t.Errorf("a member's manager should refer back to the root, got %+v", manager)
+	}
+	if _, ok := s.Defs["schema.team"]; !ok {
+		t.Errorf("$defs = %v, want schema.team", s.Defs)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s := For(reflect.TypeFor[member](), "Member")
+	m := member{base: base{ID: "1"}, Email: "a@x.com", Team: &team{Name: "growth"}, Joined: time.Now(), Manager: &member{Email: "b@x.com"}}
+	data, _ := json.Marshal(m)
+	if errs, err := s.Validate(data); err != nil || len(errs) != 0 {
+		t.Fatalf("Validate(%s) = %v, %v, want what encoding/json wrote to be valid", data, errs, err)
+	}
+
+	bad := `{"id": "1", "email": 7, "age": 1.5, "team": {"name": "growth", "size": 3}, "past": null,
+		"joined": "yesterday", "score": 2, "manager": {"email": "b@x.com"}, "Level": 0, "extra": true}`
+	errs, err := s.Validate([]byte(bad))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Error())
+	}
+	want := []string{
+		"/age: is a number, want integer",
+		"/email: is an integer, want string",
+		"has unknown property \"extra\"",
+		"/joined: is not an RFC 3339 date-time",
+		"/manager: is missing \"id\"",
+		"/manager: is missing \"age\"",
+		"/manager: is missing \"team\"",
+		"/manager: is missing \"past\"",
+		"/manager: is missing \"joined\"",
+		"/manager: is missing \"score\"",
+		"/manager: is missing \"Level\"",
+		"/team: has unknown property \"size\"",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("errors =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if _, err := s.Validate([]byte(`{"id": `)); err == nil {
+		t.Error("Validate accepted a document that is not JSON")
+	}
+}
@@ -0,0 +1,245 @@
+/*
+	Package scoring ranks scraped prospects so users work the best ones first.
+
+A score is a weighted average of independent signals (title match, company
+size, recent activity and open-to-work), each normalised to the range 0..1, and
+is reported on a 0..100 scale together with its per-signal breakdown. All
+signals come from the scraped profile, company size from its employer's page,
+or from what a search result lists: its headline stands in for the title.
+
+Basic usage:
+
+	criteria := scoring.Criteria{Weights: scoring.DefaultWeights, TargetTitles: []string{"engineering manager"}}
+	result := scoring.Score(profile, criteria)
+	fmt.Println(result.Total)
+*/
+package scoring
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+/*
+	Weights controls how much each signal contributes to the total score.
+
+Weights are relative; a zero weight disables the signal.
+*/
+type Weights struct {
+	TitleMatch     float64 `json:"titleMatch"`     // Current title, or the headline, against Criteria.TargetTitles
+	CompanySize    float64 `json:"companySize"`    // Employer headcount within the Criteria range
+	RecentActivity float64 `json:"recentActivity"` // Recent posts, weighted by age and engagement
+	OpenToWork     float64 `json:"openToWork"`     // Open-to-work badge present
+}
+
+// DefaultWeights favours title match and activity, the signals available for most profiles.
+var DefaultWeights = Weights{TitleMatch: 4, CompanySize: 2, RecentActivity: 3, OpenToWork: 1}
+
+/*
+	Criteria describes what a good prospect looks like for a batch.
+
+An empty TargetTitles or a zero company size range makes the respective signal
+neutral (0.5). A zero MaxCompanySize leaves the range open above MinCompanySize.
+*/
+type Criteria struct {
+	Weights        Weights  `json:"weights"`
+	TargetTitles   []string `json:"targetTitles,omitempty"`
+	MinCompanySize int      `json:"minCompanySize,omitempty"`
+	MaxCompanySize int      `json:"maxCompanySize,omitempty"`
+}
+
+/*
+	Breakdown is the result of scoring a single prospect.
+
+Each signal is in the range 0..1 before weighting; Total is the weighted average scaled to 0..100.
+*/
+type Breakdown struct {
+	TitleMatch     float64 `json:"titleMatch"`
+	CompanySize    float64 `json:"companySize"`
+	RecentActivity float64 `json:"recentActivity"`
+	OpenToWork     float64 `json:"openToWork"`
+	Total          float64 `json:"total"`
+}
+
+const (
+	// maxPosts is the number of posts GetRecentPosts collects, which counts as fully active.
+	maxPosts = 5
+	// recentPostAge is how old a post can be and still count fully toward recent activity.
+	recentPostAge = 90 * 24 * time.Hour
+	// engagedPost is the number of reactions and comments that makes a post count extra.
+	engagedPost = 25
+)
+
+/*
+	Score computes the weighted score of a prospect against criteria.
+
+Parameters:
+  - profile: The scraped profile
+  - criteria: Target titles, company size range, and weights
+
+Returns:
+  - Breakdown: Per-signal values and the total score
+*/
+func Score(profile scraper.Profile, criteria Criteria) Breakdown {
+	b := Breakdown{
+		TitleMatch:     titleMatch(profile, criteria.TargetTitles),
+		CompanySize:    companySize(profile, criteria.MinCompanySize, criteria.MaxCompanySize),
+		RecentActivity: recentActivity(profile),
+	}
+	if profile.OpenToWork {
+		b.OpenToWork = 1
+	}
+
+	w := criteria.Weights
+	sum := w.TitleMatch + w.CompanySize + w.RecentActivity + w.OpenToWork
+	if sum <= 0 {
+		return b
+	}
+	weighted := b.TitleMatch*w.TitleMatch + b.CompanySize*w.CompanySize + b.RecentActivity*w.RecentActivity + b.OpenToWork*w.OpenToWork
+	b.Total = weighted / sum * 100
+	return b
+}
+
+/*
+	ParseWeights parses weights in the form "titleMatch=4,companySize=2,recentActivity=3,openToWork=1".
+
+Omitted keys keep their value from DefaultWeights.
+*/
+func ParseWeights(s string) (Weights, error) {
+	w := DefaultWeights
+	if strings.TrimSpace(s) == "" {
+		return w, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return w, fmt.Errorf("invalid weight %q, expected key=value", pair)
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || f < 0 {
+			return w, fmt.Errorf("invalid value for weight %q", k)
+		}
+		switch strings.TrimSpace(k) {
+		case "titleMatch":
+			w.TitleMatch = f
+		case "companySize":
+			w.CompanySize = f
+		case "recentActivity":
+			w.RecentActivity = f
+		case "openToWork":
+			w.OpenToWork = f
+		default:
+			return w, fmt.Errorf("unknown weight %q", k)
+		}
+	}
+	return w, nil
+}
+
+// titleMatch returns the best word overlap between the current title and any target title.
+// The current title is the latest experience's, or the headline when no experience was
+// scraped, as for a search result.
+func titleMatch(profile scraper.Profile, targets []string) float64 {
+	if len(targets) == 0 {
+		return 0.5
+	}
+	title := words(profile.Headline)
+	for _, exp := range profile.Experience {
+		if strings.TrimSpace(exp.Title) != "" {
+			title = words(exp.Title)
+			break
+		}
+	}
+	if len(title) == 0 {
+		return 0
+	}
+	best := 0.0
+	for _, target := range targets {
+		want := words(target)
+		if len(want) == 0 {
+			continue
+		}
+		hits := 0
+		for w := range want {
+			if title[w] {
+				hits++
+			}
+		}
+		if m := float64(hits) / float64(len(want)); m > best {
+			best = m
+		}
+	}
+	return best
+}
+
+// companySize tells whether the headcount bracket of the profile's employer, such as
+// "51-200 employees" or "10,001+ employees", overlaps lo..hi. An unknown headcount never does.
+func companySize(profile scraper.Profile, lo, hi int) float64 {
+	if lo == 0 && hi == 0 {
+		return 0.5
+	}
+	if profile.Company == nil {
+		return 0
+	}
+	from, to, ok := headcount(profile.Company.Size)
+	if !ok || (to > 0 && to < lo) || (hi > 0 && from > hi) {
+		return 0
+	}
+	return 1
+}
+
+// headcountRe matches the numbers of a headcount bracket, with their thousands separators.
+var headcountRe = regexp.MustCompile(`\d[\d,.]*`)
+
+// headcount parses a company page's headcount bracket, to being 0 for an open one like
+// "10,001+ employees".
+func headcount(size string) (from, to int, ok bool) {
+	var bounds []int
+	for _, n := range headcountRe.FindAllString(size, 2) {
+		v, err := strconv.Atoi(strings.NewReplacer(",", "", ".", "").Replace(n))
+		if err != nil {
+			return 0, 0, false
+		}
+		bounds = append(bounds, v)
+	}
+	switch {
+	case len(bounds) == 2:
+		return bounds[0], bounds[1], true
+	case len(bounds) == 1 && strings.Contains(size, "+"):
+		return bounds[0], 0, true
+	case len(bounds) == 1:
+		return bounds[0], bounds[0], true
+	}
+	return 0, 0, false
+}
+
+// recentActivity counts the posts, an older one as half and one that drew engagedPost or
+// more reactions and comments as one and a half. Posts without a publish time count as recent.
+func recentActivity(profile scraper.Profile) float64 {
+	var n float64
+	for _, post := range profile.Posts {
+		weight := 1.0
+		if !post.PostedAt.IsZero() && time.Since(post.PostedAt) > recentPostAge {
+			weight = 0.5
+		}
+		if post.Engagement() >= engagedPost {
+			weight *= 1.5
+		}
+		n += weight
+	}
+	return min(n, maxPosts) / maxPosts
+}
+
+func words(s string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}) {
+		set[w] = true
+	}
+	return set
+}
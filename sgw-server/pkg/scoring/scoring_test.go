@@ -0,0 +1,111 @@
+package scoring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestScoreUsesProfileSignals(t *testing.T) {
+	criteria := Criteria{Weights: Weights{TitleMatch: 1, RecentActivity: 1, OpenToWork: 2}, TargetTitles: []string{"data scientist"}}
+	profile := scraper.Profile{
+		Experience: []scraper.Experience{{Title: "Senior Data Scientist"}},
+		Posts:      []scraper.Post{{Content: "a"}, {Content: "b"}},
+	}
+
+	closed := Score(profile, criteria)
+	profile.OpenToWork = true
+	open := Score(profile, criteria)
+	if closed.OpenToWork != 0 || open.OpenToWork != 1 {
+		t.Fatalf("OpenToWork = %v and %v, want 0 and 1", closed.OpenToWork, open.OpenToWork)
+	}
+	// (1 + 0.4 + 2) / 4
+	if want := 85.0; open.Total < want-0.01 || open.Total > want+0.01 {
+		t.Errorf("Total = %v, want %v", open.Total, want)
+	}
+	if open.Total <= closed.Total {
+		t.Errorf("open-to-work did not raise the score: %v <= %v", open.Total, closed.Total)
+	}
+}
+
+func TestParseWeights(t *testing.T) {
+	w, err := ParseWeights("openToWork=5,companySize=2.5")
+	if err != nil {
+		t.Fatalf("ParseWeights: %v", err)
+	}
+	if w.OpenToWork != 5 || w.CompanySize != 2.5 || w.TitleMatch != DefaultWeights.TitleMatch {
+		t.Errorf("ParseWeights = %+v", w)
+	}
+	for _, bad := range []string{"headcount=2", "titleMatch", "recentActivity=-1", "companySize=-2"} {
+		if _, err := ParseWeights(bad); err == nil {
+			t.Errorf("ParseWeights(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestRecentActivityWeighsAgeAndEngagement(t *testing.T) {
+	old := time.Now().Add(-200 * 24 * time.Hour)
+	for _, tc := range []struct {
+		posts []scraper.Post
+		want  float64
+	}{
+		{[]scraper.Post{{Content: "a"}, {Content: "b"}}, 0.4},
+		{[]scraper.Post{{Content: "a", PostedAt: old}, {Content: "b", PostedAt: time.Now()}}, 0.3},
+		{[]scraper.Post{{Content: "a", Reactions: 20, Comments: 5}}, 0.3},
+		{[]scraper.Post{{Content: "a", PostedAt: old, Reactions: 40}}, 0.15},
+	} {
+		if got := recentActivity(scraper.Profile{Posts: tc.posts}); got < tc.want-0.001 || got > tc.want+0.001 {
+			t.Errorf("recentActivity(%+v) = %v, want %v", tc.posts, got, tc.want)
+		}
+	}
+}
+
+func TestTitleMatchFallsBackToHeadline(t *testing.T) {
+	targets := []string{"data scientist"}
+	if got := titleMatch(scraper.Profile{Headline: "Data Scientist at Acme"}, targets); got != 1 {
+		t.Errorf("titleMatch of a headline = %v, want 1", got)
+	}
+	// The latest experience wins over the headline
+	profile := scraper.Profile{Headline: "Data Scientist at Acme", Experience: []scraper.Experience{{Title: ""}, {Title: "Product Manager"}}}
+	if got := titleMatch(profile, targets); got != 0 {
+		t.Errorf("titleMatch = %v, want the experience title's 0", got)
+	}
+	if got := titleMatch(scraper.Profile{}, targets); got != 0 {
+		t.Errorf("titleMatch without a title = %v, want 0", got)
+	}
+}
+
+func TestCompanySize(t *testing.T) {
+	criteria := Criteria{Weights: Weights{TitleMatch: 1, CompanySize: 1}, MinCompanySize: 50, MaxCompanySize: 500}
+	for _, tc := range []struct {
+		company *scraper.Company
+		want    float64
+	}{
+		{&scraper.Company{Size: "51-200 employees"}, 1},
+		{&scraper.Company{Size: "201-500 employees"}, 1},
+		{&scraper.Company{Size: "11-50 employees"}, 1}, // 50 is in range
+		{&scraper.Company{Size: "2-10 employees"}, 0},
+		{&scraper.Company{Size: "1,001-5,000 employees"}, 0},
+		{&scraper.Company{Size: "10,001+ employees"}, 0},
+		{&scraper.Company{}, 0},
+		{nil, 0},
+	} {
+		b := Score(scraper.Profile{Company: tc.company}, criteria)
+		if b.CompanySize != tc.want {
+			t.Errorf("CompanySize of %+v = %v, want %v", tc.company, b.CompanySize, tc.want)
+		}
+		// The neutral title signal and the company's
+		if want := (0.5 + tc.want) / 2 * 100; b.Total != want {
+			t.Errorf("Total of %+v = %v, want %v", tc.company, b.Total, want)
+		}
+	}
+
+	large := scraper.Profile{Company: &scraper.Company{Size: "10,001+ employees"}}
+	if got := Score(large, Criteria{Weights: DefaultWeights, MinCompanySize: 5000}).CompanySize; got != 1 {
+		t.Errorf("CompanySize without an upper bound = %v, want 1", got)
+	}
+	if got := Score(large, Criteria{Weights: DefaultWeights}).CompanySize; got != 0.5 {
+		t.Errorf("CompanySize without a range = %v, want 0.5", got)
+	}
+}
@@ -0,0 +1,166 @@
+package scraper
+
+import (
+	"hash/fnv"
+	"sort"
+	"strings"
+)
+
+// Pools the anonymizer draws replacements from. They are fictional, or generic enough
+// not to point at anyone, and cover a spread of lengths.
+var (
+	fakeFirstNames = []string{"Ana", "Leo", "Maya", "Omar", "Sofia", "Ravi", "Elena", "Tomas", "Aisha", "Jonas", "Nadia", "Kenji", "Isabel", "Marcus", "Priscilla", "Sebastian"}
+	fakeLastNames  = []string{"Ng", "Cole", "Ruiz", "Patel", "Moreau", "Larsen", "Okoro", "Tanaka", "Iverson", "Castillo", "Fernandes", "Whitfield", "Andersson", "Montgomery"}
+	fakeCompanies  = []string{"Acme", "Vireo", "Lumen Co", "Northwind", "Brightloop", "Tailspin Labs", "Fabrikam Inc", "Contoso Games", "Bluefin Analytics", "Silverline Software", "Harborview Technologies"}
+	fakeSchools    = []string{"Westbrook College", "Lakeside University", "Northfield Institute", "Riverside State University", "Eastmoor Institute of Technology", "Greenhill University of Applied Sciences"}
+)
+
+/*
+	Anonymize returns a copy of the profile with people, companies and schools replaced by realistic fakes.
+
+Replacements are picked by hashing the original and preferring fakes of about
+the same length, so the same input always anonymizes the same way and layouts
+look as they did. Volunteer organizations are treated like companies. The
+structure is kept: every entry stays, titles, majors, roles, causes, durations
+and locations are untouched, and company suffixes such as " · Full-time" survive. Recommenders, recipients and the authors of posts
+commented on get fake names as well, and mentions of every replaced name in
+About, posts, comments and recommendations are rewritten too. Other people or places named in free text are not, so
+review anonymized posts before sharing them.
+
+Returns:
+  - Profile: The anonymized copy, the receiver is not modified
+*/
+func (p Profile) Anonymize() Profile {
+	a := p.Clone()
+	pairs := map[string]string{}
+
+	a.Name = fakeName(p.Name, pairs)
+
+	for i, e := range a.Experience {
+		company, _, _ := strings.Cut(e.Company, " · ")
+		if company = strings.TrimSpace(company); company == "" {
+			continue
+		}
+		pairs[company] = pickFake(fakeCompanies, company)
+		a.Experience[i].Company = strings.Replace(e.Company, company, pairs[company], 1)
+	}
+	for i, e := range a.Education {
+		if school := strings.TrimSpace(e.Institute); school != "" {
+			pairs[school] = pickFake(fakeSchools, school)
+			a.Education[i].Institute = pairs[school]
+		}
+	}
+
+	for i, v := range a.Volunteering {
+		// Schools and companies take volunteers too, keep them consistent with the rest
+		if org := strings.TrimSpace(v.Organization); org != "" {
+			if _, ok := pairs[org]; !ok {
+				pairs[org] = pickFake(fakeCompanies, org)
+			}
+			a.Volunteering[i].Organization = pairs[org]
+		}
+	}
+
+	// Recommenders, recipients and the authors of posts commented on are real people too
+	for i, r := range a.Recommendations {
+		a.Recommendations[i].Name = fakeName(r.Name, pairs)
+	}
+	for i, c := range a.Comments {
+		a.Comments[i].PostAuthor = fakeName(c.PostAuthor, pairs)
+	}
+
+	replace := replacer(pairs)
+	a.About = replace.Replace(a.About)
+	for i := range a.Posts {
+		a.Posts[i].Content = replace.Replace(a.Posts[i].Content)
+	}
+	for i := range a.ContactInfo.Websites {
+		a.ContactInfo.Websites[i] = replace.Replace(a.ContactInfo.Websites[i])
+	}
+	// Ways to reach the owner point straight at them
+	a.ContactInfo.Email, a.ContactInfo.Twitter, a.ContactInfo.Birthday = "", "", ""
+	if a.Company != nil {
+		for i := range a.Company.Posts {
+			a.Company.Posts[i].Content = replace.Replace(a.Company.Posts[i].Content)
+		}
+	}
+	for i := range a.Articles {
+		a.Articles[i].Title = replace.Replace(a.Articles[i].Title)
+		a.Articles[i].Excerpt = replace.Replace(a.Articles[i].Excerpt)
+	}
+	for i := range a.Comments {
+		a.Comments[i].Content = replace.Replace(a.Comments[i].Content)
+		a.Comments[i].PostExcerpt = replace.Replace(a.Comments[i].PostExcerpt)
+	}
+	for i := range a.Recommendations {
+		a.Recommendations[i].Relationship = replace.Replace(a.Recommendations[i].Relationship)
+		a.Recommendations[i].Text = replace.Replace(a.Recommendations[i].Text)
+	}
+	return a
+}
+
+// fakeName returns a fake for a person's name and records the replacements for it and its
+// parts in pairs. Parts already mapped keep their first replacement.
+func fakeName(name string, pairs map[string]string) string {
+	parts := strings.Fields(name)
+	if len(parts) == 0 {
+		return name
+	}
+	fake := make([]string, len(parts))
+	for i, part := range parts {
+		pool := fakeLastNames
+		if i == 0 {
+			pool = fakeFirstNames
+		}
+		fake[i] = pickFake(pool, part)
+		// Initials and short particles ("de", "K.") would hit unrelated words
+		if _, taken := pairs[part]; !taken && len(part) >= 3 {
+			pairs[part] = fake[i]
+		}
+	}
+	pairs[strings.Join(parts, " ")] = strings.Join(fake, " ")
+	return strings.Join(fake, " ")
+}
+
+// pickFake deterministically picks the entry of pool closest in length to original, never original itself.
+func pickFake(pool []string, original string) string {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(original)))
+	start := int(h.Sum32() % uint32(len(pool)))
+
+	best, bestDiff := "", -1
+	for i := range pool {
+		candidate := pool[(start+i)%len(pool)]
+		if strings.EqualFold(candidate, original) {
+			continue
+		}
+		diff := len(candidate) - len(original)
+		if diff < 0 {
+			diff = -diff
+		}
+		if bestDiff < 0 || diff < bestDiff {
+			best, bestDiff = candidate, diff
+		}
+	}
+	return best
+}
+
+// replacer builds a strings.Replacer that tries longer originals first, so a full
+// name is replaced as a whole before its parts are.
+func replacer(pairs map[string]string) *strings.Replacer {
+	originals := make([]string, 0, len(pairs))
+	for original := range pairs {
+		originals = append(originals, original)
+	}
+	sort.Slice(originals, func(i, j int) bool {
+		if len(originals[i]) != len(originals[j]) {
+			return len(originals[i]) > len(originals[j])
+		}
+		return originals[i] < originals[j]
+	})
+	args := make([]string, 0, 2*len(originals))
+	for _, original := range originals {
+		args = append(args, original, pairs[original])
+	}
+	return strings.NewReplacer(args...)
+}
@@ -0,0 +1,216 @@
+package scraper
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/dom"
+	"github.com/chromedp/cdproto/input"
+	"github.com/chromedp/chromedp"
+)
+
+/*
+	Behavior is how the browser acts between reading pages.
+
+Fixed pauses, forms filled in an instant and clicks that land without the
+mouse ever moving are what bot detection looks for first. Browsers following a
+Behavior stay on every page for a random time, drifting the mouse and
+scrolling a little while they do, type the login a key at a time with uneven
+delays, and glide the mouse onto buttons before clicking them. Zero values
+disable the respective behavior.
+*/
+type Behavior struct {
+	DwellMin    time.Duration // Shortest time spent on a page after it loaded, before reading it
+	DwellMax    time.Duration // Longest time spent on a page
+	KeyDelayMin time.Duration // Shortest delay between keystrokes while typing
+	KeyDelayMax time.Duration // Longest delay between keystrokes, 0 fills fields at once
+	Gestures    bool          // Move the mouse and scroll while dwelling, and glide onto buttons before clicking
+}
+
+// Human is the behavior of every browser's tabs. Sections are given at least
+// MinSectionTime, so DwellMax should stay well below it.
+var Human = Behavior{
+	DwellMin:    1500 * time.Millisecond,
+	DwellMax:    3500 * time.Millisecond,
+	KeyDelayMin: 60 * time.Millisecond,
+	KeyDelayMax: 220 * time.Millisecond,
+	Gestures:    true,
+}
+
+type point struct{ x, y float64 }
+
+// between returns a random duration from lo to hi, lo when hi is not above it.
+func between(lo, hi time.Duration) time.Duration {
+	if hi <= lo {
+		return lo
+	}
+	return lo + rand.N(hi-lo)
+}
+
+// sleep waits for d unless ctx ends first.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pause waits for a random time around typical, for pages to react to a click or scroll.
+func pause(typical time.Duration) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		return sleep(ctx, between(typical*3/4, typical*3/2))
+	})
+}
+
+/*
+	dwell stays on the loaded page for a random time from Human.DwellMin to Human.DwellMax.
+
+With Human.Gestures the time is spent in bursts of mouse drift and small
+scrolls, as someone reading the page would. Gestures that fail are skipped,
+they are never worth failing a section over.
+*/
+func dwell() chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		behavior := Human
+		total := between(behavior.DwellMin, behavior.DwellMax)
+		if !behavior.Gestures {
+			return sleep(ctx, total)
+		}
+		deadline := time.Now().Add(total)
+		width, height := viewport(ctx)
+		at := point{rand.Float64() * width, rand.Float64() * height}
+		for remaining := total; remaining > 0; remaining = time.Until(deadline) {
+			if rand.IntN(3) == 0 {
+				input.DispatchMouseEvent(input.MouseWheel, at.x, at.y).
+					WithDeltaX(0).
+					WithDeltaY(float64(80 + rand.IntN(320))).
+					Do(ctx)
+			} else {
+				to := point{rand.Float64() * width, rand.Float64() * height}
+				glide(ctx, at, to)
+				at = to
+			}
+			if err := sleep(ctx, min(between(200*time.Millisecond, 900*time.Millisecond), max(time.Until(deadline), 0))); err != nil {
+				return err
+			}
+		}
+		return ctx.Err()
+	})
+}
+
+// viewport returns the tab's inner size, a common laptop size when it can't be read.
+func viewport(ctx context.Context) (float64, float64) {
+	var size []float64
+	if err := evaluate(`[window.innerWidth, window.innerHeight]`, &size).Do(ctx); err != nil || len(size) != 2 || size[0] <= 0 || size[1] <= 0 {
+		return 1366, 768
+	}
+	return size[0], size[1]
+}
+
+/*
+	typeText types text into the field sel selects a key at a time.
+
+The delays between keys vary from Human.KeyDelayMin to Human.KeyDelayMax, with
+the odd longer hesitation. Without key delays the field is filled at once.
+*/
+func typeText(sel, text string) chromedp.Action {
+	behavior := Human
+	if behavior.KeyDelayMax <= 0 {
+		return chromedp.SendKeys(rewritten(sel), text)
+	}
+	return chromedp.Tasks{
+		click(sel),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			for _, r := range text {
+				if err := chromedp.KeyEvent(string(r)).Do(ctx); err != nil {
+					return err
+				}
+				delay := between(behavior.KeyDelayMin, behavior.KeyDelayMax)
+				if rand.IntN(12) == 0 {
+					delay *= 3
+				}
+				if err := sleep(ctx, delay); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+	}
+}
+
+// click clicks the element sel selects, after gliding the mouse onto a random spot
+// near its middle with Human.Gestures.
+func click(sel string) chromedp.Action {
+	sel = rewritten(sel)
+	if !Human.Gestures {
+		return chromedp.Click(sel)
+	}
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		var nodes []*cdp.Node
+		if err := chromedp.Run(ctx, chromedp.ScrollIntoView(sel), chromedp.Nodes(sel, &nodes, chromedp.NodeVisible)); err != nil {
+			return err
+		}
+		quads, err := dom.GetContentQuads().WithNodeID(nodes[0].NodeID).Do(ctx)
+		if err != nil || len(quads) == 0 || len(quads[0]) != 8 {
+			// No box to aim at, let chromedp find the middle
+			return chromedp.MouseClickNode(nodes[0]).Do(ctx)
+		}
+		q := quads[0]
+		w, h := math.Abs(q[2]-q[0]), math.Abs(q[5]-q[1])
+		to := point{
+			(q[0]+q[2]+q[4]+q[6])/4 + (rand.Float64()-0.5)*w/2,
+			(q[1]+q[3]+q[5]+q[7])/4 + (rand.Float64()-0.5)*h/2,
+		}
+		angle := rand.Float64() * 2 * math.Pi
+		distance := 100 + rand.Float64()*200
+		glide(ctx, point{to.x + math.Cos(angle)*distance, to.y + math.Sin(angle)*distance}, to)
+		if err := sleep(ctx, between(50*time.Millisecond, 250*time.Millisecond)); err != nil {
+			return err
+		}
+		return chromedp.MouseClickXY(to.x, to.y).Do(ctx)
+	})
+}
+
+// glide moves the mouse from one point to another along mousePath.
+func glide(ctx context.Context, from, to point) {
+	steps := 12 + rand.IntN(14)
+	for _, p := range mousePath(from, to, steps, rand.Float64()) {
+		if input.DispatchMouseEvent(input.MouseMoved, p.x, p.y).Do(ctx) != nil {
+			return
+		}
+		if sleep(ctx, between(8*time.Millisecond, 24*time.Millisecond)) != nil {
+			return
+		}
+	}
+}
+
+/*
+	mousePath returns steps points along a curve from one point to another, ending at to.
+
+The curve is a quadratic Bézier bent to one side by up to a quarter of the
+distance, bend from 0 to 1 choosing how far and which side, and is eased so
+the mouse speeds up and slows down again as a hand does.
+*/
+func mousePath(from, to point, steps int, bend float64) []point {
+	dx, dy := to.x-from.x, to.y-from.y
+	offset := (bend - 0.5) / 2
+	control := point{from.x + dx/2 - dy*offset, from.y + dy/2 + dx*offset}
+	path := make([]point, 0, steps)
+	for i := 1; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		t = t * t * (3 - 2*t)
+		u := 1 - t
+		path = append(path, point{
+			u*u*from.x + 2*u*t*control.x + t*t*to.x,
+			u*u*from.y + 2*u*t*control.y + t*t*to.y,
+		})
+	}
+	return path
+}
@@ -0,0 +1,321 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+/*
+	Section identifies a part of a LinkedIn profile that can be scraped on its own.
+
+Sections are listed from most to least important for message generation.
+*/
+type Section string
+
+const (
+	SectionNameAndLocation Section = "nameAndLocation"
+	SectionPosts           Section = "posts"
+	SectionArticles        Section = "articles"
+	SectionComments        Section = "comments"
+	SectionExperience      Section = "experience"
+	SectionCompany         Section = "company"
+	SectionEducation       Section = "education"
+	SectionRecommendations Section = "recommendations"
+	SectionSkills          Section = "skills"
+	SectionCertifications  Section = "certifications"
+	SectionVolunteering    Section = "volunteering"
+	SectionPublications    Section = "publications"
+	SectionPatents         Section = "patents"
+	SectionLanguages       Section = "languages"
+	SectionContactInfo     Section = "contactInfo"
+	SectionAbout           Section = "about"
+)
+
+// PageOrder lists every section in the order they have to be scraped in: About reads the
+// profile page opened by NameAndLocation and Company the employer link it found, the others
+// navigate to their own page.
+var PageOrder = []Section{
+	SectionNameAndLocation, SectionAbout, SectionPosts, SectionArticles, SectionComments, SectionExperience, SectionEducation,
+	SectionSkills, SectionCertifications, SectionRecommendations, SectionVolunteering,
+	SectionPublications, SectionPatents, SectionLanguages, SectionCompany, SectionContactInfo,
+}
+
+// sectionPriority ranks sections for budget decisions; lower runs, higher is skipped first.
+var sectionPriority = map[Section]int{
+	SectionNameAndLocation: 0,
+	SectionPosts:           1,
+	SectionArticles:        2,
+	SectionComments:        3,
+	SectionExperience:      4,
+	SectionCompany:         5,
+	SectionEducation:       6,
+	SectionRecommendations: 7,
+	SectionSkills:          8,
+	SectionCertifications:  9,
+	SectionPublications:    10,
+	SectionPatents:         11,
+	SectionVolunteering:    12,
+	SectionLanguages:       13,
+	SectionContactInfo:     14,
+	SectionAbout:           15,
+}
+
+// MinSectionTime is the smallest slice of a budget worth giving to a section:
+// every section dwells on its page for up to Human.DwellMax before extracting.
+const MinSectionTime = 5 * time.Second
+
+/*
+	SectionResult records what happened to a single section during a budgeted scrape.
+
+Err is nil for sections that completed; Skipped is true for sections dropped
+because the budget could not accommodate them.
+*/
+type SectionResult struct {
+	Section Section       `json:"section"`
+	Skipped bool          `json:"skipped,omitempty"`
+	Err     error         `json:"-"`
+	Took    time.Duration `json:"took"`
+}
+
+/*
+	ScrapeWithBudget scrapes sections in the given order within budget.
+
+Before each section the remaining time is checked. If it cannot give every
+pending section MinSectionTime, the lowest-priority pending sections are
+skipped until it can. The running section may use everything not reserved for
+the sections still after it, so time left over by fast sections flows to the
+rest. Sections that depend on page state (About reads the profile page opened
+by NameAndLocation) must be passed after the section that opens it.
+
+Parameters:
+  - ctx: Ends the scrape early; sections not started by then carry its error
+  - budget: Total time allowed for all sections
+  - sections: Sections to scrape, in execution order
+
+Returns:
+  - []SectionResult: One result per requested section, in the given order
+*/
+func (s *Scraper) ScrapeWithBudget(ctx context.Context, budget time.Duration, sections ...Section) []SectionResult {
+	deadline := time.Now().Add(budget)
+	results := make([]SectionResult, len(sections))
+	pending := make([]int, 0, len(sections))
+	for i, section := range sections {
+		results[i].Section = section
+		pending = append(pending, i)
+	}
+
+	for len(pending) > 0 {
+		if err := ctx.Err(); err != nil {
+			for _, i := range pending {
+				results[i].Err = err
+			}
+			break
+		}
+		remaining := time.Until(deadline)
+		for len(pending) > 0 && remaining < time.Duration(len(pending))*MinSectionTime {
+			drop := lowestPriority(sections, pending)
+			results[pending[drop]].Skipped = true
+			fmt.Printf("Budget exhausted, skipping %s\n", sections[pending[drop]])
+			pending = append(pending[:drop], pending[drop+1:]...)
+		}
+		if len(pending) == 0 {
+			break
+		}
+
+		i := pending[0]
+		pending = pending[1:]
+		timeout := remaining - time.Duration(len(pending))*MinSectionTime
+
+		start := time.Now()
+		results[i].Err = s.scrapeSection(ctx, sections[i], timeout)
+		results[i].Took = time.Since(start)
+	}
+	return results
+}
+
+/*
+	ScrapeAll scrapes every given section in order, carrying on past failed ones.
+
+Nothing is skipped for time, each section only ends with ctx; use
+ScrapeWithBudget when a scrape has to fit in one. Sections that depend on page
+state must be passed after the section that opens it, as for ScrapeWithBudget.
+
+Parameters:
+  - ctx: Ends the scrape early; sections not started by then fail with its error
+  - sections: Sections to scrape, in execution order
+
+Returns:
+  - Profile: What was scraped, failed sections left empty; set even with an error
+  - error: SectionErrors naming every section that failed, nil when none did
+*/
+func (s *Scraper) ScrapeAll(ctx context.Context, sections ...Section) (Profile, error) {
+	results := make([]SectionResult, len(sections))
+	for i, section := range sections {
+		start := time.Now()
+		results[i] = SectionResult{Section: section, Err: s.getter(section)(ctx), Took: time.Since(start)}
+	}
+	return s.Profile(), Errors(results)
+}
+
+// getter returns the exported method scraping section on its own.
+func (s *Scraper) getter(section Section) func(context.Context) error {
+	switch section {
+	case SectionNameAndLocation:
+		return s.GetNameAndLocation
+	case SectionPosts:
+		return s.GetRecentPosts
+	case SectionArticles:
+		return s.GetArticles
+	case SectionComments:
+		return s.GetRecentComments
+	case SectionCompany:
+		return s.GetCompany
+	case SectionContactInfo:
+		return s.GetContactInfo
+	case SectionExperience:
+		return s.GetExperiences
+	case SectionEducation:
+		return s.GetEducation
+	case SectionSkills:
+		return s.GetSkills
+	case SectionCertifications:
+		return s.GetCertifications
+	case SectionRecommendations:
+		return s.GetRecommendations
+	case SectionVolunteering:
+		return s.GetVolunteering
+	case SectionPublications:
+		return s.GetPublications
+	case SectionPatents:
+		return s.GetPatents
+	case SectionLanguages:
+		return s.GetLanguages
+	case SectionAbout:
+		return s.GetAbout
+	}
+	return func(context.Context) error { return fmt.Errorf("unknown section %q", section) }
+}
+
+// SectionError is a section that failed to scrape and why.
+type SectionError struct {
+	Section Section
+	Err     error
+}
+
+func (e *SectionError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Section, e.Err)
+}
+
+func (e *SectionError) Unwrap() error {
+	return e.Err
+}
+
+// SectionErrors are the sections of a scrape that failed, in the order they were scraped.
+// errors.Is and errors.As look through every one of them.
+type SectionErrors []*SectionError
+
+func (e SectionErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e SectionErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Sections lists the sections that failed, which the scraped profile is missing.
+func (e SectionErrors) Sections() []Section {
+	sections := make([]Section, len(e))
+	for i, err := range e {
+		sections[i] = err.Section
+	}
+	return sections
+}
+
+// Failed returns why section failed, nil when it didn't.
+func (e SectionErrors) Failed(section Section) error {
+	for _, err := range e {
+		if err.Section == section {
+			return err.Err
+		}
+	}
+	return nil
+}
+
+// Errors collects the failed sections of results as SectionErrors, nil when none failed.
+// Skipped sections didn't fail, they were never tried.
+func Errors(results []SectionResult) error {
+	var errs SectionErrors
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, &SectionError{Section: r.Section, Err: r.Err})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func (s *Scraper) scrapeSection(ctx context.Context, section Section, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ctx, release := s.within(ctx)
+	defer release()
+
+	switch section {
+	case SectionNameAndLocation:
+		return s.withRelogin(ctx, s.getNameAndLocation)
+	case SectionPosts:
+		return s.withRelogin(ctx, s.getRecentPosts)
+	case SectionArticles:
+		return s.withRelogin(ctx, s.getArticles)
+	case SectionComments:
+		return s.withRelogin(ctx, s.getRecentComments)
+	case SectionCompany:
+		return s.withRelogin(ctx, s.getCompany)
+	case SectionContactInfo:
+		return s.withRelogin(ctx, s.getContactInfo)
+	case SectionExperience:
+		return s.withRelogin(ctx, s.getExperiences)
+	case SectionEducation:
+		return s.withRelogin(ctx, s.getEducation)
+	case SectionSkills:
+		return s.withRelogin(ctx, s.getSkills)
+	case SectionCertifications:
+		return s.withRelogin(ctx, s.getCertifications)
+	case SectionRecommendations:
+		return s.withRelogin(ctx, s.getRecommendations)
+	case SectionVolunteering:
+		return s.withRelogin(ctx, s.getVolunteering)
+	case SectionPublications:
+		return s.withRelogin(ctx, s.getPublications)
+	case SectionPatents:
+		return s.withRelogin(ctx, s.getPatents)
+	case SectionLanguages:
+		return s.withRelogin(ctx, s.getLanguages)
+	case SectionAbout:
+		return s.getAbout(ctx)
+	}
+	return fmt.Errorf("unknown section %q", section)
+}
+
+// lowestPriority returns the position in pending of the least important section.
+func lowestPriority(sections []Section, pending []int) int {
+	worst := 0
+	for pos, i := range pending {
+		if sectionPriority[sections[i]] >= sectionPriority[sections[pending[worst]]] {
+			worst = pos
+		}
+	}
+	return worst
+}
@@ -0,0 +1,179 @@
+package scraper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/chromedp/chromedp"
+)
+
+/*
+	CaptureDir, when set, makes successfully scraped sections save anonymized fixtures.
+
+Each section writes the page it was extracted from and the extracted result:
+
+	<CaptureDir>/<section>/<id>.html
+	<CaptureDir>/<section>/<id>.json
+
+The id is derived from the profile URL, so re-capturing a profile replaces its
+fixtures. Pages are anonymized before they are written: scripts are dropped,
+and the profile's name, URL slug, member ids, emails and phone numbers are
+replaced with placeholders, as are the names of other people a section lists
+(recommenders, for example). Sections scraped before the name is known are not
+captured, as they could not be anonymized.
+
+Only set this with the consent of the account owner and the people scraped.
+*/
+var CaptureDir = ""
+
+/*
+	SnapshotDir, when set, makes successfully scraped sections save the page they were extracted from as it was.
+
+Each profile gets a directory of its sections, which pkg/parser extracts the
+profile from again without a browser:
+
+	<SnapshotDir>/<id>/<section>.html
+
+The id is the one CaptureDir uses, and a later scrape of the profile replaces
+its pages. Unlike fixtures nothing is anonymized, so only set this with the
+consent of the people scraped.
+*/
+var SnapshotDir = ""
+
+// Placeholders written into captured fixtures.
+const (
+	captureFirstName = "Alex"
+	captureLastName  = "Sample"
+	captureSlug      = "alex-sample"
+
+	// Anyone else named on a captured page
+	captureOtherFirstName = "Jordan"
+	captureOtherLastName  = "Example"
+)
+
+var (
+	scriptRe   = regexp.MustCompile(`(?is)<script\b[^>]*>.*?</script>`)
+	memberIDRe = regexp.MustCompile(`ACoAA[A-Za-z0-9_-]+`)
+	emailRe    = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	// International numbers or ten plain digits; looser patterns would eat date ranges like "2016 - 2020"
+	phoneRe = regexp.MustCompile(`\+\d[\d\s().-]{7,}\d|\b\d{10}\b`)
+)
+
+// capture saves the current page and result for section when CaptureDir is set, and the
+// page alone when SnapshotDir is. others are the names of anyone else on the page.
+// Failures are printed and otherwise ignored, capturing never fails a scrape.
+func (s *Scraper) capture(ctx context.Context, section Section, result any, others ...string) {
+	s.snapshot(ctx, section)
+	if CaptureDir == "" {
+		return
+	}
+	name := s.Profile().Name
+	if strings.TrimSpace(name) == "" {
+		return
+	}
+
+	var html string
+	if err := chromedp.Run(ctx, chromedp.OuterHTML("html", &html, chromedp.ByQuery)); err != nil {
+		fmt.Printf("Could not capture %s fixture: %v\n", section, err)
+		return
+	}
+	raw, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		fmt.Printf("Could not capture %s fixture: %v\n", section, err)
+		return
+	}
+
+	slug := path.Base(strings.TrimSuffix(s.url(), "/"))
+	anonymize := anonymizer(name, slug, others...)
+	base := filepath.Join(CaptureDir, string(section), fixtureID(s.url()))
+
+	if err := os.MkdirAll(filepath.Dir(base), 0o700); err != nil {
+		fmt.Printf("Could not capture %s fixture: %v\n", section, err)
+		return
+	}
+	if err := os.WriteFile(base+".html", []byte(anonymize(scriptRe.ReplaceAllString(html, ""))), 0o600); err != nil {
+		fmt.Printf("Could not capture %s fixture: %v\n", section, err)
+		return
+	}
+	if err := os.WriteFile(base+".json", []byte(anonymize(string(raw))), 0o600); err != nil {
+		fmt.Printf("Could not capture %s fixture: %v\n", section, err)
+	}
+}
+
+// snapshot saves the current page for section when SnapshotDir is set.
+func (s *Scraper) snapshot(ctx context.Context, section Section) {
+	if SnapshotDir == "" {
+		return
+	}
+	var html string
+	if err := chromedp.Run(ctx, chromedp.OuterHTML("html", &html, chromedp.ByQuery)); err != nil {
+		fmt.Printf("Could not snapshot %s: %v\n", section, err)
+		return
+	}
+	file := filepath.Join(SnapshotDir, fixtureID(s.url()), filepath.FromSlash(string(section))+".html")
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		fmt.Printf("Could not snapshot %s: %v\n", section, err)
+		return
+	}
+	if err := os.WriteFile(file, []byte(html), 0o600); err != nil {
+		fmt.Printf("Could not snapshot %s: %v\n", section, err)
+	}
+}
+
+// fixtureID is the id the pages of a profile are saved under, derived from its URL slug.
+func fixtureID(profileURL string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(path.Base(strings.TrimSuffix(profileURL, "/")))))
+	return hex.EncodeToString(sum[:6])
+}
+
+// anonymizer returns a function replacing the identifying parts of a profile in captured text.
+func anonymizer(name, slug string, others ...string) func(string) string {
+	pairs := []string{}
+	if slug != "" && slug != "." && slug != "/" {
+		pairs = append(pairs, slug, captureSlug)
+	}
+	// Full names go first so they are replaced whole, their parts only after all of them
+	pairs = append(pairs, strings.TrimSpace(name), captureFirstName+" "+captureLastName)
+	for _, other := range others {
+		if other = strings.TrimSpace(other); other != "" {
+			pairs = append(pairs, other, captureOtherFirstName+" "+captureOtherLastName)
+		}
+	}
+	pairs = append(pairs, nameParts(name, captureFirstName, captureLastName)...)
+	for _, other := range others {
+		pairs = append(pairs, nameParts(other, captureOtherFirstName, captureOtherLastName)...)
+	}
+	replacer := strings.NewReplacer(pairs...)
+
+	return func(text string) string {
+		text = replacer.Replace(text)
+		text = memberIDRe.ReplaceAllString(text, "ACoAAMEMBER")
+		text = emailRe.ReplaceAllString(text, "alex.sample@example.com")
+		return phoneRe.ReplaceAllString(text, "+00 0000 000000")
+	}
+}
+
+// nameParts returns replacer pairs mapping each part of name to the first or last placeholder.
+func nameParts(name, first, last string) []string {
+	var pairs []string
+	for i, part := range strings.Fields(name) {
+		// Initials and short particles ("de", "K.") would hit unrelated words
+		if len(part) < 3 {
+			continue
+		}
+		placeholder := last
+		if i == 0 {
+			placeholder = first
+		}
+		pairs = append(pairs, part, placeholder)
+	}
+	return pairs
+}
@@ -0,0 +1,102 @@
+package scraper
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ExecPath is the browser binary NewScraper starts, usually the one FindChrome returned.
+// Empty leaves the choice to chromedp.
+var ExecPath = ""
+
+// RemoteURL is the DevTools endpoint of a running browser NewScraper attaches to instead of
+// starting ExecPath, such as a browserless or Docker sidecar's ws://chrome:3000. Empty starts
+// browsers locally.
+var RemoteURL = ""
+
+// UserDataDir, when set, is where the browsers NewScraper starts keep their Chrome profiles
+// between runs, each account in a directory of its own (see AccountDataDir), so cookies,
+// local storage and the device trust LinkedIn grants after a checkpoint survive restarts.
+// Chrome locks a profile while it runs: an account's scrapers must not overlap, which the
+// server's account leases see to. Remote browsers keep their own profiles.
+var UserDataDir = ""
+
+// ErrChromeNotFound is returned by FindChrome when no Chrome or Chromium binary is installed.
+var ErrChromeNotFound = errors.New("no Chrome or Chromium binary found")
+
+/*
+	AccountDataDir returns the Chrome profile directory of email's browsers under base.
+
+The directory is named after the lowercased email, with characters that are not
+safe in file names replaced by underscores.
+
+Parameters:
+  - base: The directory holding every account's profile, usually UserDataDir
+  - email: LinkedIn account email
+
+Returns:
+  - string: The account's profile directory, "" when base is ""
+*/
+func AccountDataDir(base, email string) string {
+	if base == "" {
+		return ""
+	}
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || strings.ContainsRune("@.-_+", r) {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(strings.TrimSpace(email)))
+	// Names such as ".." would point outside base
+	if name == "" || name[0] == '.' {
+		name = "_" + name
+	}
+	return filepath.Join(base, name)
+}
+
+/*
+	FindChrome returns the browser binary NewScraper will start when ExecPath is not set.
+
+It searches the same locations as chromedp, so a server that passes this
+check at startup can also open a browser on its first request.
+
+Returns:
+  - string: Path of the browser binary
+  - error: ErrChromeNotFound if none of the known locations exist
+*/
+func FindChrome() (string, error) {
+	var locations []string
+	switch runtime.GOOS {
+	case "darwin":
+		locations = []string{
+			"/Applications/Chromium.app/Contents/MacOS/Chromium",
+			"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+		}
+	case "windows":
+		locations = []string{
+			"chrome",
+			"chrome.exe",
+			`C:\Program Files (x86)\Google\Chrome\Application\chrome.exe`,
+			`C:\Program Files\Google\Chrome\Application\chrome.exe`,
+			filepath.Join(os.Getenv("USERPROFILE"), `AppData\Local\Google\Chrome\Application\chrome.exe`),
+			filepath.Join(os.Getenv("USERPROFILE"), `AppData\Local\Chromium\Application\chrome.exe`),
+		}
+	default:
+		locations = []string{
+			"headless_shell", "headless-shell", "chromium", "chromium-browser",
+			"google-chrome", "google-chrome-stable", "google-chrome-beta", "google-chrome-unstable",
+			"/usr/bin/google-chrome", "/usr/local/bin/chrome", "/snap/bin/chromium", "chrome",
+		}
+	}
+
+	for _, path := range locations {
+		if found, err := exec.LookPath(path); err == nil {
+			return found, nil
+		}
+	}
+	return "", ErrChromeNotFound
+}
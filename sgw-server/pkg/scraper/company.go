@@ -0,0 +1,114 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/chromedp/chromedp"
+)
+
+// companyPosts caps how many recent company posts are kept.
+const companyPosts = 3
+
+// companyPageRe matches the root of a LinkedIn company page, dropping tabs and tracking parameters.
+var companyPageRe = regexp.MustCompile(`^https://(?:[a-z]+\.)?linkedin\.com/company/[^/?#]+`)
+
+/*
+	Company represents the LinkedIn page of a profile owner's current employer.
+
+It contains what the page's About tab lists and the company's latest posts.
+*/
+type Company struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Industry string `json:"industry,omitempty"`
+	Size     string `json:"size,omitempty"` // e.g. "51-200 employees"
+	About    string `json:"about,omitempty"`
+	Posts    []Post `json:"posts,omitempty"`
+}
+
+// CompanyPage returns the root of the company page href links to, or "" when it is not one.
+func CompanyPage(href string) string {
+	root := companyPageRe.FindString(href)
+	if root == "" {
+		return ""
+	}
+	return root + "/"
+}
+
+/*
+	GetCompany scrapes the page of the profile owner's current employer.
+
+The page is the one the top card's experience links to, so GetNameAndLocation
+has to run first; profiles without a company page leave Company nil. Companies
+are remembered for the scraper's lifetime, since many prospects in a batch
+share an employer. The result is stored in the scraped profile's Company.
+
+Returns:
+  - error: Any error encountered while fetching the company page
+*/
+func (s *Scraper) GetCompany(ctx context.Context) error {
+	return s.run(ctx, SectionCompany, s.getCompany)
+}
+
+func (s *Scraper) getCompany(ctx context.Context) error {
+	fmt.Println("Getting company")
+	url := s.Profile().CompanyURL
+	if url == "" {
+		return nil
+	}
+	s.mu.Lock()
+	cached, ok := s.companies[url]
+	s.mu.Unlock()
+	if ok {
+		s.update(func(p *Profile) { p.Company = cached.clone() })
+		return nil
+	}
+
+	var about struct {
+		Name     string `json:"name"`
+		Industry string `json:"industry"`
+		Size     string `json:"size"`
+		About    string `json:"about"`
+	}
+	err := chromedp.Run(ctx,
+		navigate(url+"about/"),
+		dwell(),
+		waitVisible(`main`, chromedp.ByQuery),
+		evaluate(script("company"), &about),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get company: %w", err)
+	}
+
+	var found []scrapedPost
+	err = chromedp.Run(ctx,
+		navigate(url+"posts/"),
+		dwell(),
+		expandSeeMore(Selector("post")),
+		evaluate(script("recent-posts"), &found),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get company posts: %w", err)
+	}
+
+	company := &Company{Name: strings.TrimSpace(about.Name), URL: url, Industry: about.Industry, Size: about.Size, About: about.About}
+	company.Posts = s.readPosts(ctx, found, companyPosts)
+	s.mu.Lock()
+	s.companies[url] = company.clone()
+	s.mu.Unlock()
+	s.update(func(p *Profile) { p.Company = company })
+	s.capture(ctx, SectionCompany, company)
+	return nil
+}
+
+func (c *Company) clone() *Company {
+	if c == nil {
+		return nil
+	}
+	copied := *c
+	copied.Posts = append([]Post(nil), c.Posts...)
+	return &copied
+}
@@ -0,0 +1,97 @@
+package scraper
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// linkedInLinkRe matches links back into LinkedIn, which aren't the owner's websites.
+	linkedInLinkRe = regexp.MustCompile(`(?i)^https?://([a-z]+\.)?linkedin\.com(/|$)`)
+	// twitterRe matches a Twitter or X profile link, capturing the handle.
+	twitterRe = regexp.MustCompile(`(?i)^https?://(?:www\.|mobile\.)?(?:twitter|x)\.com/@?([A-Za-z0-9_]{1,15})/?(?:[?#].*)?$`)
+	// handleRe matches a Twitter handle as written, with or without "@".
+	handleRe = regexp.MustCompile(`^@?([A-Za-z0-9_]{1,15})$`)
+)
+
+/*
+	ContactInfo represents what a profile's contact info overlay shows.
+
+The email and birthday are only shown to the owner's connections, so they are
+often empty. Phone numbers and addresses are never read.
+*/
+type ContactInfo struct {
+	Websites []string `json:"websites,omitempty"` // Links outside LinkedIn, e.g. GitHub or a blog
+	Email    string   `json:"email,omitempty"`
+	Twitter  string   `json:"twitter,omitempty"`  // Handle without the "@"
+	Birthday string   `json:"birthday,omitempty"` // As shown, e.g. "March 14"
+}
+
+// contactSection is one labelled section of the contact info overlay, e.g. "Website" with its links.
+type contactSection struct {
+	Label  string   `json:"label"`
+	Values []string `json:"values"`
+	Links  []string `json:"links"`
+}
+
+/*
+	readContactInfo reads the sections of the contact info overlay.
+
+Sections are recognised by their label. Links outside LinkedIn that are neither
+the email nor a Twitter profile are websites, kept once each in the order shown;
+the profile's own LinkedIn URL, listed first, is left out like every other
+LinkedIn link.
+*/
+func readContactInfo(sections []contactSection) ContactInfo {
+	var contact ContactInfo
+	for _, section := range sections {
+		label := strings.ToLower(section.Label)
+		switch {
+		case strings.Contains(label, "email"):
+			for _, link := range section.Links {
+				if email, ok := strings.CutPrefix(link, "mailto:"); ok && contact.Email == "" {
+					contact.Email = email
+				}
+			}
+			if contact.Email == "" && len(section.Values) > 0 && strings.Contains(section.Values[0], "@") {
+				contact.Email = section.Values[0]
+			}
+			continue
+		case strings.Contains(label, "twitter") || label == "x":
+			for _, link := range section.Links {
+				if m := twitterRe.FindStringSubmatch(link); m != nil && contact.Twitter == "" {
+					contact.Twitter = m[1]
+				}
+			}
+			for _, value := range section.Values {
+				if m := handleRe.FindStringSubmatch(value); m != nil && contact.Twitter == "" {
+					contact.Twitter = m[1]
+				}
+			}
+			continue
+		case strings.Contains(label, "birthday"):
+			if len(section.Values) > 0 {
+				contact.Birthday = section.Values[0]
+			}
+			continue
+		case strings.Contains(label, "phone") || strings.Contains(label, "address"):
+			continue
+		}
+
+		for _, link := range section.Links {
+			if !strings.HasPrefix(link, "http") || linkedInLinkRe.MatchString(link) {
+				continue
+			}
+			if m := twitterRe.FindStringSubmatch(link); m != nil {
+				contact.Twitter = cmp.Or(contact.Twitter, m[1])
+				continue
+			}
+			if !slices.Contains(contact.Websites, link) {
+				contact.Websites = append(contact.Websites, link)
+			}
+		}
+	}
+	return contact
+}
@@ -0,0 +1,30 @@
+package scraper
+
+import "errors"
+
+// ErrNotAuthenticated is returned when LinkedIn no longer treats the session as
+// logged in, typically after a redirect to the login page or the authwall.
+var ErrNotAuthenticated = errors.New("linkedin session is not authenticated")
+
+// ErrVerificationRequired is returned when LinkedIn stops a login at a security
+// checkpoint that has to be solved by hand in a visible browser.
+var ErrVerificationRequired = errors.New("linkedin requires a security verification")
+
+// ErrAccountRestricted is returned when LinkedIn shows the account as temporarily
+// restricted or banned. Retrying only makes it worse, the owner has to act.
+var ErrAccountRestricted = errors.New("linkedin has restricted the account")
+
+// ErrBotDetected is returned when LinkedIn challenges a session that was already
+// logged in, which it does when it suspects the account is automated.
+var ErrBotDetected = errors.New("linkedin flagged the session as automated")
+
+// ErrRateLimited is returned when LinkedIn answers with a 429 or a page saying the
+// account made too many requests. It passes, but only if the account slows down.
+var ErrRateLimited = errors.New("linkedin is rate limiting the account")
+
+// ErrAuthwall is returned when LinkedIn won't show a public profile to a logged out
+// visitor, redirecting to the authwall or answering with its bot status 999.
+var ErrAuthwall = errors.New("linkedin requires signing in to see the profile")
+
+// ErrNotPublic is returned by a PublicScraper for sections public profiles don't show.
+var ErrNotPublic = errors.New("not shown on public linkedin profiles")
@@ -0,0 +1,82 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+/*
+	FailureDir, when set, makes failed sections save the page they failed on.
+
+Each failure writes a full-page screenshot and the page's HTML:
+
+	<FailureDir>/<time>-<section>-<id>.png
+	<FailureDir>/<time>-<section>-<id>.html
+
+The id is derived from the profile URL, as with CaptureDir, so the failures of
+one profile sort together by section. Unlike fixtures the pages are saved as
+they were, scripts and the people on them included, since that is what a broken
+selector is debugged against: keep the directory private and prune it.
+*/
+var FailureDir = ""
+
+// failureWait bounds saving a failure, so a browser that stopped answering doesn't hold the scraper.
+const failureWait = 15 * time.Second
+
+// saveFailure saves the current page when section failed with err and FailureDir is set.
+// Failures are printed and otherwise ignored, saving never changes the scrape's error.
+func (s *Scraper) saveFailure(section Section, err error) {
+	// A caller that gave up isn't a broken page
+	if FailureDir == "" || err == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	// The section's own context may be what ran out, the browser's outlives it
+	ctx, cancel := context.WithTimeout(s.ctx, failureWait)
+	defer cancel()
+
+	var shot []byte
+	if err := chromedp.Run(ctx, chromedp.FullScreenshot(&shot, 100)); err != nil {
+		fmt.Printf("Could not screenshot the failed %s page: %v\n", section, err)
+	}
+	var html string
+	if err := chromedp.Run(ctx, chromedp.Evaluate(`document.documentElement.outerHTML`, &html)); err != nil {
+		fmt.Printf("Could not save the failed %s page: %v\n", section, err)
+	}
+	if len(shot) == 0 && html == "" {
+		return
+	}
+	base, werr := writeFailure(FailureDir, section, s.url(), time.Now(), shot, html)
+	if werr != nil {
+		fmt.Printf("Could not save the failed %s page: %v\n", section, werr)
+		return
+	}
+	fmt.Printf("Saved the page %s failed on (%v) to %s\n", section, err, base)
+}
+
+// writeFailure writes a failed page's screenshot and HTML, whichever were taken, and
+// returns the path they share without its extension.
+func writeFailure(dir string, section Section, profileURL string, at time.Time, shot []byte, html string) (string, error) {
+	name := fmt.Sprintf("%s-%s-%s", at.UTC().Format("20060102T150405.000Z"), strings.ReplaceAll(string(section), "/", "-"), fixtureID(profileURL))
+	base := filepath.Join(dir, name)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	if len(shot) > 0 {
+		if err := os.WriteFile(base+".png", shot, 0o600); err != nil {
+			return "", err
+		}
+	}
+	if html != "" {
+		if err := os.WriteFile(base+".html", []byte(html), 0o600); err != nil {
+			return "", err
+		}
+	}
+	return base, nil
+}
@@ -0,0 +1,168 @@
+package scraper
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/chromedp/cdproto/browser"
+	"github.com/chromedp/cdproto/emulation"
+	"github.com/chromedp/chromedp"
+)
+
+// Viewport is the size of a browser's window contents, in CSS pixels.
+type Viewport struct {
+	Width  int
+	Height int
+}
+
+/*
+	Fingerprints are the pools the fingerprint of each new scraper is drawn from.
+
+Every browser, and every public scraper, sends the same user agent, window
+size, languages and timezone unless told otherwise, which ties the sessions of
+different accounts together. With Fingerprinting set each scraper picks one
+entry of every non-empty pool at random when it is created and keeps it for
+its lifetime, the visible browser of a security check included; with
+UserDataDir set an account draws the same entries every time. Empty pools
+leave the respective setting to Chrome.
+
+The scraper reads LinkedIn's English pages, so Languages should be English
+variants. User agents should be of Chrome, which is what the browser is and
+what its other headers say.
+*/
+type Fingerprints struct {
+	UserAgents []string
+	Viewports  []Viewport
+	Languages  []string // BCP 47 tags, e.g. "en-GB", sent first in Accept-Language and used as the locale
+	Timezones  []string // IANA names, e.g. "Asia/Kolkata"
+}
+
+// DefaultFingerprints are common desktop Chrome setups of English speaking users.
+var DefaultFingerprints = Fingerprints{
+	UserAgents: []string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/130.0.0.0 Safari/537.36",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/130.0.0.0 Safari/537.36",
+		"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36",
+	},
+	Viewports: []Viewport{{1920, 1080}, {1536, 864}, {1440, 900}, {1366, 768}, {1280, 800}},
+	Languages: []string{"en-US", "en-GB", "en-IN", "en-CA", "en-AU"},
+	Timezones: []string{"America/New_York", "America/Chicago", "America/Los_Angeles", "Europe/London", "Asia/Kolkata"},
+}
+
+// Fingerprinting, when set, gives every new scraper a fingerprint drawn from its pools.
+var Fingerprinting *Fingerprints
+
+// Fingerprint is what a scraper's browser presents itself as. Zero fields are left to Chrome.
+type Fingerprint struct {
+	UserAgent string
+	Viewport  Viewport
+	Language  string
+	Timezone  string
+}
+
+// Pick draws a fingerprint, one entry from every non-empty pool.
+func (f *Fingerprints) Pick() Fingerprint {
+	return f.pick(rand.N[int])
+}
+
+// pick draws a fingerprint with n, which returns a number in [0, its argument).
+func (f *Fingerprints) pick(n func(int) int) Fingerprint {
+	var fp Fingerprint
+	if len(f.UserAgents) > 0 {
+		fp.UserAgent = f.UserAgents[n(len(f.UserAgents))]
+	}
+	if len(f.Viewports) > 0 {
+		fp.Viewport = f.Viewports[n(len(f.Viewports))]
+	}
+	if len(f.Languages) > 0 {
+		fp.Language = f.Languages[n(len(f.Languages))]
+	}
+	if len(f.Timezones) > 0 {
+		fp.Timezone = f.Timezones[n(len(f.Timezones))]
+	}
+	return fp
+}
+
+// pickFingerprint draws the fingerprint of a new scraper for email, the zero Fingerprint
+// without Fingerprinting. With UserDataDir an account's browsers are one device across
+// restarts, so they draw the same fingerprint every time; other browsers draw at random.
+func pickFingerprint(email string) Fingerprint {
+	if Fingerprinting == nil {
+		return Fingerprint{}
+	}
+	if UserDataDir == "" || email == "" {
+		return Fingerprinting.Pick()
+	}
+	h := fnv.New64a()
+	h.Write([]byte(strings.ToLower(email)))
+	return Fingerprinting.pick(rand.New(rand.NewPCG(h.Sum64(), 0)).IntN)
+}
+
+// acceptLanguage is the Accept-Language header of the fingerprint's language, def without one.
+func (fp Fingerprint) acceptLanguage(def string) string {
+	if fp.Language == "" {
+		return def
+	}
+	base, _, _ := strings.Cut(fp.Language, "-")
+	if base == fp.Language {
+		return fp.Language + ";q=0.9"
+	}
+	return fp.Language + "," + base + ";q=0.9"
+}
+
+// platform is the navigator.platform of the user agent's operating system, "" when unknown.
+func (fp Fingerprint) platform() string {
+	switch ua := fp.UserAgent; {
+	case strings.Contains(ua, "Windows"):
+		return "Win32"
+	case strings.Contains(ua, "Macintosh"):
+		return "MacIntel"
+	case strings.Contains(ua, "Linux"):
+		return "Linux x86_64"
+	}
+	return ""
+}
+
+// emulate applies the fingerprint to the browser's tab. Overrides are set through DevTools
+// rather than flags so they apply to remote browsers too.
+func (fp Fingerprint) emulate() chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		if fp.UserAgent != "" || fp.Language != "" {
+			ua := fp.UserAgent
+			if ua == "" {
+				// Only the languages change, the user agent has to be given anyway
+				var err error
+				if _, _, _, ua, _, err = browser.GetVersion().Do(ctx); err != nil {
+					return err
+				}
+			}
+			override := emulation.SetUserAgentOverride(ua).WithAcceptLanguage(fp.acceptLanguage(""))
+			if p := fp.platform(); p != "" {
+				override = override.WithPlatform(p)
+			}
+			if err := override.Do(ctx); err != nil {
+				return err
+			}
+		}
+		if fp.Language != "" {
+			if err := emulation.SetLocaleOverride().WithLocale(fp.Language).Do(ctx); err != nil {
+				return err
+			}
+		}
+		if fp.Timezone != "" {
+			if err := emulation.SetTimezoneOverride(fp.Timezone).Do(ctx); err != nil {
+				return err
+			}
+		}
+		if w, h := fp.Viewport.Width, fp.Viewport.Height; w > 0 && h > 0 {
+			if err := emulation.SetDeviceMetricsOverride(int64(w), int64(h), 1, false).WithScreenWidth(int64(w)).WithScreenHeight(int64(h)).Do(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,181 @@
+package scraper
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// voyagerAPIRe matches the voyager API requests LinkedIn's pages load profile entities with.
+var voyagerAPIRe = regexp.MustCompile(`/voyager/api/(?:identity/|graphql\?(?:[^#]*&)?queryId=voyagerIdentityDash)`)
+
+// Caps on the voyager responses kept for a target, the details pages of a long profile
+// load a few dozen.
+const (
+	maxVoyagerResponses = 64
+	maxVoyagerBytes     = 8 << 20
+)
+
+// voyagerSettle is how long readVoyager waits for the responses still loading, a request
+// that never finishes doesn't hold a section up for longer.
+const voyagerSettle = 5 * time.Second
+
+/*
+	voyagerResponses are the voyager API responses the browser received for the target.
+
+Pages load what they don't embed, entries past the first ones of a details
+page included, through the API as they are scrolled. The responses are kept
+until the scraper is pointed at another profile, so every section of the
+target reads the entities any of its pages loaded. Once the caps are reached
+later responses are dropped. It is safe for concurrent use.
+*/
+type voyagerResponses struct {
+	mu       sync.Mutex
+	pending  map[network.RequestID]bool // Matching requests whose body is still loading
+	fetching int                        // Bodies being read from Chrome
+	// Counts the resets, a body read for a previous target is dropped
+	generation int
+	bodies     []string
+	size       int
+}
+
+// expect marks the response to id as one to keep once it has loaded.
+func (r *voyagerResponses) expect(id network.RequestID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pending == nil {
+		r.pending = map[network.RequestID]bool{}
+	}
+	r.pending[id] = true
+}
+
+// failed forgets the request id, whose response won't load.
+func (r *voyagerResponses) failed(id network.RequestID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pending, id)
+}
+
+// loaded reports whether id was expected, and then counts its body as being read for the
+// returned generation until add or skip is called with it.
+func (r *voyagerResponses) loaded(id network.RequestID) (generation int, expected bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if expected = r.pending[id]; expected {
+		delete(r.pending, id)
+		r.fetching++
+	}
+	return r.generation, expected
+}
+
+// add keeps a body read for generation, unless the scraper was reset since.
+func (r *voyagerResponses) add(generation int, body string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if generation != r.generation {
+		return
+	}
+	r.fetching--
+	if len(r.bodies) >= maxVoyagerResponses || r.size+len(body) > maxVoyagerBytes {
+		return
+	}
+	r.bodies = append(r.bodies, body)
+	r.size += len(body)
+}
+
+// skip gives up on a body of generation that couldn't be read.
+func (r *voyagerResponses) skip(generation int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if generation == r.generation {
+		r.fetching--
+	}
+}
+
+// settled reports whether every expected response has been read.
+func (r *voyagerResponses) settled() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.pending) == 0 && r.fetching == 0
+}
+
+// wait waits up to timeout for the responses still loading or being read.
+func (r *voyagerResponses) wait(ctx context.Context, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for !r.settled() && time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+}
+
+func (r *voyagerResponses) all() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.bodies...)
+}
+
+func (r *voyagerResponses) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending, r.fetching, r.bodies, r.size = nil, 0, nil, 0
+	r.generation++
+}
+
+/*
+	handle keeps the voyager API response of a network event, reading its body with body.
+
+Listeners must not block, so bodies are read from their own goroutine; wait
+waits for them.
+*/
+func (r *voyagerResponses) handle(ev any, body func(network.RequestID) ([]byte, error)) {
+	switch e := ev.(type) {
+	case *network.EventResponseReceived:
+		if (e.Type == network.ResourceTypeXHR || e.Type == network.ResourceTypeFetch) && e.Response.Status == 200 && voyagerAPIRe.MatchString(e.Response.URL) {
+			r.expect(e.RequestID)
+		}
+	case *network.EventLoadingFailed:
+		r.failed(e.RequestID)
+	case *network.EventLoadingFinished:
+		generation, expected := r.loaded(e.RequestID)
+		if !expected {
+			return
+		}
+		go func() {
+			data, err := body(e.RequestID)
+			if err != nil {
+				r.skip(generation)
+				return
+			}
+			r.add(generation, string(data))
+		}()
+	}
+}
+
+/*
+	interceptVoyager keeps the voyager API responses the browser's tab receives, for
+	readVoyager.
+
+The Network domain chromedp enables tells when a matching response arrives and
+when its body has loaded; the body is then read from Chrome, which keeps it
+until the page navigates away. Bodies Chrome no longer has are skipped.
+*/
+func (s *Scraper) interceptVoyager(ctx context.Context) {
+	chromedp.ListenTarget(ctx, func(ev any) {
+		s.responses.handle(ev, func(id network.RequestID) ([]byte, error) {
+			var body []byte
+			err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+				var err error
+				body, err = network.GetResponseBody(id).Do(ctx)
+				return err
+			}))
+			return body, err
+		})
+	})
+}
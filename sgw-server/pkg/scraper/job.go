@@ -0,0 +1,160 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/chromedp/chromedp"
+)
+
+// jobHighlights caps how many description highlights are kept.
+const jobHighlights = 5
+
+var (
+	// jobIDRe matches the numeric id of a job posting in its /jobs/view/ path, with or without a slug.
+	jobIDRe = regexp.MustCompile(`^/jobs/view/(?:[^/]*-)?(\d+)/?$`)
+	// currentJobIDRe matches the currentJobId parameter of job search and collection links.
+	currentJobIDRe = regexp.MustCompile(`^\d+$`)
+)
+
+/*
+	Job represents an open role posted on LinkedIn.
+
+Highlights are the description's bullet points, or its first paragraphs when
+it has none; HiringTeam lists the people the posting names under "Meet the
+hiring team".
+*/
+type Job struct {
+	Title      string             `json:"title"`
+	URL        string             `json:"url"`
+	Company    string             `json:"company"`
+	CompanyURL string             `json:"companyUrl,omitempty"`
+	Location   string             `json:"location,omitempty"`
+	Highlights []string           `json:"highlights,omitempty"`
+	HiringTeam []HiringTeamMember `json:"hiringTeam,omitempty"`
+}
+
+// HiringTeamMember is a person a job posting names as hiring for it.
+type HiringTeamMember struct {
+	Name       string `json:"name"`
+	Title      string `json:"title,omitempty"`
+	ProfileURL string `json:"profileUrl,omitempty"`
+}
+
+/*
+	JobPage returns the canonical URL of the LinkedIn job posting href links to.
+
+Both posting links (/jobs/view/<id>/) and search or collection links with a
+currentJobId parameter are understood.
+
+Parameters:
+  - href: A link to a job posting
+
+Returns:
+  - string: https://www.linkedin.com/jobs/view/<id>/, or "" when href is not a job posting
+*/
+func JobPage(href string) string {
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil || u.Scheme != "https" || (u.Host != "linkedin.com" && !strings.HasSuffix(u.Host, ".linkedin.com")) {
+		return ""
+	}
+	id := ""
+	if m := jobIDRe.FindStringSubmatch(u.Path); m != nil {
+		id = m[1]
+	} else if current := u.Query().Get("currentJobId"); currentJobIDRe.MatchString(current) && strings.HasPrefix(u.Path, "/jobs/") {
+		id = current
+	}
+	if id == "" {
+		return ""
+	}
+	return "https://www.linkedin.com/jobs/view/" + id + "/"
+}
+
+/*
+	GetJob scrapes a LinkedIn job posting.
+
+Unlike the profile sections it doesn't touch the scraped profile: a job is
+what a message is written about, not part of who it is written to. Jobs are
+remembered for the scraper's lifetime, since a batch usually pitches one role
+to many prospects.
+
+Parameters:
+  - ctx: Ends the scrape early when it is cancelled or reaches its deadline
+  - jobURL: A link to the posting, see JobPage
+
+Returns:
+  - *Job: The posting
+  - error: An error when jobURL is not a job posting, or any error encountered while fetching it
+*/
+func (s *Scraper) GetJob(ctx context.Context, jobURL string) (*Job, error) {
+	page := JobPage(jobURL)
+	if page == "" {
+		return nil, fmt.Errorf("not a LinkedIn job posting: %q", jobURL)
+	}
+	var job *Job
+	err := s.run(ctx, "job", func(ctx context.Context) error {
+		var err error
+		job, err = s.getJob(ctx, page)
+		return err
+	})
+	return job, err
+}
+
+func (s *Scraper) getJob(ctx context.Context, page string) (*Job, error) {
+	fmt.Println("Getting job")
+	s.mu.Lock()
+	cached, ok := s.jobs[page]
+	s.mu.Unlock()
+	if ok {
+		return cached.Clone(), nil
+	}
+
+	var found struct {
+		Title      string             `json:"title"`
+		Company    string             `json:"company"`
+		CompanyURL string             `json:"companyUrl"`
+		Location   string             `json:"location"`
+		Highlights []string           `json:"highlights"`
+		HiringTeam []HiringTeamMember `json:"hiringTeam"`
+	}
+	err := chromedp.Run(ctx,
+		navigate(page),
+		dwell(),
+		waitVisible(`main`, chromedp.ByQuery),
+		evaluate(script("job"), &found),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if found.Title == "" {
+		return nil, fmt.Errorf("failed to get job: no posting at %s, it may have been taken down", page)
+	}
+
+	job := &Job{
+		Title:      found.Title,
+		URL:        page,
+		Company:    found.Company,
+		CompanyURL: CompanyPage(found.CompanyURL),
+		Location:   found.Location,
+		Highlights: found.Highlights[:min(len(found.Highlights), jobHighlights)],
+		HiringTeam: found.HiringTeam,
+	}
+	s.mu.Lock()
+	s.jobs[page] = job.Clone()
+	s.mu.Unlock()
+	return job, nil
+}
+
+// Clone returns a deep copy of the job.
+func (j *Job) Clone() *Job {
+	if j == nil {
+		return nil
+	}
+	copied := *j
+	copied.Highlights = append([]string(nil), j.Highlights...)
+	copied.HiringTeam = append([]HiringTeamMember(nil), j.HiringTeam...)
+	return &copied
+}
@@ -0,0 +1,139 @@
+package scraper
+
+import (
+	"fmt"
+	"strings"
+)
+
+// jsQuoter escapes what ends or breaks a single-quoted JavaScript string.
+var jsQuoter = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`, "\u2028", `\u2028`, "\u2029", `\u2029`)
+
+// jsString returns s as a single-quoted JavaScript string literal, which is what selectors
+// are pasted into scripts as, so a selector like [data-x='y'] stays one string.
+func jsString(s string) string {
+	return "'" + jsQuoter.Replace(s) + "'"
+}
+
+// regexKeywords are the words after which a / starts a regular expression, not a division.
+var regexKeywords = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "in": true, "of": true, "new": true, "delete": true,
+	"void": true, "throw": true, "case": true, "do": true, "else": true, "yield": true, "await": true,
+}
+
+/*
+	checkScript reports where a page script is broken for the browser, without running it.
+
+It is a lexical check, not a parse: strings, template literals, regular
+expressions and comments must end, and brackets must pair up. That is what an
+override breaks when a selector or rewrite ends a string the script pastes it
+into, and what lets one end the string and run code of its own.
+*/
+func checkScript(src string) error {
+	line := func(i int) int { return strings.Count(src[:i], "\n") + 1 }
+	var closers []byte // What each open bracket expects, '$' for a template literal's ${
+	regexOK := true    // Whether a / here starts a regular expression
+
+	// template scans a template literal from i, past its opening ` or the } of a ${,
+	// to its end or its next ${
+	template := func(i int) (int, error) {
+		for ; i < len(src); i++ {
+			switch {
+			case src[i] == '\\':
+				i++
+			case src[i] == '`':
+				return i + 1, nil
+			case strings.HasPrefix(src[i:], "${"):
+				closers = append(closers, '$')
+				return i + 2, nil
+			}
+		}
+		return 0, fmt.Errorf("unterminated template literal at line %d", line(len(src)))
+	}
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			if end := strings.IndexByte(src[i:], '\n'); end >= 0 {
+				i += end
+			} else {
+				i = len(src)
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return fmt.Errorf("unterminated comment at line %d", line(i))
+			}
+			i += end + 4
+		case c == '\'' || c == '"' || c == '/' && regexOK:
+			start, class := i, false
+			for i++; ; i++ {
+				if i >= len(src) || src[i] == '\n' {
+					kind := "string"
+					if c == '/' {
+						kind = "regular expression"
+					}
+					return fmt.Errorf("unterminated %s at line %d", kind, line(start))
+				}
+				if src[i] == '\\' {
+					i++
+					continue
+				}
+				if c == '/' && (src[i] == '[' || src[i] == ']') {
+					class = src[i] == '['
+				}
+				if src[i] == c && !class {
+					break
+				}
+			}
+			for i++; i < len(src) && isWordByte(src[i]); i++ { // Flags
+			}
+			regexOK = false
+		case c == '`':
+			var err error
+			if i, err = template(i + 1); err != nil {
+				return err
+			}
+			regexOK = false
+		case c == '(' || c == '[' || c == '{':
+			closers = append(closers, map[byte]byte{'(': ')', '[': ']', '{': '}'}[c])
+			i++
+			regexOK = true
+		case c == ')' || c == ']' || c == '}':
+			if len(closers) == 0 || closers[len(closers)-1] != c && !(c == '}' && closers[len(closers)-1] == '$') {
+				return fmt.Errorf("unexpected %q at line %d", c, line(i))
+			}
+			open := closers[len(closers)-1]
+			closers = closers[:len(closers)-1]
+			if open == '$' {
+				var err error
+				if i, err = template(i + 1); err != nil {
+					return err
+				}
+				regexOK = false
+				continue
+			}
+			i++
+			regexOK = c == '}'
+		case isWordByte(c):
+			start := i
+			for i < len(src) && isWordByte(src[i]) {
+				i++
+			}
+			regexOK = regexKeywords[src[start:i]]
+		default:
+			i++
+			regexOK = true
+		}
+	}
+	if len(closers) > 0 {
+		return fmt.Errorf("%d brackets or template literals left open", len(closers))
+	}
+	return nil
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
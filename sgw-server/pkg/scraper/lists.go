@@ -0,0 +1,65 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// MaxListEntries caps the experience and education entries read off a profile. Their
+// details pages only render the first entries and load more as they are scrolled to the
+// end or their "Show more results" button is clicked, which is done until the page lists
+// this many; 0 loads every entry.
+var MaxListEntries = 50
+
+/*
+	expandList loads the remaining entries of the details page open in the tab.
+
+It scrolls to the end of the list, clicks the button loading more entries when
+there is one, and waits for them, until MaxListEntries are listed or the count
+stops growing. A failed scroll or click only ends the expansion, the entries
+already shown are still worth reading; only ctx ending fails it.
+*/
+func expandList() chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		count := func() int {
+			var n int
+			evaluate(`document.querySelectorAll(`+jsString(Selector("list.entry"))+`).length`, &n).Do(ctx)
+			return n
+		}
+		for n := count(); MaxListEntries <= 0 || n < MaxListEntries; {
+			var more bool
+			err := evaluate(`(() => {
+                window.scrollTo(0, document.body.scrollHeight);
+                const button = document.querySelector(`+jsString(Selector("list.more"))+`);
+                return !!button && !button.disabled;
+            })()`, &more).Do(ctx)
+			if err == nil && more {
+				err = click(Selector("list.more")).Do(ctx)
+			}
+			if err != nil {
+				fmt.Printf("Could not load more entries, keeping %d: %v\n", n, err)
+				break
+			}
+			if err := pause(time.Second).Do(ctx); err != nil {
+				return err
+			}
+			next := count()
+			if next <= n {
+				break
+			}
+			n = next
+		}
+		return ctx.Err()
+	})
+}
+
+// firstEntries returns at most MaxListEntries of entries.
+func firstEntries[T any](entries []T) []T {
+	if MaxListEntries > 0 && len(entries) > MaxListEntries {
+		return entries[:MaxListEntries]
+	}
+	return entries
+}
@@ -0,0 +1,62 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+/*
+	UILanguage is the language LinkedIn's pages are shown in, whatever the language of
+	the account or the fingerprint is.
+
+Page scripts find some of what they extract by its text, such as the "reposted
+this" header of reposts, "12 endorsements" and the "Open to work" card, and
+counts and dates are parsed as English writes them; a profile viewed from an
+account set to German would otherwise come back without them. LinkedIn takes
+the language from its lang cookie, which logins reset to the account's, and
+the locale parameter of the page, so both are set before every page is opened.
+"" leaves pages in the account's language.
+*/
+var UILanguage = "en_US"
+
+// inUILanguage sets LinkedIn's lang cookie to UILanguage for the next page the browser opens.
+func inUILanguage() chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		if UILanguage == "" {
+			return nil
+		}
+		lang := "v=2&lang=" + strings.ToLower(strings.ReplaceAll(UILanguage, "_", "-"))
+		return network.SetCookie("lang", lang).WithDomain(".linkedin.com").WithPath("/").WithSecure(true).Do(ctx)
+	})
+}
+
+// localized adds UILanguage as the locale parameter of a LinkedIn page's URL, and returns
+// other URLs as they are.
+func localized(page string) string {
+	u, err := url.Parse(page)
+	if UILanguage == "" || err != nil {
+		return page
+	}
+	if host := u.Hostname(); host != "linkedin.com" && !strings.HasSuffix(host, ".linkedin.com") {
+		return page
+	}
+	q := u.Query()
+	q.Set("locale", UILanguage)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// warnLanguage logs a page LinkedIn showed in another language than UILanguage anyway,
+// whose text markers won't match. lang is the page's html lang attribute.
+func warnLanguage(page, lang string) {
+	want, _, _ := strings.Cut(UILanguage, "_")
+	if UILanguage == "" || lang == "" || strings.EqualFold(strings.SplitN(lang, "-", 2)[0], want) {
+		return
+	}
+	fmt.Printf("LinkedIn showed %s in %q instead of %s, text read off it may be missing\n", page, lang, UILanguage)
+}
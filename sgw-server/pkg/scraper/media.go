@@ -0,0 +1,104 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/ocr"
+)
+
+// OCR reads the text of image and document posts into Post.MediaText when set. Without it
+// those posts keep only their caption, and posts without one are left out.
+var OCR ocr.Engine
+
+const (
+	// ocrMaxSlides caps how many slides of a document post are read.
+	ocrMaxSlides = 6
+	// ocrMaxText caps the media text kept per post.
+	ocrMaxText = 2000
+)
+
+// readPosts turns the first limit posts an extraction script found into Posts, reading the
+// text of their media with OCR and appending the transcript of their videos. Media that can't
+// be read is logged and skipped; posts left with no text at all are dropped.
+func (s *Scraper) readPosts(ctx context.Context, found []scrapedPost, limit int) []Post {
+	posts := make([]Post, 0, min(len(found), limit))
+	for _, f := range found {
+		if len(posts) == limit {
+			break
+		}
+		post := f.post()
+		if OCR != nil && f.MediaID != "" {
+			text, err := s.mediaText(ctx, f)
+			if err != nil {
+				fmt.Printf("Could not read the %s of a post: %v\n", f.Media, err)
+			}
+			post.MediaText = text
+		}
+		if f.Captions != "" {
+			text, err := s.transcript(ctx, f.Captions)
+			if err != nil {
+				fmt.Printf("Could not read the captions of a video post: %v\n", err)
+			}
+			post.Content = withTranscript(post.Content, text)
+		}
+		if post.Content == "" && post.MediaText == "" {
+			continue
+		}
+		posts = append(posts, post)
+	}
+	return posts
+}
+
+// mediaText screenshots the media of a post on the current page and reads it with OCR,
+// slide by slide for documents. What was read before an error is returned with it.
+func (s *Scraper) mediaText(ctx context.Context, post scrapedPost) (string, error) {
+	selector := fmt.Sprintf(`[data-sgw-media="%s"]`, post.MediaID)
+	slides := 1
+	if post.Media == "document" {
+		slides = ocrMaxSlides
+	}
+
+	var texts []string
+	for i := 0; i < slides; i++ {
+		var shot []byte
+		if err := chromedp.Run(ctx, chromedp.Screenshot(selector, &shot, chromedp.ByQuery)); err != nil {
+			return strings.Join(texts, "\n"), fmt.Errorf("failed to screenshot: %w", err)
+		}
+		text, err := OCR.Text(ctx, shot)
+		if err != nil {
+			return strings.Join(texts, "\n"), err
+		}
+		// The viewer stays on the last slide once there is no next one
+		if text != "" && (len(texts) == 0 || texts[len(texts)-1] != text) {
+			texts = append(texts, text)
+		}
+		if i == slides-1 {
+			break
+		}
+		var next bool
+		err = chromedp.Run(ctx, evaluate(`
+            (() => {
+                const next = document.querySelector('`+selector+` button[aria-label*="next" i]');
+                if (!next || next.disabled) return false;
+                next.click();
+                return true;
+            })()
+        `, &next))
+		if err != nil || !next {
+			break
+		}
+		if err := chromedp.Run(ctx, pause(700*time.Millisecond)); err != nil {
+			break
+		}
+	}
+
+	text := strings.Join(texts, "\n")
+	if len(text) > ocrMaxText {
+		text = strings.ToValidUTF8(text[:ocrMaxText], "")
+	}
+	return text, nil
+}
@@ -0,0 +1,77 @@
+package scraper
+
+import (
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+/*
+	ScraperOptions configure the browser a scraper starts.
+
+Zero values leave the respective setting to Chrome or the package, as noted
+per field. ResourceLimits still come from Limits and proxies from Proxies.
+With RemoteURL set the browser is already running: ExecPath, the window size,
+Flags, Limits and UserDataDir are up to whoever started it.
+*/
+type ScraperOptions struct {
+	Headless     bool           // Start without a window; see NewScraperWithOptions for security checks
+	Timeout      time.Duration  // How long the new scraper may be used before it has to be renewed, DefaultLease when zero
+	ExecPath     string         // Browser binary, the one chromedp finds when empty
+	WindowWidth  int            // Window width in pixels, Chrome's default unless both sizes are set
+	WindowHeight int            // Window height in pixels
+	Flags        map[string]any // Extra Chrome flags, e.g. {"lang": "en-US"} or {"mute-audio": true}; false removes a default flag
+	RemoteURL    string         // DevTools endpoint of a running browser to attach to instead of starting one, see NewRemoteScraper
+	UserDataDir  string         // Chrome profile directory kept between runs, a temporary one when empty; see UserDataDir
+}
+
+// DefaultOptions returns the options NewScraper starts browsers with, from Headless, ExecPath and RemoteURL.
+func DefaultOptions() ScraperOptions {
+	return ScraperOptions{Headless: Headless, ExecPath: ExecPath, RemoteURL: RemoteURL}
+}
+
+// forAccount returns the options for email's browser: with its profile directory under
+// UserDataDir, unless the options name one or the browser is remote.
+func (o ScraperOptions) forAccount(email string) ScraperOptions {
+	if o.UserDataDir == "" && o.RemoteURL == "" {
+		o.UserDataDir = AccountDataDir(UserDataDir, email)
+	}
+	return o
+}
+
+// lease is how long a scraper started with the options may be used at first.
+func (o ScraperOptions) lease() time.Duration {
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+	return DefaultLease
+}
+
+// hasDisplay reports whether a visible browser can be started, which on Linux and
+// the BSDs needs an X or Wayland display.
+func hasDisplay() bool {
+	switch runtime.GOOS {
+	case "windows", "darwin":
+		return true
+	}
+	return os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != ""
+}
+
+func (o ScraperOptions) flags() []chromedp.ExecAllocatorOption {
+	var opts []chromedp.ExecAllocatorOption
+	if o.WindowWidth > 0 && o.WindowHeight > 0 {
+		opts = append(opts, chromedp.WindowSize(o.WindowWidth, o.WindowHeight))
+	}
+	for name, value := range o.Flags {
+		opts = append(opts, chromedp.Flag(name, value))
+	}
+	if o.ExecPath != "" {
+		opts = append(opts, chromedp.ExecPath(o.ExecPath))
+	}
+	if o.UserDataDir != "" {
+		opts = append(opts, chromedp.UserDataDir(o.UserDataDir))
+	}
+	return opts
+}
@@ -0,0 +1,222 @@
+package scraper
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/chromedp/chromedp/kb"
+)
+
+// OTPMethod is how LinkedIn sent a login's verification code.
+type OTPMethod string
+
+const (
+	OTPEmail OTPMethod = "email" // Emailed to the account's address
+	OTPSMS   OTPMethod = "sms"   // Texted to the account's phone
+	OTPApp   OTPMethod = "app"   // Shown by an authenticator app
+)
+
+// OTPRequest is a verification code LinkedIn asked a login for.
+type OTPRequest struct {
+	Email   string    // Account logging in
+	Method  OTPMethod // Where the code is
+	Attempt int       // 1 for the first code, more once LinkedIn rejected one
+}
+
+/*
+	OTPProvider answers the verification codes LinkedIn asks logins for.
+
+Code may block until the code arrives, but must return once ctx is done. It
+returns ErrNoOTP for requests it can't answer, such as another account's or
+another method's.
+*/
+type OTPProvider interface {
+	Code(ctx context.Context, req OTPRequest) (string, error)
+}
+
+// OTPFunc lets a function be used as an OTPProvider.
+type OTPFunc func(ctx context.Context, req OTPRequest) (string, error)
+
+// Code calls f.
+func (f OTPFunc) Code(ctx context.Context, req OTPRequest) (string, error) {
+	return f(ctx, req)
+}
+
+/*
+	OTP, when set, answers the verification code pages LinkedIn shows logins.
+
+The login types the code in itself, so it carries on without anyone at the
+browser, headless and background re-logins included. Checkpoints that aren't
+a code page, or codes OTP doesn't answer, are handled as before.
+*/
+var OTP OTPProvider
+
+// ErrNoOTP is returned by OTP providers for requests they can't answer.
+var ErrNoOTP = errors.New("no verification code for this login")
+
+// maxOTPAttempts is how many codes a login tries before giving up on a code page.
+const maxOTPAttempts = 3
+
+/*
+	OTPProviders asks providers in order, returning the first code one answers with.
+
+Providers that return ErrNoOTP are skipped, so e.g. TOTP secrets can answer the
+accounts they know while the others wait for a posted code. Nil providers are
+ignored.
+
+Parameters:
+  - providers: The providers to ask
+
+Returns:
+  - OTPProvider: The chain, which returns ErrNoOTP when no provider answers
+*/
+func OTPProviders(providers ...OTPProvider) OTPProvider {
+	return OTPFunc(func(ctx context.Context, req OTPRequest) (string, error) {
+		for _, p := range providers {
+			if p == nil {
+				continue
+			}
+			code, err := p.Code(ctx, req)
+			if errors.Is(err, ErrNoOTP) {
+				continue
+			}
+			return code, err
+		}
+		return "", ErrNoOTP
+	})
+}
+
+// TOTP answers authenticator app codes from the base32 TOTP secrets of accounts, by email.
+type TOTP map[string]string
+
+// Code generates the current RFC 6238 code of req's account.
+func (t TOTP) Code(ctx context.Context, req OTPRequest) (string, error) {
+	if req.Method != OTPApp {
+		return "", ErrNoOTP
+	}
+	for email, secret := range t {
+		if !strings.EqualFold(email, req.Email) {
+			continue
+		}
+		key, err := DecodeTOTPSecret(secret)
+		if err != nil {
+			return "", err
+		}
+		return totp(key, time.Now()), nil
+	}
+	return "", ErrNoOTP
+}
+
+/*
+	DecodeTOTPSecret decodes a TOTP secret as authenticator apps show it.
+
+Parameters:
+  - secret: Base32, spaces, lower case and padding allowed
+
+Returns:
+  - []byte: The HMAC key
+  - error: An error when secret is not base32 or empty
+*/
+func DecodeTOTPSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil || len(key) == 0 {
+		return nil, errors.New("TOTP secret is not base32")
+	}
+	return key, nil
+}
+
+// totp is the 6 digit HMAC-SHA1 code of key for the 30 second step at t.
+func totp(key []byte, t time.Time) string {
+	var step [8]byte
+	binary.BigEndian.PutUint64(step[:], uint64(t.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(step[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", code%1_000_000)
+}
+
+type otpPage struct {
+	Input  string `json:"input"`
+	Submit string `json:"submit"`
+	Text   string `json:"text"`
+}
+
+// otpMethod is where the code a verification page's input asks for was sent, and
+// whether it asks for one at all.
+func otpMethod(input, text string) (OTPMethod, bool) {
+	if input == "" {
+		return "", false
+	}
+	text = strings.ToLower(text)
+	switch {
+	case strings.Contains(text, "authenticator"):
+		return OTPApp, true
+	case strings.Contains(input, "phone"), strings.Contains(text, "text message"), strings.Contains(text, "sms"):
+		return OTPSMS, true
+	}
+	return OTPEmail, true
+}
+
+/*
+	answerOTP types the codes OTP answers into the verification code page a login is at.
+
+A page that doesn't ask for a code, a provider that can't answer and codes
+LinkedIn keeps rejecting all leave the browser at the checkpoint, for the
+caller to handle as any other.
+
+Returns:
+  - string: The URL the browser ended at
+  - error: Any error driving the browser
+*/
+func (s *Scraper) answerOTP(ctx context.Context, currentURL string) (string, error) {
+	for attempt := 1; attempt <= maxOTPAttempts; attempt++ {
+		var page otpPage
+		if err := chromedp.Run(ctx, evaluate(script("otp-page"), &page)); err != nil {
+			return currentURL, err
+		}
+		method, ok := otpMethod(page.Input, page.Text)
+		if !ok {
+			return currentURL, nil
+		}
+		fmt.Printf("LinkedIn asked for a verification code sent by %s\n", method)
+		code, err := OTP.Code(ctx, OTPRequest{Email: s.email, Method: method, Attempt: attempt})
+		if err != nil {
+			if !errors.Is(err, ErrNoOTP) {
+				fmt.Printf("Could not get a verification code: %v\n", err)
+			}
+			return currentURL, nil
+		}
+
+		var submit chromedp.Action = chromedp.KeyEvent(kb.Enter)
+		if page.Submit != "" {
+			submit = click(page.Submit)
+		}
+		err = chromedp.Run(ctx,
+			chromedp.SetValue(page.Input, ""),
+			typeText(page.Input, strings.TrimSpace(code)),
+			pause(400*time.Millisecond),
+			submit,
+			pause(2*time.Second),
+			chromedp.Location(&currentURL),
+		)
+		if err != nil {
+			return currentURL, err
+		}
+		if !challenged(currentURL) {
+			return currentURL, nil
+		}
+		fmt.Println("LinkedIn rejected the verification code")
+	}
+	return currentURL, nil
+}
@@ -0,0 +1,174 @@
+package scraper
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"strings"
+	"sync/atomic"
+	"text/template"
+)
+
+// builtinFiles are the built-in page scripts and selectors.
+//
+//go:embed pages
+var builtinFiles embed.FS
+
+/*
+	Pages are the scripts the scraper runs on LinkedIn's pages and the selectors of the
+	elements it waits for, clicks and types into, by name.
+
+The built-in ones are embedded from pages/: pages.json holds their version and
+the selectors, and each <name>.js file is a script. Scripts use selectors by
+name with {{selector "name"}}, which writes the selector as a JavaScript
+string literal, so a selector fixed once is fixed in every script reading it. Version changes whenever a build changes what a script
+returns or which scripts and selectors there are, which is what an override
+written for another build can't know about.
+*/
+type Pages struct {
+	Version   int               `json:"version"`
+	Selectors map[string]string `json:"selectors,omitempty"`
+	Scripts   map[string]string `json:"scripts,omitempty"`
+	Rewrites  map[string]string `json:"rewrites,omitempty"` // As for SetSelectors, applied after the selectors and scripts
+}
+
+// builtinPages are the pages embedded in the build.
+var builtinPages = loadBuiltinPages()
+
+// activePages are the rendered scripts and the selectors scrapes use, with the override applied.
+var activePages atomic.Pointer[renderedPages]
+
+type renderedPages struct {
+	selectors map[string]string
+	scripts   map[string]string
+}
+
+func init() {
+	rendered, err := render(nil)
+	if err != nil {
+		panic(err)
+	}
+	activePages.Store(rendered)
+}
+
+func loadBuiltinPages() Pages {
+	var p Pages
+	data, err := builtinFiles.ReadFile("pages/pages.json")
+	if err == nil {
+		err = json.Unmarshal(data, &p)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in pages.json: %v", err))
+	}
+	entries, _ := builtinFiles.ReadDir("pages")
+	p.Scripts = map[string]string{}
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".js"); ok {
+			data, _ := builtinFiles.ReadFile(path.Join("pages", e.Name()))
+			p.Scripts[name] = string(data)
+		}
+	}
+	return p
+}
+
+// DefaultPages returns the built-in pages, a starting point for an override.
+func DefaultPages() Pages {
+	return Pages{Version: builtinPages.Version, Selectors: maps.Clone(builtinPages.Selectors), Scripts: maps.Clone(builtinPages.Scripts)}
+}
+
+// PagesVersion is the version of the built-in pages, which an override must be written for.
+func PagesVersion() int {
+	return builtinPages.Version
+}
+
+/*
+	SetPages applies an override to the built-in pages, for the page scripts, waits and
+	clicks scrapes run from then on.
+
+The override's selectors and scripts replace the built-in ones of the same
+names, and its rewrites replace those of SetSelectors. An override written for
+another Version, naming selectors or scripts the build doesn't have, or with
+a script that doesn't render, or rendered and rewritten fails checkScript,
+changes nothing and returns why. nil goes back to the built-in pages.
+
+Parameters:
+  - override: The selectors and scripts to replace, nil for none
+*/
+func SetPages(override *Pages) error {
+	rendered, err := render(override)
+	if err != nil {
+		return err
+	}
+	activePages.Store(rendered)
+	if override == nil {
+		SetSelectors(nil)
+	} else {
+		SetSelectors(override.Rewrites)
+	}
+	return nil
+}
+
+// render applies override to the built-in pages and renders their scripts.
+func render(override *Pages) (*renderedPages, error) {
+	r := &renderedPages{selectors: maps.Clone(builtinPages.Selectors), scripts: map[string]string{}}
+	scripts := maps.Clone(builtinPages.Scripts)
+	if override != nil {
+		if override.Version != builtinPages.Version {
+			return nil, fmt.Errorf("the override is written for version %d of the page scripts, this build has version %d", override.Version, builtinPages.Version)
+		}
+		for _, replaced := range []struct {
+			kind       string
+			from, onto map[string]string
+		}{{"selector", override.Selectors, r.selectors}, {"script", override.Scripts, scripts}} {
+			for name, v := range replaced.from {
+				if _, ok := replaced.onto[name]; !ok {
+					return nil, fmt.Errorf("the override replaces the %s %q, which this build doesn't have", replaced.kind, name)
+				}
+				if strings.TrimSpace(v) == "" {
+					return nil, fmt.Errorf("the override replaces the %s %q with nothing", replaced.kind, name)
+				}
+				replaced.onto[name] = v
+			}
+		}
+	}
+
+	funcs := template.FuncMap{"selector": func(name string) (string, error) {
+		sel, ok := r.selectors[name]
+		if !ok {
+			return "", fmt.Errorf("no selector %q", name)
+		}
+		return jsString(sel), nil
+	}}
+	var rewrites *rewriter
+	if override != nil && len(override.Rewrites) > 0 {
+		rewrites = newRewriter(override.Rewrites)
+	}
+	for name, src := range scripts {
+		var b strings.Builder
+		t, err := template.New(name).Funcs(funcs).Parse(src)
+		if err == nil {
+			err = t.Execute(&b, nil)
+		}
+		if err == nil {
+			err = checkScript(rewrites.script(b.String()))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to render the page script %s: %w", name, err)
+		}
+		r.scripts[name] = b.String()
+	}
+	return r, nil
+}
+
+// script returns the page script called name, "" when there is none.
+func script(name string) string {
+	return activePages.Load().scripts[name]
+}
+
+// Selector returns the selector called name, with the override applied, for pkg/parser to
+// read saved pages like the page scripts do. It is "" when there is none.
+func Selector(name string) string {
+	return activePages.Load().selectors[name]
+}
@@ -0,0 +1,9 @@
+(() => {
+    // Find the About section's text content
+    const aboutSpans = document.querySelectorAll('div[class*="display-flex full-width"] span[aria-hidden="true"]');
+    if (!aboutSpans.length) return "";
+
+    return Array.from(aboutSpans)
+        .map(span => span.textContent.trim())
+        .filter(text => text.length > 0)[0]
+})()
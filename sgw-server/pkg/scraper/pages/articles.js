@@ -0,0 +1,15 @@
+Array.from(document.querySelectorAll({{selector "post"}})).map(card => {
+    const article = card.querySelector('.update-components-article, .feed-shared-article');
+    if (!article) return null;
+    const title = article.querySelector('.update-components-article__title, .feed-shared-article__title')?.textContent?.trim() || '';
+    if (!title) return null;
+    const link = article.querySelector('a[href*="/pulse/"]')?.href || '';
+    const excerpt = article.querySelector('.update-components-article__description, .feed-shared-article__description')?.textContent?.trim() ||
+        card.querySelector('.feed-shared-update-v2__description-wrapper .break-words')?.textContent?.trim() || '';
+    return {
+        title: title,
+        url: link.split('?')[0],
+        urn: card.getAttribute('data-urn') || card.closest('[data-urn]')?.getAttribute('data-urn') || '',
+        excerpt: excerpt
+    };
+}).filter(item => item !== null).slice(0, 5);
@@ -0,0 +1,11 @@
+Array.from(document.querySelectorAll({{selector "list.entry"}})).map(el => {
+    const position = el.querySelector({{selector "entry"}});
+    if (!position) return null;
+    const name = position.querySelector({{selector "entry.linked-title"}})?.textContent?.trim() || '';
+    if (!name) return null;
+    const issuer = position.querySelector({{selector "entry.subtitle"}})?.textContent?.trim() || '';
+    // Shown as "Issued Mar 2023 · Expires Mar 2026", keep the issue date only
+    const issued = position.querySelector({{selector "entry.caption"}})?.textContent?.trim() || '';
+    const issuedAt = issued.split('·')[0].replace(/^Issued\s*/i, '').trim();
+    return { name, issuer, issuedAt };
+}).filter(item => item !== null);
@@ -0,0 +1,13 @@
+Array.from(document.querySelectorAll({{selector "post"}})).map(card => {
+    const comment = card.querySelector('.comments-comment-entity, .comments-comment-item');
+    if (!comment) return null;
+    const content = comment.querySelector('.comments-comment-item__main-content, .update-components-text')?.textContent?.trim() || '';
+    if (!content) return null;
+    return {
+        content: content,
+        postAuthor: card.querySelector('.update-components-actor__name span[aria-hidden="true"], .update-components-actor__title span[aria-hidden="true"]')?.textContent?.trim() || '',
+        postExcerpt: (card.querySelector('.feed-shared-update-v2__description-wrapper .break-words')?.textContent?.trim() || '').slice(0, 300),
+        urn: card.getAttribute('data-urn') || card.closest('[data-urn]')?.getAttribute('data-urn') || '',
+        commentUrn: comment.getAttribute('data-id') || ''
+    };
+}).filter(item => item !== null).slice(0, 5);
@@ -0,0 +1,15 @@
+(() => {
+    const text = selector => document.querySelector(selector)?.textContent?.trim() || '';
+    // The overview is a list of dt/dd pairs: Website, Industry, Company size...
+    const details = {};
+    document.querySelectorAll('dl dt').forEach(dt => {
+        const dd = dt.nextElementSibling;
+        if (dd && dd.tagName === 'DD') details[dt.textContent.trim().toLowerCase()] = dd.textContent.trim();
+    });
+    return {
+        name: text('h1'),
+        industry: details['industry'] || '',
+        size: (details['company size'] || '').split('\n')[0].trim(),
+        about: text('section p.break-words') || text('.org-about-us-organization-description__text')
+    };
+})()
@@ -0,0 +1,10 @@
+Array.from(document.querySelectorAll('.artdeco-modal section, .artdeco-modal .pv-contact-info__contact-type'))
+    .filter(section => section.querySelector('h3'))
+    .map(section => ({
+        label: section.querySelector('h3').textContent.trim(),
+        values: Array.from(section.querySelectorAll('a, span.t-14, li'))
+            .filter(el => !el.querySelector('a'))
+            .map(el => el.textContent.trim().replace(/\s+/g, ' '))
+            .filter(Boolean),
+        links: Array.from(section.querySelectorAll('a[href]')).map(a => a.href),
+    }));
@@ -0,0 +1,12 @@
+Array.from(document.querySelectorAll({{selector "list.entry"}})).map(el => {
+    const position = el.querySelector({{selector "entry"}});
+    if (!position) return null;
+    const institute = position.querySelector({{selector "entry.linked-title"}})?.textContent?.trim() || '';
+    const major = position.querySelector({{selector "entry.subtitle"}})?.textContent?.trim() || '';
+    const duration = position.querySelector({{selector "entry.caption"}})?.textContent?.trim() || '';
+    return {
+        institute,
+        major,
+        duration
+    };
+}).filter(item => item !== null);
@@ -0,0 +1,10 @@
+Array.from(document.querySelectorAll({{selector "list.entry"}})).map(el => {
+    const position = el.querySelector({{selector "entry"}});
+    if (!position) return null;
+    const title = position.querySelector({{selector "entry.title"}})?.textContent?.trim()
+                || position.querySelector('div.display-flex.align-items-center.mr1.t-bold span.visually-hidden')?.textContent?.trim()
+                || '';
+    const company = position.querySelector({{selector "entry.subtitle"}})?.textContent?.trim() || '';
+    const duration = position.querySelector({{selector "entry.caption"}})?.textContent?.trim() || '';
+    return { title, company, duration };
+}).filter(item => item !== null);
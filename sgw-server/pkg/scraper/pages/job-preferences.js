@@ -0,0 +1,14 @@
+(() => {
+    const modal = document.querySelector({{selector "modal"}});
+    const rows = Array.from(modal.querySelectorAll('section, li.pb3, div.pb3'))
+        .map(row => {
+            const label = row.querySelector('h3, dt, strong')?.textContent?.trim()?.replace(/\s+/g, ' ') || '';
+            const items = Array.from(row.querySelectorAll('li, dd, p'))
+                .map(el => el.textContent.trim().replace(/\s+/g, ' '))
+                .filter(t => t && t !== label);
+            return {label, values: items};
+        })
+        .filter(row => row.label && row.values.length);
+    modal.querySelector('button[aria-label="Dismiss"]')?.click();
+    return rows;
+})()
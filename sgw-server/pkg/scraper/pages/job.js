@@ -0,0 +1,30 @@
+(() => {
+    const text = (root, selector) => root.querySelector(selector)?.textContent?.trim().replace(/\s+/g, ' ') || '';
+    const top = document.querySelector('.job-details-jobs-unified-top-card__container--two-pane, .jobs-unified-top-card') || document;
+    const company = top.querySelector('.job-details-jobs-unified-top-card__company-name a, .jobs-unified-top-card__company-name a');
+    const description = document.querySelector('#job-details, .jobs-description__content') || document.createElement('div');
+
+    // Bullet points when the description has them, else its opening paragraphs
+    let highlights = [...description.querySelectorAll('li')].map(li => li.textContent.trim().replace(/\s+/g, ' '));
+    if (highlights.length === 0) {
+        highlights = [...description.querySelectorAll('p')].map(p => p.textContent.trim().replace(/\s+/g, ' '));
+    }
+
+    const hiringTeam = [...document.querySelectorAll('.hirer-card__hirer-information, .job-details-people-who-can-help__section--two-pane .artdeco-entity-lockup')].map(card => {
+        const link = card.querySelector('a[href*="/in/"]');
+        return {
+            name: text(card, '.jobs-poster__name, .artdeco-entity-lockup__title') || link?.textContent?.trim() || '',
+            title: text(card, '.hirer-card__job-poster, .linked-area .text-body-small, .artdeco-entity-lockup__subtitle'),
+            profileUrl: link ? link.href.split('?')[0] : ''
+        };
+    }).filter(m => m.name);
+
+    return {
+        title: text(top, 'h1'),
+        company: company?.textContent?.trim() || '',
+        companyUrl: company?.href || '',
+        location: text(top, '.job-details-jobs-unified-top-card__primary-description-container .tvm__text, .jobs-unified-top-card__bullet'),
+        highlights: highlights.filter(h => h.length > 0),
+        hiringTeam
+    };
+})()
@@ -0,0 +1,8 @@
+Array.from(document.querySelectorAll({{selector "list.entry"}})).map(el => {
+    const position = el.querySelector({{selector "entry"}});
+    if (!position) return null;
+    const name = position.querySelector({{selector "entry.title"}})?.textContent?.trim() || '';
+    if (!name) return null;
+    const proficiency = position.querySelector({{selector "entry.caption"}})?.textContent?.trim() || '';
+    return { name, proficiency };
+}).filter(item => item !== null);
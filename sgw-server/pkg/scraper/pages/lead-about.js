@@ -0,0 +1 @@
+document.querySelector('[data-anonymize="person-blurb"]')?.textContent?.trim() || ''
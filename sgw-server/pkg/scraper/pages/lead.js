@@ -0,0 +1,17 @@
+(() => {
+    const text = selector => document.querySelector(selector)?.textContent?.trim() || '';
+    const photo = document.querySelector('img[data-anonymize="headshot-photo"]')?.src || '';
+    const counts = Array.from(document.querySelectorAll('main span, main div'))
+        .map(el => el.textContent.trim().replace(/\s+/g, ' '));
+    return {
+        name: text({{selector "lead.name"}}),
+        location: text('[data-anonymize="location"]'),
+        headline: text('[data-anonymize="headline"]'),
+        photoUrl: photo.startsWith('http') ? photo : '',
+        // The current role links to the employer's account page
+        companyUrl: document.querySelector('a[data-anonymize="company-name"]')?.href || '',
+        // Behind "View LinkedIn profile" in the overflow menu, rendered closed
+        profileUrl: document.querySelector('a[href*="linkedin.com/in/"]')?.href || '',
+        connections: counts.find(t => /^[0-9][0-9.,]*[KM]?\+? connections?$/.test(t)) || '',
+    };
+})()
@@ -0,0 +1,5 @@
+// Reports whether LinkedIn showed the login form again with an error under a field.
+(() => {
+    const error = document.querySelector('#error-for-password, #error-for-username');
+    return !!error && error.offsetParent !== null && error.textContent.trim() !== '';
+})()
@@ -0,0 +1,10 @@
+(() => {
+    const card = Array.from(document.querySelectorAll('[class*="open-to-carousel"] li, [class*="open-to-carousel"]'))
+        .find(el => /open to work/i.test(el.textContent));
+    if (!card) return {summary: '', details: false};
+    const lines = Array.from(card.querySelectorAll('p, span[aria-hidden="true"], strong'))
+        .map(el => el.textContent.trim().replace(/\s+/g, ' '));
+    const link = card.querySelector('a[href*="job-opportunities"], a[href*="opportunities"]');
+    if (link) link.click();
+    return {summary: lines.find(t => / roles?$/i.test(t)) || '', details: !!link};
+})()
@@ -0,0 +1,13 @@
+// Finds the code field of a verification page, and the text around it.
+(() => {
+    const input = document.querySelector('#input__email_verification_pin, #input__phone_verification_pin, input[name="pin"]');
+    if (!input) return { input: '', submit: '', text: '' };
+    const form = input.closest('form');
+    const submit = document.querySelector('#two-step-submit-button, #email-pin-submit-button') || form?.querySelector('button[type="submit"]');
+    const mark = (el, name) => { el.setAttribute('data-sgw-otp', name); return '[data-sgw-otp="' + name + '"]'; };
+    return {
+        input: input.id ? '#' + input.id : mark(input, 'input'),
+        submit: submit ? mark(submit, 'submit') : '',
+        text: (form || document.body).innerText || ''
+    };
+})()
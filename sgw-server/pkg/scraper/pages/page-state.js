@@ -0,0 +1,11 @@
+// Reads the status LinkedIn answered the page with and the text of its
+// alert banners, with the whole text of short pages, which is all error pages have,
+// whether the page is the one logged out visitors see and the language it is in.
+(() => {
+    const nav = performance.getEntriesByType('navigation')[0];
+    const alerts = Array.from(document.querySelectorAll('[role="alert"], .artdeco-global-alert, .artdeco-inline-feedback--error'))
+        .map(el => el.innerText || '').join('\n');
+    const body = document.body?.innerText || '';
+    const guest = !!document.querySelector({{selector "guest"}});
+    return { status: nav?.responseStatus || 0, text: alerts + '\n' + (body.length <= 2000 ? body : ''), guest, lang: document.documentElement.lang || '' };
+})()
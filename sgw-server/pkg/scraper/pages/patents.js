@@ -0,0 +1,10 @@
+Array.from(document.querySelectorAll({{selector "list.entry"}})).map(el => {
+    const position = el.querySelector({{selector "entry"}});
+    if (!position) return null;
+    const title = position.querySelector({{selector "entry.title"}})?.textContent?.trim() || '';
+    if (!title) return null;
+    // Shown as "US 10,946,520 · Issued Mar 16, 2021" or "US 17/123,456 · Filed Jan 5, 2022"
+    const subtitle = position.querySelector({{selector "entry.subtitle"}})?.textContent?.trim() || '';
+    const [office, date] = subtitle.split('·').map(part => part.trim());
+    return { title, office: office || '', date: (date || '').replace(/^(Issued|Filed)\s*/i, '') };
+}).filter(item => item !== null);
@@ -0,0 +1,11 @@
+Array.from(document.querySelectorAll({{selector "list.entry"}})).map(el => {
+    const position = el.querySelector({{selector "entry"}});
+    if (!position) return null;
+    const title = position.querySelector({{selector "entry.title"}})?.textContent?.trim() || '';
+    if (!title) return null;
+    // Shown as "IEEE Transactions on Games · Mar 12, 2021", either part may be missing
+    const subtitle = position.querySelector({{selector "entry.subtitle"}})?.textContent?.trim() || '';
+    const parts = subtitle.split('·').map(part => part.trim());
+    const date = parts.length > 1 ? parts.pop() : (/\d{4}$/.test(parts[0]) ? parts.pop() : '');
+    return { title, venue: parts.join(' · '), date };
+}).filter(item => item !== null);
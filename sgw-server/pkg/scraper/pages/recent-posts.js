@@ -0,0 +1,33 @@
+// Extracts the 5 latest original posts from an activity or company posts page.
+Array.from(document.querySelectorAll({{selector "post"}})).map((post, i) => {
+    // Check if it's a repost by looking for specific class or text in header
+    const header = post.querySelector('.update-components-header__text-view');
+    if (header && header.textContent.includes('reposted this')) {
+        return null;
+    }
+
+    // Get the content wrapper
+    const wrapper = post.querySelector('.feed-shared-update-v2__description-wrapper');
+    const content = wrapper?.querySelector('.feed-shared-inline-show-more-text')?.textContent?.trim() || wrapper?.querySelector('.break-words span[dir="ltr"]')?.textContent?.trim() || '';
+
+    // Image and document posts often say it all in the media, marked so it can be screenshotted
+    const slides = post.querySelector('.update-components-document__container, .feed-shared-document');
+    const image = post.querySelector('.update-components-image, .feed-shared-image');
+    const media = slides || image;
+    // Native videos carry LinkedIn's auto-generated captions as a track
+    const captions = post.querySelector('video track[kind="captions"], video track[kind="subtitles"]')?.src || '';
+    if (!content && !media && !captions) return null;
+    const mediaId = media ? String(i) : '';
+    media?.setAttribute('data-sgw-media', mediaId);
+
+    const counts = post.querySelector('.social-details-social-counts');
+    return {
+        content: content,
+        urn: post.getAttribute('data-urn') || post.closest('[data-urn]')?.getAttribute('data-urn') || '',
+        reactions: counts?.querySelector('.social-details-social-counts__reactions-count')?.textContent?.trim() || '',
+        comments: counts?.querySelector('.social-details-social-counts__comments')?.textContent?.trim() || '',
+        media: slides ? 'document' : image ? 'image' : '',
+        mediaId: mediaId,
+        captions: captions
+    };
+}).filter(item => item !== null).slice(0, 5);
@@ -0,0 +1,13 @@
+Array.from(document.querySelectorAll({{selector "list.entry"}})).map(el => {
+    const position = el.querySelector({{selector "entry"}});
+    if (!position) return null;
+    const name = position.querySelector({{selector "entry.linked-title"}})?.textContent?.trim() || '';
+    if (!name) return null;
+    const relationship = position.querySelector({{selector "entry.caption"}})?.textContent?.trim() || '';
+    // The text has no stable class of its own, it is the longest block in the entry
+    const text = Array.from(position.querySelectorAll('span[aria-hidden="true"]'))
+        .map(span => span.textContent.trim())
+        .filter(t => t !== name && t !== relationship)
+        .reduce((longest, t) => t.length > longest.length ? t : longest, '');
+    return { name, relationship, text };
+}).filter(item => item !== null);
@@ -0,0 +1,15 @@
+(() => {
+    const text = (root, selector) => root.querySelector(selector)?.textContent?.trim().replace(/\s+/g, ' ') || '';
+    const cards = [...document.querySelectorAll('li.reusable-search__result-container, div[data-view-name="search-entity-result-universal-template"]')];
+    const results = cards.map(card => {
+        const link = card.querySelector('span.entity-result__title-text a[href*="/in/"], a[data-test-app-aware-link][href*="/in/"]');
+        return {
+            url: link?.href || '',
+            name: (link?.querySelector('span[aria-hidden="true"]') || link)?.textContent?.trim() || '',
+            headline: text(card, '.entity-result__primary-subtitle'),
+            location: text(card, '.entity-result__secondary-subtitle')
+        };
+    });
+    const next = document.querySelector('button.artdeco-pagination__button--next');
+    return { results, hasMore: !!next && !next.disabled };
+})()
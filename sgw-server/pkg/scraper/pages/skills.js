@@ -0,0 +1,17 @@
+(() => {
+    const seen = new Set();
+    return Array.from(document.querySelectorAll({{selector "list.entry"}})).map(el => {
+        const position = el.querySelector({{selector "entry"}});
+        if (!position) return null;
+        const name = position.querySelector({{selector "entry.linked-title"}})?.textContent?.trim() || '';
+        // The skills page lists a skill once per tab, keep the first
+        if (!name || seen.has(name)) return null;
+        seen.add(name);
+        // Endorsements show as "12 endorsements" among the entity's sub-components
+        const endorsed = Array.from(position.querySelectorAll('span[aria-hidden="true"]'))
+            .map(span => span.textContent.trim())
+            .find(text => /endorsement/i.test(text)) || '';
+        const endorsements = parseInt(endorsed.replace(/[^0-9]/g, ''), 10) || 0;
+        return { name, endorsements };
+    }).filter(item => item !== null);
+})()
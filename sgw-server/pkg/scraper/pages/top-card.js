@@ -0,0 +1,22 @@
+(() => {
+    const text = selector => document.querySelector(selector)?.textContent?.trim() || '';
+    const photo = document.querySelector('.pv-top-card-profile-picture__image, .pv-top-card-profile-picture img');
+    const src = photo?.src || '';
+    const openToWork = (photo && /open_?to_?work/i.test((photo.alt || '') + ' ' + (photo.title || ''))) ||
+        Array.from(document.querySelectorAll('[class*="open-to-carousel"]'))
+            .some(el => /open to work/i.test(el.textContent));
+    // Counts sit under the top card or, for followers, atop the activity section
+    const counts = Array.from(document.querySelectorAll('main li, main span, main p'))
+        .map(el => el.textContent.trim().replace(/\s+/g, ' '));
+    const count = noun => counts.find(t => new RegExp('^[0-9][0-9.,]*[KM]?\\+? ' + noun + 's?$').test(t)) || '';
+    return {
+        headline: text('.mt2.relative .text-body-medium.break-words'),
+        pronouns: text('.mt2.relative .text-body-small.v-align-middle.break-words.t-black--light'),
+        photoUrl: src.startsWith('http') && !/ghost/i.test(src + ' ' + (photo?.className || '')) ? src : '',
+        openToWork: !!openToWork,
+        // The first experience entry is the current role, its logo links to the employer
+        companyUrl: document.querySelector('a[data-field="experience_company_logo"]')?.href || '',
+        connections: count('connection'),
+        followers: count('follower'),
+    };
+})()
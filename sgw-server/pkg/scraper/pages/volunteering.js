@@ -0,0 +1,10 @@
+Array.from(document.querySelectorAll({{selector "list.entry"}})).map(el => {
+    const position = el.querySelector({{selector "entry"}});
+    if (!position) return null;
+    const role = position.querySelector({{selector "entry.title"}})?.textContent?.trim() || '';
+    const organization = position.querySelector({{selector "entry.subtitle"}})?.textContent?.trim() || '';
+    // Duration and cause are consecutive light lines, the cause is left out when not set
+    const light = Array.from(position.querySelectorAll({{selector "entry.caption"}}))
+        .map(span => span.textContent.trim());
+    return { role, organization, duration: light[0] || '', cause: light[1] || '' };
+}).filter(item => item !== null);
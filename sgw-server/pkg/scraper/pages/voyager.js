@@ -0,0 +1,3 @@
+// Returns the voyager API responses LinkedIn embeds in the page for its scripts to hydrate
+// from, as the HTML of their code tags: some wrap the JSON in a comment, which has no text.
+Array.from(document.querySelectorAll({{selector "voyager"}})).map(code => code.innerHTML)
@@ -0,0 +1,130 @@
+package scraper
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scrapedPost is a post as the extraction script returns it, before its counts and
+// activity URN are parsed.
+type scrapedPost struct {
+	Content   string `json:"content"`
+	URN       string `json:"urn"`
+	Reactions string `json:"reactions"`
+	Comments  string `json:"comments"`
+	Media     string `json:"media"`   // "image" or "document" for media posts
+	MediaID   string `json:"mediaId"` // data-sgw-media value marking the media element
+	// Captions track of a video post, "" for other posts
+	Captions string `json:"captions"`
+}
+
+func (p scrapedPost) post() Post {
+	post := Post{Content: trimSeeMore(p.Content), Reactions: ParseCount(p.Reactions), Comments: ParseCount(p.Comments)}
+	if id := activityID(p.URN); id != 0 {
+		post.URL = "https://www.linkedin.com/feed/update/" + p.URN + "/"
+		post.PostedAt = activityTime(id)
+	}
+	return post
+}
+
+// scrapedArticle is an article card as the extraction script returns it.
+type scrapedArticle struct {
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	URN     string `json:"urn"`
+	Excerpt string `json:"excerpt"`
+}
+
+func (a scrapedArticle) article() Article {
+	article := Article{Title: a.Title, URL: a.URL, Excerpt: a.Excerpt}
+	if id := activityID(a.URN); id != 0 {
+		article.PublishedAt = activityTime(id)
+	}
+	return article
+}
+
+// scrapedComment is a comment card as the extraction script returns it, with the URN of the
+// post it replies to and its own urn:li:comment URN.
+type scrapedComment struct {
+	Content     string `json:"content"`
+	PostAuthor  string `json:"postAuthor"`
+	PostExcerpt string `json:"postExcerpt"`
+	URN         string `json:"urn"`
+	CommentURN  string `json:"commentUrn"`
+}
+
+func (c scrapedComment) comment() Comment {
+	comment := Comment{Content: c.Content, PostAuthor: c.PostAuthor, PostExcerpt: c.PostExcerpt}
+	if activityID(c.URN) != 0 {
+		comment.URL = "https://www.linkedin.com/feed/update/" + c.URN + "/"
+	}
+	if id := commentID(c.CommentURN); id != 0 {
+		comment.CommentedAt = activityTime(id)
+	}
+	return comment
+}
+
+// activityID returns the numeric id of an urn:li:activity URN, 0 for anything else.
+func activityID(urn string) uint64 {
+	id, ok := strings.CutPrefix(urn, "urn:li:activity:")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// commentID returns the numeric id of a comment in an urn:li:comment URN, e.g.
+// "urn:li:comment:(activity:7200000000000000000,7200000000000000123)"; 0 for anything else.
+func commentID(urn string) uint64 {
+	inner, ok := strings.CutPrefix(urn, "urn:li:comment:(")
+	if !ok {
+		return 0
+	}
+	_, id, ok := strings.Cut(strings.TrimSuffix(inner, ")"), ",")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// activityTime decodes the publish time LinkedIn keeps in the top 41 bits of an activity or comment id,
+// as milliseconds since the Unix epoch. The feed only shows relative times such as "3w".
+func activityTime(id uint64) time.Time {
+	return time.UnixMilli(int64(id >> 22)).UTC()
+}
+
+/*
+	ParseCount parses an engagement count as LinkedIn renders it.
+
+Counts are shown as "42", "1,204", "1.2K", "3M" or "500+", optionally followed
+by a word such as "comments". Text without a number counts as 0.
+*/
+func ParseCount(text string) int {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return 0
+	}
+	n := strings.TrimSuffix(strings.ReplaceAll(fields[0], ",", ""), "+")
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(n, "K"):
+		n, multiplier = strings.TrimSuffix(n, "K"), 1e3
+	case strings.HasSuffix(n, "M"):
+		n, multiplier = strings.TrimSuffix(n, "M"), 1e6
+	}
+	v, err := strconv.ParseFloat(n, 64)
+	if err != nil || v < 0 {
+		return 0
+	}
+	return int(math.Round(v * multiplier))
+}
@@ -0,0 +1,128 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// roleListRe splits the roles of an open-to-work card, "Data Engineer, Analytics Engineer and ML Engineer roles".
+var roleListRe = regexp.MustCompile(`\s*,\s*(?:and\s+)?|\s+and\s+`)
+
+/*
+	JobPreferences represents what an open-to-work profile owner said they are looking for.
+
+It is read from the "Open to work" card under the top card and the details it
+opens; LinkedIn only shows the card when the owner shares it with everyone.
+*/
+type JobPreferences struct {
+	Titles    []string `json:"titles"`              // Job titles, e.g. "Data Engineer"
+	Locations []string `json:"locations,omitempty"` // Places and workplace types, e.g. "Bengaluru" or "Remote"
+	StartDate string   `json:"startDate,omitempty"` // As shown, e.g. "Immediately, I'm actively applying"
+}
+
+func (j *JobPreferences) clone() *JobPreferences {
+	if j == nil {
+		return nil
+	}
+	copied := *j
+	copied.Titles = append([]string(nil), j.Titles...)
+	copied.Locations = append([]string(nil), j.Locations...)
+	return &copied
+}
+
+// preferenceDetail is one labelled row of the open-to-work details, e.g. "Job titles" with its values.
+type preferenceDetail struct {
+	Label  string   `json:"label"`
+	Values []string `json:"values"`
+}
+
+/*
+	jobPreferences reads the open-to-work card of the profile page the browser is on.
+
+The card lists the titles; its details dialog, when it opens, adds the
+locations and start date and is closed again so later sections read the
+profile page. Returns nil when the card isn't shown.
+*/
+func (s *Scraper) jobPreferences(ctx context.Context) (*JobPreferences, error) {
+	var card struct {
+		Summary string `json:"summary"`
+		Details bool   `json:"details"`
+	}
+	err := chromedp.Run(ctx, evaluate(script("open-to-work"), &card))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the open-to-work card: %w", err)
+	}
+	if card.Summary == "" && !card.Details {
+		return nil, nil
+	}
+	prefs := &JobPreferences{Titles: roleTitles(card.Summary)}
+	if !card.Details {
+		return prefs, nil
+	}
+
+	var details []preferenceDetail
+	err = chromedp.Run(ctx,
+		waitVisible(Selector("modal"), chromedp.ByQuery),
+		pause(time.Second),
+		evaluate(script("job-preferences"), &details),
+	)
+	if err != nil {
+		// The card's titles are still worth keeping
+		fmt.Printf("Could not read the open-to-work details: %v\n", err)
+		return prefs, nil
+	}
+	readPreferences(prefs, details)
+	return prefs, nil
+}
+
+// roleTitles returns the titles of an open-to-work card summary, nil when it lists none.
+func roleTitles(summary string) []string {
+	summary = strings.TrimSpace(summary)
+	summary = strings.TrimSuffix(strings.TrimSuffix(summary, " roles"), " role")
+	var titles []string
+	for _, title := range roleListRe.Split(summary, -1) {
+		if title = strings.TrimSpace(title); title != "" {
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
+
+/*
+	readPreferences sets the preferences the open-to-work details list.
+
+Rows are recognised by their label: job titles replace the card's shorter list,
+on-site and remote locations and location types ("Remote", "Hybrid") become
+Locations, and the start date is kept as shown. Values may be one per row or
+joined with " · ".
+*/
+func readPreferences(prefs *JobPreferences, details []preferenceDetail) {
+	var locations []string
+	for _, d := range details {
+		var values []string
+		for _, v := range d.Values {
+			for _, part := range strings.Split(v, " · ") {
+				if part = strings.TrimSpace(part); part != "" {
+					values = append(values, part)
+				}
+			}
+		}
+		label := strings.ToLower(d.Label)
+		switch {
+		case strings.HasPrefix(label, "job title"):
+			prefs.Titles = values
+		case strings.HasPrefix(label, "location"):
+			locations = append(locations, values...)
+		case strings.HasPrefix(label, "start date") && len(values) > 0:
+			prefs.StartDate = values[0]
+		}
+	}
+	if locations != nil {
+		prefs.Locations = locations
+	}
+}
@@ -0,0 +1,131 @@
+//go:build linux
+
+package scraper
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// runnerMarker is part of the temporary user-data-dir chromedp gives every browser it launches,
+// which tells our Chrome processes apart from any other Chrome on the host.
+const runnerMarker = "chromedp-runner"
+
+type procInfo struct {
+	pid, ppid int
+	rss       int64
+	state     byte
+}
+
+// processTreeRSS returns the resident memory in bytes of pid and all its descendants.
+func processTreeRSS(pid int) (int64, error) {
+	procs, err := listProcs()
+	if err != nil {
+		return 0, err
+	}
+	children := map[int][]int{}
+	byPid := map[int]procInfo{}
+	for _, p := range procs {
+		children[p.ppid] = append(children[p.ppid], p.pid)
+		byPid[p.pid] = p
+	}
+
+	var total int64
+	queue := []int{pid}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		total += byPid[current].rss
+		queue = append(queue, children[current]...)
+	}
+	return total, nil
+}
+
+/*
+	reapOrphans kills orphaned chromedp browser processes and, as PID 1, waits on orphaned zombies.
+
+Orphans are re-parented to PID 1, which is also the parent of our own browsers
+when the server is PID 1, so a process only counts as orphaned when it is not
+an open scraper's browser. Zombies are waited on by PID, never with -1, so the
+exit status of a browser still owned by its exec.Cmd is not stolen.
+*/
+func reapOrphans() int {
+	live, settled := liveBrowsers()
+	if !settled {
+		return 0
+	}
+	procs, err := listProcs()
+	if err != nil {
+		return 0
+	}
+
+	reaped := 0
+	self := os.Getpid()
+	for _, p := range procs {
+		if p.ppid != 1 || p.pid == self || live[p.pid] {
+			continue
+		}
+		if p.state == 'Z' {
+			if self != 1 {
+				continue
+			}
+			var status syscall.WaitStatus
+			if pid, err := syscall.Wait4(p.pid, &status, syscall.WNOHANG, nil); err == nil && pid == p.pid {
+				reaped++
+			}
+			continue
+		}
+		cmdline, err := os.ReadFile("/proc/" + strconv.Itoa(p.pid) + "/cmdline")
+		if err != nil || !ours(cmdline) {
+			continue
+		}
+		if syscall.Kill(p.pid, syscall.SIGKILL) == nil {
+			reaped++
+		}
+	}
+	return reaped
+}
+
+// ours reports whether a process was started by a scraper: with chromedp's temporary
+// user-data-dir, or with an account's profile under UserDataDir.
+func ours(cmdline []byte) bool {
+	if bytes.Contains(cmdline, []byte(runnerMarker)) {
+		return true
+	}
+	return UserDataDir != "" && bytes.Contains(cmdline, []byte("--user-data-dir="+UserDataDir))
+}
+
+func listProcs() ([]procInfo, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+	pageSize := int64(os.Getpagesize())
+	procs := make([]procInfo, 0, len(entries))
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		stat, err := os.ReadFile("/proc/" + e.Name() + "/stat")
+		if err != nil {
+			continue
+		}
+		// The command name may contain spaces, so fields are read after its closing paren.
+		end := bytes.LastIndexByte(stat, ')')
+		if end < 0 {
+			continue
+		}
+		fields := strings.Fields(string(stat[end+1:]))
+		if len(fields) < 22 {
+			continue
+		}
+		ppid, _ := strconv.Atoi(fields[1])
+		rssPages, _ := strconv.ParseInt(fields[21], 10, 64)
+		procs = append(procs, procInfo{pid: pid, ppid: ppid, rss: rssPages * pageSize, state: fields[0][0]})
+	}
+	return procs, nil
+}
@@ -0,0 +1,94 @@
+//go:build linux
+
+package scraper
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// orphan starts a process carrying runnerMarker whose parent exits, so it is re-parented.
+func orphan(t *testing.T) int {
+	t.Helper()
+	out, err := exec.Command("sh", "-c", `sh -c "sleep 60 # `+runnerMarker+`" >/dev/null 2>&1 & echo $!`).Output()
+	if err != nil {
+		t.Fatalf("start orphan: %v", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(out)))
+	if err != nil {
+		t.Fatalf("orphan pid %q: %v", out, err)
+	}
+	t.Cleanup(func() { syscall.Kill(pid, syscall.SIGKILL) })
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		procs, _ := listProcs()
+		for _, p := range procs {
+			if p.pid == pid && p.ppid == 1 {
+				return pid
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Skip("orphans are not re-parented to PID 1 here (a subreaper is running)")
+	return 0
+}
+
+func alive(pid int) bool {
+	procs, _ := listProcs()
+	for _, p := range procs {
+		if p.pid == pid && p.state != 'Z' {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReapOrphansSparesOpenBrowsers(t *testing.T) {
+	if os.Getpid() == 1 {
+		t.Skip("the test process must not be PID 1")
+	}
+	live := orphan(t)
+	registerBrowser(live)
+	defer unregisterBrowser(live)
+	dead := orphan(t)
+
+	reapOrphans()
+	time.Sleep(100 * time.Millisecond)
+	if !alive(live) {
+		t.Error("a registered browser was killed")
+	}
+	if alive(dead) {
+		t.Error("an orphaned browser survived the sweep")
+	}
+}
+
+func TestReapOrphansWaitsForLaunches(t *testing.T) {
+	if os.Getpid() == 1 {
+		t.Skip("the test process must not be PID 1")
+	}
+	pid := orphan(t)
+	done := launching()
+	n := reapOrphans()
+	done()
+	if n != 0 || !alive(pid) {
+		t.Error("a sweep ran while a browser was being launched")
+	}
+}
+
+func TestOursWithUserDataDir(t *testing.T) {
+	cmdline := []byte("/usr/bin/chromium\x00--user-data-dir=/var/lib/segwise/chrome/a@x.com\x00--headless")
+	if ours(cmdline) {
+		t.Error("claimed a browser with a profile while UserDataDir is unset")
+	}
+	UserDataDir = "/var/lib/segwise/chrome"
+	defer func() { UserDataDir = "" }()
+	if !ours(cmdline) || ours([]byte("/usr/bin/chromium\x00--user-data-dir=/home/me/.config/chromium")) {
+		t.Error("ours doesn't tell the accounts' profiles from other browsers")
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package scraper
+
+import "errors"
+
+func processTreeRSS(pid int) (int64, error) {
+	return 0, errors.New("process memory sampling is only supported on linux")
+}
+
+func reapOrphans() int {
+	return 0
+}
@@ -0,0 +1,486 @@
+package scraper
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// publicTimeout bounds fetching a public profile outside ScrapeWithBudget.
+	publicTimeout = 20 * time.Second
+	// publicMaxBytes caps how much of a public profile page is read.
+	publicMaxBytes = 2 << 20
+	// publicUserAgent is sent with public profile requests, LinkedIn answers unknown clients with status 999.
+	publicUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36"
+)
+
+var (
+	ldJSONRe   = regexp.MustCompile(`(?is)<script[^>]*type="application/ld\+json"[^>]*>(.*?)</script>`)
+	ogRe       = regexp.MustCompile(`(?is)<meta\s[^>]*property="og:([a-z:]+)"[^>]*content="([^"]*)"`)
+	headlineRe = regexp.MustCompile(`(?is)<h2[^>]*class="[^"]*top-card-layout__headline[^"]*"[^>]*>(.*?)</h2>`)
+	sublineRe  = regexp.MustCompile(`(?is)<(?:div|span)[^>]*class="[^"]*top-card__subline-item[^"]*"[^>]*>(.*?)</(?:div|span)>`)
+	networkRe  = regexp.MustCompile(`(?i)([0-9][0-9.,]*[KM]?\+?)\s+(connection|follower)s?\b`)
+	htmlTagRe  = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// publicSections are the sections a public profile shows, in PageOrder.
+var publicSections = []Section{SectionNameAndLocation, SectionAbout, SectionArticles, SectionExperience, SectionEducation, SectionLanguages}
+
+/*
+	PublicScraper reads the public profile LinkedIn shows logged out visitors, for
+	when no LinkedIn credentials are supplied.
+
+It fetches the profile page once per target and reads the JSON-LD metadata
+LinkedIn embeds for search engines, falling back to the page's Open Graph tags
+and top card. The Profile is reduced: name, location, headline, photo, current
+employer link, connection and follower counts, About, experience, education,
+languages and articles when the page lists them. Other sections, posts
+included, fail with ErrNotPublic, and LinkedIn often keeps the page behind its
+authwall (ErrAuthwall) for visitors it has seen too often.
+
+It has the methods of a Scraper but needs no browser; Client defaults to
+http.DefaultClient, through the proxy Proxies hands out when that is set. With
+Fingerprinting set it sends the user agent and language of a fingerprint drawn
+when it is created.
+*/
+type PublicScraper struct {
+	Client *http.Client
+
+	fingerprint Fingerprint
+	mu          sync.Mutex
+	linkedInURL string
+	page        *Profile // Everything the public page of linkedInURL shows, nil until fetched
+	profile     *Profile
+}
+
+/*
+	NewPublicScraper returns a PublicScraper positioned at linkedInURL.
+
+Parameters:
+  - linkedInURL: Target profile URL to scrape
+
+Returns:
+  - *PublicScraper: The scraper, nothing is fetched before the first section
+*/
+func NewPublicScraper(linkedInURL string) *PublicScraper {
+	return &PublicScraper{linkedInURL: linkedInURL, profile: &Profile{}, fingerprint: pickFingerprint("")}
+}
+
+// SetProfileURL points the scraper at another profile, resetting what was scraped.
+func (s *PublicScraper) SetProfileURL(linkedInURL string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.linkedInURL = linkedInURL
+	s.page = nil
+	s.profile = &Profile{}
+}
+
+/*
+	ScrapeWithBudget copies the given sections from the public profile, fetched within budget.
+
+Returns:
+  - []SectionResult: One result per requested section, in the given order; sections
+    public profiles don't show carry ErrNotPublic
+*/
+func (s *PublicScraper) ScrapeWithBudget(ctx context.Context, budget time.Duration, sections ...Section) []SectionResult {
+	ctx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+	results := make([]SectionResult, len(sections))
+	for i, section := range sections {
+		start := time.Now()
+		results[i] = SectionResult{Section: section, Err: s.scrape(ctx, section), Took: time.Since(start)}
+	}
+	return results
+}
+
+// ScrapeAll copies every given section from the public profile, see Scraper.ScrapeAll.
+// Sections the public page doesn't show fail with ErrNotPublic.
+func (s *PublicScraper) ScrapeAll(ctx context.Context, sections ...Section) (Profile, error) {
+	results := make([]SectionResult, len(sections))
+	for i, section := range sections {
+		start := time.Now()
+		results[i] = SectionResult{Section: section, Err: s.get(ctx, section), Took: time.Since(start)}
+	}
+	return s.Profile(), Errors(results)
+}
+
+func (s *PublicScraper) GetNameAndLocation(ctx context.Context) error {
+	return s.get(ctx, SectionNameAndLocation)
+}
+
+func (s *PublicScraper) GetAbout(ctx context.Context) error {
+	return s.get(ctx, SectionAbout)
+}
+
+func (s *PublicScraper) GetExperiences(ctx context.Context) error {
+	return s.get(ctx, SectionExperience)
+}
+
+func (s *PublicScraper) GetEducation(ctx context.Context) error {
+	return s.get(ctx, SectionEducation)
+}
+
+func (s *PublicScraper) GetSkills(ctx context.Context) error {
+	return s.get(ctx, SectionSkills)
+}
+
+func (s *PublicScraper) GetCertifications(ctx context.Context) error {
+	return s.get(ctx, SectionCertifications)
+}
+
+func (s *PublicScraper) GetRecommendations(ctx context.Context) error {
+	return s.get(ctx, SectionRecommendations)
+}
+
+func (s *PublicScraper) GetVolunteering(ctx context.Context) error {
+	return s.get(ctx, SectionVolunteering)
+}
+
+func (s *PublicScraper) GetPublications(ctx context.Context) error {
+	return s.get(ctx, SectionPublications)
+}
+
+func (s *PublicScraper) GetPatents(ctx context.Context) error {
+	return s.get(ctx, SectionPatents)
+}
+
+func (s *PublicScraper) GetLanguages(ctx context.Context) error {
+	return s.get(ctx, SectionLanguages)
+}
+
+func (s *PublicScraper) GetArticles(ctx context.Context) error {
+	return s.get(ctx, SectionArticles)
+}
+
+func (s *PublicScraper) GetRecentComments(ctx context.Context) error {
+	return s.get(ctx, SectionComments)
+}
+
+func (s *PublicScraper) GetRecentPosts(ctx context.Context) error {
+	return s.get(ctx, SectionPosts)
+}
+
+func (s *PublicScraper) GetCompany(ctx context.Context) error {
+	return s.get(ctx, SectionCompany)
+}
+
+func (s *PublicScraper) GetContactInfo(ctx context.Context) error {
+	return s.get(ctx, SectionContactInfo)
+}
+
+// GetJob fails, job postings are only read with a logged in session.
+func (s *PublicScraper) GetJob(ctx context.Context, jobURL string) (*Job, error) {
+	return nil, fmt.Errorf("%w: job postings", ErrNotPublic)
+}
+
+// SearchPeople fails, LinkedIn only lets members search people.
+func (s *PublicScraper) SearchPeople(ctx context.Context, query string, filters SearchFilters) (SearchPage, error) {
+	return SearchPage{}, fmt.Errorf("%w: people search", ErrNotPublic)
+}
+
+// Profile returns a copy of what was copied from the public profile so far.
+func (s *PublicScraper) Profile() Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.profile.Clone()
+}
+
+// Renew, Ping, Relogin and Close have nothing to do without a session.
+func (s *PublicScraper) Renew(lease time.Duration) {}
+func (s *PublicScraper) Ping() error               { return nil }
+func (s *PublicScraper) Relogin() error            { return nil }
+func (s *PublicScraper) Close()                    {}
+
+func (s *PublicScraper) get(ctx context.Context, section Section) error {
+	ctx, cancel := context.WithTimeout(ctx, publicTimeout)
+	defer cancel()
+	return s.scrape(ctx, section)
+}
+
+// scrape copies section from the public page into the profile, fetching the page first.
+func (s *PublicScraper) scrape(ctx context.Context, section Section) error {
+	found := false
+	for _, public := range publicSections {
+		found = found || public == section
+	}
+	if !found {
+		return fmt.Errorf("%w: %s", ErrNotPublic, section)
+	}
+	page, err := s.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.profile
+	switch section {
+	case SectionNameAndLocation:
+		p.Name, p.Location, p.Headline, p.PhotoURL = page.Name, page.Location, page.Headline, page.PhotoURL
+		p.CompanyURL, p.Connections, p.Followers = page.CompanyURL, page.Connections, page.Followers
+	case SectionAbout:
+		p.About = page.About
+	case SectionArticles:
+		p.Articles = append([]Article{}, page.Articles...)
+	case SectionExperience:
+		p.Experience = append([]Experience{}, page.Experience...)
+	case SectionEducation:
+		p.Education = append([]Education{}, page.Education...)
+	case SectionLanguages:
+		p.Languages = append([]Language{}, page.Languages...)
+	}
+	return nil
+}
+
+// fetch returns the parsed public page of the current target, fetching it on first use.
+func (s *PublicScraper) fetch(ctx context.Context) (*Profile, error) {
+	s.mu.Lock()
+	page, target := s.page, s.linkedInURL
+	s.mu.Unlock()
+	if page != nil {
+		return page, nil
+	}
+	public := ProfilePage(target)
+	if public == "" {
+		return nil, fmt.Errorf("not a public LinkedIn profile: %q", target)
+	}
+
+	fmt.Println("Getting public profile")
+	// The description's "500+ connections" is read in English
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, localized(public), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", cmp.Or(s.fingerprint.UserAgent, publicUserAgent))
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("Accept-Language", s.fingerprint.acceptLanguage("en-US,en;q=0.9"))
+	client, proxy := s.Client, (*url.URL)(nil)
+	if client == nil {
+		if proxy, err = proxyFor(""); err != nil {
+			return nil, err
+		}
+		client = http.DefaultClient
+		if proxy != nil {
+			client = &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxy)}}
+		}
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		reportProxy(proxy, err)
+		return nil, fmt.Errorf("failed to fetch public profile: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == 999 && proxy != nil {
+		// LinkedIn's answer to addresses it has had enough of, unlike the authwall it shows everyone
+		Proxies.Failed(proxy, fmt.Errorf("%w: status 999", ErrAuthwall))
+	}
+	if res.StatusCode == 999 || loggedOut(res.Request.URL.String()) {
+		return nil, fmt.Errorf("%w: %s", ErrAuthwall, public)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch public profile: status %d", res.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, publicMaxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public profile: %w", err)
+	}
+
+	page, err = parsePublicProfile(string(body))
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	if s.linkedInURL == target {
+		s.page = page
+	}
+	s.mu.Unlock()
+	return page, nil
+}
+
+/*
+	parsePublicProfile reads a public profile page.
+
+The JSON-LD Person LinkedIn embeds gives most fields; the headline, and the
+location and counts when the metadata lacks them, come from the top card and the
+Open Graph description ("... · Location: Bengaluru · 500+ connections on LinkedIn").
+*/
+func parsePublicProfile(doc string) (*Profile, error) {
+	p := &Profile{}
+	found := false
+	for _, m := range ldJSONRe.FindAllStringSubmatch(doc, -1) {
+		var ld map[string]json.RawMessage
+		if json.Unmarshal([]byte(html.UnescapeString(strings.TrimSpace(m[1]))), &ld) != nil {
+			continue
+		}
+		nodes := ldList(ld["@graph"])
+		if len(nodes) == 0 {
+			nodes = []map[string]json.RawMessage{ld}
+		}
+		for _, node := range nodes {
+			switch ldText(node["@type"]) {
+			case "Person":
+				readPerson(p, node)
+				found = true
+			case "Article":
+				if title := ldText(node["headline"]); title != "" {
+					a := Article{Title: title, URL: ldText(node["url"]), Excerpt: ldText(node["description"])}
+					a.PublishedAt, _ = time.Parse(time.RFC3339, ldText(node["datePublished"]))
+					p.Articles = append(p.Articles, a)
+				}
+			}
+		}
+	}
+
+	og := map[string]string{}
+	for _, m := range ogRe.FindAllStringSubmatch(doc, -1) {
+		og[m[1]] = html.UnescapeString(m[2])
+	}
+	if p.Name == "" {
+		// "Priya Raman - Moonfrog Labs | LinkedIn"
+		name, _, _ := strings.Cut(strings.TrimSuffix(og["title"], " | LinkedIn"), " - ")
+		p.Name = strings.TrimSpace(name)
+		found = found || p.Name != ""
+	}
+	if !found {
+		return nil, errors.New("public profile page has no profile metadata")
+	}
+	if m := headlineRe.FindStringSubmatch(doc); m != nil {
+		p.Headline = pageText(m[1])
+	}
+	if p.PhotoURL == "" && strings.HasPrefix(og["image"], "https://media.licdn.com/") {
+		p.PhotoURL = og["image"]
+	}
+
+	description := og["description"]
+	for _, m := range sublineRe.FindAllStringSubmatch(doc, -1) {
+		description += " · " + pageText(m[1])
+	}
+	for _, part := range strings.Split(description, " · ") {
+		if location, ok := strings.CutPrefix(strings.TrimSpace(part), "Location: "); ok && p.Location == "" {
+			p.Location = location
+		}
+	}
+	if p.Location == "" {
+		if m := sublineRe.FindStringSubmatch(doc); m != nil && !networkRe.MatchString(m[1]) {
+			p.Location = pageText(m[1])
+		}
+	}
+	for _, m := range networkRe.FindAllStringSubmatch(description, -1) {
+		if strings.EqualFold(m[2], "connection") && p.Connections == 0 {
+			p.Connections = ParseCount(m[1])
+		} else if strings.EqualFold(m[2], "follower") && p.Followers == 0 {
+			p.Followers = ParseCount(m[1])
+		}
+	}
+	return p, nil
+}
+
+// readPerson reads a JSON-LD Person into p. LinkedIn lists the current titles in jobTitle in
+// the order of the employers in worksFor.
+func readPerson(p *Profile, person map[string]json.RawMessage) {
+	p.Name = ldText(person["name"])
+	p.About = ldText(person["description"])
+	if image := ldText(person["image"]); strings.HasPrefix(image, "https://") {
+		p.PhotoURL = image
+	}
+	for _, address := range ldList(person["address"]) {
+		var parts []string
+		for _, key := range []string{"addressLocality", "addressRegion", "addressCountry"} {
+			if part := ldText(address[key]); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		p.Location = strings.Join(parts, ", ")
+	}
+
+	var titles []string
+	if json.Unmarshal(person["jobTitle"], &titles) != nil {
+		titles = []string{ldText(person["jobTitle"])}
+	}
+	for i, org := range ldList(person["worksFor"]) {
+		e := Experience{Company: ldText(org["name"]), Duration: ldDuration(org["member"])}
+		if i < len(titles) {
+			e.Title = titles[i]
+		}
+		if i == 0 {
+			p.CompanyURL = CompanyPage(ldText(org["url"]))
+		}
+		p.Experience = append(p.Experience, e)
+	}
+	for _, school := range ldList(person["alumniOf"]) {
+		if ldText(school["@type"]) != "EducationalOrganization" {
+			continue
+		}
+		p.Education = append(p.Education, Education{Institute: ldText(school["name"]), Duration: ldDuration(school["member"])})
+	}
+	for _, language := range ldList(person["knowsLanguage"]) {
+		p.Languages = append(p.Languages, Language{Name: ldText(language["name"])})
+	}
+	for _, stat := range ldList(person["interactionStatistic"]) {
+		var n int
+		if strings.HasSuffix(ldText(stat["interactionType"]), "FollowAction") && json.Unmarshal(stat["userInteractionCount"], &n) == nil {
+			p.Followers = n
+		}
+	}
+}
+
+// ldList returns a JSON-LD value that may be one node or a list of them as a list of nodes.
+func ldList(raw json.RawMessage) []map[string]json.RawMessage {
+	var list []map[string]json.RawMessage
+	if json.Unmarshal(raw, &list) == nil {
+		return list
+	}
+	var one map[string]json.RawMessage
+	if json.Unmarshal(raw, &one) == nil && one != nil {
+		return []map[string]json.RawMessage{one}
+	}
+	return nil
+}
+
+// ldText returns a JSON-LD string, number or, for nodes, their name or contentUrl, "" for anything else.
+func ldText(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return strings.TrimSpace(s)
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String()
+	}
+	if nodes := ldList(raw); len(nodes) > 0 {
+		return cmp.Or(ldText(nodes[0]["name"]), ldText(nodes[0]["contentUrl"]))
+	}
+	return ""
+}
+
+// ldDuration formats the start and end dates of a JSON-LD role like the profile does, e.g. "2021 - Present".
+func ldDuration(raw json.RawMessage) string {
+	roles := ldList(raw)
+	if len(roles) == 0 {
+		return ""
+	}
+	start, end := ldText(roles[0]["startDate"]), ldText(roles[0]["endDate"])
+	if start == "" {
+		return end
+	}
+	if end == "" {
+		end = "Present"
+	}
+	return start + " - " + end
+}
+
+// pageText returns the text of an HTML fragment with its whitespace collapsed.
+func pageText(fragment string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(htmlTagRe.ReplaceAllString(fragment, " "))), " ")
+}
@@ -0,0 +1,174 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+/*
+	ScrapeRate paces the pages each LinkedIn account opens and caps the profiles it scrapes.
+
+A burst of requests would otherwise have one account open hundreds of
+profiles in minutes, which is what gets accounts restricted. Navigations of an
+account are spaced NavigationDelay apart, and a profile that would take the
+account past PerHour profiles in the last hour or PerDay in the last day fails
+its sections with a *CapError instead of being opened. Zero values disable
+the respective limit.
+*/
+type ScrapeRate struct {
+	NavigationDelay time.Duration // Shortest time between two pages an account opens
+	PerHour         int           // Most profiles an account scrapes in any hour
+	PerDay          int           // Most profiles an account scrapes in any 24 hours
+}
+
+// Rate is the pace and the caps every account is held to.
+var Rate = ScrapeRate{
+	NavigationDelay: 2 * time.Second,
+	PerHour:         60,
+	PerDay:          250,
+}
+
+// ScrapeCounter keeps the times each account scraped profiles at, so the caps of Rate
+// hold across restarts and the instances sharing them.
+type ScrapeCounter interface {
+	// TakeScrape counts a profile scraped by account at now unless rate's caps are reached,
+	// and returns now when it did, otherwise when the account may scrape its next profile.
+	TakeScrape(account string, now time.Time, rate ScrapeRate) (time.Time, error)
+}
+
+// ScrapeCounts counts the profiles against Rate's caps, nil keeps the counts in memory,
+// lost on restart.
+var ScrapeCounts ScrapeCounter
+
+// CapError is returned by the sections of a profile when the account scraping it reached
+// one of Rate's caps.
+type CapError struct {
+	Until time.Time // When the account may scrape its next profile
+}
+
+func (e *CapError) Error() string {
+	return "linkedin account reached its profile scrape cap, the next profile may be scraped at " + e.Until.Format(time.RFC3339)
+}
+
+/*
+	Next returns when an account that scraped profiles at recent, oldest first, may scrape another.
+
+It is now when neither cap is reached, otherwise the time the oldest scrape
+counting towards the reached cap drops out of its window.
+*/
+func (r ScrapeRate) Next(recent []time.Time, now time.Time) time.Time {
+	next := now
+	for _, limit := range []struct {
+		max    int
+		window time.Duration
+	}{{r.PerHour, time.Hour}, {r.PerDay, 24 * time.Hour}} {
+		if limit.max <= 0 {
+			continue
+		}
+		i, _ := slices.BinarySearchFunc(recent, now.Add(-limit.window), func(t, since time.Time) int { return t.Compare(since) })
+		if within := recent[i:]; len(within) >= limit.max {
+			if at := within[len(within)-limit.max].Add(limit.window); at.After(next) {
+				next = at
+			}
+		}
+	}
+	return next
+}
+
+// memoryCounts counts scrapes in this process when ScrapeCounts is nil.
+var memoryCounts = &memoryCounter{scrapes: map[string][]time.Time{}}
+
+type memoryCounter struct {
+	mu      sync.Mutex
+	scrapes map[string][]time.Time
+}
+
+func (c *memoryCounter) TakeScrape(account string, now time.Time, rate ScrapeRate) (time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	account = strings.ToLower(account)
+	recent := Recent(c.scrapes[account], now)
+	next := rate.Next(recent, now)
+	if !next.After(now) {
+		recent = append(recent, now)
+	}
+	c.scrapes[account] = recent
+	return next, nil
+}
+
+// Recent drops the scrapes older than the longest cap's window from times, oldest first.
+func Recent(times []time.Time, now time.Time) []time.Time {
+	i, _ := slices.BinarySearchFunc(times, now.Add(-24*time.Hour), func(t, since time.Time) int { return t.Compare(since) })
+	return slices.Clone(times[i:])
+}
+
+/*
+	admit counts the target profile against the account's caps the first time one of its sections runs.
+
+Later sections of the same profile don't count again. A profile turned away
+is tried again by its next section, which fails the same way until a scrape
+drops out of the cap's window.
+*/
+func (s *Scraper) admit() error {
+	if Rate.PerHour <= 0 && Rate.PerDay <= 0 {
+		return nil
+	}
+	s.mu.Lock()
+	admitted := s.admitted
+	s.mu.Unlock()
+	if admitted {
+		return nil
+	}
+	counts := ScrapeCounts
+	if counts == nil {
+		counts = memoryCounts
+	}
+	now := time.Now()
+	next, err := counts.TakeScrape(s.email, now, Rate)
+	if err != nil {
+		return fmt.Errorf("failed to count the profile against the account's caps: %w", err)
+	}
+	if next.After(now) {
+		return &CapError{Until: next}
+	}
+	s.mu.Lock()
+	s.admitted = true
+	s.mu.Unlock()
+	return nil
+}
+
+type accountKey struct{}
+
+// lastNavigation is when each account opened, or is due to open, its latest page.
+var lastNavigation = struct {
+	sync.Mutex
+	at map[string]time.Time
+}{at: map[string]time.Time{}}
+
+// paced waits until Rate.NavigationDelay passed since the account of the browser ctx
+// belongs to opened its previous page. Navigations waiting together go one after another.
+func paced() chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		account, _ := ctx.Value(accountKey{}).(string)
+		if Rate.NavigationDelay <= 0 || account == "" {
+			return nil
+		}
+		lastNavigation.Lock()
+		at := lastNavigation.at[account].Add(Rate.NavigationDelay)
+		if now := time.Now(); at.Before(now) {
+			at = now
+		}
+		lastNavigation.at[account] = at
+		lastNavigation.Unlock()
+		if wait := time.Until(at); wait > 0 {
+			return sleep(ctx, wait)
+		}
+		return nil
+	})
+}
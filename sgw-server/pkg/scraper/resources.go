@@ -0,0 +1,166 @@
+package scraper
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+/*
+	ResourceLimits bounds what a single Chrome instance may consume.
+
+Zero values disable the respective limit. The limits are applied to every
+browser launched by NewScraper, so set them before creating scrapers.
+*/
+type ResourceLimits struct {
+	MaxRendererProcesses int           // --renderer-process-limit, caps renderer processes per browser
+	JSHeapMB             int           // V8 old space size per renderer, in megabytes
+	MaxMemoryMB          int           // Resident memory of the whole browser process tree that triggers a recycle
+	CheckInterval        time.Duration // How often the memory watchdog samples the process tree
+}
+
+// Limits are the resource limits applied to new browsers.
+var Limits = ResourceLimits{
+	MaxRendererProcesses: 4,
+	JSHeapMB:             512,
+	MaxMemoryMB:          1536,
+	CheckInterval:        10 * time.Second,
+}
+
+// flags returns the cgroup friendly Chrome flags for the limits. /dev/shm is tiny
+// in most containers, so shared memory is always moved to /tmp.
+func (l ResourceLimits) flags() []chromedp.ExecAllocatorOption {
+	opts := []chromedp.ExecAllocatorOption{
+		chromedp.Flag("disable-dev-shm-usage", true),
+		chromedp.Flag("disable-background-networking", true),
+		chromedp.Flag("disable-component-update", true),
+	}
+	if l.MaxRendererProcesses > 0 {
+		opts = append(opts, chromedp.Flag("renderer-process-limit", strconv.Itoa(l.MaxRendererProcesses)))
+	}
+	if l.JSHeapMB > 0 {
+		opts = append(opts, chromedp.Flag("js-flags", "--max-old-space-size="+strconv.Itoa(l.JSHeapMB)))
+	}
+	return opts
+}
+
+/*
+	startWatchdog samples the browser's process tree memory until the scraper is closed.
+
+When the tree exceeds Limits.MaxMemoryMB the browser is recycled: it is
+closed, which kills every renderer, and in-flight sections fail with a
+context error instead of the host running out of memory.
+*/
+func (s *Scraper) startWatchdog() {
+	limits := Limits
+	if limits.MaxMemoryMB <= 0 || limits.CheckInterval <= 0 {
+		return
+	}
+	c := chromedp.FromContext(s.browserCtx)
+	if c == nil || c.Browser == nil || c.Browser.Process() == nil {
+		return
+	}
+	pid := c.Browser.Process().Pid
+	ctx := s.browserCtx
+
+	go func() {
+		ticker := time.NewTicker(limits.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				rss, err := processTreeRSS(pid)
+				if err != nil {
+					continue
+				}
+				if mb := rss / (1024 * 1024); mb > int64(limits.MaxMemoryMB) {
+					fmt.Printf("Browser using %dMB (limit %dMB), recycling\n", mb, limits.MaxMemoryMB)
+					s.browserCancel()
+					return
+				}
+			}
+		}
+	}()
+}
+
+// browsers tracks the processes of open scrapers, which the reaper must leave alone.
+var browsers = struct {
+	sync.Mutex
+	pids      map[int]bool
+	launching int
+}{pids: map[int]bool{}}
+
+// launching marks a browser launch in progress until the returned function is called.
+// Its PID is not known before the launch completes, so sweeps are skipped meanwhile.
+func launching() func() {
+	browsers.Lock()
+	browsers.launching++
+	browsers.Unlock()
+	return func() {
+		browsers.Lock()
+		browsers.launching--
+		browsers.Unlock()
+	}
+}
+
+func registerBrowser(pid int) {
+	browsers.Lock()
+	defer browsers.Unlock()
+	browsers.pids[pid] = true
+}
+
+func unregisterBrowser(pid int) {
+	browsers.Lock()
+	defer browsers.Unlock()
+	delete(browsers.pids, pid)
+}
+
+// liveBrowsers returns a copy of the open browser PIDs, and false while a launch is in progress.
+func liveBrowsers() (map[int]bool, bool) {
+	browsers.Lock()
+	defer browsers.Unlock()
+	live := make(map[int]bool, len(browsers.pids))
+	for pid := range browsers.pids {
+		live[pid] = true
+	}
+	return live, browsers.launching == 0
+}
+
+/*
+	StartReaper periodically cleans up browser processes left behind by crashed scrapers.
+
+On Linux it kills Chrome processes launched by this package that were
+orphaned (re-parented when their browser died) and, when the server runs as
+PID 1 in a container, reaps zombie children that nothing else would wait for.
+Browsers of open scrapers are never killed, and their exit status is left to
+the chromedp allocator that started them. It is a no-op on other platforms. The returned function stops the reaper.
+
+Parameters:
+  - interval: Time between sweeps
+
+Returns:
+  - func(): Stops the reaper
+*/
+func StartReaper(interval time.Duration) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if n := reapOrphans(); n > 0 {
+					fmt.Printf("Reaped %d orphaned browser processes\n", n)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
@@ -0,0 +1,182 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+var (
+	// salesLeadRe matches a Sales Navigator lead link, capturing the member's profile id, e.g.
+	// https://www.linkedin.com/sales/lead/ACwAAB0R2xQBkXz,NAME_SEARCH,tbM5
+	salesLeadRe = regexp.MustCompile(`^https://(?:[a-z]+\.)?linkedin\.com/sales/(?:lead|people)/([A-Za-z0-9_-]+)`)
+	// salesCompanyRe matches a Sales Navigator account link, capturing the company's numeric id.
+	salesCompanyRe = regexp.MustCompile(`^https://(?:[a-z]+\.)?linkedin\.com/sales/company/(\d+)`)
+)
+
+/*
+	SalesLead returns the profile id of the Sales Navigator lead href links to.
+
+Lead links (/sales/lead/ and /sales/people/) name the member by the id LinkedIn
+uses internally rather than their vanity URL; the scraper resolves them to the
+public profile before scraping.
+
+Parameters:
+  - href: A link, e.g. copied from Sales Navigator's address bar
+
+Returns:
+  - string: The member's profile id, or "" when href is not a lead link
+*/
+func SalesLead(href string) string {
+	m := salesLeadRe.FindStringSubmatch(strings.TrimSpace(href))
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// leadPublicProfile returns the public profile URL for a lead's profile id, which LinkedIn
+// redirects to the member's vanity URL.
+func leadPublicProfile(id string) string {
+	return "https://www.linkedin.com/in/" + id + "/"
+}
+
+// salesCompanyPage returns the company page a Sales Navigator account link stands for, "" for anything else.
+func salesCompanyPage(href string) string {
+	m := salesCompanyRe.FindStringSubmatch(href)
+	if m == nil {
+		return ""
+	}
+	return "https://www.linkedin.com/company/" + m[1] + "/"
+}
+
+/*
+	openLead resolves a Sales Navigator lead target to its public profile, which the other
+	sections navigate from.
+
+Accounts with Sales Navigator access stay on the lead page, whose top card is read
+with Sales Navigator's selectors; it also shows out-of-network leads in full. Other
+accounts are redirected away from it, which is remembered for the session, and open
+the public profile instead. It reports whether the lead page was read.
+*/
+func (s *Scraper) openLead(ctx context.Context, lead string) (bool, error) {
+	id := SalesLead(lead)
+	if access, known := s.salesNavAccess(); !known || access {
+		var current string
+		err := chromedp.Run(ctx, paced(), inUILanguage(), chromedp.Navigate(localized(lead)), chromedp.Location(&current))
+		if err != nil {
+			return false, fmt.Errorf("failed to open lead: %w", err)
+		}
+		// Accounts without a seat are sent to a Sales Navigator login or upsell page, which is
+		// not a logged out session, so navigate's checks only apply past this point
+		if restricted(current) {
+			return false, fmt.Errorf("%w: redirected to %s", ErrAccountRestricted, current)
+		}
+		if challenged(current) {
+			return false, fmt.Errorf("%w: redirected to %s", ErrBotDetected, current)
+		}
+		access = SalesLead(current) != ""
+		s.setSalesNavAccess(access)
+		if access {
+			return true, s.readLead(ctx, id)
+		}
+		fmt.Println("No Sales Navigator access, opening the lead's public profile")
+	}
+
+	var current string
+	if err := chromedp.Run(ctx, navigate(leadPublicProfile(id)), chromedp.Location(&current)); err != nil {
+		return false, fmt.Errorf("failed to open public profile of lead: %w", err)
+	}
+	public := ProfilePage(current)
+	if public == "" {
+		public = leadPublicProfile(id)
+	}
+	s.setURL(public)
+	return false, nil
+}
+
+// readLead reads the top card of the open Sales Navigator lead page into the profile and
+// points the scraper at the public profile it links to.
+func (s *Scraper) readLead(ctx context.Context, id string) error {
+	var lead struct {
+		Name        string `json:"name"`
+		Location    string `json:"location"`
+		Headline    string `json:"headline"`
+		PhotoURL    string `json:"photoUrl"`
+		CompanyURL  string `json:"companyUrl"`
+		ProfileURL  string `json:"profileUrl"`
+		Connections string `json:"connections"`
+	}
+	err := chromedp.Run(ctx,
+		waitVisible(Selector("lead.name"), chromedp.ByQuery),
+		pause(time.Second),
+		evaluate(script("lead"), &lead),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to read lead: %w", err)
+	}
+
+	public := ProfilePage(lead.ProfileURL)
+	if public == "" {
+		public = leadPublicProfile(id)
+	}
+	s.setURL(public)
+	s.mu.Lock()
+	s.onLead = true
+	s.mu.Unlock()
+
+	s.update(func(p *Profile) {
+		p.Name = lead.Name
+		p.Location = lead.Location
+		p.Headline = lead.Headline
+		p.PhotoURL = lead.PhotoURL
+		p.CompanyURL = salesCompanyPage(lead.CompanyURL)
+		p.Connections = ParseCount(lead.Connections)
+	})
+	s.capture(ctx, SectionNameAndLocation, map[string]any{
+		"name": lead.Name, "location": lead.Location, "headline": lead.Headline, "photoUrl": lead.PhotoURL,
+		"companyUrl": salesCompanyPage(lead.CompanyURL), "connections": ParseCount(lead.Connections),
+	})
+	return nil
+}
+
+// getLeadAbout reads the About section off the open Sales Navigator lead page.
+func (s *Scraper) getLeadAbout(ctx context.Context) error {
+	var about string
+	err := chromedp.Run(ctx,
+		evaluate(script("lead-about"), &about),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get about: %w", err)
+	}
+	s.update(func(p *Profile) { p.About = about })
+	s.capture(ctx, SectionAbout, map[string]string{"about": about})
+	return nil
+}
+
+// salesNavAccess reports whether the account can open Sales Navigator, and whether that is known yet.
+func (s *Scraper) salesNavAccess() (access, known bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.salesNav == nil {
+		return false, false
+	}
+	return *s.salesNav, true
+}
+
+func (s *Scraper) setSalesNavAccess(access bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.salesNav = &access
+}
+
+// setURL points the scraper at the resolved profile URL, keeping what was scraped so far.
+func (s *Scraper) setURL(linkedInURL string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.linkedInURL = linkedInURL
+}
@@ -3,7 +3,8 @@
 
 It uses Chrome DevTools Protocol (CDP) via the chromedp package to automate browser interactions
 and extract various sections of LinkedIn profiles including basic information, experience,
-education, and recent posts.
+education, skills, certifications, recommendations, volunteering, publications, patents, languages,
+recent posts, articles and comments, the websites listed in the contact info, and the page of the profile owner's current employer.
 Scraping is down by injecting javscript in the launched chrome instance, and getting the results
 
 Basic usage:
@@ -14,24 +15,68 @@ Basic usage:
 	}
 	defer scraper.Close()
 
-Fetch profile information:
+Start the browser with options of its own instead of Headless and ExecPath, e.g.
+on a server without a display:
 
-	scraper.GetNameAndLocation()
-	scraper.GetAbout()
-	scraper.GetExperiences()
-	scraper.GetEducation()
-	scraper.GetRecentPosts()
+	opts := scraper.ScraperOptions{Headless: true, WindowWidth: 1366, WindowHeight: 768}
+	scraper, err := scraper.NewScraperWithOptions(opts, "email", "password", "https://www.linkedin.com/in/username")
+
+Or attach to a browser that is already running, such as a browserless container,
+where no Chrome is installed:
+
+	scraper, err := scraper.NewRemoteScraper("ws://chrome:3000", "email", "password", "https://www.linkedin.com/in/username")
+
+Fetch profile information, for as long as ctx allows:
+
+	scraper.GetNameAndLocation(ctx)
+	scraper.GetAbout(ctx)
+	scraper.GetExperiences(ctx)
+	scraper.GetEducation(ctx)
+	scraper.GetSkills(ctx)
+	scraper.GetCertifications(ctx)
+	scraper.GetRecommendations(ctx)
+	scraper.GetVolunteering(ctx)
+	scraper.GetPublications(ctx)
+	scraper.GetPatents(ctx)
+	scraper.GetLanguages(ctx)
+	scraper.GetRecentPosts(ctx)
+	scraper.GetArticles(ctx)
+	scraper.GetRecentComments(ctx)
+	scraper.GetCompany(ctx)
+	scraper.GetContactInfo(ctx)
+
+	profile := scraper.Profile()
+
+Or scrape several sections at once, keeping whatever succeeded; the error is a
+SectionErrors naming the ones that failed:
+
+	profile, err := scraper.ScrapeAll(ctx, scraper.SectionNameAndLocation, scraper.SectionAbout, scraper.SectionExperience)
+
+Each Get* method only writes its own section under the scraper's lock, and
+Profile returns a deep copy, so sections may be scraped from separate goroutines
+(on separate tabs) and read while scraping is still in progress.
+
+Save the session to log later scrapers in without the login form:
+
+	session, err := scraper.Session()
+	// ...
+	next, err := scraper.NewScraperFromSession(session, "email", "password", "https://www.linkedin.com/in/other")
 */
 package scraper
 
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"github.com/chromedp/cdproto/target"
 	"github.com/chromedp/chromedp"
+	"net/url"
 	"os"
 	"path"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -49,10 +94,49 @@ type Experience struct {
 /*
 	Post represents a LinkedIn post made by the profile owner.
 
-It contains the textual content of the post.
+It contains the textual content of the post and, when LinkedIn showed them,
+its link, publish time and engagement counts. PostedAt is zero when unknown.
 */
 type Post struct {
-	Content string `json:"content"` // Text content of the post
+	Content   string    `json:"content"`            // Text content of the post, then the transcript of its video
+	URL       string    `json:"url,omitempty"`      // Link to the post
+	PostedAt  time.Time `json:"postedAt,omitempty"` // When the post was published
+	Reactions int       `json:"reactions"`          // Likes and other reactions
+	Comments  int       `json:"comments"`           // Number of comments
+	// Text read off the post's images or document slides, empty without OCR
+	MediaText string `json:"mediaText,omitempty"`
+}
+
+/*
+	Article represents a long-form LinkedIn article published by the profile owner.
+
+It contains the title, a link, the publish time and the opening of the text.
+PublishedAt is zero when unknown.
+*/
+type Article struct {
+	Title       string    `json:"title"`                 // Headline of the article
+	URL         string    `json:"url,omitempty"`         // Link to the article
+	PublishedAt time.Time `json:"publishedAt,omitempty"` // When the article was published
+	Excerpt     string    `json:"excerpt"`               // Subtitle or opening lines, empty when not shown
+}
+
+/*
+	Comment represents a comment the profile owner left on someone else's post.
+
+It contains the comment text and, when LinkedIn showed them, who wrote the post,
+its opening lines, its link and when the comment was made. CommentedAt is zero when unknown.
+*/
+type Comment struct {
+	Content     string    `json:"content"`               // Text of the comment
+	PostAuthor  string    `json:"postAuthor,omitempty"`  // Author of the post commented on
+	PostExcerpt string    `json:"postExcerpt,omitempty"` // Opening lines of the post commented on
+	URL         string    `json:"url,omitempty"`         // Link to the post commented on
+	CommentedAt time.Time `json:"commentedAt,omitempty"` // When the comment was made
+}
+
+// Engagement is the number of reactions and comments a post got.
+func (p Post) Engagement() int {
+	return p.Reactions + p.Comments
 }
 
 /*
@@ -66,6 +150,92 @@ type Education struct {
 	Duration  string `json:"duration"`  // Period of study (e.g., "2015 - 2019")
 }
 
+/*
+	Skill represents a skill listed on a LinkedIn profile.
+
+It contains the skill name and how many connections endorsed it.
+*/
+type Skill struct {
+	Name         string `json:"name"`         // Skill as shown on the profile
+	Endorsements int    `json:"endorsements"` // Endorsement count, 0 when none are shown
+}
+
+/*
+	Certification represents a license or certification listed on a LinkedIn profile.
+
+It contains the certification name, the issuing organization and when it was issued.
+*/
+type Certification struct {
+	Name     string `json:"name"`     // Name of the license or certification
+	Issuer   string `json:"issuer"`   // Issuing organization
+	IssuedAt string `json:"issuedAt"` // Issue date as shown (e.g., "Mar 2023"), empty when not listed
+}
+
+/*
+	Recommendation represents a recommendation on a LinkedIn profile, received or given.
+
+Name is the other person: the recommender for received recommendations and the
+recipient for given ones.
+*/
+type Recommendation struct {
+	Name         string `json:"name"`         // The other person's name
+	Relationship string `json:"relationship"` // As shown (e.g., "March 5, 2022, Alex managed Sam directly")
+	Text         string `json:"text"`         // Recommendation text
+	Given        bool   `json:"given"`        // Written by the profile owner rather than about them
+}
+
+/*
+	VolunteerEntry represents a volunteer experience entry from a LinkedIn profile.
+
+It contains the organization, the role held, the cause it supports and the duration.
+*/
+type VolunteerEntry struct {
+	Organization string `json:"organization"` // Organization volunteered with
+	Role         string `json:"role"`         // Role held
+	Cause        string `json:"cause"`        // Cause as shown (e.g., "Education"), empty when not listed
+	Duration     string `json:"duration"`     // Period of volunteering (e.g., "2019 - Present")
+}
+
+/*
+	Publication represents a publication entry from a LinkedIn profile.
+
+It contains the title, where it was published and the publication date.
+*/
+type Publication struct {
+	Title string `json:"title"` // Title of the paper, article or book
+	Venue string `json:"venue"` // Journal, conference or publisher, empty when not listed
+	Date  string `json:"date"`  // Publication date as shown (e.g., "Mar 12, 2021")
+}
+
+/*
+	Patent represents a patent entry from a LinkedIn profile.
+
+It contains the title, the office and number it was filed under and the date.
+*/
+type Patent struct {
+	Title  string `json:"title"`  // Title of the patent
+	Office string `json:"office"` // Patent office and number as shown (e.g., "US 10,946,520")
+	Date   string `json:"date"`   // Issue date, or filing date for pending patents
+}
+
+/*
+	Language represents a language entry from a LinkedIn profile.
+
+It contains the language and the proficiency the profile owner claims.
+*/
+type Language struct {
+	Name        string `json:"name"`        // Language (e.g., "Tamil")
+	Proficiency string `json:"proficiency"` // As shown (e.g., "Native or bilingual proficiency"), empty when not set
+}
+
+/*
+	ProfileSchemaVersion is the version of the Profile JSON layout.
+
+Bump it whenever a change to Profile needs stored profiles to be migrated,
+for example a section whose zero value would be misleading for old records.
+*/
+const ProfileSchemaVersion = 4
+
 /*
 	Profile represents the complete LinkedIn profile information that can be scraped.
 
@@ -75,144 +245,665 @@ education history, and recent posts.
 type Profile struct {
 	Name       string       // Full name of the profile owner
 	Location   string       // Geographic location
+	Headline   string       // Headline under the name, often the clearest summary of the role
+	Pronouns   string       // Pronouns shown next to the name, empty when not shown
+	PhotoURL   string       // Profile photo, empty when the placeholder is shown
+	OpenToWork bool         // Open-to-work badge shown on the top card
+	CompanyURL string       // Page of the current employer, empty when the experience links none
+	Company    *Company     // Current employer's page, nil when not scraped
 	About      string       // "About" section content
 	Experience []Experience // List of work experiences
 	Education  []Education  // List of education entries
 	Posts      []Post       // List of recent posts
+	Articles   []Article    // Recent long-form articles, nil when not scraped
+	Comments   []Comment    // Recent comments on other people's posts, nil when not scraped
+	Skills     []Skill      // Listed skills, nil when not scraped
+	// Licenses and certifications, nil when not scraped
+	Certifications []Certification
+	// Received recommendations first, then given ones; nil when not scraped
+	Recommendations []Recommendation
+	Volunteering    []VolunteerEntry // Volunteer experience, nil when not scraped
+	Publications    []Publication    // Publications, nil when not scraped
+	Patents         []Patent         // Patents, nil when not scraped
+	Languages       []Language       // Languages, nil when not scraped
+	// Connections and followers shown on the profile, 500 connections for "500+"; 0 when not shown
+	Connections, Followers int
+	// Hashtags ("#ai") and recurring themes of the posts, articles and comments, most mentioned first;
+	// derived after scraping by the topics package, nil when not derived
+	Topics []string
+	// Latest post, article or comment the profile owner made, as LastActivity finds it
+	// after scraping; zero when none is dated
+	LastActiveAt time.Time
+	// Titles, locations and start date from the open-to-work card, nil when it isn't shown
+	JobPreferences *JobPreferences
+	// Websites, email, Twitter handle and birthday from the contact info overlay
+	ContactInfo ContactInfo
+}
+
+// Clone returns a deep copy of the profile, sharing no slices with the original.
+func (p Profile) Clone() Profile {
+	p.Experience = append([]Experience(nil), p.Experience...)
+	p.Education = append([]Education(nil), p.Education...)
+	p.Posts = append([]Post(nil), p.Posts...)
+	p.Articles = append([]Article(nil), p.Articles...)
+	p.Comments = append([]Comment(nil), p.Comments...)
+	p.Skills = append([]Skill(nil), p.Skills...)
+	p.Certifications = append([]Certification(nil), p.Certifications...)
+	p.Recommendations = append([]Recommendation(nil), p.Recommendations...)
+	p.Volunteering = append([]VolunteerEntry(nil), p.Volunteering...)
+	p.Publications = append([]Publication(nil), p.Publications...)
+	p.Patents = append([]Patent(nil), p.Patents...)
+	p.Languages = append([]Language(nil), p.Languages...)
+	p.ContactInfo.Websites = append([]string(nil), p.ContactInfo.Websites...)
+	p.Topics = append([]string(nil), p.Topics...)
+	p.Company = p.Company.clone()
+	p.JobPreferences = p.JobPreferences.clone()
+	return p
+}
+
+// LastActivity returns when the profile owner last posted, published an article or commented,
+// zero when none of them is dated.
+func (p Profile) LastActivity() time.Time {
+	var last time.Time
+	for _, post := range p.Posts {
+		if post.PostedAt.After(last) {
+			last = post.PostedAt
+		}
+	}
+	for _, a := range p.Articles {
+		if a.PublishedAt.After(last) {
+			last = a.PublishedAt
+		}
+	}
+	for _, c := range p.Comments {
+		if c.CommentedAt.After(last) {
+			last = c.CommentedAt
+		}
+	}
+	return last
 }
 
 /*
 	Scraper handles the LinkedIn profile scraping operations.
 
 It maintains the browser context and authentication state required
-for accessing LinkedIn profile information.
+for accessing LinkedIn profile information. The target URL and the profile
+being assembled are guarded by mu. Sections run until the context they are
+given ends or the lease runs out, whichever comes first.
 */
 type Scraper struct {
-	ctx         context.Context
-	cancel      context.CancelFunc
-	linkedInURL string
-	email       string
-	password    string
-	Profile     *Profile
+	allocCancel   context.CancelFunc
+	browserCtx    context.Context // chromedp context owning the browser, lives until Close
+	browserCancel context.CancelFunc
+	ctx           context.Context // browserCtx bounded by the current lease
+	cancel        context.CancelFunc
+	email         string
+	password      string
+	mu            sync.Mutex
+	linkedInURL   string
+	profile       *Profile
+	companies     map[string]*Company // Company pages scraped so far, by URL
+	jobs          map[string]*Job     // Job postings scraped so far, by URL
+	pid           int                 // Browser process, registered with the reaper while open
+	salesNav      *bool               // Whether the account can open Sales Navigator, nil until a lead was opened
+	onLead        bool                // The profile was read off a Sales Navigator lead page, which is still open
+	proxy         *url.URL            // The proxy from Proxies the browser connects through, nil for none
+	opts          ScraperOptions      // What the browser was started with
+	fingerprint   Fingerprint         // What the browser presents itself as, drawn from Fingerprinting
+	admitted      bool                // The target was counted against the account's caps in Rate
+	responses     voyagerResponses    // Voyager API responses the browser received for the target
 }
 
+// Headless starts browsers without a window. A login that hits a security check
+// is still retried in a visible browser so the user can solve it.
+var Headless = false
+
+// DefaultLease is how long a new scraper may be used before it has to be renewed.
+const DefaultLease = 3 * time.Minute
+
 /*
 	NewScraper creates and initializes a new LinkedIn scraper with the provided credentials.
 
-It handles the initial login process and automatically manages browser visibility
-for security verification if required.
+It is NewScraperWithOptions with DefaultOptions, so browsers follow Headless and ExecPath,
+or attach to RemoteURL when that is set.
 
 Parameters:
   - email: LinkedIn account email
   - password: LinkedIn account password
-  - linkedInURL: Target profile URL to scrape
+  - linkedInURL: Target profile URL to scrape, or a Sales Navigator lead URL
 
 Returns:
   - *Scraper: Initialized scraper instance
   - error: Any error encountered during setup or login
 */
 func NewScraper(email, password, linkedInURL string) (*Scraper, error) {
+	return NewScraperWithOptions(DefaultOptions(), email, password, linkedInURL)
+}
 
-	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		chromedp.Flag("headless", false), // Start headless
-		chromedp.Flag("disable-gpu", false),
-		chromedp.Flag("disable-extensions", false),
-		chromedp.Flag("disable-setuid-sandbox", true),
-	)
-	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
-	ctx, _ := chromedp.NewContext(allocCtx)
-	ctx, cancel = context.WithTimeout(ctx, 3*time.Minute)
+/*
+	NewScraperWithOptions creates and initializes a new LinkedIn scraper whose browser is started with opts.
+
+It handles the initial login process and automatically manages browser visibility
+for security verification if required, or streams the verification to
+RemoteVerification when that is set. A headless browser that hits a security
+check is restarted with a window, unless there is no display to show one, as on
+most servers, where the login fails with ErrVerificationRequired. The browser
+connects through the proxy Proxies hands out for the account, if any. The
+scraper can be used for opts.Timeout; long-lived scrapers call Renew before each use.
+
+Parameters:
+  - opts: How to start the browser
+  - email: LinkedIn account email
+  - password: LinkedIn account password
+  - linkedInURL: Target profile URL to scrape, or a Sales Navigator lead URL
+
+Returns:
+  - *Scraper: Initialized scraper instance
+  - error: Any error encountered during setup or login
+*/
+func NewScraperWithOptions(opts ScraperOptions, email, password, linkedInURL string) (*Scraper, error) {
+	proxy, err := proxyFor(email)
+	if err != nil {
+		return nil, err
+	}
+	opts = opts.forAccount(email)
+	allocCtx, allocCancel := allocator(opts, proxy)
 	s := &Scraper{
-		ctx:         ctx,
-		cancel:      cancel,
+		allocCancel: allocCancel,
 		linkedInURL: linkedInURL,
 		email:       email,
 		password:    password,
-		Profile:     &Profile{},
+		profile:     &Profile{},
+		companies:   map[string]*Company{},
+		jobs:        map[string]*Job{},
+		proxy:       proxy,
+		opts:        opts,
+		fingerprint: pickFingerprint(email),
+	}
+	if err := s.open(allocCtx); err != nil {
+		allocCancel()
+		return nil, fmt.Errorf("failed to start browser: %w", err)
 	}
 
-	err := s.login(false)
+	// Nobody is at a remote browser's window to solve a checkpoint in
+	err = s.login(s.ctx, !opts.Headless && opts.RemoteURL == "")
+	reportProxy(proxy, err)
 	if err == nil {
+		s.startWatchdog()
+		return s, nil
+	}
+
+	// A headless browser can stream the verification page to another browser instead
+	if errors.Is(err, ErrVerificationRequired) && RemoteVerification != nil {
+		if err := s.verifyRemotely(); err != nil {
+			s.Close()
+			return nil, fmt.Errorf("failed to login even with verification: %w", err)
+		}
+		s.Renew(opts.lease())
+		s.startWatchdog()
 		return s, nil
 	}
 
+	// Nor can a remote browser be restarted with a window here
+	if errors.Is(err, ErrVerificationRequired) && opts.RemoteURL != "" {
+		s.Close()
+		return nil, fmt.Errorf("failed to login: %w, and the browser is remote", err)
+	}
+
+	// Without a display, as on most servers, there is no visible browser to solve it in
+	if errors.Is(err, ErrVerificationRequired) && opts.Headless && !hasDisplay() {
+		s.Close()
+		return nil, fmt.Errorf("failed to login: %w, and there is no display for a visible browser", err)
+	}
+
 	// If we get to a verification page, restart with visible browser
-	if strings.Contains(err.Error(), "verification") {
-		s.cancel() // Clean up the first browser
+	if errors.Is(err, ErrVerificationRequired) {
+		s.Close() // Clean up the first browser
 
 		// Create visible browser for verification
-		visibleOpts := append(chromedp.DefaultExecAllocatorOptions[:],
-			chromedp.Flag("headless", false),
-			chromedp.Flag("disable-gpu", false),
-			chromedp.Flag("disable-extensions", false),
-			chromedp.Flag("disable-setuid-sandbox", true),
-		)
-		visibleAllocCtx, visibleCancel := chromedp.NewExecAllocator(context.Background(), visibleOpts...)
-		defer visibleCancel()
-
-		ctx, _ = chromedp.NewContext(visibleAllocCtx)
-		ctx, cancel = context.WithTimeout(ctx, 3*time.Minute)
-		s.ctx = ctx
-		s.cancel = cancel
+		visible := opts
+		visible.Headless = false
+		visibleAllocCtx, visibleCancel := chromedp.NewExecAllocator(context.Background(), allocatorOptions(visible, proxy)...)
+		s.allocCancel = visibleCancel
+		if err := s.open(visibleAllocCtx); err != nil {
+			visibleCancel()
+			return nil, fmt.Errorf("failed to start browser: %w", err)
+		}
 
-		// Try login with visible browser
-		if err := s.login(false); err != nil {
-			cancel()
+		// Try login with visible browser. The verified browser is kept, a fresh
+		// one would not carry the session.
+		if err := s.login(s.ctx, true); err != nil {
+			s.Close()
 			return nil, fmt.Errorf("failed to login even with verification: %w", err)
 		}
-
-		// After successful verification, switch back to headless
-		s.cancel()
-		ctx, _ = chromedp.NewContext(allocCtx)
-		ctx, cancel = context.WithTimeout(ctx, 3*time.Minute)
-		s.ctx = ctx
-		s.cancel = cancel
 	} else {
+		s.Close()
 		return nil, fmt.Errorf("failed to login: %w", err)
 	}
 
+	s.startWatchdog()
 	return s, nil
 }
 
 /*
-	login authenticates with LinkedIn using the provided credentials.
+	NewRemoteScraper creates a LinkedIn scraper in a browser that is already running, attached to
+	through its DevTools endpoint, so the server needs no Chrome installation of its own.
 
-It automatically handles security verification challenges by switching
-to a visible browser when necessary.
+It is NewScraperWithOptions with DefaultOptions and RemoteURL set to wsURL. Every
+scraper gets its own browser context in the remote browser, so accounts sharing it
+don't share cookies, and the context connects through the account's proxy. A login
+that hits a security check fails with ErrVerificationRequired unless
+RemoteVerification is set.
 
 Parameters:
-  - headless: Whether to use headless browser mode
+  - wsURL: The browser's DevTools endpoint, e.g. ws://chrome:9222 or a browserless URL with its token;
+    one without a /devtools/browser/ path is looked up at /json/version
+  - email: LinkedIn account email
+  - password: LinkedIn account password
+  - linkedInURL: Target profile URL to scrape, or a Sales Navigator lead URL
 
 Returns:
-  - error: Any error encountered during login
+  - *Scraper: Initialized scraper instance
+  - error: Any error encountered connecting to the browser or during login
 */
-func (s *Scraper) login(headless bool) error {
-	fmt.Println("Logging user in...")
+func NewRemoteScraper(wsURL, email, password, linkedInURL string) (*Scraper, error) {
+	opts := DefaultOptions()
+	opts.RemoteURL = wsURL
+	return NewScraperWithOptions(opts, email, password, linkedInURL)
+}
 
-	err := chromedp.Run(s.ctx,
-		chromedp.Navigate("https://www.linkedin.com/login"),
-		chromedp.WaitVisible(`input[name="session_key"]`),
-		chromedp.SendKeys(`input[name="session_key"]`, s.email),
-		chromedp.SendKeys(`input[name="session_password"]`, s.password),
-		chromedp.Click(`button[type="submit"]`),
+// allocator returns the context a scraper's browser comes from: one attached to
+// o.RemoteURL, or one starting Chrome with allocatorOptions.
+func allocator(o ScraperOptions, proxy *url.URL) (context.Context, context.CancelFunc) {
+	if o.RemoteURL != "" {
+		return chromedp.NewRemoteAllocator(context.Background(), o.RemoteURL)
+	}
+	return chromedp.NewExecAllocator(context.Background(), allocatorOptions(o, proxy)...)
+}
+
+// allocatorOptions are the Chrome flags a scraper's browser is started with, connecting through proxy unless it is nil.
+func allocatorOptions(o ScraperOptions, proxy *url.URL) []chromedp.ExecAllocatorOption {
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", o.Headless),
+		chromedp.Flag("disable-gpu", false),
+		chromedp.Flag("disable-extensions", false),
+		chromedp.Flag("disable-setuid-sandbox", true),
 	)
-	if err != nil {
+	if proxy != nil {
+		opts = append(opts, chromedp.ProxyServer(proxyServer(proxy)))
+	}
+	opts = append(opts, Limits.flags()...)
+	// Last, so extra flags override the defaults
+	return append(opts, o.flags()...)
+}
+
+/*
+	open starts a browser on allocCtx and gives the scraper its first lease.
+
+The first chromedp.Run allocates the browser and ties its process to the
+context it was given, so it is made without a deadline; leases bound the work
+done with the browser instead. In a remote browser it opens a browser context
+of the scraper's own, which Close disposes of with its cookies.
+*/
+func (s *Scraper) open(allocCtx context.Context) error {
+	done := launching()
+	defer done()
+	var opts []chromedp.ContextOption
+	if s.opts.RemoteURL != "" {
+		opts = append(opts, chromedp.WithNewBrowserContext(func(p *target.CreateBrowserContextParams) *target.CreateBrowserContextParams {
+			// There are no flags to start a remote browser with, the proxy is set per context
+			if s.proxy != nil {
+				p = p.WithProxyServer(proxyServer(s.proxy))
+			}
+			return p
+		}))
+	}
+	browserCtx, browserCancel := chromedp.NewContext(allocCtx, opts...)
+	// Paces the account's navigations, see paced
+	browserCtx = context.WithValue(browserCtx, accountKey{}, strings.ToLower(s.email))
+	if err := chromedp.Run(browserCtx); err != nil {
+		browserCancel()
 		return err
 	}
+	if err := chromedp.Run(browserCtx, s.fingerprint.emulate()); err != nil {
+		browserCancel()
+		return fmt.Errorf("failed to apply the fingerprint: %w", err)
+	}
+	if s.proxy != nil && s.proxy.User != nil {
+		if err := authenticateProxy(browserCtx, s.proxy); err != nil {
+			browserCancel()
+			return fmt.Errorf("failed to set up proxy authentication: %w", err)
+		}
+	}
+	s.interceptVoyager(browserCtx)
+	s.browserCtx, s.browserCancel = browserCtx, browserCancel
+	if c := chromedp.FromContext(browserCtx); c != nil && c.Browser != nil && c.Browser.Process() != nil {
+		s.pid = c.Browser.Process().Pid
+		registerBrowser(s.pid)
+	}
+	s.Renew(s.opts.lease())
+	return nil
+}
 
-	time.Sleep(1 * time.Second)
+/*
+	Renew starts a new lease on the logged in browser.
 
+Work already running under the previous lease is cancelled. Renew must not be
+called while another goroutine is using the scraper.
+
+Parameters:
+  - lease: How long the scraper may be used from now
+*/
+func (s *Scraper) Renew(lease time.Duration) {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.ctx, s.cancel = context.WithTimeout(s.browserCtx, lease)
+}
+
+/*
+	Ping opens the feed to keep the session warm.
+
+Returns:
+  - error: Any navigation error, or an error when LinkedIn no longer considers the session logged in
+*/
+func (s *Scraper) Ping() error {
 	var currentURL string
-	err = chromedp.Run(s.ctx,
+	var state pageState
+	err := chromedp.Run(s.ctx,
+		paced(),
+		chromedp.Navigate("https://www.linkedin.com/feed/"),
+		dwell(),
 		chromedp.Location(&currentURL),
+		evaluate(script("page-state"), &state),
+	)
+	if err == nil {
+		err = pageError(currentURL, state)
+	} else {
+		err = fmt.Errorf("failed to ping feed: %w", err)
+	}
+	reportProxy(s.proxy, err)
+	return err
+}
+
+// pageState is what the page-state script read.
+type pageState struct {
+	Status int    `json:"status"`
+	Text   string `json:"text"`
+	Guest  bool   `json:"guest"`
+	Lang   string `json:"lang"`
+}
+
+// rateLimitedTexts and unusualActivityTexts are what LinkedIn's pages say, lower cased,
+// when it throttles an account and when it suspects it is automated.
+var (
+	rateLimitedTexts     = []string{"too many requests", "commercial use limit", "you've reached the weekly invitation limit"}
+	unusualActivityTexts = []string{"unusual activity", "automated activity", "prove you're not a robot"}
+)
+
+/*
+	pageError is why LinkedIn showed a logged in browser something other than the page asked for, nil when it didn't.
+
+Redirects to a restriction or challenge page mean ErrAccountRestricted or
+ErrBotDetected, and to a logged out page, or one showing the logged out view
+where the page asked for used to be, ErrNotAuthenticated. On any other page
+a 429 or a banner about too many requests means ErrRateLimited, and LinkedIn's
+bot status 999 or a banner about unusual activity ErrBotDetected.
+*/
+func pageError(currentURL string, state pageState) error {
+	if restricted(currentURL) {
+		return fmt.Errorf("%w: redirected to %s", ErrAccountRestricted, currentURL)
+	}
+	if challenged(currentURL) {
+		return fmt.Errorf("%w: redirected to %s", ErrBotDetected, currentURL)
+	}
+	if loggedOut(currentURL) {
+		return fmt.Errorf("%w: redirected to %s", ErrNotAuthenticated, currentURL)
+	}
+	if state.Guest {
+		return fmt.Errorf("%w: %s shows the logged out view", ErrNotAuthenticated, currentURL)
+	}
+	text := strings.ToLower(strings.ReplaceAll(state.Text, "’", "'"))
+	if state.Status == 429 {
+		return fmt.Errorf("%w: %s answered with status 429", ErrRateLimited, currentURL)
+	}
+	if said, ok := says(text, rateLimitedTexts); ok {
+		return fmt.Errorf("%w: %s says %q", ErrRateLimited, currentURL, said)
+	}
+	if state.Status == 999 {
+		return fmt.Errorf("%w: %s answered with status 999", ErrBotDetected, currentURL)
+	}
+	if said, ok := says(text, unusualActivityTexts); ok {
+		return fmt.Errorf("%w: %s says %q", ErrBotDetected, currentURL, said)
+	}
+	return nil
+}
+
+// says returns the first of phrases text contains.
+func says(text string, phrases []string) (string, bool) {
+	for _, phrase := range phrases {
+		if strings.Contains(text, phrase) {
+			return phrase, true
+		}
+	}
+	return "", false
+}
+
+// challenged reports whether LinkedIn sent the browser to a security challenge. Once logged
+// in, that is how LinkedIn reacts to a session it suspects is automated.
+func challenged(currentURL string) bool {
+	return strings.Contains(currentURL, "checkpoint/challenge")
+}
+
+// restricted reports whether LinkedIn sent the browser to the page it shows restricted or banned accounts.
+func restricted(currentURL string) bool {
+	return strings.Contains(currentURL, "/checkpoint/") && strings.Contains(currentURL, "restricted")
+}
+
+// loggedOut reports whether LinkedIn sent the browser to a page only shown to logged out visitors.
+func loggedOut(currentURL string) bool {
+	return strings.Contains(currentURL, "/login") || strings.Contains(currentURL, "/authwall") ||
+		strings.Contains(currentURL, "/uas/login") || strings.Contains(currentURL, "/signup")
+}
+
+/*
+	navigate opens url and fails fast when LinkedIn did not show it.
+
+The error is ErrNotAuthenticated when the session has expired, ErrBotDetected
+when LinkedIn challenges the session, ErrAccountRestricted when the account
+was restricted and ErrRateLimited when LinkedIn throttles it, as pageError
+tells them apart.
+
+Without the check an expired session lands on the authwall and the following
+WaitVisible calls block until their deadline. The page is opened in UILanguage.
+*/
+func navigate(url string) chromedp.Action {
+	return chromedp.Tasks{
+		paced(),
+		inUILanguage(),
+		chromedp.Navigate(localized(url)),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			var currentURL string
+			if err := chromedp.Location(&currentURL).Do(ctx); err != nil {
+				return err
+			}
+			// A page that can't be read yet is checked by its URL alone
+			var state pageState
+			evaluate(script("page-state"), &state).Do(ctx)
+			warnLanguage(currentURL, state.Lang)
+			return pageError(currentURL, state)
+		}),
+	}
+}
+
+/*
+	Relogin logs the existing browser in again after the session expired.
+
+It never waits for someone to solve a security checkpoint, since nobody is
+watching a background re-login; it fails with ErrVerificationRequired instead.
+
+Returns:
+  - error: Any error encountered during login
+*/
+func (s *Scraper) Relogin() error {
+	return s.login(s.ctx, false)
+}
+
+/*
+	withRelogin runs a section and, if the session expired while scraping it,
+
+logs in again with the scraper's credentials and retries the section once.
+A session can also expire after the section's page was opened, when LinkedIn
+redirects to the login page or lays its sign in modal over the page while the
+section waits or reads; the section then fails waiting or reads nothing, so
+the page is checked again once the section is done, whether or not it failed.
+*/
+func (s *Scraper) withRelogin(ctx context.Context, section func(context.Context) error) error {
+	err := section(ctx)
+	if !errors.Is(err, ErrNotAuthenticated) && !s.signedOut() {
+		return err
+	}
+
+	fmt.Println("Session expired, logging in again...")
+	// Not interactive: the section runs in a request, blocking on stdin would hang it
+	if err := s.login(ctx, false); err != nil {
+		return fmt.Errorf("%w: re-login failed: %w", ErrNotAuthenticated, err)
+	}
+	return section(ctx)
+}
+
+// signedOut reports whether the browser shows a page only logged out visitors see. It gives
+// the check a few seconds of its own, the section's ctx may have ended waiting on the page.
+func (s *Scraper) signedOut() bool {
+	ctx, cancel := context.WithTimeout(s.ctx, 5*time.Second)
+	defer cancel()
+	var currentURL string
+	var state pageState
+	if err := chromedp.Run(ctx, chromedp.Location(&currentURL), evaluate(script("page-state"), &state)); err != nil {
+		return false
+	}
+	return errors.Is(pageError(currentURL, state), ErrNotAuthenticated)
+}
+
+// run runs a section within ctx and the lease, logging in again once if the session expired.
+// The page a section fails on is saved to FailureDir under its name.
+func (s *Scraper) run(ctx context.Context, name Section, section func(context.Context) error) error {
+	if slices.Contains(PageOrder, name) {
+		if err := s.admit(); err != nil {
+			return err
+		}
+	}
+	ctx, cancel := s.within(ctx)
+	defer cancel()
+	err := s.withRelogin(ctx, section)
+	s.saveFailure(name, err)
+	return err
+}
+
+/*
+	within returns the lease's context, also ended when ctx is cancelled or reaches its deadline.
+
+chromedp only runs actions in contexts derived from the browser's, so the
+caller's context can't be used as it is: its deadline is copied and its
+cancellation forwarded, with its cause. The returned cancel function must be
+called once the work is done.
+*/
+func (s *Scraper) within(ctx context.Context) (context.Context, context.CancelFunc) {
+	bounded, cancel := context.WithCancelCause(s.ctx)
+	stop := context.AfterFunc(ctx, func() { cancel(context.Cause(ctx)) })
+	release := func() {
+		stop()
+		cancel(context.Canceled)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancelDeadline context.CancelFunc
+		bounded, cancelDeadline = context.WithDeadline(bounded, deadline)
+		return bounded, func() {
+			cancelDeadline()
+			release()
+		}
+	}
+	return bounded, release
+}
+
+// loginWait is how long a login waits for LinkedIn to answer the form.
+const loginWait = 10 * time.Second
+
+/*
+	login authenticates with LinkedIn using the provided credentials.
+
+A verification code page is answered by OTP, when set. At any other security
+checkpoint, or when OTP has no code, an interactive login waits for the puzzle
+to be solved in the (visible) browser window; otherwise it fails with
+ErrVerificationRequired so the caller can retry with a visible browser.
+Rejected credentials fail with ErrNotAuthenticated, and LinkedIn throttling
+the login with ErrRateLimited.
+
+Parameters:
+  - ctx: Context bounding the login
+  - interactive: Whether someone at the terminal can solve a checkpoint
+
+Returns:
+  - error: Any error encountered during login
+*/
+func (s *Scraper) login(ctx context.Context, interactive bool) error {
+	fmt.Println("Logging user in...")
+
+	err := chromedp.Run(ctx,
+		paced(),
+		chromedp.Navigate("https://www.linkedin.com/login"),
+		waitVisible(Selector("login.username")),
+		pause(time.Second),
+		typeText(Selector("login.username"), s.email),
+		pause(400*time.Millisecond),
+		typeText(Selector("login.password"), s.password),
+		click(Selector("login.submit")),
 	)
 	if err != nil {
 		return err
 	}
 
-	if strings.Contains(currentURL, "checkpoint/challenge") {
-		if headless {
-			return fmt.Errorf("verification required, please retry with headless=false")
+	// Wait for LinkedIn to answer the form, with another page or an error under a field
+	var currentURL string
+	var rejected bool
+	for deadline := time.Now().Add(loginWait); ; {
+		err = chromedp.Run(ctx,
+			pause(time.Second),
+			chromedp.Location(&currentURL),
+			evaluate(script("login-rejected"), &rejected),
+		)
+		if err != nil {
+			return err
+		}
+		if rejected || !strings.HasPrefix(currentURL, "https://www.linkedin.com/login") || time.Now().After(deadline) {
+			break
+		}
+	}
+	if rejected {
+		return fmt.Errorf("%w: LinkedIn did not accept the email and password", ErrNotAuthenticated)
+	}
+	if !challenged(currentURL) {
+		var state pageState
+		if err := chromedp.Run(ctx, evaluate(script("page-state"), &state)); err != nil {
+			return err
+		}
+		if err := pageError(currentURL, state); err != nil {
+			return err
+		}
+	}
+
+	// A code page is answered by OTP whether or not someone is at the browser
+	if challenged(currentURL) && OTP != nil {
+		if currentURL, err = s.answerOTP(ctx, currentURL); err != nil {
+			return err
+		}
+	}
+	if restricted(currentURL) {
+		return fmt.Errorf("%w: redirected to %s", ErrAccountRestricted, currentURL)
+	}
+	if challenged(currentURL) {
+		if !interactive {
+			return fmt.Errorf("%w, please retry with headless=false", ErrVerificationRequired)
 		}
 
 		fmt.Println("\nSecurity verification required!")
@@ -221,14 +912,14 @@ func (s *Scraper) login(headless bool) error {
 		reader := bufio.NewReader(os.Stdin)
 		_, _ = reader.ReadString('\n')
 
-		err = chromedp.Run(s.ctx,
+		err = chromedp.Run(ctx,
 			chromedp.Location(&currentURL),
 		)
 		if err != nil {
 			return err
 		}
-		if strings.Contains(currentURL, "checkpoint/challenge") {
-			return fmt.Errorf("verification was not completed successfully")
+		if challenged(currentURL) {
+			return fmt.Errorf("%w: verification was not completed successfully", ErrVerificationRequired)
 		}
 	}
 
@@ -239,91 +930,193 @@ func (s *Scraper) login(headless bool) error {
 /*
 	GetRecentPosts retrieves the 5 most recent posts from the profile,
 
-excluding reposts. The results are stored in Profile.Posts.
+excluding reposts, with the text LinkedIn cuts off behind "…see more"
+clicked open. Video posts get the transcript of their captions, when
+LinkedIn has them, appended to Content. The results are stored in the scraped
+profile's Posts.
 
 Returns:
   - error: Any error encountered while fetching posts
 */
-func (s *Scraper) GetRecentPosts() error {
+func (s *Scraper) GetRecentPosts(ctx context.Context) error {
+	return s.run(ctx, SectionPosts, s.getRecentPosts)
+}
+
+func (s *Scraper) getRecentPosts(ctx context.Context) error {
 	fmt.Println("Getting latest posts")
-	url := path.Join(s.linkedInURL, "recent-activity/all/")
-	var posts []Post
-	err := chromedp.Run(s.ctx,
-		chromedp.Navigate(url),
-		chromedp.Sleep(2*time.Second),
-		chromedp.Evaluate(`
-                 Array.from(document.querySelectorAll('.feed-shared-update-v2')).map(post => {
-                    // Check if it's a repost by looking for specific class or text in header
-                    const header = post.querySelector('.update-components-header__text-view');
-                    if (header && header.textContent.includes('reposted this')) {
-                        return null;
-                    }
-
-                    // Get the content wrapper
-                    const wrapper = post.querySelector('.feed-shared-update-v2__description-wrapper');
-                    if (!wrapper) return null;
-                    const content = wrapper.querySelector('.feed-shared-inline-show-more-text')?.textContent?.trim() || wrapper.querySelector('.break-words span[dir="ltr"]')?.textContent?.trim() || '';;
-                    
-                    if (!content) return null;
-
-                    return {
-                        content: content
-                    };
-                }).filter(item => item !== null).slice(0, 5);
-        `, &posts),
+	url := path.Join(s.url(), "recent-activity/all/")
+	var found []scrapedPost
+	err := chromedp.Run(ctx,
+		navigate(url),
+		dwell(),
+		expandSeeMore(Selector("post")),
+		evaluate(script("recent-posts"), &found),
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to extract posts: %w", err)
 	}
+	posts := s.readPosts(ctx, found, len(found))
+	s.update(func(p *Profile) { p.Posts = posts })
+	s.capture(ctx, SectionPosts, posts)
+	return nil
+}
+
+/*
+	GetArticles retrieves the 5 most recent long-form articles from the profile.
+
+Some profiles publish almost only articles and have no posts. The results are
+stored in the scraped profile's Articles.
+
+Returns:
+  - error: Any error encountered while fetching articles
+*/
+func (s *Scraper) GetArticles(ctx context.Context) error {
+	return s.run(ctx, SectionArticles, s.getArticles)
+}
+
+func (s *Scraper) getArticles(ctx context.Context) error {
+	fmt.Println("Getting articles")
+	url := path.Join(s.url(), "recent-activity/articles/")
+
+	// Profiles without articles render no cards, so only main is waited for
+	var found []scrapedArticle
+	err := chromedp.Run(ctx,
+		navigate(url),
+		dwell(),
+		waitVisible(`main`, chromedp.ByQuery),
+		evaluate(script("articles"), &found),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to extract articles: %w", err)
+	}
+
+	articles := make([]Article, 0, len(found))
+	for _, f := range found {
+		articles = append(articles, f.article())
+	}
+	s.update(func(p *Profile) { p.Articles = articles })
+	s.capture(ctx, SectionArticles, articles)
+	return nil
+}
+
+/*
+	GetRecentComments retrieves the 5 most recent comments the profile left on other people's posts.
+
+Some profiles never post but comment actively, which still shows what they care
+about right now. Each comment is kept with the author and opening of the post it
+replies to. The results are stored in the scraped profile's Comments.
+
+Returns:
+  - error: Any error encountered while fetching comments
+*/
+func (s *Scraper) GetRecentComments(ctx context.Context) error {
+	return s.run(ctx, SectionComments, s.getRecentComments)
+}
+
+func (s *Scraper) getRecentComments(ctx context.Context) error {
+	fmt.Println("Getting recent comments")
+	url := path.Join(s.url(), "recent-activity/comments/")
+
+	// The feed shows the post with the profile's own comment first under it
+	var found []scrapedComment
+	err := chromedp.Run(ctx,
+		navigate(url),
+		dwell(),
+		waitVisible(`main`, chromedp.ByQuery),
+		evaluate(script("comments"), &found),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to extract comments: %w", err)
+	}
 
-	s.Profile.Posts = posts
+	comments := make([]Comment, 0, len(found))
+	for _, f := range found {
+		comments = append(comments, f.comment())
+	}
+	s.update(func(p *Profile) { p.Comments = comments })
+	s.capture(ctx, SectionComments, comments)
+	return nil
+}
+
+/*
+	GetContactInfo reads the profile's contact info overlay.
+
+Websites keep only links outside LinkedIn, such as a GitHub profile or a
+personal site. The email and birthday are only shown to connections, and
+phone numbers and addresses are never read. The result is stored in the
+scraped profile's ContactInfo.
+
+Returns:
+  - error: Any error encountered while fetching the contact info
+*/
+func (s *Scraper) GetContactInfo(ctx context.Context) error {
+	return s.run(ctx, SectionContactInfo, s.getContactInfo)
+}
+
+func (s *Scraper) getContactInfo(ctx context.Context) error {
+	fmt.Println("Getting contact info")
+	url := path.Join(s.url(), "overlay/contact-info/")
+	var sections []contactSection
+	err := chromedp.Run(ctx,
+		navigate(url),
+		dwell(),
+		waitVisible(Selector("modal"), chromedp.ByQuery),
+		evaluate(script("contact-info"), &sections),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to extract contact info: %w", err)
+	}
+
+	contact := readContactInfo(sections)
+	s.update(func(p *Profile) { p.ContactInfo = contact })
+	s.capture(ctx, SectionContactInfo, contact)
 	return nil
 }
 
 /*
 	GetExperiences extracts work experience entries from the profile.
 
-The results are stored in Profile.Experience.
+The results are stored in the scraped profile's Experience, up to
+MaxListEntries of them; entries the page only loads on scrolling are loaded.
 
 Returns:
   - error: Any error encountered while fetching experiences
 */
-func (s *Scraper) GetExperiences() error {
+func (s *Scraper) GetExperiences(ctx context.Context) error {
+	return s.run(ctx, SectionExperience, s.getExperiences)
+}
+
+func (s *Scraper) getExperiences(ctx context.Context) error {
 	fmt.Println("Getting experience")
-	url := path.Join(s.linkedInURL, "details/experience")
+	url := path.Join(s.url(), "details/experience")
 
-	err := chromedp.Run(s.ctx,
-		chromedp.Navigate(url),
-		chromedp.Sleep(2*time.Second),
-		chromedp.WaitVisible(`main`, chromedp.ByQuery),
-		chromedp.WaitVisible(`div[data-view-name="profile-component-entity"]`),
+	err := chromedp.Run(ctx,
+		navigate(url),
+		dwell(),
+		waitVisible(`main`, chromedp.ByQuery),
 	)
 	if err != nil {
-		return fmt.Errorf("navigation failed: %v", err)
-	}
-
-	var experienceElements []Experience
-	err = chromedp.Run(s.ctx,
-		chromedp.Evaluate(`
-        Array.from(document.querySelectorAll('.pvs-list__paged-list-item')).map(el => {
-            const position = el.querySelector('div[data-view-name="profile-component-entity"]');
-            if (!position) return null;
-            const title = position.querySelector('div.display-flex.align-items-center.mr1.t-bold span[aria-hidden="true"]')?.textContent?.trim() 
-                        || position.querySelector('div.display-flex.align-items-center.mr1.t-bold span.visually-hidden')?.textContent?.trim()
-                        || '';
-            const company = position.querySelector('span.t-14.t-normal span[aria-hidden="true"]')?.textContent?.trim() || '';
-            const duration = position.querySelector('span.t-14.t-normal.t-black--light span[aria-hidden="true"]')?.textContent?.trim() || '';
-            return { title, company, duration };
-        }).filter(item => item !== null);
-		`, &experienceElements),
-	)
+		return fmt.Errorf("navigation failed: %w", err)
+	}
 
+	v := s.readVoyager(ctx)
+	experienceElements, err := fromVoyager(v.partial["Position"], v.experience(), func() ([]Experience, error) {
+		var read []Experience
+		err := chromedp.Run(ctx,
+			waitVisible(Selector("entry")),
+			expandList(),
+			evaluate(script("experience"), &read),
+		)
+		return afterScrolling(ctx, s, voyager.experience, read, err)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to extract experiences: %v", err)
+		return fmt.Errorf("failed to extract experiences: %w", err)
 	}
 
-	s.Profile.Experience = experienceElements
+	// Fixtures keep every entry the page showed, they are compared with the page
+	s.update(func(p *Profile) { p.Experience = firstEntries(experienceElements) })
+	s.capture(ctx, SectionExperience, experienceElements)
 
 	return nil
 }
@@ -331,111 +1124,530 @@ func (s *Scraper) GetExperiences() error {
 /*
 	GetEducation extracts education history from the profile.
 
-The results are stored in Profile.Education.
+The results are stored in the scraped profile's Education, up to
+MaxListEntries of them; entries the page only loads on scrolling are loaded.
 
 Returns:
   - error: Any error encountered while fetching education
 */
-func (s *Scraper) GetEducation() error {
+func (s *Scraper) GetEducation(ctx context.Context) error {
+	return s.run(ctx, SectionEducation, s.getEducation)
+}
+
+func (s *Scraper) getEducation(ctx context.Context) error {
 	fmt.Println("Getting education")
-	url := path.Join(s.linkedInURL, "details/education")
+	url := path.Join(s.url(), "details/education")
 
-	err := chromedp.Run(s.ctx,
-		chromedp.Navigate(url),
-		chromedp.Sleep(2*time.Second),
-		chromedp.WaitVisible(`main`, chromedp.ByQuery),
-		chromedp.WaitVisible(`div[data-view-name="profile-component-entity"]`),
+	err := chromedp.Run(ctx,
+		navigate(url),
+		dwell(),
+		waitVisible(`main`, chromedp.ByQuery),
+	)
+	if err != nil {
+		return fmt.Errorf("navigation failed: %w", err)
+	}
+
+	v := s.readVoyager(ctx)
+	educationElements, err := fromVoyager(v.partial["Education"], v.education(), func() ([]Education, error) {
+		var read []Education
+		err := chromedp.Run(ctx,
+			waitVisible(Selector("entry")),
+			expandList(),
+			evaluate(script("education"), &read),
+		)
+		return afterScrolling(ctx, s, voyager.education, read, err)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to extract education: %w", err)
+	}
+	s.update(func(p *Profile) { p.Education = firstEntries(educationElements) })
+	s.capture(ctx, SectionEducation, educationElements)
+
+	return nil
+}
+
+/*
+	GetSkills extracts the skills listed on the profile with their endorsement counts.
+
+The results are stored in the scraped profile's Skills.
+
+Returns:
+  - error: Any error encountered while fetching skills
+*/
+func (s *Scraper) GetSkills(ctx context.Context) error {
+	return s.run(ctx, SectionSkills, s.getSkills)
+}
+
+func (s *Scraper) getSkills(ctx context.Context) error {
+	fmt.Println("Getting skills")
+	url := path.Join(s.url(), "details/skills")
+
+	// Profiles without skills render no entities, so only main is waited for
+	err := chromedp.Run(ctx,
+		navigate(url),
+		dwell(),
+		waitVisible(`main`, chromedp.ByQuery),
+	)
+	if err != nil {
+		return fmt.Errorf("navigation failed: %w", err)
+	}
+
+	var skillElements []Skill
+	err = chromedp.Run(ctx,
+		evaluate(script("skills"), &skillElements),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to extract skills: %w", err)
+	}
+	s.update(func(p *Profile) { p.Skills = skillElements })
+	s.capture(ctx, SectionSkills, skillElements)
+
+	return nil
+}
+
+/*
+	GetCertifications extracts the licenses and certifications listed on the profile.
+
+The results are stored in the scraped profile's Certifications.
+
+Returns:
+  - error: Any error encountered while fetching certifications
+*/
+func (s *Scraper) GetCertifications(ctx context.Context) error {
+	return s.run(ctx, SectionCertifications, s.getCertifications)
+}
+
+func (s *Scraper) getCertifications(ctx context.Context) error {
+	fmt.Println("Getting certifications")
+	url := path.Join(s.url(), "details/certifications")
+
+	// Most profiles list no certifications, an empty page is only waited for up to main
+	err := chromedp.Run(ctx,
+		navigate(url),
+		dwell(),
+		waitVisible(`main`, chromedp.ByQuery),
+	)
+	if err != nil {
+		return fmt.Errorf("navigation failed: %w", err)
+	}
+
+	v := s.readVoyager(ctx)
+	certificationElements, err := fromVoyager(v.partial["Certification"], v.certifications(), func() ([]Certification, error) {
+		var read []Certification
+		err := chromedp.Run(ctx, evaluate(script("certifications"), &read))
+		return read, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to extract certifications: %w", err)
+	}
+	s.update(func(p *Profile) { p.Certifications = certificationElements })
+	s.capture(ctx, SectionCertifications, certificationElements)
+
+	return nil
+}
+
+/*
+	GetRecommendations extracts the recommendations the profile owner received and gave.
+
+The results are stored in the scraped profile's Recommendations.
+
+Returns:
+  - error: Any error encountered while fetching recommendations
+*/
+func (s *Scraper) GetRecommendations(ctx context.Context) error {
+	return s.run(ctx, SectionRecommendations, s.getRecommendations)
+}
+
+func (s *Scraper) getRecommendations(ctx context.Context) error {
+	fmt.Println("Getting recommendations")
+	base := path.Join(s.url(), "details/recommendations")
+
+	var recommendations []Recommendation
+	for _, tab := range []struct {
+		index int
+		given bool
+		name  string
+	}{{0, false, "received"}, {1, true, "given"}} {
+		// Tabs without recommendations render no entities, so only main is waited for
+		err := chromedp.Run(ctx,
+			navigate(fmt.Sprintf("%s?detailScreenTabIndex=%d", base, tab.index)),
+			dwell(),
+			waitVisible(`main`, chromedp.ByQuery),
+		)
+		if err != nil {
+			return fmt.Errorf("navigation failed: %w", err)
+		}
+
+		var tabRecommendations []Recommendation
+		err = chromedp.Run(ctx,
+			evaluate(script("recommendations"), &tabRecommendations),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to extract recommendations: %w", err)
+		}
+		names := make([]string, 0, len(tabRecommendations))
+		for i := range tabRecommendations {
+			tabRecommendations[i].Given = tab.given
+			names = append(names, tabRecommendations[i].Name)
+		}
+		s.capture(ctx, SectionRecommendations+"/"+Section(tab.name), tabRecommendations, names...)
+		recommendations = append(recommendations, tabRecommendations...)
+	}
+
+	s.update(func(p *Profile) { p.Recommendations = recommendations })
+	return nil
+}
+
+/*
+	GetVolunteering extracts volunteer experience entries from the profile.
+
+The results are stored in the scraped profile's Volunteering.
+
+Returns:
+  - error: Any error encountered while fetching volunteer experience
+*/
+func (s *Scraper) GetVolunteering(ctx context.Context) error {
+	return s.run(ctx, SectionVolunteering, s.getVolunteering)
+}
+
+func (s *Scraper) getVolunteering(ctx context.Context) error {
+	fmt.Println("Getting volunteering")
+	url := path.Join(s.url(), "details/volunteering-experiences")
+
+	// Empty volunteering pages render no entities, so only main is waited for
+	err := chromedp.Run(ctx,
+		navigate(url),
+		dwell(),
+		waitVisible(`main`, chromedp.ByQuery),
+	)
+	if err != nil {
+		return fmt.Errorf("navigation failed: %w", err)
+	}
+
+	var volunteerElements []VolunteerEntry
+	err = chromedp.Run(ctx,
+		evaluate(script("volunteering"), &volunteerElements),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to extract volunteering: %w", err)
+	}
+	s.update(func(p *Profile) { p.Volunteering = volunteerElements })
+	s.capture(ctx, SectionVolunteering, volunteerElements)
+
+	return nil
+}
+
+/*
+	GetPublications extracts publication entries from the profile.
+
+The results are stored in the scraped profile's Publications.
+
+Returns:
+  - error: Any error encountered while fetching publications
+*/
+func (s *Scraper) GetPublications(ctx context.Context) error {
+	return s.run(ctx, SectionPublications, s.getPublications)
+}
+
+func (s *Scraper) getPublications(ctx context.Context) error {
+	fmt.Println("Getting publications")
+	url := path.Join(s.url(), "details/publications")
+
+	// Few profiles have publications, only main is waited for so an empty page is not a timeout
+	err := chromedp.Run(ctx,
+		navigate(url),
+		dwell(),
+		waitVisible(`main`, chromedp.ByQuery),
 	)
 	if err != nil {
-		return fmt.Errorf("navigation failed: %v", err)
-	}
-
-	var educationElements []Education
-	err = chromedp.Run(s.ctx,
-		chromedp.Evaluate(`
-            Array.from(document.querySelectorAll('.pvs-list__paged-list-item')).map(el => {
-                const position = el.querySelector('div[data-view-name="profile-component-entity"]');
-                if (!position) return null;
-                const institute = position.querySelector('div.display-flex.align-items-center.mr1.hoverable-link-text.t-bold span[aria-hidden="true"]')?.textContent?.trim() || '';
-                const major = position.querySelector('span.t-14.t-normal span[aria-hidden="true"]')?.textContent?.trim() || '';
-                const duration = position.querySelector('span.t-14.t-normal.t-black--light span[aria-hidden="true"]')?.textContent?.trim() || '';
-                return {
-                    institute,
-                    major,
-                    duration
-                };
-            }).filter(item => item !== null);
-		`, &educationElements),
+		return fmt.Errorf("navigation failed: %w", err)
+	}
+
+	var publicationElements []Publication
+	err = chromedp.Run(ctx,
+		evaluate(script("publications"), &publicationElements),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to extract education: %v", err)
+		return fmt.Errorf("failed to extract publications: %w", err)
 	}
-	s.Profile.Education = educationElements
+	s.update(func(p *Profile) { p.Publications = publicationElements })
+	s.capture(ctx, SectionPublications, publicationElements)
 
 	return nil
 }
 
 /*
-	GetNameAndLocation retrieves the profile owner's name and location.
+	GetPatents extracts patent entries from the profile.
 
-The results are stored in Profile.Name and Profile.Location.
+The results are stored in the scraped profile's Patents.
+
+Returns:
+  - error: Any error encountered while fetching patents
+*/
+func (s *Scraper) GetPatents(ctx context.Context) error {
+	return s.run(ctx, SectionPatents, s.getPatents)
+}
+
+func (s *Scraper) getPatents(ctx context.Context) error {
+	fmt.Println("Getting patents")
+	url := path.Join(s.url(), "details/patents")
+
+	// Patent pages are usually empty and render no entities, so only main is waited for
+	err := chromedp.Run(ctx,
+		navigate(url),
+		dwell(),
+		waitVisible(`main`, chromedp.ByQuery),
+	)
+	if err != nil {
+		return fmt.Errorf("navigation failed: %w", err)
+	}
+
+	var patentElements []Patent
+	err = chromedp.Run(ctx,
+		evaluate(script("patents"), &patentElements),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to extract patents: %w", err)
+	}
+	s.update(func(p *Profile) { p.Patents = patentElements })
+	s.capture(ctx, SectionPatents, patentElements)
+
+	return nil
+}
+
+/*
+	GetLanguages extracts the languages listed on the profile with their proficiency.
+
+The results are stored in the scraped profile's Languages.
+
+Returns:
+  - error: Any error encountered while fetching languages
+*/
+func (s *Scraper) GetLanguages(ctx context.Context) error {
+	return s.run(ctx, SectionLanguages, s.getLanguages)
+}
+
+func (s *Scraper) getLanguages(ctx context.Context) error {
+	fmt.Println("Getting languages")
+	url := path.Join(s.url(), "details/languages")
+
+	// Profiles without languages render no entities, so only main is waited for
+	err := chromedp.Run(ctx,
+		navigate(url),
+		dwell(),
+		waitVisible(`main`, chromedp.ByQuery),
+	)
+	if err != nil {
+		return fmt.Errorf("navigation failed: %w", err)
+	}
+
+	v := s.readVoyager(ctx)
+	languageElements, err := fromVoyager(v.partial["Language"], v.languages(), func() ([]Language, error) {
+		var read []Language
+		err := chromedp.Run(ctx, evaluate(script("languages"), &read))
+		return read, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to extract languages: %w", err)
+	}
+	s.update(func(p *Profile) { p.Languages = languageElements })
+	s.capture(ctx, SectionLanguages, languageElements)
+
+	return nil
+}
+
+/*
+	GetNameAndLocation retrieves the profile owner's top card.
+
+The results are stored in the scraped profile's Name, Location, Headline,
+Pronouns, PhotoURL, OpenToWork and, from the open-to-work card, JobPreferences.
+Only the name and location are required, the rest is left empty when the top
+card does not show it. A Sales Navigator lead target is resolved to the public
+profile first, see SalesLead; accounts with Sales Navigator access read the
+lead page's top card instead.
 
 Returns:
   - error: Any error encountered while fetching name and location
 */
-func (s *Scraper) GetNameAndLocation() error {
+func (s *Scraper) GetNameAndLocation(ctx context.Context) error {
+	return s.run(ctx, SectionNameAndLocation, s.getNameAndLocation)
+}
+
+func (s *Scraper) getNameAndLocation(ctx context.Context) error {
 	fmt.Println("Getting name and location")
-	var name, location string
-	err := chromedp.Run(s.ctx,
-		chromedp.Navigate(s.linkedInURL),
-		chromedp.Sleep(2*time.Second),
-		chromedp.WaitVisible(`.mt2.relative`),
-		chromedp.Text(`h1.inline.t-24.v-align-middle.break-words`, &name),
-		chromedp.Text(`.text-body-small.inline.t-black--light.break-words`, &location),
+	if lead := s.url(); SalesLead(lead) != "" {
+		read, err := s.openLead(ctx, lead)
+		if err != nil || read {
+			return err
+		}
+	}
+	err := chromedp.Run(ctx,
+		navigate(s.url()),
+		dwell(),
+		waitVisible(`main`, chromedp.ByQuery),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to get name and location: %v", err)
+		return fmt.Errorf("failed to get name and location: %w", err)
+	}
+	v := s.readVoyager(ctx)
+	name, location := v.name(), v.location()
+	if name == "" || location == "" {
+		err = chromedp.Run(ctx,
+			waitVisible(Selector("top-card")),
+			chromedp.Text(rewritten(Selector("top-card.name")), &name),
+			chromedp.Text(rewritten(Selector("top-card.location")), &location),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to get name and location: %w", err)
+		}
 	}
 
-	s.Profile.Name = name
-	s.Profile.Location = location
+	// The open-to-work badge is a frame drawn into the photo, named in its alt text, or an
+	// "Open to work" card under the top card when the owner shows it to everyone. Accounts
+	// without a photo show a ghost placeholder, which isn't worth keeping
+	var header struct {
+		Headline   string `json:"headline"`
+		Pronouns   string `json:"pronouns"`
+		PhotoURL   string `json:"photoUrl"`
+		OpenToWork bool   `json:"openToWork"`
+		CompanyURL string `json:"companyUrl"`
+		// "500+ connections" and "12K followers" as shown, parsed by count
+		Connections string `json:"connections"`
+		Followers   string `json:"followers"`
+	}
+	err = chromedp.Run(ctx,
+		evaluate(script("top-card"), &header),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get headline and badges: %w", err)
+	}
+	if v.profile != nil && v.profile.Headline != "" {
+		header.Headline = v.profile.Headline
+	}
+	var prefs *JobPreferences
+	if header.OpenToWork {
+		// The badge alone is enough, preferences only add to it
+		if prefs, err = s.jobPreferences(ctx); err != nil {
+			fmt.Printf("Could not read job preferences: %v\n", err)
+		}
+	}
+
+	s.update(func(p *Profile) {
+		p.Name = name
+		p.Location = location
+		p.Headline = header.Headline
+		p.Pronouns = header.Pronouns
+		p.PhotoURL = header.PhotoURL
+		p.OpenToWork = header.OpenToWork
+		p.CompanyURL = CompanyPage(header.CompanyURL)
+		p.Connections = ParseCount(header.Connections)
+		p.Followers = ParseCount(header.Followers)
+		p.JobPreferences = prefs
+	})
+	s.capture(ctx, SectionNameAndLocation, map[string]any{
+		"name": name, "location": location, "headline": header.Headline, "pronouns": header.Pronouns,
+		"photoUrl": header.PhotoURL, "openToWork": header.OpenToWork, "companyUrl": CompanyPage(header.CompanyURL),
+		"connections": ParseCount(header.Connections), "followers": ParseCount(header.Followers), "jobPreferences": prefs,
+	})
 	return nil
 }
 
 /*
 	GetAbout extracts the "About" section content from the profile.
 
-The result is stored in Profile.About.
+The text LinkedIn cuts off behind "…see more" is clicked open first. The result
+is stored in the scraped profile's About.
 
 Returns:
   - error: Any error encountered while fetching about section
 */
-func (s *Scraper) GetAbout() error {
-	fmt.Println("Getting about")
-	var about string
-	err := chromedp.Run(s.ctx,
-		chromedp.WaitVisible(`div[class*="display-flex ph5"]`), // Wait for main content
-		chromedp.Evaluate(`(() => {
-            // Find the About section's text content
-            const aboutSpans = document.querySelectorAll('div[class*="display-flex full-width"] span[aria-hidden="true"]');
-            if (!aboutSpans.length) return "";
-            
-            return Array.from(aboutSpans)
-                .map(span => span.textContent.trim())
-                .filter(text => text.length > 0)[0]
-        })()`, &about),
-	)
+func (s *Scraper) GetAbout(ctx context.Context) error {
+	ctx, cancel := s.within(ctx)
+	defer cancel()
+	err := s.getAbout(ctx)
+	s.saveFailure(SectionAbout, err)
+	return err
+}
 
-	if err != nil {
-		return fmt.Errorf("failed to get about: %w", err)
+func (s *Scraper) getAbout(ctx context.Context) error {
+	fmt.Println("Getting about")
+	s.mu.Lock()
+	onLead := s.onLead
+	s.mu.Unlock()
+	if onLead {
+		return s.getLeadAbout(ctx)
+	}
+	// The data holds the whole text, there is nothing to click open
+	about := s.readVoyager(ctx).about()
+	if about == "" {
+		err := chromedp.Run(ctx,
+			waitVisible(Selector("about")), // Wait for main content
+			expandSeeMore(Selector("about.section")),
+			evaluate(script("about"), &about),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to get about: %w", err)
+		}
 	}
 
-	s.Profile.About = about
+	about = trimSeeMore(about)
+	s.update(func(p *Profile) { p.About = about })
+	s.capture(ctx, SectionAbout, map[string]string{"about": about})
 	return nil
 }
 
+/*
+	SetProfileURL points the scraper at another profile, reusing the logged in session.
+
+Profile is reset so sections scraped for the previous target don't leak into the new one.
+
+Parameters:
+  - linkedInURL: Target profile URL to scrape, or a Sales Navigator lead URL
+*/
+func (s *Scraper) SetProfileURL(linkedInURL string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.linkedInURL = linkedInURL
+	s.profile = &Profile{}
+	s.onLead = false
+	s.admitted = false
+	s.responses.reset()
+}
+
+/*
+	Profile returns a copy of everything scraped for the current target so far.
+
+It is safe to call while other goroutines are still scraping sections.
+*/
+func (s *Scraper) Profile() Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.profile.Clone()
+}
+
+// update applies fn to the profile under the lock. Each section only sets its own fields.
+func (s *Scraper) update(fn func(p *Profile)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.profile)
+}
+
+// url returns the current target profile URL.
+func (s *Scraper) url() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.linkedInURL
+}
+
 func (s *Scraper) Close() {
 	s.cancel()
+	s.browserCancel()
+	// The allocator waits for the browser to exit, only then may the reaper touch its PID
+	s.allocCancel()
+	if s.pid != 0 {
+		unregisterBrowser(s.pid)
+		s.pid = 0
+	}
 }
 
 /*
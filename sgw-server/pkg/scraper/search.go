@@ -0,0 +1,183 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// MaxSearchPage is the last page of results LinkedIn shows for a people search.
+const MaxSearchPage = 100
+
+// profilePageRe matches a LinkedIn profile link, dropping tabs and tracking parameters.
+var profilePageRe = regexp.MustCompile(`^https://(?:[a-z]+\.)?linkedin\.com/in/([^/?#]+)`)
+
+// Network degrees accepted by SearchFilters.Network.
+const (
+	NetworkFirst  = "F" // 1st degree connections
+	NetworkSecond = "S" // 2nd degree connections
+	NetworkThird  = "O" // 3rd degree and everyone else
+)
+
+/*
+	SearchFilters narrows a people search.
+
+Title, Company and School match the text of a profile's current title,
+current company and school. Network takes NetworkFirst, NetworkSecond and
+NetworkThird; Locations takes LinkedIn geo ids, the numbers in the geoUrn
+parameter of a search URL (e.g. "102713980" for India). Page is 1-based, 0
+means the first page.
+*/
+type SearchFilters struct {
+	Title     string   `json:"title,omitempty"`
+	Company   string   `json:"company,omitempty"`
+	School    string   `json:"school,omitempty"`
+	Network   []string `json:"network,omitempty"`
+	Locations []string `json:"locations,omitempty"`
+	Page      int      `json:"page,omitempty"`
+}
+
+// SearchResult is a person listed by a people search.
+type SearchResult struct {
+	URL      string `json:"url"` // Profile URL, https://www.linkedin.com/in/<id>/
+	Name     string `json:"name"`
+	Headline string `json:"headline,omitempty"`
+	Location string `json:"location,omitempty"`
+}
+
+// SearchPage is one page of people search results.
+type SearchPage struct {
+	Results []SearchResult `json:"results"`
+	Page    int            `json:"page"`
+	HasMore bool           `json:"hasMore"` // Whether a next page exists
+}
+
+// ProfilePage returns the canonical URL of the LinkedIn profile href links to, or "" when it is not one.
+func ProfilePage(href string) string {
+	m := profilePageRe.FindStringSubmatch(strings.TrimSpace(href))
+	if m == nil {
+		return ""
+	}
+	return "https://www.linkedin.com/in/" + m[1] + "/"
+}
+
+/*
+	SearchURL returns the LinkedIn people search URL for query and filters.
+
+Parameters:
+  - ctx: Ends the search early when it is cancelled or reaches its deadline
+  - query: Keywords, as typed in LinkedIn's search box
+  - filters: Filters narrowing the search, including the page
+
+Returns:
+  - string: The search results URL
+  - error: An error naming the first invalid filter
+*/
+func SearchURL(query string, filters SearchFilters) (string, error) {
+	v := url.Values{}
+	if query = strings.TrimSpace(query); query != "" {
+		v.Set("keywords", query)
+	}
+	for param, value := range map[string]string{"titleFreeText": filters.Title, "company": filters.Company, "schoolFreetext": filters.School} {
+		if value = strings.TrimSpace(value); value != "" {
+			v.Set(param, value)
+		}
+	}
+	for _, n := range filters.Network {
+		if n != NetworkFirst && n != NetworkSecond && n != NetworkThird {
+			return "", fmt.Errorf("unknown network %q, expected %s, %s or %s", n, NetworkFirst, NetworkSecond, NetworkThird)
+		}
+	}
+	for _, l := range filters.Locations {
+		if _, err := strconv.ParseUint(l, 10, 64); err != nil {
+			return "", fmt.Errorf("location %q is not a LinkedIn geo id", l)
+		}
+	}
+	// LinkedIn takes list filters as JSON arrays
+	if len(filters.Network) > 0 {
+		network, _ := json.Marshal(filters.Network)
+		v.Set("network", string(network))
+	}
+	if len(filters.Locations) > 0 {
+		locations, _ := json.Marshal(filters.Locations)
+		v.Set("geoUrn", string(locations))
+	}
+	if len(v) == 0 {
+		return "", fmt.Errorf("a query or a filter is required")
+	}
+	if filters.Page < 0 || filters.Page > MaxSearchPage {
+		return "", fmt.Errorf("page %d is out of range, LinkedIn shows pages 1 to %d", filters.Page, MaxSearchPage)
+	}
+	if filters.Page > 1 {
+		v.Set("page", strconv.Itoa(filters.Page))
+	}
+	v.Set("origin", "FACETED_SEARCH")
+	return "https://www.linkedin.com/search/results/people/?" + v.Encode(), nil
+}
+
+/*
+	SearchPeople runs a LinkedIn people search and returns one page of results.
+
+People outside the account's network that LinkedIn only shows as "LinkedIn
+Member" are left out, since they have no profile URL. It doesn't touch the
+scraped profile.
+
+Parameters:
+  - query: Keywords, as typed in LinkedIn's search box
+  - filters: Filters narrowing the search, including the page
+
+Returns:
+  - SearchPage: The page's results and whether more follow
+  - error: An error for invalid filters, or any error encountered while searching
+*/
+func (s *Scraper) SearchPeople(ctx context.Context, query string, filters SearchFilters) (SearchPage, error) {
+	searchURL, err := SearchURL(query, filters)
+	if err != nil {
+		return SearchPage{}, err
+	}
+	var page SearchPage
+	err = s.run(ctx, "search", func(ctx context.Context) error {
+		var err error
+		page, err = s.searchPeople(ctx, searchURL)
+		return err
+	})
+	page.Page = max(filters.Page, 1)
+	return page, err
+}
+
+func (s *Scraper) searchPeople(ctx context.Context, searchURL string) (SearchPage, error) {
+	fmt.Println("Searching people")
+	var found struct {
+		Results []SearchResult `json:"results"`
+		HasMore bool           `json:"hasMore"`
+	}
+	err := chromedp.Run(ctx,
+		navigate(searchURL),
+		dwell(),
+		waitVisible(`main`, chromedp.ByQuery),
+		// The pagination bar only renders once scrolled into view
+		evaluate(`window.scrollTo(0, document.body.scrollHeight)`, nil),
+		pause(time.Second),
+		evaluate(script("search-people"), &found),
+	)
+	if err != nil {
+		return SearchPage{}, fmt.Errorf("failed to search people: %w", err)
+	}
+
+	page := SearchPage{Results: []SearchResult{}, HasMore: found.HasMore}
+	for _, r := range found.Results {
+		r.URL = ProfilePage(r.URL)
+		if r.URL == "" || r.Name == "" || r.Name == "LinkedIn Member" {
+			continue
+		}
+		page.Results = append(page.Results, r)
+	}
+	return page, nil
+}
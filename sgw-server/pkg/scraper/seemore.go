@@ -0,0 +1,67 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// maxSeeMore caps the toggles clicked on a page, a few more than the posts read off it.
+const maxSeeMore = 10
+
+// seeMoreLabel matches the label of a toggle left at the end of text that wasn't expanded.
+var seeMoreLabel = regexp.MustCompile(`(?i)\s*(?:…|\.\.\.)\s*(?:see )?more\s*$`)
+
+/*
+	expandSeeMore clicks open the "…see more" toggles within the elements scope selects.
+
+Text read before its toggle is clicked ends mid-sentence, which the model then
+writes from. Toggles already open or hidden are skipped. A toggle that can't be
+clicked leaves its text cut off, trimSeeMore drops its label; only ctx ending
+fails it.
+*/
+func expandSeeMore(scope string) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		var n int
+		err := evaluate(`(() => {
+            let n = 0;
+            document.querySelectorAll(`+jsString(scope)+`).forEach(el => el.querySelectorAll(`+jsString(Selector("see-more"))+`).forEach(button => {
+                if (n < `+strconv.Itoa(maxSeeMore)+` && button.getAttribute('aria-expanded') !== 'true' && button.offsetParent !== null) {
+                    button.setAttribute('data-sgw-more', String(n++));
+                }
+            }));
+            return n;
+        })()`, &n).Do(ctx)
+		if err != nil {
+			fmt.Printf("Could not find see more toggles: %v\n", err)
+			return ctx.Err()
+		}
+		for i := range n {
+			// A toggle a re-render removed would otherwise be waited on until ctx ends
+			clickCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			err := click(`[data-sgw-more="` + strconv.Itoa(i) + `"]`).Do(clickCtx)
+			cancel()
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != nil {
+				fmt.Printf("Could not expand text, keeping it cut off: %v\n", err)
+				continue
+			}
+			if err := pause(300 * time.Millisecond).Do(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// trimSeeMore drops the label of a toggle that wasn't clicked from the end of text.
+func trimSeeMore(text string) string {
+	return strings.TrimSpace(seeMoreLabel.ReplaceAllString(text, ""))
+}
@@ -0,0 +1,81 @@
+package scraper
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/chromedp/chromedp"
+)
+
+// selectorRewrites replaces selectors in page scripts, nil when there are none.
+var selectorRewrites atomic.Pointer[rewriter]
+
+// rewriter applies selector rewrites to selectors, and to the selectors' string literals in
+// scripts, where the new ones are escaped as jsString escapes them.
+type rewriter struct {
+	selectors, scripts *strings.Replacer
+}
+
+/*
+	SetSelectors replaces the selector rewrites applied to the page scripts the scraper runs and the elements it waits for, clicks and types into.
+
+Each key is replaced by its value wherever it appears, so a selector LinkedIn
+broke, e.g. ".pvs-list__paged-list-item", can be swapped for the new one
+without a new build. Longer keys are replaced first. Scrapes already running
+use the new rewrites from their next page script.
+
+Parameters:
+  - rewrites: The new selector by the one it replaces, nil or empty for none
+*/
+func SetSelectors(rewrites map[string]string) {
+	if len(rewrites) == 0 {
+		selectorRewrites.Store(nil)
+		return
+	}
+	selectorRewrites.Store(newRewriter(rewrites))
+}
+
+// newRewriter returns the rewriter applying rewrites, longer keys first.
+func newRewriter(rewrites map[string]string) *rewriter {
+	old := make([]string, 0, len(rewrites))
+	for k := range rewrites {
+		old = append(old, k)
+	}
+	slices.SortFunc(old, func(a, b string) int {
+		return cmp.Or(len(b)-len(a), strings.Compare(a, b))
+	})
+	selectors, scripts := make([]string, 0, 2*len(old)), make([]string, 0, 2*len(old))
+	for _, k := range old {
+		selectors = append(selectors, k, rewrites[k])
+		scripts = append(scripts, jsQuoter.Replace(k), jsQuoter.Replace(rewrites[k]))
+	}
+	return &rewriter{selectors: strings.NewReplacer(selectors...), scripts: strings.NewReplacer(scripts...)}
+}
+
+// script applies the rewrites to a script, nil applying none.
+func (r *rewriter) script(s string) string {
+	if r == nil {
+		return s
+	}
+	return r.scripts.Replace(s)
+}
+
+// rewritten applies the selector rewrites to a selector.
+func rewritten(sel string) string {
+	if r := selectorRewrites.Load(); r != nil {
+		return r.selectors.Replace(sel)
+	}
+	return sel
+}
+
+// evaluate is chromedp.Evaluate with the selector rewrites applied to the script.
+func evaluate(script string, res any, opts ...chromedp.EvaluateOption) chromedp.EvaluateAction {
+	return chromedp.Evaluate(selectorRewrites.Load().script(script), res, opts...)
+}
+
+// waitVisible is chromedp.WaitVisible with the selector rewrites applied.
+func waitVisible(sel string, opts ...chromedp.QueryOption) chromedp.QueryAction {
+	return chromedp.WaitVisible(rewritten(sel), opts...)
+}
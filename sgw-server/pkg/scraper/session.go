@@ -0,0 +1,170 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/storage"
+	"github.com/chromedp/chromedp"
+)
+
+/*
+	Session is the cookie jar of a logged in browser, for logging later browsers in without the login form.
+
+Every login through the form is a chance for LinkedIn to stop the account at a
+checkpoint; a browser started with the jar of an earlier one continues that
+session instead. The li_at cookie is what LinkedIn authenticates with, the rest
+of the jar keeps the browser looking like the one that logged in.
+*/
+type Session struct {
+	Cookies []Cookie  `json:"cookies"`
+	SavedAt time.Time `json:"savedAt"`
+}
+
+// Cookie is one cookie of a Session.
+type Cookie struct {
+	Name     string    `json:"name"`
+	Value    string    `json:"value"`
+	Domain   string    `json:"domain"`
+	Path     string    `json:"path"`
+	Expires  time.Time `json:"expires,omitempty"` // Zero for cookies that end with the browser
+	HTTPOnly bool      `json:"httpOnly,omitempty"`
+	Secure   bool      `json:"secure,omitempty"`
+	SameSite string    `json:"sameSite,omitempty"`
+}
+
+// LiAt returns the session's li_at cookie, "" when it has none or it has expired.
+func (s *Session) LiAt() string {
+	for _, c := range s.Cookies {
+		if c.Name == "li_at" && (c.Expires.IsZero() || c.Expires.After(time.Now())) {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+func (s *Session) params() []*network.CookieParam {
+	params := make([]*network.CookieParam, 0, len(s.Cookies))
+	for _, c := range s.Cookies {
+		param := &network.CookieParam{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			HTTPOnly: c.HTTPOnly,
+			Secure:   c.Secure,
+			SameSite: network.CookieSameSite(c.SameSite),
+		}
+		if !c.Expires.IsZero() {
+			expires := cdp.TimeSinceEpoch(c.Expires)
+			param.Expires = &expires
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+/*
+	Session returns the LinkedIn cookies of the scraper's browser.
+
+Returns:
+  - *Session: The cookies, for NewScraperFromSession
+  - error: Any error reading the browser's cookies, or ErrNotAuthenticated when it holds no li_at cookie
+*/
+func (s *Scraper) Session() (*Session, error) {
+	var cookies []*network.Cookie
+	err := chromedp.Run(s.ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		cookies, err = storage.GetCookies().Do(ctx)
+		return err
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cookies: %w", err)
+	}
+	session := &Session{SavedAt: time.Now()}
+	for _, c := range cookies {
+		if !strings.HasSuffix(strings.TrimPrefix(c.Domain, "."), "linkedin.com") {
+			continue
+		}
+		cookie := Cookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			HTTPOnly: c.HTTPOnly,
+			Secure:   c.Secure,
+			SameSite: string(c.SameSite),
+		}
+		if !c.Session && c.Expires > 0 {
+			cookie.Expires = time.Unix(0, int64(c.Expires*float64(time.Second)))
+		}
+		session.Cookies = append(session.Cookies, cookie)
+	}
+	if session.LiAt() == "" {
+		return nil, fmt.Errorf("%w: the browser has no li_at cookie", ErrNotAuthenticated)
+	}
+	return session, nil
+}
+
+/*
+	NewScraperFromSession creates a scraper logged in with a saved session instead of the login form.
+
+The browser is started with the session's cookies and opens the feed; when
+LinkedIn no longer accepts the session the browser is closed again, and callers
+fall back to NewScraper. As with NewScraper, the browser connects through the
+proxy Proxies hands out for the account, and the credentials are kept for
+re-logging in when the session expires while scraping.
+
+Parameters:
+  - session: Cookies saved with (*Scraper).Session
+  - email: LinkedIn account email
+  - password: LinkedIn account password
+  - linkedInURL: Target profile URL to scrape, or a Sales Navigator lead URL
+
+Returns:
+  - *Scraper: Initialized scraper instance
+  - error: ErrNotAuthenticated when the session has expired, ErrBotDetected or ErrAccountRestricted when LinkedIn stopped it, or any error starting the browser
+*/
+func NewScraperFromSession(session *Session, email, password, linkedInURL string) (*Scraper, error) {
+	if session.LiAt() == "" {
+		return nil, fmt.Errorf("%w: the session has no li_at cookie", ErrNotAuthenticated)
+	}
+	proxy, err := proxyFor(email)
+	if err != nil {
+		return nil, err
+	}
+	opts := DefaultOptions().forAccount(email)
+	allocCtx, allocCancel := allocator(opts, proxy)
+	s := &Scraper{
+		allocCancel: allocCancel,
+		linkedInURL: linkedInURL,
+		email:       email,
+		password:    password,
+		profile:     &Profile{},
+		companies:   map[string]*Company{},
+		jobs:        map[string]*Job{},
+		proxy:       proxy,
+		opts:        opts,
+		fingerprint: pickFingerprint(email),
+	}
+	if err := s.open(allocCtx); err != nil {
+		allocCancel()
+		return nil, fmt.Errorf("failed to start browser: %w", err)
+	}
+
+	err = chromedp.Run(s.ctx,
+		storage.SetCookies(session.params()),
+		navigate("https://www.linkedin.com/feed/"),
+	)
+	reportProxy(proxy, err)
+	if err != nil {
+		s.Close()
+		return nil, fmt.Errorf("failed to restore session: %w", err)
+	}
+	s.startWatchdog()
+	return s, nil
+}
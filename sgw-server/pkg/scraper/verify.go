@@ -0,0 +1,223 @@
+package scraper
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+	"github.com/chromedp/chromedp/kb"
+)
+
+/*
+	RemoteVerification, when set, lets a security checkpoint hit by a headless login be
+
+solved from another browser instead of relaunching Chrome with a window, which a
+server without a display cannot show. It is called with the verification once the
+page is being streamed and must not block; the login waits until the checkpoint is
+cleared or VerificationTimeout passes.
+*/
+var RemoteVerification func(v *Verification)
+
+// VerificationTimeout is how long a remote verification waits for the checkpoint to be solved.
+var VerificationTimeout = 5 * time.Minute
+
+// ErrVerificationEnded is returned for input sent after a verification finished or timed out.
+var ErrVerificationEnded = errors.New("verification has ended")
+
+// ErrUnsupportedKey is returned by Press for keys it cannot replay.
+var ErrUnsupportedKey = errors.New("unsupported key")
+
+// remoteKeys maps the names of keys without a character to what chromedp types for them.
+var remoteKeys = map[string]string{
+	"Enter":      kb.Enter,
+	"Backspace":  kb.Backspace,
+	"Tab":        kb.Tab,
+	"Escape":     kb.Escape,
+	"Delete":     kb.Delete,
+	"ArrowLeft":  kb.ArrowLeft,
+	"ArrowRight": kb.ArrowRight,
+	"ArrowUp":    kb.ArrowUp,
+	"ArrowDown":  kb.ArrowDown,
+}
+
+/*
+	Verification is a security checkpoint being solved from another browser.
+
+The checkpoint page is streamed as JPEG screencast frames, and clicks and key
+presses are replayed into it. All methods are safe for concurrent use.
+*/
+type Verification struct {
+	Email     string    // Account stopped at the checkpoint
+	ExpiresAt time.Time // When the login gives up waiting
+
+	ctx  context.Context
+	done chan struct{}
+
+	mu    sync.Mutex
+	frame []byte
+	meta  page.ScreencastFrameMetadata
+}
+
+/*
+	Frame returns the latest screencast frame.
+
+Returns:
+  - []byte: JPEG image of the checkpoint page
+  - bool: Whether a frame has been received yet
+*/
+func (v *Verification) Frame() ([]byte, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.frame, v.frame != nil
+}
+
+/*
+	Click clicks the checkpoint page at a point of the latest frame.
+
+Parameters:
+  - x: Horizontal position as a fraction of the frame width, 0 to 1
+  - y: Vertical position as a fraction of the frame height, 0 to 1
+
+Returns:
+  - error: ErrVerificationEnded, or any error dispatching the click
+*/
+func (v *Verification) Click(x, y float64) error {
+	v.mu.Lock()
+	meta, framed := v.meta, v.frame != nil
+	v.mu.Unlock()
+	if !framed {
+		return errors.New("no frame received yet")
+	}
+	// Frames show the viewport, so the page's CSS pixels map onto them directly
+	return v.run(chromedp.MouseClickXY(x*meta.DeviceWidth, y*meta.DeviceHeight))
+}
+
+/*
+	Type types text into the focused element of the checkpoint page.
+
+Parameters:
+  - text: Characters to type
+
+Returns:
+  - error: ErrVerificationEnded, or any error dispatching the key events
+*/
+func (v *Verification) Type(text string) error {
+	return v.run(chromedp.KeyEvent(text))
+}
+
+/*
+	Press presses a key without a character, such as Enter or Backspace.
+
+Parameters:
+  - key: Key name as browsers report it in KeyboardEvent.key
+
+Returns:
+  - error: ErrVerificationEnded, ErrUnsupportedKey, or any error dispatching the key
+*/
+func (v *Verification) Press(key string) error {
+	k, ok := remoteKeys[key]
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnsupportedKey, key)
+	}
+	return v.run(chromedp.KeyEvent(k))
+}
+
+// Done is closed once the checkpoint was solved or the login gave up on it.
+func (v *Verification) Done() <-chan struct{} {
+	return v.done
+}
+
+func (v *Verification) run(action chromedp.Action) error {
+	select {
+	case <-v.done:
+		return ErrVerificationEnded
+	default:
+	}
+	if err := chromedp.Run(v.ctx, action); err != nil {
+		if v.ctx.Err() != nil {
+			return ErrVerificationEnded
+		}
+		return err
+	}
+	return nil
+}
+
+func (v *Verification) setFrame(f *page.EventScreencastFrame) {
+	frame, err := base64.StdEncoding.DecodeString(f.Data)
+	if err != nil || f.Metadata == nil {
+		return
+	}
+	v.mu.Lock()
+	v.frame, v.meta = frame, *f.Metadata
+	v.mu.Unlock()
+}
+
+/*
+	verifyRemotely streams the checkpoint the browser is stopped at to RemoteVerification
+
+and waits for it to be solved.
+
+Returns:
+  - error: ErrVerificationRequired when the checkpoint was not solved within
+    VerificationTimeout, ErrAccountRestricted when solving it showed the account
+    restricted, or any browser error
+*/
+func (s *Scraper) verifyRemotely() error {
+	ctx, cancel := context.WithTimeout(s.browserCtx, VerificationTimeout)
+	defer cancel()
+	v := &Verification{Email: s.email, ExpiresAt: time.Now().Add(VerificationTimeout), ctx: ctx, done: make(chan struct{})}
+	defer close(v.done)
+
+	chromedp.ListenTarget(ctx, func(ev interface{}) {
+		f, ok := ev.(*page.EventScreencastFrame)
+		if !ok {
+			return
+		}
+		v.setFrame(f)
+		// Chrome sends no further frames until this one is acknowledged. Listeners
+		// must not block, so the acknowledgement is sent from its own goroutine.
+		go chromedp.Run(ctx, page.ScreencastFrameAck(f.SessionID))
+	})
+	err := chromedp.Run(ctx, page.StartScreencast().
+		WithFormat(page.ScreencastFormatJpeg).
+		WithQuality(70).
+		WithMaxWidth(1280).
+		WithMaxHeight(960))
+	if err != nil {
+		return fmt.Errorf("failed to stream the checkpoint: %w", err)
+	}
+	defer chromedp.Run(ctx, page.StopScreencast())
+
+	fmt.Println("Security verification required, waiting for it to be solved remotely")
+	RemoteVerification(v)
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: verification was not completed within %s", ErrVerificationRequired, VerificationTimeout)
+		case <-ticker.C:
+		}
+
+		var currentURL string
+		if err := chromedp.Run(ctx, chromedp.Location(&currentURL)); err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			return err
+		}
+		if restricted(currentURL) {
+			return fmt.Errorf("%w: redirected to %s", ErrAccountRestricted, currentURL)
+		}
+		if !challenged(currentURL) {
+			fmt.Println("Verification completed remotely")
+			return nil
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// videoMaxTranscript caps the transcript kept per video post.
+const videoMaxTranscript = 1500
+
+// cueTagRe matches the voice, class and timestamp tags WebVTT cues may carry, e.g. <v Priya> or <00:01.500>.
+var cueTagRe = regexp.MustCompile(`<[^>]*>`)
+
+// transcript fetches the captions track of a video post with the session's cookies and
+// returns its text, truncated to videoMaxTranscript.
+func (s *Scraper) transcript(ctx context.Context, src string) (string, error) {
+	quoted, _ := json.Marshal(src)
+	var vtt string
+	err := chromedp.Run(ctx, evaluate(`
+            fetch(`+string(quoted)+`, {credentials: 'include'})
+                .then(r => r.ok ? r.text() : Promise.reject(new Error('HTTP ' + r.status)))
+        `, &vtt, func(p *runtime.EvaluateParams) *runtime.EvaluateParams { return p.WithAwaitPromise(true) }))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch captions: %w", err)
+	}
+	text := captionText(vtt)
+	if len(text) > videoMaxTranscript {
+		text = strings.ToValidUTF8(text[:videoMaxTranscript], "") + "..."
+	}
+	return text, nil
+}
+
+// withTranscript appends a video's transcript to the caption of its post.
+func withTranscript(content, transcript string) string {
+	if transcript == "" {
+		return content
+	}
+	if content == "" {
+		return "Video transcript: " + transcript
+	}
+	return content + "\n\nVideo transcript: " + transcript
+}
+
+/*
+	captionText returns the spoken text of a WebVTT captions track.
+
+Headers, NOTE and STYLE blocks, cue ids, timings and tags are dropped, entities
+are decoded and lines repeated by rolling captions are kept once, leaving the
+cues' text joined by spaces.
+*/
+func captionText(vtt string) string {
+	var words []string
+	last := ""
+	for _, block := range strings.Split(strings.ReplaceAll(vtt, "\r\n", "\n"), "\n\n") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+		timing := -1
+		for i, line := range lines {
+			if strings.Contains(line, "-->") {
+				timing = i
+				break
+			}
+		}
+		// Blocks without a timing line are the header, notes and styles
+		if timing < 0 {
+			continue
+		}
+		for _, line := range lines[timing+1:] {
+			line = strings.Join(strings.Fields(html.UnescapeString(cueTagRe.ReplaceAllString(line, ""))), " ")
+			if line == "" || line == last {
+				continue
+			}
+			words = append(words, line)
+			last = line
+		}
+	}
+	return strings.Join(words, " ")
+}
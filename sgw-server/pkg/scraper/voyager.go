@@ -0,0 +1,388 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// voyagerType prefixes the $type of the profile entities in LinkedIn's voyager responses.
+const voyagerType = "com.linkedin.voyager.dash.identity.profile."
+
+/*
+	voyager is the structured data of a profile LinkedIn embeds in its pages.
+
+Pages are rendered from responses of LinkedIn's voyager API, which it embeds
+in <code> tags for its scripts to hydrate from, or which the pages request as
+they load and scroll, see interceptVoyager. Their entities name fields
+the way the API does, so unlike the rendered page they don't break with a new
+class name. Only the entities of the profile the page is of are kept, pages
+also carry the viewer's own profile and the people also viewed.
+*/
+type voyager struct {
+	profile  *voyagerEntity    // The profile the page is of, nil when the page has none
+	entities []voyagerEntity   // Its positions, education, certifications and languages
+	geos     map[string]string // Localized name by geo URN
+	// The types of entities, e.g. Position, of which a collection on the page holds
+	// fewer than it has, the rest loading as the page is scrolled
+	partial map[string]bool
+}
+
+type voyagerEntity struct {
+	Type      string `json:"$type"`
+	EntityURN string `json:"entityUrn"`
+
+	PublicIdentifier string `json:"publicIdentifier"`
+	FirstName        string `json:"firstName"`
+	LastName         string `json:"lastName"`
+	Headline         string `json:"headline"`
+	Summary          string `json:"summary"`
+	LocationName     string `json:"locationName"`
+	GeoLocation      *struct {
+		GeoURN string `json:"*geo"`
+		Geo    *struct {
+			DefaultLocalizedName string `json:"defaultLocalizedName"`
+		} `json:"geo"`
+	} `json:"geoLocation"`
+	DefaultLocalizedName string `json:"defaultLocalizedName"` // Of geo entities
+
+	Title        string            `json:"title"`
+	CompanyName  string            `json:"companyName"`
+	SchoolName   string            `json:"schoolName"`
+	DegreeName   string            `json:"degreeName"`
+	FieldOfStudy string            `json:"fieldOfStudy"`
+	Name         string            `json:"name"`
+	Authority    string            `json:"authority"`
+	Proficiency  string            `json:"proficiency"`
+	DateRange    *voyagerDateRange `json:"dateRange"`
+}
+
+type voyagerDateRange struct {
+	Start *voyagerDate `json:"start"`
+	End   *voyagerDate `json:"end"`
+}
+
+type voyagerDate struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+}
+
+// readVoyager reads the voyager data of the page open in the tab, with the API responses
+// its pages received once those still loading are in, for the target profile. A page
+// whose data can't be read is as one without any, read through the page scripts.
+func (s *Scraper) readVoyager(ctx context.Context) voyager {
+	var blocks []string
+	if err := chromedp.Run(ctx, evaluate(script("voyager"), &blocks)); err != nil {
+		fmt.Printf("Could not read the page's voyager data: %v\n", err)
+	}
+	s.responses.wait(ctx, voyagerSettle)
+	blocks = append(blocks, s.responses.all()...)
+	m := profilePageRe.FindStringSubmatch(s.url())
+	if m == nil {
+		return voyager{}
+	}
+	slug, err := url.PathUnescape(m[1])
+	if err != nil {
+		slug = m[1]
+	}
+	return parseVoyager(blocks, slug)
+}
+
+/*
+	parseVoyager parses voyager responses, from a page's <code> tags or its API requests,
+	for the profile whose public identifier is slug.
+
+Tags hold a response as JSON, as HTML with its quotes escaped or within an
+HTML comment; blocks holding anything else are skipped. A profile's entities
+are told apart by its member id in their URNs. Responses list entities in no
+particular order, so they are put in the order of the collections' *elements,
+the page's, and those no collection lists after them, latest start first.
+*/
+func parseVoyager(blocks []string, slug string) voyager {
+	v := voyager{geos: map[string]string{}, partial: map[string]bool{}}
+	var included []voyagerEntity
+	rank := map[string]int{}
+	var paged []string
+	for _, block := range blocks {
+		block = strings.TrimSpace(block)
+		block = strings.TrimSuffix(strings.TrimPrefix(block, "<!--"), "-->")
+		var res struct {
+			Data     any               `json:"data"`
+			Included []json.RawMessage `json:"included"`
+		}
+		// Only blocks that aren't JSON as they are get unescaped, so an &amp; in an API response's text stays
+		if json.Unmarshal([]byte(block), &res) != nil && json.Unmarshal([]byte(html.UnescapeString(block)), &res) != nil {
+			continue
+		}
+		walkCollections(res.Data, func(elements []string, partial bool) {
+			for _, urn := range elements {
+				if _, ok := rank[urn]; !ok {
+					rank[urn] = len(rank)
+				}
+			}
+			if partial {
+				paged = append(paged, elements...)
+			}
+		})
+		for _, raw := range res.Included {
+			var e voyagerEntity
+			if json.Unmarshal(raw, &e) != nil {
+				continue
+			}
+			if e.DefaultLocalizedName != "" {
+				v.geos[e.EntityURN] = e.DefaultLocalizedName
+			}
+			included = append(included, e)
+		}
+	}
+	types := map[string]string{}
+	for _, e := range included {
+		types[e.EntityURN] = strings.TrimPrefix(e.Type, voyagerType)
+	}
+	for _, urn := range paged {
+		if t := urnType(urn, types); t != "" {
+			v.partial[t] = true
+		}
+	}
+	for i, e := range included {
+		if e.Type == voyagerType+"Profile" && slug != "" && strings.EqualFold(e.PublicIdentifier, slug) {
+			v.profile = &included[i]
+			break
+		}
+	}
+	if v.profile == nil {
+		return v
+	}
+	// Responses the page was rendered from overlap, an entity is kept once
+	owner := memberIDRe.FindString(v.profile.EntityURN)
+	seen := map[string]bool{}
+	for _, e := range included {
+		if owner != "" && strings.Contains(e.EntityURN, "("+owner+",") && !seen[e.EntityURN] {
+			seen[e.EntityURN] = true
+			v.entities = append(v.entities, e)
+		}
+	}
+	sort.SliceStable(v.entities, func(i, j int) bool {
+		ri, iListed := rank[v.entities[i].EntityURN]
+		rj, jListed := rank[v.entities[j].EntityURN]
+		switch {
+		case iListed && jListed:
+			return ri < rj
+		case iListed != jListed:
+			return iListed
+		}
+		return v.entities[i].DateRange.start() > v.entities[j].DateRange.start()
+	})
+	return v
+}
+
+// walkCollections calls f with the element URNs of every collection in a voyager response,
+// and whether it pages through more entries than it holds.
+func walkCollections(data any, f func(elements []string, partial bool)) {
+	switch data := data.(type) {
+	case map[string]any:
+		if listed, ok := data["*elements"].([]any); ok {
+			var elements []string
+			for _, urn := range listed {
+				if urn, ok := urn.(string); ok {
+					elements = append(elements, urn)
+				}
+			}
+			paging, _ := data["paging"].(map[string]any)
+			total, _ := paging["total"].(float64)
+			f(elements, int(total) > len(listed))
+		}
+		for _, v := range data {
+			walkCollections(v, f)
+		}
+	case []any:
+		for _, v := range data {
+			walkCollections(v, f)
+		}
+	}
+}
+
+// urnType returns the type of the entity urn names, from types when the response included
+// it or else from the URN, e.g. Position for urn:li:fsd_profilePosition:(ACoAA...,1).
+func urnType(urn string, types map[string]string) string {
+	if t, ok := types[urn]; ok {
+		return t
+	}
+	rest, ok := strings.CutPrefix(urn, "urn:li:fsd_profile")
+	if !ok {
+		return ""
+	}
+	t, _, _ := strings.Cut(rest, ":")
+	return t
+}
+
+// of returns the profile's entities of the voyager type name.
+func (v voyager) of(name string) []voyagerEntity {
+	var found []voyagerEntity
+	for _, e := range v.entities {
+		if e.Type == voyagerType+name {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// name returns the profile owner's full name, "" when the page has no profile.
+func (v voyager) name() string {
+	if v.profile == nil {
+		return ""
+	}
+	return strings.TrimSpace(v.profile.FirstName + " " + v.profile.LastName)
+}
+
+// location returns the profile owner's location as the top card shows it.
+func (v voyager) location() string {
+	p := v.profile
+	switch {
+	case p == nil:
+		return ""
+	case p.GeoLocation != nil && p.GeoLocation.Geo != nil && p.GeoLocation.Geo.DefaultLocalizedName != "":
+		return p.GeoLocation.Geo.DefaultLocalizedName
+	case p.GeoLocation != nil && v.geos[p.GeoLocation.GeoURN] != "":
+		return v.geos[p.GeoLocation.GeoURN]
+	}
+	return p.LocationName
+}
+
+// about returns the profile owner's About, "" when the page has no profile.
+func (v voyager) about() string {
+	if v.profile == nil {
+		return ""
+	}
+	return strings.TrimSpace(v.profile.Summary)
+}
+
+func (v voyager) experience() []Experience {
+	var experience []Experience
+	for _, e := range v.of("Position") {
+		if e.Title != "" {
+			experience = append(experience, Experience{Title: e.Title, Company: e.CompanyName, Duration: e.DateRange.duration(true)})
+		}
+	}
+	return experience
+}
+
+func (v voyager) education() []Education {
+	var education []Education
+	for _, e := range v.of("Education") {
+		if e.SchoolName == "" {
+			continue
+		}
+		major := e.DegreeName
+		if e.FieldOfStudy != "" {
+			major = strings.TrimPrefix(major+", "+e.FieldOfStudy, ", ")
+		}
+		education = append(education, Education{Institute: e.SchoolName, Major: major, Duration: e.DateRange.duration(false)})
+	}
+	return education
+}
+
+func (v voyager) certifications() []Certification {
+	var certifications []Certification
+	for _, e := range v.of("Certification") {
+		if e.Name == "" {
+			continue
+		}
+		var issued string
+		if e.DateRange != nil {
+			issued = e.DateRange.Start.String()
+		}
+		certifications = append(certifications, Certification{Name: e.Name, Issuer: e.Authority, IssuedAt: issued})
+	}
+	return certifications
+}
+
+func (v voyager) languages() []Language {
+	var languages []Language
+	for _, e := range v.of("Language") {
+		if e.Name != "" {
+			languages = append(languages, Language{Name: e.Name, Proficiency: proficiency(e.Proficiency)})
+		}
+	}
+	return languages
+}
+
+// proficiency returns a voyager language proficiency, e.g. NATIVE_OR_BILINGUAL, as the page
+// shows it: "Native or bilingual proficiency".
+func proficiency(level string) string {
+	if level == "" {
+		return ""
+	}
+	words := strings.ToLower(strings.ReplaceAll(level, "_", " "))
+	return strings.ToUpper(words[:1]) + words[1:] + " proficiency"
+}
+
+// start returns when the range starts in months, -1 without a start.
+func (r *voyagerDateRange) start() int {
+	if r == nil || r.Start == nil || r.Start.Year == 0 {
+		return -1
+	}
+	return r.Start.Year*12 + r.Start.Month
+}
+
+// duration returns the range as the page shows it, e.g. "Mar 2021 - Present"; without an
+// end, ongoing ranges end at Present and others are their start alone.
+func (r *voyagerDateRange) duration(ongoing bool) string {
+	if r == nil || r.Start == nil {
+		return ""
+	}
+	switch {
+	case r.End != nil:
+		return r.Start.String() + " - " + r.End.String()
+	case ongoing:
+		return r.Start.String() + " - Present"
+	}
+	return r.Start.String()
+}
+
+// String returns the date as the page shows it, "Mar 2021", or "2021" without a month.
+func (d *voyagerDate) String() string {
+	switch {
+	case d == nil || d.Year == 0:
+		return ""
+	case d.Month < 1 || d.Month > 12:
+		return fmt.Sprint(d.Year)
+	}
+	return time.Month(d.Month).String()[:3] + " " + fmt.Sprint(d.Year)
+}
+
+// afterScrolling returns the entries read found on a details page expandList scrolled, or
+// the section's entries in the voyager data read again when those are more: the entries
+// loaded by scrolling came through the API, whether or not the page script found them.
+func afterScrolling[T any](ctx context.Context, s *Scraper, section func(voyager) []T, read []T, err error) ([]T, error) {
+	if scrolled := section(s.readVoyager(ctx)); len(scrolled) > len(read) {
+		return scrolled, nil
+	}
+	return read, err
+}
+
+/*
+	fromVoyager returns the section's entries found in the page's voyager data, or reads them
+	off the page with dom when there are none, or partial tells they're not all of them.
+
+The page's entries are taken when they are more than found, and found when dom
+fails or reads fewer, so a page script broken by a new class name doesn't fail
+a section whose entries are in the data.
+*/
+func fromVoyager[T any](partial bool, found []T, dom func() ([]T, error)) ([]T, error) {
+	if len(found) > 0 && !partial {
+		return found, nil
+	}
+	read, err := dom()
+	if len(found) > 0 && (err != nil || len(read) <= len(found)) {
+		return found, nil
+	}
+	return read, err
+}
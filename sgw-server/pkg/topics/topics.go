@@ -0,0 +1,260 @@
+/*
+	Package topics finds what a LinkedIn profile talks about in its posts, articles and comments.
+
+Extraction is lightweight and rule-based: hashtags are always topics, and words
+or two-word phrases become topics when they recur across two or more pieces of
+writing, leaving out common words. Hashtags keep their "#" and everything is
+lower case, so "#AI" in one post and "#ai" in another are the same topic.
+
+Basic usage:
+
+	profile.Topics = topics.Extract(profile)
+	if topics.Match(profile.Topics, []string{"#AI", "data engineering"}) {
+	    ...
+	}
+*/
+package topics
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// MaxTopics caps how many topics Extract returns.
+const MaxTopics = 10
+
+var (
+	// hashtagRe matches a hashtag, with the "hashtag" label LinkedIn renders in front of its links.
+	hashtagRe = regexp.MustCompile(`(?i)(?:hashtag\s*)?#([\p{L}\p{N}_]+)`)
+	// sentenceRe splits text where a phrase can't continue.
+	sentenceRe = regexp.MustCompile(`[.,;:!?()\[\]"“”…|/\n]+|\s[-–—]\s`)
+	// wordRe matches a word, allowing inner apostrophes, pluses and dashes as in "player's", "c++" or "e-commerce".
+	wordRe = regexp.MustCompile(`[\p{L}\p{N}][\p{L}\p{N}'’+\-]*`)
+)
+
+// stopwords are words too common to be a topic on their own or to start or end a phrase.
+var stopwords = toSet(`a about above after again against all also am an and any are as at be because been before being
+below between both but by can could did do does doing down during each even every few for from further get got great had
+has have having he her here hers him his how i if in into is it its just know last let like made make many me more most
+much my new next no not now of off on once one only or other our ours out over own people really same she should so some
+still such than thanks that the their them then there these they thing things think this those through time to today too
+under until up us very via want was way we week well were what when where which while who why will with would year years
+yet you your yours able across already always another anyone around back best better big day days done ever first going
+good happy help lot lots love need never part proud see share sharing since sure take team
+thank these three two used using work working world excited looking forward ones`)
+
+type candidate struct {
+	name    string
+	docs    map[int]bool // Pieces of writing mentioning it, by position
+	hashtag bool
+	words   int
+	first   int
+}
+
+/*
+	Extract returns the topics of a profile's posts, including the text read off their media,
+	articles and comments.
+
+Hashtags count from a single mention, and writing the word out counts as
+mentioning its hashtag; words and two-word phrases need to recur in
+at least two separate posts, articles or comments, and a word is left out when it
+only recurs as part of a phrase already kept. Topics are ordered by how many pieces
+mention them, hashtags first among equals.
+
+Parameters:
+  - profile: The scraped profile
+
+Returns:
+  - []string: Up to MaxTopics topics, nil when the profile has no writing to go on
+*/
+func Extract(profile scraper.Profile) []string {
+	var docs []string
+	for _, p := range profile.Posts {
+		docs = append(docs, p.Content+"\n"+p.MediaText)
+	}
+	for _, a := range profile.Articles {
+		docs = append(docs, a.Title+"\n"+a.Excerpt)
+	}
+	for _, c := range profile.Comments {
+		docs = append(docs, c.Content)
+	}
+
+	found := map[string]*candidate{}
+	order := 0
+	add := func(doc int, name string, hashtag bool, words int) {
+		c := found[name]
+		if c == nil {
+			c = &candidate{name: name, docs: map[int]bool{}, hashtag: hashtag, words: words, first: order}
+			found[name] = c
+			order++
+		}
+		c.docs[doc] = true
+	}
+
+	for i, doc := range docs {
+		for _, m := range hashtagRe.FindAllStringSubmatch(doc, -1) {
+			add(i, "#"+strings.ToLower(m[1]), true, 1)
+		}
+		text := hashtagRe.ReplaceAllString(doc, ".")
+		for _, sentence := range sentenceRe.Split(text, -1) {
+			var prev string
+			for _, word := range wordRe.FindAllString(sentence, -1) {
+				if word = strings.TrimSuffix(strings.TrimSuffix(word, "'s"), "’s"); !keyword(word) {
+					prev = ""
+					continue
+				}
+				word = strings.ToLower(word)
+				add(i, word, false, 1)
+				if prev != "" {
+					add(i, prev+" "+word, false, 2)
+				}
+				prev = word
+			}
+		}
+	}
+
+	// Writing "AI" mentions #ai as much as tagging it does
+	for _, c := range found {
+		if word := found[strings.TrimPrefix(c.name, "#")]; c.hashtag && word != nil {
+			for doc := range word.docs {
+				c.docs[doc] = true
+			}
+		}
+	}
+
+	var kept []*candidate
+	for _, c := range found {
+		if c.hashtag || len(c.docs) >= 2 {
+			kept = append(kept, c)
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool {
+		a, b := kept[i], kept[j]
+		if len(a.docs) != len(b.docs) {
+			return len(a.docs) > len(b.docs)
+		}
+		if a.hashtag != b.hashtag {
+			return a.hashtag
+		}
+		if a.words != b.words {
+			return a.words > b.words
+		}
+		return a.first < b.first
+	})
+
+	var topics []string
+	for _, c := range kept {
+		if len(topics) == MaxTopics {
+			break
+		}
+		if c.words == 1 && !c.hashtag && covered(c, topics, found) {
+			continue
+		}
+		topics = append(topics, c.name)
+	}
+	return topics
+}
+
+/*
+	Match reports whether any of the wanted topics is among a profile's topics.
+
+Matching is case-insensitive, and a wanted topic without "#" also matches the
+hashtag, so "AI" matches "#ai" while "#AI" only matches the hashtag. Phrases
+match topics containing them, so "data" matches "data engineering".
+
+Parameters:
+  - topics: The profile's topics, as Extract returned them
+  - wanted: The topics to look for
+
+Returns:
+  - bool: Whether one of wanted is found, false when wanted is empty
+*/
+func Match(topics, wanted []string) bool {
+	for _, w := range wanted {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if w == "" || w == "#" {
+			continue
+		}
+		for _, t := range topics {
+			if strings.HasPrefix(w, "#") {
+				if t == w {
+					return true
+				}
+				continue
+			}
+			if strings.Contains(strings.TrimPrefix(t, "#"), w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/*
+	Keywords returns the words of text that may be part of a topic, such as a campaign's goal.
+
+Words are lower case and listed once, in the order they first appear; hashtags
+count as their word and common words are left out, as in Extract.
+
+Parameters:
+  - text: Any text, e.g. "Hiring senior data engineers in Bengaluru"
+
+Returns:
+  - []string: The keywords, e.g. "hiring", "senior", "data", "engineers" and "bengaluru"
+*/
+func Keywords(text string) []string {
+	var words []string
+	seen := map[string]bool{}
+	for _, word := range wordRe.FindAllString(hashtagRe.ReplaceAllString(text, " $1 "), -1) {
+		if word = strings.TrimSuffix(strings.TrimSuffix(word, "'s"), "’s"); !keyword(word) {
+			continue
+		}
+		if word = strings.ToLower(word); !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// keyword reports whether word, as written, may be part of a topic. Two letter words only
+// are when written in capitals, like AI or UX.
+func keyword(word string) bool {
+	if len(word) < 2 || (len(word) == 2 && word != strings.ToUpper(word)) {
+		return false
+	}
+	if word = strings.ToLower(word); stopwords[word] || strings.ContainsAny(word, "'’") {
+		return false
+	}
+	// Numbers alone are dates, counts and prices, not topics
+	return strings.Trim(word, "0123456789+-") != ""
+}
+
+// covered reports whether a word only recurs as part of a phrase or hashtag already kept:
+// every piece mentioning it mentions one of those as well.
+func covered(word *candidate, topics []string, found map[string]*candidate) bool {
+	for _, t := range topics {
+		c := found[t]
+		if len(c.docs) < len(word.docs) {
+			continue
+		}
+		if c.hashtag && t == "#"+word.name {
+			return true
+		}
+		if c.words == 2 && strings.Contains(" "+t+" ", " "+word.name+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func toSet(words string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
@@ -0,0 +1,54 @@
+package topics
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestExtract(t *testing.T) {
+	profile := scraper.Profile{
+		Posts: []scraper.Post{
+			{Content: "Our churn models were late, not wrong. Fixing late-arriving events in the data pipeline hashtag#DataEngineering #AI"},
+			{Content: "Slides from my talk on the data pipeline behind our offers are up. #ai"},
+			{Content: "Hiring two engineers in Bengaluru!", MediaText: "Join the Data Pipeline team\nBengaluru"},
+		},
+		Comments: []scraper.Comment{{Content: "AI won't fix late events, but it helps to know which ones are late."}},
+	}
+	// AI written out counts toward #ai, Bengaluru twice in one post counts once
+	want := []string{"#ai", "data pipeline", "late", "events", "#dataengineering"}
+	if got := Extract(profile); !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract = %q, want %q", got, want)
+	}
+	if got := Extract(scraper.Profile{Name: "Quiet"}); got != nil {
+		t.Errorf("Extract without writing = %q, want nil", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	topics := []string{"#ai", "data engineering"}
+	for _, c := range []struct {
+		wanted []string
+		want   bool
+	}{
+		{[]string{"#AI"}, true},
+		{[]string{"AI"}, true},
+		{[]string{"data"}, true},
+		{[]string{"#data"}, false},
+		{[]string{"kafka", "Data Engineering"}, true},
+		{nil, false},
+	} {
+		if got := Match(topics, c.wanted); got != c.want {
+			t.Errorf("Match(%q) = %v, want %v", c.wanted, got, c.want)
+		}
+	}
+}
+
+func TestKeywords(t *testing.T) {
+	got := Keywords("Hiring senior Data engineers for our hashtag#DataPlatform team, data engineers first")
+	want := []string{"hiring", "senior", "data", "engineers", "dataplatform"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Keywords = %q, want %q", got, want)
+	}
+}
@@ -1,9 +1,14 @@
 package utils
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/i18n"
 	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
 	"golang.org/x/crypto/argon2"
 	"net/http"
@@ -35,11 +40,53 @@ func GenerateSalt() ([]byte, error) {
 	return salt, nil
 }
 
+func GenerateID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
 func CreateHash(password string, salt []byte) []byte {
 	hash := argon2.Key([]byte(password), salt, sTime, memory, 8, keyLen)
 	return hash
 }
 
+// Seal encrypts and authenticates plaintext with AES-GCM under a 32 byte key, such as one from CreateHash.
+func Seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Open decrypts what Seal sealed under key, failing when the key is wrong or sealed was changed.
+func Open(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("sealed data is too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
 func DecodeReqBody(r *http.Request, d any) error {
 	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
 		return err
@@ -52,7 +99,15 @@ func ValidEmail(email string) bool {
 	return err == nil
 }
 
+// WriteResponse answers msg as JSON. A string or i18n.Message msg is translated into the
+// language Localize picked for the response first.
 func WriteResponse(w http.ResponseWriter, msg any, httpStatus int) error {
+	switch m := msg.(type) {
+	case string:
+		msg = i18n.T(Language(w), m)
+	case i18n.Message:
+		msg = m.In(Language(w))
+	}
 	w.Header().Add("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(httpStatus)
 	return json.NewEncoder(w).Encode(msg)
@@ -62,8 +117,26 @@ func IsoDateToTime(date string) (time.Time, error) {
 	return time.Parse(time.RFC3339, date)
 }
 
+// Localize picks the language to answer r in from its Accept-Language header and announces
+// it in the response's Content-Language header, where Language reads it back.
+func Localize(w http.ResponseWriter, r *http.Request) string {
+	lang := i18n.Negotiate(r.Header.Get("Accept-Language"))
+	w.Header().Set("Content-Language", lang)
+	w.Header().Add("Vary", "Accept-Language")
+	return lang
+}
+
+// Language is the language Localize picked for the response, i18n.Default without one.
+func Language(w http.ResponseWriter) string {
+	if lang := w.Header().Get("Content-Language"); lang != "" {
+		return lang
+	}
+	return i18n.Default
+}
+
 func WithCORS(handler http.Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		Localize(w, r)
 		origin := r.Header.Get("Origin")
 
 		allowedOrigins := []string{
@@ -81,7 +154,8 @@ func WithCORS(handler http.Handler) http.HandlerFunc {
 		if allowedOrigin != "" {
 			w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Origin, Accept, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Origin, Accept, Authorization, X-Segwise-Schema-Version")
+			w.Header().Set("Access-Control-Expose-Headers", "X-Segwise-Schema-Version, Deprecation, Sunset")
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 		}
 
@@ -96,11 +170,25 @@ func WithCORS(handler http.Handler) http.HandlerFunc {
 
 func GetUsedParams(profile scraper.Profile) []string {
 	checks := map[string]func() bool{
-		"Posts":      func() bool { return len(profile.Posts) > 0 },
-		"Experience": func() bool { return len(profile.Experience) > 0 },
-		"Education":  func() bool { return len(profile.Education) > 0 },
-		"Location":   func() bool { return profile.Location != "" },
-		"Name":       func() bool { return profile.Name != "" },
+		"Posts":           func() bool { return len(profile.Posts) > 0 },
+		"Articles":        func() bool { return len(profile.Articles) > 0 },
+		"Company":         func() bool { return profile.Company != nil },
+		"Websites":        func() bool { return len(profile.ContactInfo.Websites) > 0 },
+		"Experience":      func() bool { return len(profile.Experience) > 0 },
+		"Education":       func() bool { return len(profile.Education) > 0 },
+		"Skills":          func() bool { return len(profile.Skills) > 0 },
+		"Certifications":  func() bool { return len(profile.Certifications) > 0 },
+		"Recommendations": func() bool { return len(profile.Recommendations) > 0 },
+		"Volunteering":    func() bool { return len(profile.Volunteering) > 0 },
+		"Publications":    func() bool { return len(profile.Publications) > 0 },
+		"Patents":         func() bool { return len(profile.Patents) > 0 },
+		"Languages":       func() bool { return len(profile.Languages) > 0 },
+		"Location":        func() bool { return profile.Location != "" },
+		"Name":            func() bool { return profile.Name != "" },
+		"Headline":        func() bool { return profile.Headline != "" },
+		"Pronouns":        func() bool { return profile.Pronouns != "" },
+		"OpenToWork":      func() bool { return profile.OpenToWork },
+		"JobPreferences":  func() bool { return profile.JobPreferences != nil },
 	}
 	paramsUsed := make([]string, 0, len(checks))
 
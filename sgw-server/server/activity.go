@@ -0,0 +1,225 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/config"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+const (
+	// activityBacklog is how many activities are kept in memory for the feed and for
+	// stream clients catching up after a reconnect.
+	activityBacklog = 500
+	// activityBuffer is how far a stream client may fall behind before it is
+	// disconnected; EventSource reconnects and catches up from the backlog.
+	activityBuffer = 64
+	// activityHeartbeat keeps idle streams from being closed by proxies.
+	activityHeartbeat = 30 * time.Second
+)
+
+type ActivityKind string
+
+const (
+	ActivityMessageGenerated    ActivityKind = "message.generated"
+	ActivityMessageFailed       ActivityKind = "message.failed"
+	ActivityProspectSkipped     ActivityKind = "prospect.skipped"
+	ActivityBatchStarted        ActivityKind = "batch.started"
+	ActivityBatchDone           ActivityKind = "batch.done"
+	ActivityBatchFailed         ActivityKind = "batch.failed"
+	ActivityBatchPaused         ActivityKind = "batch.paused"
+	ActivityRegenerationApplied ActivityKind = "regeneration.applied"
+	ActivityMessageApproved     ActivityKind = "message.approved"
+	ActivityMessageRejected     ActivityKind = "message.rejected"
+	ActivityLoginChallenge      ActivityKind = "login.challenge"
+	ActivityLoginFailed         ActivityKind = "login.failed"
+)
+
+// activityFeed keeps the latest activities and fans new ones out to stream clients.
+type activityFeed struct {
+	mu     sync.Mutex
+	seq    uint64
+	recent []Activity // Oldest first, at most activityBacklog
+	subs   map[chan Activity]struct{}
+}
+
+func newActivityFeed() *activityFeed {
+	return &activityFeed{subs: map[chan Activity]struct{}{}}
+}
+
+func (f *activityFeed) publish(a Activity) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.seq++
+	a.ID = f.seq
+	a.At = time.Now()
+	f.recent = append(f.recent, a)
+	if len(f.recent) > activityBacklog {
+		f.recent = append([]Activity(nil), f.recent[len(f.recent)-activityBacklog:]...)
+	}
+	for ch := range f.subs {
+		select {
+		case ch <- a:
+		default:
+			// A stalled client must not hold up the work being recorded
+			delete(f.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// subscribe returns the retained activities after the given ID and a channel receiving
+// new ones, closed when the client falls behind. cancel must be called when done.
+func (f *activityFeed) subscribe(after uint64) ([]Activity, chan Activity, func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if after > f.seq {
+		// IDs restart with the process, the client saw a previous run's feed
+		after = 0
+	}
+	backlog := f.since(after)
+	ch := make(chan Activity, activityBuffer)
+	f.subs[ch] = struct{}{}
+	return backlog, ch, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.subs[ch]; ok {
+			delete(f.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+func (f *activityFeed) snapshot() []Activity {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.since(0)
+}
+
+// since returns a copy of the retained activities with an ID above after. Callers must hold mu.
+func (f *activityFeed) since(after uint64) []Activity {
+	activities := make([]Activity, 0, len(f.recent))
+	for _, a := range f.recent {
+		if a.ID > after {
+			activities = append(activities, a)
+		}
+	}
+	return activities
+}
+
+// TeamActivity returns what a team's members recently did, newest first. Clients sending
+// Accept: text/event-stream get the same activities oldest first followed by live
+// updates; Last-Event-ID resumes a dropped stream.
+func (s *Server) TeamActivity(w http.ResponseWriter, r *http.Request) {
+	team := s.team(r.PathValue("team"))
+	if team == nil {
+		utils.WriteResponse(w, "team not found", http.StatusNotFound)
+		return
+	}
+	email := r.URL.Query().Get("email")
+	members := map[string]bool{}
+	for _, m := range team.Members {
+		members[key(m)] = true
+	}
+	if !utils.ValidEmail(email) || !members[key(email)] {
+		utils.WriteResponse(w, "only team members can see the team's activity", http.StatusForbidden)
+		return
+	}
+	visible := func(a Activity) bool { return members[key(a.Actor)] || members[key(a.Owner)] }
+
+	if !strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		all := s.activity.snapshot()
+		activities := make([]Activity, 0, len(all))
+		for i := len(all) - 1; i >= 0; i-- {
+			if visible(all[i]) {
+				activities = append(activities, all[i])
+			}
+		}
+		utils.WriteResponse(w, &ActivityRes{Activities: activities}, 200)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		utils.WriteResponse(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	after, _ := strconv.ParseUint(r.Header.Get("Last-Event-ID"), 10, 64)
+	backlog, updates, cancel := s.activity.subscribe(after)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	for _, a := range backlog {
+		if visible(a) {
+			writeActivity(w, a)
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(activityHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case a, ok := <-updates:
+			if !ok {
+				return
+			}
+			if visible(a) {
+				writeActivity(w, a)
+				flusher.Flush()
+			}
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func writeActivity(w http.ResponseWriter, a Activity) {
+	data, err := json.Marshal(a)
+	if err != nil {
+		log.Printf("error while encoding activity: %v\n", err)
+		return
+	}
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", a.ID, a.Kind, data)
+}
+
+// record adds an activity to the team feeds.
+func (s *Server) record(a Activity) {
+	s.activity.publish(a)
+}
+
+// recordLoginFailure records a failed LinkedIn login, as a challenge when LinkedIn asked
+// for a security verification, and cools the account down and alerts its owner when the
+// account was stopped.
+func (s *Server) recordLoginFailure(email string, j job, err error) {
+	kind := ActivityLoginFailed
+	if errors.Is(err, scraper.ErrVerificationRequired) {
+		kind = ActivityLoginChallenge
+	}
+	s.record(Activity{Kind: kind, Actor: email, Detail: err.Error()})
+	s.accountStopped(email, j, err)
+}
+
+func (s *Server) team(name string) *config.Team {
+	for i := range s.Teams {
+		if strings.EqualFold(s.Teams[i].Name, name) {
+			return &s.Teams[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/config"
+)
+
+func TestTeamActivityShowsOnlyMembers(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.Teams = []config.Team{{Name: "growth", Members: []string{"a@x.com", "b@x.com"}}, {Name: "sales", Members: []string{"c@x.com"}}}
+
+	home(t, ts, "a@x.com", "https://www.linkedin.com/in/one/")
+	home(t, ts, "c@x.com", "https://www.linkedin.com/in/two/")
+
+	var res ActivityRes
+	if code := call(t, ts, http.MethodGet, "/api/teams/growth/activity?email=B@x.com", nil, &res); code != http.StatusOK {
+		t.Fatalf("GET growth activity: status %d", code)
+	}
+	if len(res.Activities) != 1 {
+		t.Fatalf("got %d activities, want only a@x.com's: %+v", len(res.Activities), res.Activities)
+	}
+	if a := res.Activities[0]; a.Actor != "a@x.com" || a.Kind != ActivityMessageGenerated || a.ProspectID == "" {
+		t.Errorf("activity = %+v", a)
+	}
+
+	if code := call(t, ts, http.MethodGet, "/api/teams/growth/activity?email=c@x.com", nil, nil); code != http.StatusForbidden {
+		t.Errorf("non-member: status %d, want 403", code)
+	}
+	if code := call(t, ts, http.MethodGet, "/api/teams/nope/activity?email=c@x.com", nil, nil); code != http.StatusNotFound {
+		t.Errorf("unknown team: status %d, want 404", code)
+	}
+}
+
+func TestTeamActivityStream(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.Teams = []config.Team{{Name: "growth", Members: []string{"a@x.com"}}}
+	home(t, ts, "a@x.com", "https://www.linkedin.com/in/one/")
+
+	events := stream(t, ts.URL+"/api/teams/growth/activity?email=a@x.com", "")
+	first := next(t, events)
+	if first.kind != string(ActivityMessageGenerated) || first.id != "1" {
+		t.Fatalf("backlog event = %+v", first)
+	}
+
+	home(t, ts, "a@x.com", "https://www.linkedin.com/in/two/")
+	if live := next(t, events); live.id != "2" || !strings.Contains(live.data, "/in/two/") {
+		t.Fatalf("live event = %+v", live)
+	}
+
+	// A reconnect only gets what it missed
+	resumed := stream(t, ts.URL+"/api/teams/growth/activity?email=a@x.com", "1")
+	if e := next(t, resumed); e.id != "2" {
+		t.Fatalf("resumed stream started at %+v, want id 2", e)
+	}
+}
+
+func TestActivityFeedDisconnectsStalledSubscriber(t *testing.T) {
+	f := newActivityFeed()
+	_, ch, cancel := f.subscribe(0)
+	defer cancel()
+
+	for i := 0; i <= activityBuffer; i++ {
+		f.publish(Activity{Kind: ActivityBatchStarted})
+	}
+	received := 0
+	for range ch {
+		received++
+	}
+	if received != activityBuffer {
+		t.Errorf("received %d before the channel closed, want %d", received, activityBuffer)
+	}
+	if got := len(f.snapshot()); got != activityBuffer+1 {
+		t.Errorf("snapshot has %d activities, want %d", got, activityBuffer+1)
+	}
+}
+
+type sseEvent struct {
+	id, kind, data string
+}
+
+// stream opens an event stream that is closed when the test ends.
+func stream(t *testing.T, url, lastEventID string) <-chan sseEvent {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	events := make(chan sseEvent)
+	go func() {
+		defer res.Body.Close()
+		var e sseEvent
+		scanner := bufio.NewScanner(res.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(line, ":") {
+				continue // heartbeat
+			}
+			field, value, _ := strings.Cut(line, ": ")
+			switch field {
+			case "id":
+				e.id = value
+			case "event":
+				e.kind = value
+			case "data":
+				e.data = value
+			case "":
+				select {
+				case events <- e:
+				case <-ctx.Done():
+					return
+				}
+				e = sseEvent{}
+			}
+		}
+	}()
+	return events
+}
+
+func next(t *testing.T, events <-chan sseEvent) sseEvent {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event within 5s")
+		return sseEvent{}
+	}
+}
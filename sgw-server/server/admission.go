@@ -0,0 +1,180 @@
+package server
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/i18n"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+// loadWeight is how much the latest measurement moves the running averages the wait
+// estimates are made from.
+const loadWeight = 0.2
+
+// busyError is returned for work turned away because this instance has too much of it.
+type busyError struct {
+	reason i18n.Message
+	wait   time.Duration // Estimated time until the work would be admitted
+}
+
+func (e *busyError) Error() string {
+	return e.reason.String()
+}
+
+/*
+	load is the work this instance has taken on: the profiles of batches it runs that
+	are not scraped yet, and the browsers requests and batches hold.
+
+It keeps running averages of how long a profile and a browser take, to estimate
+how long turned away work would have to wait. It is safe for concurrent use.
+*/
+type load struct {
+	mu            sync.Mutex
+	batches       map[string]int // Profiles left, by running batch
+	browsers      int            // Scrapers handed out by acquireScraper and not released
+	profileTime   time.Duration  // Average time a batch spends on a profile, 0 until measured
+	browserTime   time.Duration  // Average time a scraper is held, 0 until measured
+	pressureLimit int            // Scrapers that may be in use while memory is low, 0 when it isn't
+}
+
+func newLoad() *load {
+	return &load{batches: map[string]int{}}
+}
+
+// queued returns the profiles left in running batches and how many batches there are.
+func (l *load) queued() (int, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	profiles := 0
+	for _, left := range l.batches {
+		profiles += left
+	}
+	return profiles, len(l.batches)
+}
+
+func (l *load) enqueue(batchID string, profiles int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.batches[batchID] += profiles
+}
+
+// scraped counts off one profile of a batch that took took.
+func (l *load) scraped(batchID string, took time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.batches[batchID] > 0 {
+		l.batches[batchID]--
+	}
+	l.profileTime = average(l.profileTime, took)
+}
+
+// finished drops a batch, with the profiles it never got to.
+func (l *load) finished(batchID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.batches, batchID)
+}
+
+// hold counts a scraper in use until the returned release, which wraps release, is called.
+func (l *load) hold(release func()) func() {
+	l.mu.Lock()
+	l.browsers++
+	l.mu.Unlock()
+	start := time.Now()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			l.browsers--
+			l.browserTime = average(l.browserTime, time.Since(start))
+			l.mu.Unlock()
+			release()
+		})
+	}
+}
+
+func average(avg, latest time.Duration) time.Duration {
+	if avg == 0 {
+		return latest
+	}
+	return avg + time.Duration(loadWeight*float64(latest-avg))
+}
+
+// admitBatch returns a *busyError when queueing profiles more would take the profiles
+// left in running batches past MaxQueueDepth, nil when there is room or no limit. A batch
+// larger than MaxQueueDepth is admitted when nothing else is queued, it would never fit.
+// It returns errDraining while the instance drains.
+func (s *Server) admitBatch(profiles int) error {
+	if s.life.isDraining() {
+		return errDraining
+	}
+	if s.MaxQueueDepth <= 0 {
+		return nil
+	}
+	queued, batches := s.load.queued()
+	excess := queued + profiles - s.MaxQueueDepth
+	if excess <= 0 || queued == 0 {
+		return nil
+	}
+	excess = min(excess, queued)
+	s.load.mu.Lock()
+	perProfile := s.load.profileTime
+	s.load.mu.Unlock()
+	if perProfile == 0 {
+		perProfile = s.ScrapeBudget
+	}
+	// Running batches each work through a profile at a time
+	wait := time.Duration(excess) * perProfile / time.Duration(max(batches, 1))
+	return &busyError{reason: i18n.Format("the server is busy with %d queued profiles", queued), wait: wait}
+}
+
+// admitScrape returns a *busyError when MaxBrowsers scrapers are in use, or the fewer
+// StartPressureWatch allows while memory is low, nil while one is free or there is no
+// limit. It returns errDraining while the instance drains.
+func (s *Server) admitScrape() error {
+	if s.life.isDraining() {
+		return errDraining
+	}
+	s.load.mu.Lock()
+	browsers, held, limit := s.load.browsers, s.load.browserTime, s.load.pressureLimit
+	s.load.mu.Unlock()
+	reason := i18n.Format("the server is low on memory with %d browsers in use", browsers)
+	if limit == 0 {
+		if s.MaxBrowsers <= 0 {
+			return nil
+		}
+		limit, reason = s.MaxBrowsers, i18n.Format("all %d browsers are in use", s.MaxBrowsers)
+	}
+	if browsers < limit {
+		return nil
+	}
+	if held == 0 {
+		held = s.ScrapeBudget
+	}
+	return &busyError{reason: reason, wait: held}
+}
+
+// writeBusy answers 429 with a Retry-After header and the estimated wait when err is a
+// *busyError, 503 when it is errDraining so the client retries on another instance, and
+// reports whether it was either.
+func writeBusy(w http.ResponseWriter, err error) bool {
+	if errors.Is(err, errDraining) {
+		w.Header().Set("Retry-After", "5")
+		utils.WriteResponse(w, "this instance is shutting down, please try again", http.StatusServiceUnavailable)
+		return true
+	}
+	var busy *busyError
+	if !errors.As(err, &busy) {
+		return false
+	}
+	seconds := max(int(math.Ceil(busy.wait.Seconds())), 1)
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	wait := time.Duration(seconds) * time.Second
+	utils.WriteResponse(w, &BusyRes{Error: i18n.Format("%s, please try again in about %s", busy.reason, wait).In(utils.Language(w)), RetryAfterSeconds: seconds}, http.StatusTooManyRequests)
+	return true
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestBusyBrowsers(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.MaxBrowsers = 1
+	_, release, err := s.acquireScraper("b@x.com", "secret", "", job{name: "home"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := &HomeReq{Email: "a@x.com", Password: "secret", LinkedinUrl: "https://www.linkedin.com/in/one/"}
+	res, busy := postBusy(t, ts, "/api/home", body)
+	if res.StatusCode != http.StatusTooManyRequests || busy.RetryAfterSeconds < 1 || busy.Error == "" {
+		t.Fatalf("home with every browser in use: status %d, %+v, want 429 with the wait", res.StatusCode, busy)
+	}
+	if got := res.Header.Get("Retry-After"); got != strconv.Itoa(busy.RetryAfterSeconds) {
+		t.Errorf("Retry-After = %q, want %d", got, busy.RetryAfterSeconds)
+	}
+	if res, _ := postBusy(t, ts, "/api/batches", &BatchReq{Email: "a@x.com", Password: "secret", LinkedinUrls: []string{"https://www.linkedin.com/in/one/"}}); res.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("batch with every browser in use: status %d, want 429", res.StatusCode)
+	}
+
+	release()
+	home(t, ts, "a@x.com", "https://www.linkedin.com/in/one/")
+}
+
+func TestBusyQueue(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.MaxQueueDepth = 3
+	s.load.enqueue("running", 2)
+	s.load.scraped("other", 10*time.Second)
+
+	urls := []string{"https://www.linkedin.com/in/one/", "https://www.linkedin.com/in/two/"}
+	res, busy := postBusy(t, ts, "/api/batches", &BatchReq{Email: "a@x.com", Password: "secret", LinkedinUrls: urls})
+	if res.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("batch past the queue depth: status %d, want 429", res.StatusCode)
+	}
+	// One profile too many, at the 10s a profile has been taking
+	if busy.RetryAfterSeconds != 10 {
+		t.Errorf("retryAfterSeconds = %d, want 10", busy.RetryAfterSeconds)
+	}
+
+	// A batch that fits is taken, and so is one past the limit once nothing else is queued
+	runTestBatch(t, ts, "a@x.com", urls[:1]...)
+	s.load.finished("running")
+	done := runTestBatch(t, ts, "a@x.com", "https://www.linkedin.com/in/one/", "https://www.linkedin.com/in/two/", "https://www.linkedin.com/in/three/", "https://www.linkedin.com/in/four/")
+	if len(done.Results) != 4 {
+		t.Errorf("%d results, want 4", len(done.Results))
+	}
+}
+
+// postBusy sends body as JSON and decodes a 429 into the BusyRes it carries.
+func postBusy(t *testing.T, ts *httptest.Server, path string, body any) (*http.Response, BusyRes) {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := ts.Client().Post(ts.URL+path, "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("POST %s: %v", path, err)
+	}
+	defer res.Body.Close()
+	var busy BusyRes
+	if res.StatusCode == http.StatusTooManyRequests {
+		if err := json.NewDecoder(res.Body).Decode(&busy); err != nil {
+			t.Fatalf("decode POST %s: %v", path, err)
+		}
+	}
+	return res, busy
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+// accountAlertInterval keeps an account stuck at a checkpoint from raising an alert on every attempt.
+const accountAlertInterval = 10 * time.Minute
+
+// job names the work an account was being used for, reported with account alerts.
+type job struct {
+	name    string // "home", "sender", "batch", "drift-check", "warm-up" or "keep-alive"
+	batchID string
+}
+
+// accountEvent returns the event kind for errors showing LinkedIn stopped an account.
+func accountEvent(err error) (models.EventKind, bool) {
+	switch {
+	case errors.Is(err, scraper.ErrAccountRestricted):
+		return models.EventAccountRestricted, true
+	case errors.Is(err, scraper.ErrVerificationRequired):
+		return models.EventAccountCheckpoint, true
+	case errors.Is(err, scraper.ErrBotDetected):
+		return models.EventAccountBotDetected, true
+	}
+	return "", false
+}
+
+// accountStopText describes an error accountEvent recognises.
+func accountStopText(err error) string {
+	if errors.Is(err, scraper.ErrAccountRestricted) {
+		return "restricted the account"
+	}
+	if errors.Is(err, scraper.ErrBotDetected) {
+		return "flagged the account as automated"
+	}
+	return "stopped the account at a security checkpoint"
+}
+
+// accountStopped cools the account down when LinkedIn flagged or restricted it and alerts its owner.
+func (s *Server) accountStopped(email string, j job, err error) {
+	s.coolDown(email, err)
+	s.alertAccount(email, j, err)
+}
+
+// alertAccount queues a high-priority notification when err shows LinkedIn stopped email's
+// account at a checkpoint or restricted it, so the owner can step in before the account
+// is lost. Repeats within accountAlertInterval are dropped.
+func (s *Server) alertAccount(email string, j job, err error) {
+	kind, ok := accountEvent(err)
+	if !ok || len(s.destinationsFor(kind, email)) == 0 {
+		return
+	}
+
+	s.alertMu.Lock()
+	last, seen := s.alerted[key(email)+" "+string(kind)]
+	if seen && time.Since(last) < accountAlertInterval {
+		s.alertMu.Unlock()
+		return
+	}
+	s.alerted[key(email)+" "+string(kind)] = time.Now()
+	s.alertMu.Unlock()
+
+	log.Printf("LinkedIn stopped %s during %s: %v\n", email, j.name, err)
+	s.queueAlert(models.Event{Kind: kind, Owner: email, Job: j.name, BatchID: j.batchID, Error: err.Error()})
+}
+
+// queueAlert queues e as a high-priority account event.
+func (s *Server) queueAlert(e models.Event) {
+	id, err := utils.GenerateID()
+	if err != nil {
+		log.Printf("error while queueing %s alert: %v\n", e.Kind, err)
+		return
+	}
+	e.ID, e.Priority, e.CreatedAt = id, models.PriorityHigh, time.Now()
+	s.queueEvent(e)
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// alertHook records the priority of every event POSTed to it.
+type alertHook struct {
+	mu         sync.Mutex
+	priorities []string
+}
+
+func newAlertHook(t *testing.T) (*alertHook, string) {
+	t.Helper()
+	h := &alertHook{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		h.priorities = append(h.priorities, r.Header.Get("X-Segwise-Priority"))
+		h.mu.Unlock()
+	}))
+	t.Cleanup(srv.Close)
+	return h, srv.URL
+}
+
+func (h *alertHook) received() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.priorities...)
+}
+
+func TestRestrictedLoginAlertsOnce(t *testing.T) {
+	s, ts := newTestServer(t)
+	hook, url := newAlertHook(t)
+	s.AccountAlertURL = url
+	// Without a cooldown every request tries to log in again
+	s.AccountCooldown = 0
+	s.NewScraper = func(email, password, url string) (Scraper, error) {
+		return nil, scraper.ErrAccountRestricted
+	}
+
+	for i := 0; i < 3; i++ {
+		body := &HomeReq{Email: "a@x.com", Password: "secret", LinkedinUrl: "https://www.linkedin.com/in/one/"}
+		if code := call(t, ts, http.MethodPost, "/api/home", body, nil); code != http.StatusForbidden {
+			t.Fatalf("home with a restricted account: status %d, want 403", code)
+		}
+	}
+	due, _ := s.Store.DueOutbox(time.Now())
+	if len(due) != 1 {
+		t.Fatalf("%d alerts queued, want 1 for repeated failures", len(due))
+	}
+	if e := due[0].Event; e.Kind != models.EventAccountRestricted || e.Job != "home" || e.Owner != "a@x.com" {
+		t.Errorf("alert = %+v", e)
+	}
+
+	s.relayOutbox()
+	if got := hook.received(); len(got) != 1 || got[0] != models.PriorityHigh {
+		t.Fatalf("alert webhook received %v, want one high-priority event", got)
+	}
+}
+
+// stoppingScraper fails with err after its first prospect; batches renew the lease once per prospect.
+type stoppingScraper struct {
+	*fake.Scraper
+	err      error
+	mu       sync.Mutex
+	renewals int
+}
+
+func (s *stoppingScraper) Renew(lease time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.renewals++
+}
+
+func (s *stoppingScraper) ScrapeWithBudget(ctx context.Context, budget time.Duration, sections ...scraper.Section) []scraper.SectionResult {
+	s.mu.Lock()
+	first := s.renewals <= 1
+	s.mu.Unlock()
+	if first {
+		return s.Scraper.ScrapeWithBudget(ctx, budget, sections...)
+	}
+	return []scraper.SectionResult{{Section: sections[0], Err: s.err}}
+}
+
+func TestCheckpointStopsBatch(t *testing.T) {
+	s, ts := newTestServer(t)
+	_, url := newAlertHook(t)
+	s.AccountAlertURL = url
+	s.NewScraper = func(email, password, url string) (Scraper, error) {
+		sc, err := fake.Backend{}.NewScraper(email, password, url)
+		return &stoppingScraper{Scraper: sc, err: scraper.ErrVerificationRequired}, err
+	}
+
+	res := runTestBatch(t, ts, "a@x.com", "https://www.linkedin.com/in/one/", "https://www.linkedin.com/in/two/", "https://www.linkedin.com/in/three/")
+	if res.Status != models.BatchFailed || len(res.Results) != 1 {
+		t.Fatalf("batch = %s with %d results, want failed after the first", res.Status, len(res.Results))
+	}
+
+	due, _ := s.Store.DueOutbox(time.Now())
+	var alerts int
+	for _, entry := range due {
+		if entry.Event.Kind == models.EventAccountCheckpoint {
+			alerts++
+			if entry.Event.Job != "batch" || entry.Event.BatchID != res.ID {
+				t.Errorf("alert = %+v, want it to name batch %s", entry.Event, res.ID)
+			}
+		}
+	}
+	if alerts != 1 {
+		t.Errorf("%d checkpoint alerts queued, want 1", alerts)
+	}
+}
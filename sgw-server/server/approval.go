@@ -0,0 +1,127 @@
+package server
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/payload"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+// ReviewerTokenHeader carries the reviewer's entry of ReviewerTokens, which proves the
+// reviewer email a request names is the reviewer's.
+const ReviewerTokenHeader = "X-Reviewer-Token"
+
+// ListApprovals returns the messages waiting for a reviewer, oldest first.
+func (s *Server) ListApprovals(w http.ResponseWriter, r *http.Request) {
+	version, ok := responseVersion(w, r)
+	if !ok {
+		return
+	}
+	if !s.isReviewer(r, r.URL.Query().Get("email")) {
+		utils.WriteResponse(w, "only reviewers can see the approval queue", http.StatusForbidden)
+		return
+	}
+	prospects, err := s.Store.ListAwaitingApproval()
+	if err != nil {
+		log.Printf("error while listing approvals: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	if owner := r.URL.Query().Get("owner"); owner != "" {
+		matching := make([]*models.Prospect, 0, len(prospects))
+		for _, p := range prospects {
+			if strings.EqualFold(p.Owner, owner) {
+				matching = append(matching, p)
+			}
+		}
+		prospects = matching
+	}
+	sort.Slice(prospects, func(i, j int) bool { return prospects[i].ScrapedAt.Before(prospects[j].ScrapedAt) })
+	writeVersioned(w, version, &ListProfilesRes{SchemaVersion: payload.Current, Profiles: prospects}, 200)
+}
+
+// ReviewProspect approves or rejects a pending message. Reviewers can't review their own
+// prospects, so every message is seen by a second person.
+func (s *Server) ReviewProspect(w http.ResponseWriter, r *http.Request) {
+	d := &ReviewReq{}
+	if err := utils.DecodeReqBody(r, d); err != nil {
+		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
+		return
+	}
+	if !s.isReviewer(r, d.Email) {
+		utils.WriteResponse(w, "only reviewers can approve messages", http.StatusForbidden)
+		return
+	}
+	prospect, err := s.Store.GetProspect(r.PathValue("id"))
+	if errors.Is(err, store.ErrNotFound) {
+		utils.WriteResponse(w, "prospect not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("error while getting prospect: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	if strings.EqualFold(prospect.Owner, d.Email) {
+		utils.WriteResponse(w, "reviewers can't approve their own messages", http.StatusForbidden)
+		return
+	}
+	if prospect.Approval == nil || prospect.Approval.Status != models.ApprovalPending {
+		utils.WriteResponse(w, "message is not pending approval", http.StatusConflict)
+		return
+	}
+
+	status := models.ApprovalRejected
+	if d.Approve {
+		status = models.ApprovalApproved
+	}
+	prospect.Approval = &models.Approval{
+		Status:      status,
+		Reviewer:    d.Email,
+		Note:        d.Note,
+		RequestedAt: prospect.Approval.RequestedAt,
+		ReviewedAt:  time.Now(),
+	}
+	if err := s.Store.SaveProspect(prospect); err != nil {
+		log.Printf("error while saving review: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	kind := ActivityMessageRejected
+	if d.Approve {
+		kind = ActivityMessageApproved
+	}
+	s.record(Activity{Kind: kind, Actor: d.Email, Owner: prospect.Owner, ProspectID: prospect.ID, LinkedinUrl: prospect.LinkedinUrl, Detail: d.Note})
+	utils.WriteResponse(w, prospect, 200)
+}
+
+// awaitApproval puts a prospect's new message in the approval queue when approval is
+// required. Drafts are never sent, so they are never queued.
+func (s *Server) awaitApproval(prospect *models.Prospect) {
+	if s.RequireApproval && !prospect.Draft && prospect.Message != "" {
+		prospect.Approval = &models.Approval{Status: models.ApprovalPending, RequestedAt: time.Now()}
+	}
+}
+
+// isReviewer reports whether email is one of Reviewers and r carries their token, so nobody
+// approves messages, or reviews their own, by typing a reviewer's email.
+func (s *Server) isReviewer(r *http.Request, email string) bool {
+	token, ok := s.ReviewerTokens[strings.ToLower(email)]
+	if !ok || subtle.ConstantTimeCompare([]byte(r.Header.Get(ReviewerTokenHeader)), []byte(token)) != 1 || !utils.ValidEmail(email) {
+		return false
+	}
+	for _, reviewer := range s.Reviewers {
+		if strings.EqualFold(reviewer, email) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+)
+
+func TestApprovalQueue(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.RequireApproval = true
+	s.Reviewers = []string{"lead@x.com", "a@x.com"}
+	s.ReviewerTokens = map[string]string{"lead@x.com": "lead-secret", "a@x.com": "a-secret"}
+	lead, a := http.Header{ReviewerTokenHeader: {"lead-secret"}}, http.Header{ReviewerTokenHeader: {"a-secret"}}
+	home(t, ts, "a@x.com", "https://www.linkedin.com/in/one/")
+
+	var queue ListProfilesRes
+	if code := callWith(t, ts, http.MethodGet, "/api/approvals?email=lead@x.com", lead, nil, &queue); code != http.StatusOK {
+		t.Fatalf("GET /api/approvals: status %d", code)
+	}
+	if len(queue.Profiles) != 1 || queue.Profiles[0].Approval.Status != models.ApprovalPending {
+		t.Fatalf("queue = %+v, want one pending message", queue.Profiles)
+	}
+	review := "/api/prospects/" + queue.Profiles[0].ID + "/review"
+
+	if code := callWith(t, ts, http.MethodGet, "/api/approvals?email=b@x.com", lead, nil, nil); code != http.StatusForbidden {
+		t.Errorf("queue as non-reviewer: status %d, want 403", code)
+	}
+	if code := callWith(t, ts, http.MethodPost, review, a, &ReviewReq{Email: "a@x.com", Approve: true}, nil); code != http.StatusForbidden {
+		t.Errorf("self-review: status %d, want 403", code)
+	}
+	// Claiming a reviewer's email proves nothing without their token, another reviewer's included
+	for _, header := range []http.Header{nil, {ReviewerTokenHeader: {"guessed"}}, a} {
+		if code := callWith(t, ts, http.MethodPost, review, header, &ReviewReq{Email: "lead@x.com", Approve: true}, nil); code != http.StatusForbidden {
+			t.Errorf("approval claiming lead@x.com with token %q: status %d, want 403", header.Get(ReviewerTokenHeader), code)
+		}
+	}
+	if code := call(t, ts, http.MethodGet, "/api/approvals?email=lead@x.com", nil, nil); code != http.StatusForbidden {
+		t.Errorf("queue without the token: status %d, want 403", code)
+	}
+
+	var reviewed models.Prospect
+	if code := callWith(t, ts, http.MethodPost, review, lead, &ReviewReq{Email: "lead@x.com", Approve: true, Note: "ok"}, &reviewed); code != http.StatusOK {
+		t.Fatalf("approve: status %d", code)
+	}
+	if a := reviewed.Approval; a.Status != models.ApprovalApproved || a.Reviewer != "lead@x.com" {
+		t.Errorf("approval = %+v", a)
+	}
+	if code := callWith(t, ts, http.MethodPost, review, lead, &ReviewReq{Email: "lead@x.com"}, nil); code != http.StatusConflict {
+		t.Errorf("second review: status %d, want 409", code)
+	}
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"log"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/backup"
+)
+
+const backupLease = "backup"
+
+// StartBackups writes an encrypted backup of the store to dir every interval and deletes
+// all but the keep latest. Instances sharing a store take turns, so one backup is written
+// per interval.
+func (s *Server) StartBackups(dir, passphrase string, interval time.Duration, keep int) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				// The lease is kept for the whole interval, so one instance backs up per interval
+				ok, err := s.Store.AcquireLease(backupLease, s.InstanceID, interval)
+				if err != nil {
+					log.Printf("error while leasing the backup: %v\n", err)
+				}
+				if ok {
+					s.backUp(dir, passphrase, keep)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+func (s *Server) backUp(dir, passphrase string, keep int) {
+	snapshot, err := s.Store.Snapshot()
+	if err != nil {
+		log.Printf("error while taking store snapshot: %v\n", err)
+		return
+	}
+	path, err := backup.Write(dir, snapshot, passphrase, time.Now())
+	if err != nil {
+		log.Printf("error while writing backup: %v\n", err)
+		return
+	}
+	log.Printf("Backed up store to %s\n", path)
+	if err := backup.Prune(dir, keep); err != nil {
+		log.Printf("error while deleting old backups: %v\n", err)
+	}
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/backup"
+)
+
+func TestScheduledBackupsKeepTheLatest(t *testing.T) {
+	s, _ := newTestServer(t)
+	if err := s.Store.SaveProspect(&models.Prospect{ID: "p1", Owner: "a@x.com", Message: "Hi Priya"}); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	stop := s.StartBackups(dir, "correct horse battery", 10*time.Millisecond, 2)
+
+	deadline := time.Now().Add(5 * time.Second)
+	var backups []string
+	for len(backups) < 2 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+		backups, _ = backup.List(dir)
+	}
+	stop()
+	if len(backups) < 2 {
+		t.Fatalf("backups after waiting: %v", backups)
+	}
+	time.Sleep(50 * time.Millisecond) // Lets a backup in progress finish pruning
+	if backups, _ = backup.List(dir); len(backups) > 2 {
+		t.Errorf("%d backups kept, want 2", len(backups))
+	}
+
+	sealed, err := os.ReadFile(backups[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := backup.Open(sealed, "correct horse battery")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Store.SaveProspect(&models.Prospect{ID: "p1", Owner: "a@x.com", Message: "changed"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Store.Restore(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if p, err := s.Store.GetProspect("p1"); err != nil || p.Message != "Hi Priya" {
+		t.Errorf("GetProspect after restoring the backup = %+v, %v", p, err)
+	}
+}
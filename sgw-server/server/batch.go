@@ -0,0 +1,401 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/i18n"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/icp"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/latency"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/payload"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/persona"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scoring"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+// CreateBatch stores a batch and scrapes its profiles in the background with a single login.
+func (s *Server) CreateBatch(w http.ResponseWriter, r *http.Request) {
+	d := &BatchReq{}
+	if err := utils.DecodeReqBody(r, d); err != nil {
+		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
+		return
+	}
+	if !utils.ValidEmail(d.Email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	if len(d.LinkedinUrls) == 0 {
+		utils.WriteResponse(w, "at least one linkedinUrl is required", http.StatusBadRequest)
+		return
+	}
+
+	id, err := utils.GenerateID()
+	if err != nil {
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	criteria := scoring.Criteria{
+		Weights:        s.settingsFor(d.Email).weights,
+		TargetTitles:   d.TargetTitles,
+		MinCompanySize: d.MinCompanySize,
+		MaxCompanySize: d.MaxCompanySize,
+	}
+	if d.Weights != nil {
+		criteria.Weights = *d.Weights
+	}
+	if d.ICPFilterID != "" {
+		if _, ok := s.ownedICPFilter(w, d.ICPFilterID, d.Email); !ok {
+			return
+		}
+	}
+	if d.JobUrl != "" && scraper.JobPage(d.JobUrl) == "" {
+		utils.WriteResponse(w, "jobUrl is not a LinkedIn job posting", http.StatusBadRequest)
+		return
+	}
+	if len(d.Goal) > maxGoalLength {
+		utils.WriteResponse(w, i18n.Format("goal is longer than %d characters", maxGoalLength), http.StatusBadRequest)
+		return
+	}
+	batch := &models.Batch{
+		ID:           id,
+		Owner:        d.Email,
+		LinkedinUrls: d.LinkedinUrls,
+		Criteria:     criteria,
+		ICPFilterID:  d.ICPFilterID,
+		JobURL:       d.JobUrl,
+		Goal:         strings.TrimSpace(d.Goal),
+		DryRun:       d.DryRun,
+		Status:       models.BatchPending,
+		CreatedAt:    time.Now(),
+	}
+	err = s.startBatch(batch, d.Password)
+	if writeBusy(w, err) {
+		return
+	}
+	if err != nil {
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	utils.WriteResponse(w, &CreateBatchRes{ID: batch.ID, Status: models.BatchPending}, http.StatusAccepted)
+}
+
+// startBatch saves a new pending batch and runs it in the background, which owns the
+// batch from then on and must not be touched by the caller. It fails with a *busyError,
+// saving nothing, when the instance has no room for the batch, and with errDraining
+// while it drains.
+func (s *Server) startBatch(batch *models.Batch, password string) error {
+	if err := s.admitScrape(); err != nil {
+		return err
+	}
+	if err := s.admitBatch(len(batch.LinkedinUrls)); err != nil {
+		return err
+	}
+	// Counted before it is saved, so a drain starting meanwhile waits for it
+	if !s.life.begin() {
+		return errDraining
+	}
+	// Leased before it is saved, so RecoverInterrupted on another instance never sees it unowned
+	release, _, err := s.holdLease(batchLease(batch.ID), s.InstanceID)
+	if err != nil {
+		s.life.end()
+		log.Printf("error while leasing batch: %v\n", err)
+		return err
+	}
+	if err := s.Store.SaveBatch(batch); err != nil {
+		release()
+		s.life.end()
+		log.Printf("error while saving batch: %v\n", err)
+		return err
+	}
+	s.load.enqueue(batch.ID, len(batch.LinkedinUrls))
+	go func() {
+		defer s.life.end()
+		s.runBatch(batch, password, release)
+	}()
+	return nil
+}
+
+// GetBatch returns a batch and the prospects scraped so far, best score first.
+func (s *Server) GetBatch(w http.ResponseWriter, r *http.Request) {
+	version, ok := responseVersion(w, r)
+	if !ok {
+		return
+	}
+	email := r.URL.Query().Get("email")
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	batch, err := s.Store.GetBatch(r.PathValue("id"))
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Printf("error while getting batch: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	if err != nil || !strings.EqualFold(batch.Owner, email) {
+		utils.WriteResponse(w, "batch not found", http.StatusNotFound)
+		return
+	}
+
+	prospects, err := s.Store.ListBatchProspects(batch.ID)
+	if err != nil {
+		log.Printf("error while listing batch prospects: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	sortByScore(prospects)
+	for _, p := range prospects {
+		s.checkResponse("prospect", p)
+	}
+	writeVersioned(w, version, &BatchRes{SchemaVersion: payload.Current, Batch: batch, Results: prospects, Cost: totalCost(prospects)}, 200)
+}
+
+// RecoverInterrupted fails the batches and regenerations left pending or running by an
+// instance that is gone, which shows as an expired lease. Their goroutines died with that
+// process and batch passwords are never stored, so they cannot be resumed; batch owners
+// are notified as for any other failed batch.
+func (s *Server) RecoverInterrupted() error {
+	batches, err := s.Store.ListUnfinishedBatches()
+	if err != nil {
+		return err
+	}
+	for _, batch := range batches {
+		orphaned, err := s.orphaned(batchLease(batch.ID))
+		if err != nil {
+			return err
+		}
+		if !orphaned {
+			continue
+		}
+		log.Printf("Failing batch %s, interrupted while %s\n", batch.ID, batch.Status)
+		batch.Status = models.BatchFailed
+		batch.Error = "interrupted by a server restart, create the batch again"
+		s.finishBatch(batch)
+	}
+
+	regens, err := s.Store.ListUnfinishedRegenerations()
+	if err != nil {
+		return err
+	}
+	for _, regen := range regens {
+		orphaned, err := s.orphaned(regenerationLease(regen.ID))
+		if err != nil {
+			return err
+		}
+		if !orphaned {
+			continue
+		}
+		log.Printf("Failing regeneration %s, interrupted while %s\n", regen.ID, regen.Status)
+		regen.Status = models.BatchFailed
+		regen.CompletedAt = time.Now()
+		if err := s.Store.SaveRegeneration(regen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orphaned reports whether nobody holds the named lease any more.
+func (s *Server) orphaned(lease string) (bool, error) {
+	_, err := s.Store.ActiveLease(lease)
+	if errors.Is(err, store.ErrNotFound) {
+		return true, nil
+	}
+	return false, err
+}
+
+// runBatch processes a batch and then releases its lease. When LinkedIn sends the account
+// scraping it into a cooldown, the rest of the batch moves to a teammate's warm session or
+// waits for the cooldown to end; when the account reaches a cap of scraper.Rate, the batch
+// waits until it may scrape again.
+func (s *Server) runBatch(batch *models.Batch, password string, releaseLease func()) {
+	defer releaseLease()
+	defer s.load.finished(batch.ID)
+	batch.Status = models.BatchRunning
+	s.saveBatch(batch)
+	s.record(Activity{Kind: ActivityBatchStarted, Actor: batch.Owner, BatchID: batch.ID, Detail: fmt.Sprintf("%d profiles", len(batch.LinkedinUrls))})
+
+	var filter *icp.Filter
+	if batch.ICPFilterID != "" {
+		saved, err := s.Store.GetICPFilter(batch.ICPFilterID)
+		if err != nil {
+			log.Printf("error while getting icp filter for batch %s: %v\n", batch.ID, err)
+			batch.Status = models.BatchFailed
+			batch.Error = err.Error()
+			s.finishBatch(batch)
+			return
+		}
+		filter = &saved.Filter
+	}
+
+	opts := s.settingsFor(batch.Owner)
+	var sender *models.Sender
+	var posting *scraper.Job
+	remaining := batch.LinkedinUrls
+	for first := true; len(remaining) > 0; first = false {
+		sc, release, err := s.batchScraper(batch, password, remaining[0])
+		if err != nil {
+			log.Printf("error while logging in for batch %s: %v\n", batch.ID, err)
+			batch.Status = models.BatchFailed
+			batch.Error = err.Error()
+			s.finishBatch(batch)
+			return
+		}
+		if first {
+			sender = s.senderFor(context.Background(), sc, batch.Owner)
+			// Read once, every message in the batch is about the same role
+			if posting, err = s.jobFor(context.Background(), sc, batch.JobURL, nil); err != nil {
+				release()
+				s.accountStopped(batch.Owner, job{name: "batch", batchID: batch.ID}, err)
+				batch.Status = models.BatchFailed
+				batch.Error = "could not read the job posting: " + err.Error()
+				s.finishBatch(batch)
+				return
+			}
+		}
+		done, err := s.scrapeBatch(batch, sc, remaining, filter, sender, posting, opts)
+		release()
+		remaining = remaining[done:]
+		if err == nil {
+			continue
+		}
+		var capped *scraper.CapError
+		if errors.As(err, &capped) {
+			s.pauseBatch(batch, capped.Until)
+			time.Sleep(time.Until(capped.Until))
+			continue
+		}
+
+		account := batch.Owner
+		if batch.Account != "" {
+			account = batch.Account
+		}
+		s.accountStopped(account, job{name: "batch", batchID: batch.ID}, err)
+		if _, cooling := s.cooldown(account); !cooling {
+			// Carrying on would only make LinkedIn's case against the account stronger
+			batch.Status = models.BatchFailed
+			batch.Error = "stopped, LinkedIn " + accountStopText(err) + ": " + err.Error()
+			s.finishBatch(batch)
+			return
+		}
+	}
+
+	batch.Status = models.BatchDone
+	s.finishBatch(batch)
+}
+
+// batchScraper logs in to scrape the rest of batch from linkedinUrl on. While the owner's
+// account cools off it uses a teammate's warm session, and with none free the batch is
+// paused until the cooldown ends.
+func (s *Server) batchScraper(batch *models.Batch, password, linkedinUrl string) (Scraper, func(), error) {
+	j := job{name: "batch", batchID: batch.ID}
+	for {
+		sc, release, err := s.acquireScraper(batch.Owner, password, linkedinUrl, j)
+		c, cooling := s.cooldown(batch.Owner)
+		if err == nil || !cooling {
+			if err == nil && (batch.Account != "" || batch.Status == models.BatchPaused) {
+				batch.Account, batch.Status, batch.ResumeAt = "", models.BatchRunning, time.Time{}
+				s.saveBatch(batch)
+			}
+			return sc, release, err
+		}
+
+		if email, standInPassword, ok := s.standIn(batch.Owner); ok {
+			sc, release, err := s.acquireScraper(email, standInPassword, linkedinUrl, j)
+			if err == nil {
+				log.Printf("Batch %s continues on %s while %s cools off\n", batch.ID, email, batch.Owner)
+				batch.Account, batch.Status, batch.ResumeAt = email, models.BatchRunning, time.Time{}
+				s.saveBatch(batch)
+				return sc, release, nil
+			}
+			log.Printf("error while moving batch %s to %s: %v\n", batch.ID, email, err)
+		}
+
+		s.pauseBatch(batch, c.Until)
+		s.waitCooldown(batch.Owner)
+	}
+}
+
+// pauseBatch records that batch waits until until, for a cooldown to end or a cap of
+// scraper.Rate to let its account scrape again. The next scraper it gets resumes it.
+func (s *Server) pauseBatch(batch *models.Batch, until time.Time) {
+	log.Printf("Pausing batch %s until %s\n", batch.ID, until.Format(time.RFC3339))
+	batch.Account, batch.Status, batch.ResumeAt = "", models.BatchPaused, until
+	s.saveBatch(batch)
+	s.record(Activity{Kind: ActivityBatchPaused, Actor: batch.Owner, BatchID: batch.ID, Detail: "until " + until.Format(time.RFC3339)})
+}
+
+// scrapeBatch scrapes and generates for urls in order with sc, writing about posting when
+// it is set. It returns how many were processed and, when LinkedIn stopped the account
+// partway, the error it stopped with; the url it stopped at is left for the next account.
+func (s *Server) scrapeBatch(batch *models.Batch, sc Scraper, urls []string, filter *icp.Filter, sender *models.Sender, posting *scraper.Job, opts settings) (int, error) {
+	for i, url := range urls {
+		// Each profile gets a fresh lease so long batches don't outlive the first one
+		sc.Renew(scraper.DefaultLease)
+		// Every profile waited in the batch since it was submitted
+		start := s.observe("batch", latency.Queue, batch.CreatedAt)
+		// The login and the posting are shared by the batch's prospects, neither is counted
+		cost := &jobCost{}
+		pc, err := s.scrapeProspect(context.Background(), sc, url, sender != nil || (filter != nil && filter.NeedsDetails()), opts.degradation, cost)
+		if err != nil {
+			return i, err
+		}
+		s.load.scraped(batch.ID, time.Since(start))
+		pc.Job, pc.Goal = posting.Clone(), batch.Goal
+		profile := pc.Profile
+		prospect := &models.Prospect{
+			Owner:       batch.Owner,
+			BatchID:     batch.ID,
+			LinkedinUrl: url,
+			Profile:     profile,
+			Job:         pc.Job,
+			Goal:        batch.Goal,
+			Score:       scoring.Score(profile, batch.Criteria),
+			Draft:       batch.DryRun,
+		}
+
+		if filter != nil {
+			// Rule-based persona only, so skipping never costs an LLM call
+			prospect.Persona = persona.Classify(profile)
+			if ok, reason := filter.Match(profile, prospect.Persona); !ok {
+				prospect.SkipReason = reason
+				prospect.Sources = pc.Sources
+				prospect.Cost = cost.report(s.LLMPrices)
+				s.saveProspect(prospect)
+				s.observe("batch", latency.Scrape, start)
+				continue
+			}
+		}
+
+		s.enrich(&pc, cost)
+		generating := s.observe("batch", latency.Scrape, start)
+		prospect.Enrichment, prospect.Sources = pc.Enrichment, pc.Sources
+		input, msg, status, err := s.generate(pc, sender, opts, cost)
+		prospect.Persona = *input.Persona
+		prospect.Message, prospect.Status = msg, status
+		if err != nil {
+			log.Printf("error while generating message for %s: %v\n", url, err)
+			prospect.Error = err.Error()
+		} else {
+			s.observe("batch", latency.Generate, generating)
+			s.observe("batch", latency.Total, batch.CreatedAt)
+		}
+		prospect.Cost = cost.report(s.LLMPrices)
+		s.saveProspect(prospect)
+	}
+	return len(urls), nil
+}
+
+func (s *Server) saveBatch(batch *models.Batch) {
+	if err := s.Store.SaveBatch(batch); err != nil {
+		log.Printf("error while saving batch %s: %v\n", batch.ID, err)
+	}
+}
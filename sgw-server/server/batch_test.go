@@ -0,0 +1,88 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+)
+
+// runTestBatch creates a batch as email and waits for it to finish.
+func runTestBatch(t *testing.T, ts *httptest.Server, email string, urls ...string) *BatchRes {
+	t.Helper()
+	var created CreateBatchRes
+	if code := call(t, ts, http.MethodPost, "/api/batches", &BatchReq{Email: email, Password: "secret", LinkedinUrls: urls}, &created); code != http.StatusAccepted {
+		t.Fatalf("POST /api/batches: status %d", code)
+	}
+	return waitTestBatch(t, ts, email, created.ID)
+}
+
+// waitTestBatch waits for the batch id of email to finish.
+func waitTestBatch(t *testing.T, ts *httptest.Server, email, id string) *BatchRes {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		var res BatchRes
+		if code := call(t, ts, http.MethodGet, "/api/batches/"+id+"?email="+email, nil, &res); code != http.StatusOK {
+			t.Fatalf("GET batch: status %d", code)
+		}
+		if res.Status == models.BatchDone || res.Status == models.BatchFailed {
+			return &res
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("batch did not finish within 5s")
+	return nil
+}
+
+func TestGetBatchOnlyForOwner(t *testing.T) {
+	_, ts := newTestServer(t)
+	res := runTestBatch(t, ts, "a@x.com", "https://www.linkedin.com/in/one/", "https://www.linkedin.com/in/two/")
+	if res.Status != models.BatchDone || len(res.Results) != 2 {
+		t.Fatalf("batch = %s with %d results, want done with 2", res.Status, len(res.Results))
+	}
+
+	if code := call(t, ts, http.MethodGet, "/api/batches/"+res.ID+"?email=A@x.com", nil, nil); code != http.StatusOK {
+		t.Errorf("owner with different case: status %d, want 200", code)
+	}
+	if code := call(t, ts, http.MethodGet, "/api/batches/"+res.ID+"?email=b@x.com", nil, nil); code != http.StatusNotFound {
+		t.Errorf("other user: status %d, want 404", code)
+	}
+	if code := call(t, ts, http.MethodGet, "/api/batches/"+res.ID, nil, nil); code != http.StatusBadRequest {
+		t.Errorf("no email: status %d, want 400", code)
+	}
+}
+
+func TestDryRunBatch(t *testing.T) {
+	s, ts := newTestServer(t)
+	_, url := newWebhook(t)
+	s.WebhookURL = url
+	s.RequireApproval, s.Reviewers = true, []string{"lead@x.com"}
+
+	var created CreateBatchRes
+	req := &BatchReq{Email: "a@x.com", Password: "secret", LinkedinUrls: []string{"https://www.linkedin.com/in/one/"}, DryRun: true}
+	if code := call(t, ts, http.MethodPost, "/api/batches", req, &created); code != http.StatusAccepted {
+		t.Fatalf("POST /api/batches: status %d", code)
+	}
+	res := waitTestBatch(t, ts, "a@x.com", created.ID)
+	if !res.DryRun || len(res.Results) != 1 {
+		t.Fatalf("batch = %+v", res.Batch)
+	}
+	draft := res.Results[0]
+	if !draft.Draft || draft.Message == "" || draft.Approval != nil {
+		t.Errorf("dry run prospect = %+v, want a draft with a message and no approval", draft)
+	}
+	if due, _ := s.Store.DueOutbox(time.Now().Add(time.Hour)); len(due) != 0 {
+		t.Errorf("dry run queued %d notifications", len(due))
+	}
+
+	var profiles ListProfilesRes
+	if code := call(t, ts, http.MethodGet, "/api/profiles?email=a@x.com", nil, &profiles); code != http.StatusOK || len(profiles.Profiles) != 0 {
+		t.Errorf("profiles = %d (status %d), want drafts left out", len(profiles.Profiles), code)
+	}
+	if code := call(t, ts, http.MethodPost, "/api/prospects/"+draft.ID+"/share", &ShareLinkReq{Email: "a@x.com"}, nil); code != http.StatusConflict {
+		t.Errorf("sharing a draft: status %d, want 409", code)
+	}
+}
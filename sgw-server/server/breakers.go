@@ -0,0 +1,126 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/breaker"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/i18n"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/openai"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/persona"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+// openAIBreaker names the breaker of one kind of OpenAI call, so a failing endpoint
+// doesn't stop the calls that still work.
+func openAIBreaker(call string) string {
+	return "openai/" + call
+}
+
+// loginBreaker names the breaker of logging in to one LinkedIn account. Browsers fail to
+// log in for a single account (its proxy, a checkpoint loop) as often as for all of them,
+// so every account trips on its own.
+func loginBreaker(email string) string {
+	return "linkedin/" + key(email)
+}
+
+// breakerLLM is an LLM behind the OpenAI breakers.
+type breakerLLM struct {
+	llm      LLM
+	breakers *breaker.Set
+}
+
+func (b breakerLLM) GetMessage(prospect openai.Prospect) (string, openai.Usage, error) {
+	var msg string
+	var usage openai.Usage
+	err := b.breakers.Get(openAIBreaker("message")).Do(func() error {
+		var err error
+		msg, usage, err = b.llm.GetMessage(prospect)
+		return err
+	})
+	return msg, usage, err
+}
+
+func (b breakerLLM) ClassifyPersona(profile scraper.Profile) (persona.Persona, openai.Usage, error) {
+	var p persona.Persona
+	var usage openai.Usage
+	err := b.breakers.Get(openAIBreaker("persona")).Do(func() error {
+		var err error
+		p, usage, err = b.llm.ClassifyPersona(profile)
+		return err
+	})
+	return p, usage, err
+}
+
+func (b breakerLLM) SummarizeWebsite(site, text string) (string, openai.Usage, error) {
+	var summary string
+	var usage openai.Usage
+	err := b.breakers.Get(openAIBreaker("website")).Do(func() error {
+		var err error
+		summary, usage, err = b.llm.SummarizeWebsite(site, text)
+		return err
+	})
+	return summary, usage, err
+}
+
+func (b breakerLLM) Embed(texts []string) ([][]float64, openai.Usage, error) {
+	var vectors [][]float64
+	var usage openai.Usage
+	err := b.breakers.Get(openAIBreaker("embeddings")).Do(func() error {
+		var err error
+		vectors, usage, err = b.llm.Embed(texts)
+		return err
+	})
+	return vectors, usage, err
+}
+
+// ProtectedLLM returns LLM behind the OpenAI breakers: while a kind of call keeps failing
+// it fails fast with a *breaker.OpenError instead of waiting on OpenAI.
+func (s *Server) ProtectedLLM() LLM {
+	return breakerLLM{llm: s.LLM, breakers: s.Breakers}
+}
+
+// loginFailure is err when it counts against the account's login breaker. Rejected
+// credentials, checkpoints and flagged accounts mean the browser reached LinkedIn, the
+// latter two are handled by verification and cooldowns.
+func loginFailure(err error) error {
+	if errors.Is(err, scraper.ErrNotAuthenticated) || errors.Is(err, scraper.ErrVerificationRequired) || coolsDown(err) {
+		return nil
+	}
+	return err
+}
+
+// writeOpenBreaker answers 503 with a Retry-After header when err is a call a breaker
+// refused, and reports whether it was. what names the failing dependency for users.
+func writeOpenBreaker(w http.ResponseWriter, err error, what i18n.Message) bool {
+	var open *breaker.OpenError
+	if !errors.As(err, &open) {
+		return false
+	}
+	setRetryAfter(w, open.Until)
+	utils.WriteResponse(w, i18n.Format("%s keeps failing, please try again after %s", what, open.Until.Format(time.RFC3339)), http.StatusServiceUnavailable)
+	return true
+}
+
+// BreakerStats reports the state of the circuit breakers used so far, by name: the OpenAI
+// ones and the login breaker of the user's own account, every account's for support staff
+// sending the support token.
+func (s *Server) BreakerStats(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	stats := s.Breakers.Stats()
+	if !s.isSupportStaff(r, email) {
+		for name := range stats {
+			if strings.HasPrefix(name, "linkedin/") && name != loginBreaker(email) {
+				delete(stats, name)
+			}
+		}
+	}
+	utils.WriteResponse(w, &BreakersRes{Breakers: stats}, 200)
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/breaker"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/openai"
+)
+
+// failingLLM fails every message until it is told to work again.
+type failingLLM struct {
+	fake.LLM
+	failing atomic.Bool
+	calls   atomic.Int32
+}
+
+func (l *failingLLM) GetMessage(prospect openai.Prospect) (string, openai.Usage, error) {
+	l.calls.Add(1)
+	if l.failing.Load() {
+		return "", openai.Usage{}, errors.New("openai: 503 service unavailable")
+	}
+	return l.LLM.GetMessage(prospect)
+}
+
+func TestOpenAIBreaker(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.Breakers = breaker.NewSet(2, time.Hour)
+	llm := &failingLLM{}
+	llm.failing.Store(true)
+	s.LLM = llm
+
+	body := &HomeReq{Email: "a@x.com", Password: "secret", LinkedinUrl: "https://www.linkedin.com/in/one/"}
+	for i := 0; i < 2; i++ {
+		if code := call(t, ts, http.MethodPost, "/api/home", body, nil); code != http.StatusInternalServerError {
+			t.Fatalf("home while OpenAI fails: status %d, want 500", code)
+		}
+	}
+	res := post(t, ts, "/api/home", body)
+	if res.StatusCode != http.StatusServiceUnavailable || res.Header.Get("Retry-After") == "" {
+		t.Fatalf("home with the breaker open: status %d, Retry-After %q, want 503 with one", res.StatusCode, res.Header.Get("Retry-After"))
+	}
+	if got := llm.calls.Load(); got != 2 {
+		t.Errorf("OpenAI called %d times, want the open breaker to stop the third", got)
+	}
+
+	var stats BreakersRes
+	if code := call(t, ts, http.MethodGet, "/api/stats/breakers?email=a@x.com", nil, &stats); code != http.StatusOK {
+		t.Fatalf("GET /api/stats/breakers: status %d", code)
+	}
+	if got := stats.Breakers["openai/message"]; got.State != breaker.Open || got.Refused != 1 {
+		t.Errorf("openai/message = %+v, want open with one refused call", got)
+	}
+}
+
+func TestLoginBreakerIsPerAccount(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.Breakers = breaker.NewSet(2, time.Hour)
+	var logins atomic.Int32
+	s.NewScraper = func(email, password, url string) (Scraper, error) {
+		logins.Add(1)
+		if email == "a@x.com" {
+			return nil, errors.New("chrome failed to start: exec: not found")
+		}
+		return fake.Backend{}.NewScraper(email, password, url)
+	}
+
+	body := &HomeReq{Email: "a@x.com", Password: "secret", LinkedinUrl: "https://www.linkedin.com/in/one/"}
+	for i := 0; i < 2; i++ {
+		if code := call(t, ts, http.MethodPost, "/api/home", body, nil); code != http.StatusInternalServerError {
+			t.Fatalf("home with a failing login: status %d, want 500", code)
+		}
+	}
+	if code := call(t, ts, http.MethodPost, "/api/home", body, nil); code != http.StatusServiceUnavailable {
+		t.Fatalf("home with the account's breaker open: status %d, want 503", code)
+	}
+	if got := logins.Load(); got != 2 {
+		t.Errorf("%d logins, want the open breaker to stop the third", got)
+	}
+
+	// Other accounts log in as before
+	home(t, ts, "b@x.com", "https://www.linkedin.com/in/one/")
+
+	var stats BreakersRes
+	call(t, ts, http.MethodGet, "/api/stats/breakers?email=b@x.com", nil, &stats)
+	if _, ok := stats.Breakers["linkedin/a@x.com"]; ok {
+		t.Error("another user sees a@x.com's breaker")
+	}
+	if got := stats.Breakers["linkedin/b@x.com"]; got.State != breaker.Closed {
+		t.Errorf("linkedin/b@x.com = %+v, want closed", got)
+	}
+}
+
+// post sends body as JSON and returns the response, for tests that check its headers.
+func post(t *testing.T, ts *httptest.Server, path string, body any) *http.Response {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := ts.Client().Post(ts.URL+path, "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("POST %s: %v", path, err)
+	}
+	res.Body.Close()
+	return res
+}
@@ -0,0 +1,260 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/i18n"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+// bundleVersion is the ConfigBundle layout version; imports of newer bundles are refused
+// rather than silently dropping what this build doesn't know about. Version 2 added Settings
+// and version 3 Campaigns.
+const bundleVersion = 3
+
+// ExportBundle returns a user's saved configuration as a bundle another deployment can import.
+func (s *Server) ExportBundle(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	filters, err := s.Store.ListICPFilters(email)
+	if err != nil {
+		log.Printf("error while listing icp filters: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+
+	settings, err := s.Store.GetSettings(email)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Printf("error while getting settings: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	campaigns, err := s.Store.ListCampaigns(email)
+	if err != nil {
+		log.Printf("error while listing campaigns: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+
+	bundle := &ConfigBundle{Version: bundleVersion, ExportedAt: time.Now(), ICPFilters: []BundleICPFilter{}, Campaigns: []BundleCampaign{}}
+	names := map[string]string{}
+	for _, f := range filters {
+		// IDs and owners are deployment specific, the importer assigns its own
+		bundle.ICPFilters = append(bundle.ICPFilters, BundleICPFilter{Name: f.Name, Filter: f.Filter})
+		names[f.ID] = f.Name
+	}
+	sort.Slice(campaigns, func(i, j int) bool { return campaigns[i].CreatedAt.Before(campaigns[j].CreatedAt) })
+	for _, c := range campaigns {
+		// Filters are referenced by name, and what sourcing found stays with the deployment
+		bundle.Campaigns = append(bundle.Campaigns, BundleCampaign{
+			Name:      c.Name,
+			Query:     c.Query,
+			Filters:   c.Filters,
+			Criteria:  c.Criteria,
+			JobUrl:    c.JobURL,
+			Goal:      c.Goal,
+			ICPFilter: names[c.ICPFilterID],
+		})
+	}
+	if settings != nil {
+		bundle.Settings = &BundleSettings{
+			ScoringWeights:   settings.ScoringWeights,
+			Fallbacks:        settings.Fallbacks,
+			NativeLanguage:   settings.NativeLanguage,
+			PersonaLLMAssist: settings.PersonaLLMAssist,
+		}
+	}
+	utils.WriteResponse(w, bundle, 200)
+}
+
+// ImportBundle saves a bundle's configuration for a user. Filters and campaigns named like
+// an existing one, and settings when the user saved their own, are skipped unless Replace is
+// set, so importing the same bundle twice is harmless. A replaced campaign keeps what its
+// sourcing runs found.
+func (s *Server) ImportBundle(w http.ResponseWriter, r *http.Request) {
+	d := &ImportBundleReq{}
+	if err := utils.DecodeReqBody(r, d); err != nil {
+		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
+		return
+	}
+	if !utils.ValidEmail(d.Email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	if d.Bundle.Version < 1 || d.Bundle.Version > bundleVersion {
+		utils.WriteResponse(w, i18n.Format("unsupported bundle version %d, this server reads version %d", d.Bundle.Version, bundleVersion), http.StatusBadRequest)
+		return
+	}
+	for i, f := range d.Bundle.ICPFilters {
+		if strings.TrimSpace(f.Name) == "" {
+			utils.WriteResponse(w, i18n.Format("icp filter %d has no name", i+1), http.StatusBadRequest)
+			return
+		}
+	}
+	if b := d.Bundle.Settings; b != nil {
+		if err := validateSettings(b.ScoringWeights, b.Fallbacks, ""); err != nil {
+			utils.WriteResponse(w, i18n.Format("bundle settings: %s", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	existing, err := s.Store.ListICPFilters(d.Email)
+	if err != nil {
+		log.Printf("error while listing icp filters: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	byName := map[string]*models.ICPFilter{}
+	for _, f := range existing {
+		byName[strings.ToLower(f.Name)] = f
+	}
+	// Campaigns are checked before anything is saved, so a bundle imports whole or not at all
+	for i, c := range d.Bundle.Campaigns {
+		if msg, ok := validateBundleCampaign(c, d.Bundle.ICPFilters, byName); !ok {
+			utils.WriteResponse(w, i18n.Format("campaign %d: %s", i+1, msg), http.StatusBadRequest)
+			return
+		}
+	}
+
+	res := &ImportBundleRes{Skipped: []string{}, SkippedCampaigns: []string{}}
+	for _, f := range d.Bundle.ICPFilters {
+		filter, ok := byName[strings.ToLower(f.Name)]
+		switch {
+		case ok && !d.Replace:
+			res.Skipped = append(res.Skipped, f.Name)
+			continue
+		case ok:
+			filter.Filter = f.Filter
+		default:
+			id, err := utils.GenerateID()
+			if err != nil {
+				utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+				return
+			}
+			filter = &models.ICPFilter{ID: id, Owner: d.Email, Name: f.Name, Filter: f.Filter, CreatedAt: time.Now()}
+			byName[strings.ToLower(f.Name)] = filter
+		}
+		if err := s.Store.SaveICPFilter(filter); err != nil {
+			log.Printf("error while saving icp filter: %v\n", err)
+			utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+			return
+		}
+		res.Imported++
+	}
+
+	if b := d.Bundle.Settings; b != nil {
+		existing, err := s.Store.GetSettings(d.Email)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			log.Printf("error while getting settings: %v\n", err)
+			utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+			return
+		}
+		if err != nil || d.Replace {
+			settings := &models.Settings{
+				Owner:            d.Email,
+				ScoringWeights:   b.ScoringWeights,
+				Fallbacks:        b.Fallbacks,
+				NativeLanguage:   b.NativeLanguage,
+				PersonaLLMAssist: b.PersonaLLMAssist,
+				UpdatedAt:        time.Now(),
+			}
+			// Routes name this deployment's channels, bundles don't carry them
+			if existing != nil {
+				settings.NotifyRoutes = existing.NotifyRoutes
+			}
+			if err := s.Store.SaveSettings(settings); err != nil {
+				log.Printf("error while saving settings: %v\n", err)
+				utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+				return
+			}
+			res.SettingsImported = true
+		}
+	}
+
+	if len(d.Bundle.Campaigns) > 0 && !s.importCampaigns(w, d, byName, res) {
+		return
+	}
+	utils.WriteResponse(w, res, 200)
+}
+
+// importCampaigns saves the bundle's campaigns, with their ICP filters found in byName, and
+// counts them in res. It writes the error response and returns false when one can't be saved.
+func (s *Server) importCampaigns(w http.ResponseWriter, d *ImportBundleReq, byName map[string]*models.ICPFilter, res *ImportBundleRes) bool {
+	campaigns, err := s.Store.ListCampaigns(d.Email)
+	if err != nil {
+		log.Printf("error while listing campaigns: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return false
+	}
+	existing := map[string]*models.Campaign{}
+	for _, c := range campaigns {
+		existing[strings.ToLower(c.Name)] = c
+	}
+
+	for _, c := range d.Bundle.Campaigns {
+		campaign, ok := existing[strings.ToLower(c.Name)]
+		switch {
+		case ok && !d.Replace:
+			res.SkippedCampaigns = append(res.SkippedCampaigns, c.Name)
+			continue
+		case !ok:
+			id, err := utils.GenerateID()
+			if err != nil {
+				utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+				return false
+			}
+			campaign = &models.Campaign{ID: id, Owner: d.Email, Name: c.Name, NextPage: max(c.Filters.Page, 1), CreatedAt: time.Now()}
+			existing[strings.ToLower(c.Name)] = campaign
+		}
+		campaign.Query, campaign.Filters, campaign.Criteria = c.Query, c.Filters, c.Criteria
+		campaign.JobURL, campaign.Goal, campaign.ICPFilterID = c.JobUrl, strings.TrimSpace(c.Goal), ""
+		campaign.Filters.Page = 0
+		if c.ICPFilter != "" {
+			campaign.ICPFilterID = byName[strings.ToLower(c.ICPFilter)].ID
+		}
+		if err := s.Store.SaveCampaign(campaign); err != nil {
+			log.Printf("error while saving campaign: %v\n", err)
+			utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+			return false
+		}
+		res.CampaignsImported++
+	}
+	return true
+}
+
+// validateBundleCampaign checks a campaign as CreateCampaign does, its ICP filter being one
+// of filters or of the importer's own in existing. It returns what is wrong and false.
+func validateBundleCampaign(c BundleCampaign, filters []BundleICPFilter, existing map[string]*models.ICPFilter) (i18n.Message, bool) {
+	if strings.TrimSpace(c.Name) == "" {
+		return i18n.Format("name is required"), false
+	}
+	if _, err := scraper.SearchURL(c.Query, c.Filters); err != nil {
+		return i18n.Format("%s", err), false
+	}
+	if c.JobUrl != "" && scraper.JobPage(c.JobUrl) == "" {
+		return i18n.Format("jobUrl is not a LinkedIn job posting"), false
+	}
+	if len(c.Goal) > maxGoalLength {
+		return i18n.Format("goal is longer than %d characters", maxGoalLength), false
+	}
+	if c.ICPFilter == "" || existing[strings.ToLower(c.ICPFilter)] != nil {
+		return i18n.Message{}, true
+	}
+	for _, f := range filters {
+		if strings.EqualFold(f.Name, c.ICPFilter) {
+			return i18n.Message{}, true
+		}
+	}
+	return i18n.Format("icp filter %q is neither in the bundle nor saved", c.ICPFilter), false
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/icp"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scoring"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	_, staging := newTestServer(t)
+	_, prod := newTestServer(t)
+
+	native := true
+	saved := &SettingsReq{
+		Email:          "a@x.com",
+		ScoringWeights: &scoring.Weights{TitleMatch: 1, RecentActivity: 2, OpenToWork: 3},
+		Fallbacks:      "posts<2:experience",
+		NativeLanguage: &native,
+	}
+	if code := call(t, staging, http.MethodPut, "/api/settings", saved, nil); code != http.StatusOK {
+		t.Fatalf("PUT /api/settings: status %d", code)
+	}
+	filter := &ICPFilterReq{Email: "a@x.com", Name: "Gaming CTOs", Filter: icp.Filter{Titles: []string{"CTO"}}}
+	var savedFilter models.ICPFilter
+	if code := call(t, staging, http.MethodPost, "/api/icp-filters", filter, &savedFilter); code >= 300 {
+		t.Fatalf("POST /api/icp-filters: status %d", code)
+	}
+	campaign := &CampaignReq{
+		Email:        "a@x.com",
+		Name:         "Studio leads",
+		Query:        "cto gaming",
+		ICPFilterID:  savedFilter.ID,
+		TargetTitles: []string{"CTO"},
+		Goal:         "book a demo",
+	}
+	if code := call(t, staging, http.MethodPost, "/api/campaigns", campaign, nil); code != http.StatusCreated {
+		t.Fatalf("POST /api/campaigns: status %d", code)
+	}
+
+	var bundle ConfigBundle
+	if code := call(t, staging, http.MethodGet, "/api/config-bundle?email=a@x.com", nil, &bundle); code != http.StatusOK {
+		t.Fatalf("export: status %d", code)
+	}
+	if bundle.Version != bundleVersion || len(bundle.ICPFilters) != 1 || bundle.Settings == nil || len(bundle.Campaigns) != 1 {
+		t.Fatalf("bundle = %+v", bundle)
+	}
+	if c := bundle.Campaigns[0]; c.Name != campaign.Name || c.ICPFilter != filter.Name {
+		t.Errorf("exported campaign = %+v", c)
+	}
+
+	var res ImportBundleRes
+	if code := call(t, prod, http.MethodPost, "/api/config-bundle", &ImportBundleReq{Email: "b@x.com", Bundle: bundle}, &res); code != http.StatusOK {
+		t.Fatalf("import: status %d", code)
+	}
+	if res.Imported != 1 || !res.SettingsImported || res.CampaignsImported != 1 {
+		t.Fatalf("import = %+v", res)
+	}
+	var filters ListICPFiltersRes
+	if code := call(t, prod, http.MethodGet, "/api/icp-filters?email=b@x.com", nil, &filters); code != http.StatusOK || len(filters.Filters) != 1 {
+		t.Fatalf("GET /api/icp-filters: status %d, %+v", code, filters)
+	}
+	var campaigns ListCampaignsRes
+	if code := call(t, prod, http.MethodGet, "/api/campaigns?email=b@x.com", nil, &campaigns); code != http.StatusOK || len(campaigns.Campaigns) != 1 {
+		t.Fatalf("GET /api/campaigns: status %d, %+v", code, campaigns)
+	}
+	if c := campaigns.Campaigns[0]; c.Owner != "b@x.com" || c.Query != campaign.Query || c.Goal != campaign.Goal ||
+		c.ICPFilterID != filters.Filters[0].ID || !reflect.DeepEqual(c.Criteria.TargetTitles, campaign.TargetTitles) || c.NextPage != 1 {
+		t.Errorf("imported campaign = %+v", c)
+	}
+	var imported models.Settings
+	if code := call(t, prod, http.MethodGet, "/api/settings?email=b@x.com", nil, &imported); code != http.StatusOK {
+		t.Fatalf("GET /api/settings: status %d", code)
+	}
+	if !reflect.DeepEqual(imported.ScoringWeights, saved.ScoringWeights) || imported.Fallbacks != saved.Fallbacks ||
+		imported.NativeLanguage == nil || !*imported.NativeLanguage || imported.PersonaLLMAssist != nil {
+		t.Errorf("imported settings = %+v", imported)
+	}
+
+	// A second import keeps what is there
+	res = ImportBundleRes{}
+	if code := call(t, prod, http.MethodPost, "/api/config-bundle", &ImportBundleReq{Email: "b@x.com", Bundle: bundle}, &res); code != http.StatusOK {
+		t.Fatalf("re-import: status %d", code)
+	}
+	if res.Imported != 0 || len(res.Skipped) != 1 || res.SettingsImported || res.CampaignsImported != 0 || len(res.SkippedCampaigns) != 1 {
+		t.Errorf("re-import = %+v", res)
+	}
+}
+
+func TestImportVersion2Bundle(t *testing.T) {
+	_, ts := newTestServer(t)
+	body := map[string]any{
+		"email": "a@x.com",
+		"bundle": map[string]any{
+			"version":    2,
+			"icpFilters": []map[string]any{{"name": "CTOs", "filter": map[string]any{"titles": []string{"CTO"}}}},
+		},
+	}
+	var res ImportBundleRes
+	if code := call(t, ts, http.MethodPost, "/api/config-bundle", body, &res); code != http.StatusOK {
+		t.Fatalf("import: status %d", code)
+	}
+	if res.Imported != 1 || res.CampaignsImported != 0 {
+		t.Errorf("import = %+v", res)
+	}
+}
+
+func TestImportRejectsInvalidCampaigns(t *testing.T) {
+	_, ts := newTestServer(t)
+	for name, c := range map[string]BundleCampaign{
+		"no name":        {Query: "cto"},
+		"bad jobUrl":     {Name: "Leads", Query: "cto", JobUrl: "https://example.com/job"},
+		"unknown filter": {Name: "Leads", Query: "cto", ICPFilter: "Nope"},
+	} {
+		bundle := ConfigBundle{Version: bundleVersion, Campaigns: []BundleCampaign{c}}
+		if code := call(t, ts, http.MethodPost, "/api/config-bundle", &ImportBundleReq{Email: "a@x.com", Bundle: bundle}, nil); code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", name, code)
+		}
+	}
+}
+
+func TestImportRejectsInvalidSettings(t *testing.T) {
+	_, ts := newTestServer(t)
+	bundle := ConfigBundle{Version: bundleVersion, Settings: &BundleSettings{Fallbacks: "posts<3:nope"}}
+	if code := call(t, ts, http.MethodPost, "/api/config-bundle", &ImportBundleReq{Email: "a@x.com", Bundle: bundle}, nil); code != http.StatusBadRequest {
+		t.Errorf("import with unknown fallback section: status %d, want 400", code)
+	}
+	bundle.Version = bundleVersion + 1
+	if code := call(t, ts, http.MethodPost, "/api/config-bundle", &ImportBundleReq{Email: "a@x.com", Bundle: bundle}, nil); code != http.StatusBadRequest {
+		t.Errorf("import of a newer bundle: status %d, want 400", code)
+	}
+}
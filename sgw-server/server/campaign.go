@@ -0,0 +1,300 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/i18n"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/icp"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scoring"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+func (s *Server) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+	d := &CampaignReq{}
+	if err := utils.DecodeReqBody(r, d); err != nil {
+		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
+		return
+	}
+	if !utils.ValidEmail(d.Email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(d.Name) == "" {
+		utils.WriteResponse(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	if _, err := scraper.SearchURL(d.Query, d.Filters); err != nil {
+		utils.WriteResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if d.ICPFilterID != "" {
+		if _, ok := s.ownedICPFilter(w, d.ICPFilterID, d.Email); !ok {
+			return
+		}
+	}
+	if d.JobUrl != "" && scraper.JobPage(d.JobUrl) == "" {
+		utils.WriteResponse(w, "jobUrl is not a LinkedIn job posting", http.StatusBadRequest)
+		return
+	}
+	if len(d.Goal) > maxGoalLength {
+		utils.WriteResponse(w, i18n.Format("goal is longer than %d characters", maxGoalLength), http.StatusBadRequest)
+		return
+	}
+
+	id, err := utils.GenerateID()
+	if err != nil {
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	criteria := scoring.Criteria{
+		Weights:        s.settingsFor(d.Email).weights,
+		TargetTitles:   d.TargetTitles,
+		MinCompanySize: d.MinCompanySize,
+		MaxCompanySize: d.MaxCompanySize,
+	}
+	if d.Weights != nil {
+		criteria.Weights = *d.Weights
+	}
+	campaign := &models.Campaign{
+		ID:          id,
+		Owner:       d.Email,
+		Name:        d.Name,
+		Query:       d.Query,
+		Filters:     d.Filters,
+		ICPFilterID: d.ICPFilterID,
+		Criteria:    criteria,
+		JobURL:      d.JobUrl,
+		Goal:        strings.TrimSpace(d.Goal),
+		NextPage:    max(d.Filters.Page, 1),
+		CreatedAt:   time.Now(),
+	}
+	campaign.Filters.Page = 0
+	if err := s.Store.SaveCampaign(campaign); err != nil {
+		log.Printf("error while saving campaign: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	utils.WriteResponse(w, campaign, http.StatusCreated)
+}
+
+func (s *Server) ListCampaigns(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	campaigns, err := s.Store.ListCampaigns(email)
+	if err != nil {
+		log.Printf("error while listing campaigns: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	sort.Slice(campaigns, func(i, j int) bool { return campaigns[i].CreatedAt.After(campaigns[j].CreatedAt) })
+	utils.WriteResponse(w, &ListCampaignsRes{Campaigns: campaigns}, 200)
+}
+
+func (s *Server) GetCampaign(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := s.ownedCampaign(w, r.PathValue("id"), r.URL.Query().Get("email"))
+	if !ok {
+		return
+	}
+	utils.WriteResponse(w, campaign, 200)
+}
+
+// SourceCampaign runs the whole prospecting loop for a campaign: it reads the next d.Pages
+// pages of the campaign's search, drops people an earlier run already considered and those
+// outside the ICP filter's locations, ranks the rest by score and queues them as a batch,
+// which scrapes, applies the full ICP filter and generates like any other. A dry run
+// queues a dry run batch and leaves the campaign as it was.
+func (s *Server) SourceCampaign(w http.ResponseWriter, r *http.Request) {
+	d := &SourceCampaignReq{}
+	if err := utils.DecodeReqBody(r, d); err != nil {
+		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
+		return
+	}
+	if !utils.ValidEmail(d.Email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	if d.Pages == 0 {
+		d.Pages = 1
+	}
+	if d.Pages < 0 || d.Pages > maxSearchPages {
+		utils.WriteResponse(w, i18n.Format("pages must be between 1 and %d", maxSearchPages), http.StatusBadRequest)
+		return
+	}
+	id := r.PathValue("id")
+	if _, ok := s.ownedCampaign(w, id, d.Email); !ok {
+		return
+	}
+
+	// One run at a time, or two would read the same pages and queue the same people
+	releaseLease, ok, err := s.holdLease(campaignLease(id), s.InstanceID)
+	if err != nil {
+		log.Printf("error while leasing campaign: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	if !ok {
+		utils.WriteResponse(w, "this campaign is already being sourced", http.StatusConflict)
+		return
+	}
+	defer releaseLease()
+
+	// Read again under the lease, a run that just finished may have moved it on
+	campaign, ok := s.ownedCampaign(w, id, d.Email)
+	if !ok {
+		return
+	}
+	if campaign.Exhausted {
+		utils.WriteResponse(w, &SourceCampaignRes{Exhausted: true, NextPage: campaign.NextPage}, 200)
+		return
+	}
+	var filter *icp.Filter
+	if campaign.ICPFilterID != "" {
+		saved, err := s.Store.GetICPFilter(campaign.ICPFilterID)
+		if err != nil {
+			log.Printf("error while getting icp filter for campaign %s: %v\n", campaign.ID, err)
+			utils.WriteResponse(w, "the campaign's icp filter could not be loaded", http.StatusConflict)
+			return
+		}
+		filter = &saved.Filter
+	}
+
+	// Turned away before searching, the pages read would be wasted on a batch with no room
+	if writeBusy(w, s.admitScrape()) || writeBusy(w, s.admitBatch(1)) {
+		return
+	}
+	sc, release, err := s.acquireScraper(d.Email, d.Password, "", job{name: "source"})
+	if errors.Is(err, errAccountBusy) {
+		utils.WriteResponse(w, "this LinkedIn account is busy, please try again shortly", http.StatusServiceUnavailable)
+		return
+	}
+	if writeCooling(w, err) || writeOpenBreaker(w, err, i18n.Format("logging in to this LinkedIn account")) || s.writeLoginError(w, d.Email, err) {
+		return
+	}
+	if err != nil {
+		log.Printf("error while logging in: %v\n", err)
+		utils.WriteResponse(w, "could not log in to LinkedIn, please try again later", 500)
+		return
+	}
+	filters := campaign.Filters
+	filters.Page = campaign.NextPage
+	found, err := s.searchPeople(r.Context(), sc, campaign.Query, filters, d.Pages)
+	// Released before the batch starts, it logs in with the same account
+	release()
+	if err != nil {
+		s.accountStopped(d.Email, job{name: "source"}, err)
+		if found.Page == 0 {
+			utils.WriteResponse(w, "could not search LinkedIn, please try again later", 500)
+			return
+		}
+	}
+
+	res := &SourceCampaignRes{Found: len(found.Results)}
+	var matches []scraper.SearchResult
+	for _, result := range found.Results {
+		if slices.Contains(campaign.Sourced, result.URL) {
+			res.AlreadySourced++
+			continue
+		}
+		campaign.Sourced = append(campaign.Sourced, result.URL)
+		if filter != nil {
+			if ok, _ := filter.MatchLocation(result.Location); !ok {
+				res.Skipped++
+				continue
+			}
+		}
+		matches = append(matches, result)
+	}
+	sortSearchResults(matches, campaign.Criteria)
+	campaign.NextPage = found.Page + 1
+	campaign.Exhausted = !found.HasMore || found.Page >= scraper.MaxSearchPage
+	res.NextPage, res.Exhausted = campaign.NextPage, campaign.Exhausted
+
+	if len(matches) > 0 {
+		batchID, err := utils.GenerateID()
+		if err != nil {
+			utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+			return
+		}
+		batch := &models.Batch{
+			ID:          batchID,
+			Owner:       campaign.Owner,
+			Criteria:    campaign.Criteria,
+			ICPFilterID: campaign.ICPFilterID,
+			JobURL:      campaign.JobURL,
+			CampaignID:  campaign.ID,
+			Goal:        campaign.Goal,
+			DryRun:      d.DryRun,
+			Status:      models.BatchPending,
+			CreatedAt:   time.Now(),
+		}
+		for _, m := range matches {
+			batch.LinkedinUrls = append(batch.LinkedinUrls, m.URL)
+		}
+		err = s.startBatch(batch, d.Password)
+		if writeBusy(w, err) {
+			return
+		}
+		if err != nil {
+			utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+			return
+		}
+		campaign.BatchIDs = append(campaign.BatchIDs, batch.ID)
+		res.BatchID, res.Queued = batch.ID, len(matches)
+	}
+	// A dry run reads the same pages again next time, the batch is found by its ID
+	if d.DryRun {
+		res.NextPage, res.Exhausted = filters.Page, false
+	} else if err := s.Store.SaveCampaign(campaign); err != nil {
+		log.Printf("error while saving campaign %s: %v\n", campaign.ID, err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+
+	code := http.StatusOK
+	if res.BatchID != "" {
+		code = http.StatusAccepted
+	}
+	utils.WriteResponse(w, res, code)
+}
+
+// sortSearchResults orders search results best first by scoring what they list, the name,
+// headline and location, so the most promising people are scraped first.
+func sortSearchResults(results []scraper.SearchResult, criteria scoring.Criteria) {
+	scores := make(map[string]float64, len(results))
+	for _, r := range results {
+		scores[r.URL] = scoring.Score(scraper.Profile{Name: r.Name, Headline: r.Headline, Location: r.Location}, criteria).Total
+	}
+	sort.SliceStable(results, func(i, j int) bool { return scores[results[i].URL] > scores[results[j].URL] })
+}
+
+// ownedCampaign loads a campaign belonging to email, writing the error response
+// and returning false when it doesn't exist or belongs to someone else.
+func (s *Server) ownedCampaign(w http.ResponseWriter, id, email string) (*models.Campaign, bool) {
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return nil, false
+	}
+	campaign, err := s.Store.GetCampaign(id)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Printf("error while getting campaign: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return nil, false
+	}
+	if err != nil || !strings.EqualFold(campaign.Owner, email) {
+		utils.WriteResponse(w, "campaign not found", http.StatusNotFound)
+		return nil, false
+	}
+	return campaign, true
+}
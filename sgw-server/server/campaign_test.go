@@ -0,0 +1,83 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/icp"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scoring"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestSourceCampaign(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	var filter models.ICPFilter
+	if code := call(t, ts, http.MethodPost, "/api/icp-filters", &ICPFilterReq{Email: "a@x.com", Name: "India", Filter: icp.Filter{Locations: []string{"India"}}}, &filter); code != http.StatusCreated {
+		t.Fatalf("POST /api/icp-filters: status %d", code)
+	}
+	var campaign models.Campaign
+	if code := call(t, ts, http.MethodPost, "/api/campaigns", &CampaignReq{Email: "a@x.com", Name: "Data leads", Query: "data", ICPFilterID: filter.ID}, &campaign); code != http.StatusCreated {
+		t.Fatalf("POST /api/campaigns: status %d", code)
+	}
+
+	// Priya and Mei Lin match the search, only Priya is in India
+	var res SourceCampaignRes
+	path := "/api/campaigns/" + campaign.ID + "/source"
+	if code := call(t, ts, http.MethodPost, path, &SourceCampaignReq{Email: "a@x.com", Password: "secret"}, &res); code != http.StatusAccepted {
+		t.Fatalf("POST %s: status %d", path, code)
+	}
+	if res.Found != 2 || res.Skipped != 1 || res.Queued != 1 || res.BatchID == "" || !res.Exhausted || res.NextPage != 2 {
+		t.Fatalf("source = %+v", res)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	var batch BatchRes
+	for batch.Batch == nil || batch.Status != models.BatchDone {
+		if time.Now().After(deadline) {
+			t.Fatal("sourced batch not done after 5s")
+		}
+		if code := call(t, ts, http.MethodGet, "/api/batches/"+res.BatchID+"?email=a@x.com", nil, &batch); code != http.StatusOK {
+			t.Fatalf("GET batch: status %d", code)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if batch.CampaignID != campaign.ID || len(batch.Results) != 1 || batch.Results[0].Profile.Name != "Priya Raman" || batch.Results[0].Message == "" {
+		t.Errorf("batch = %+v", batch)
+	}
+
+	saved, err := s.Store.GetCampaign(campaign.ID)
+	if err != nil || len(saved.Sourced) != 2 || len(saved.BatchIDs) != 1 || !saved.Exhausted {
+		t.Errorf("campaign = %+v, %v", saved, err)
+	}
+	var again SourceCampaignRes
+	if code := call(t, ts, http.MethodPost, path, &SourceCampaignReq{Email: "a@x.com", Password: "secret"}, &again); code != http.StatusOK || again.BatchID != "" || !again.Exhausted {
+		t.Errorf("exhausted campaign: status %d, %+v", code, again)
+	}
+
+	if code := call(t, ts, http.MethodPost, path, &SourceCampaignReq{Email: "b@x.com", Password: "secret"}, nil); code != http.StatusNotFound {
+		t.Errorf("other user: status %d, want 404", code)
+	}
+	if code := call(t, ts, http.MethodPost, "/api/campaigns", &CampaignReq{Email: "a@x.com", Name: "Empty"}, nil); code != http.StatusBadRequest {
+		t.Errorf("campaign without a search: status %d, want 400", code)
+	}
+}
+
+func TestSortSearchResults(t *testing.T) {
+	results := []scraper.SearchResult{
+		{URL: "https://www.linkedin.com/in/one/", Name: "One", Headline: "Recruiter at Acme"},
+		{URL: "https://www.linkedin.com/in/two/", Name: "Two", Headline: "Engineering Manager, Platform"},
+		{URL: "https://www.linkedin.com/in/three/", Name: "Three", Headline: "Engineering Lead at Initech"},
+	}
+	sortSearchResults(results, scoring.Criteria{Weights: scoring.DefaultWeights, TargetTitles: []string{"engineering manager"}})
+	var order []string
+	for _, r := range results {
+		order = append(order, r.Name)
+	}
+	if got := strings.Join(order, ","); got != "Two,Three,One" {
+		t.Errorf("order = %s, want the headlines closest to the target title first", got)
+	}
+}
@@ -0,0 +1,209 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/i18n"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+// cooldownPoll is how often a paused batch checks whether its account's cooldown was lifted early.
+const cooldownPoll = time.Minute
+
+// coolingError is returned by acquireScraper for an account in a cooldown.
+type coolingError struct {
+	until time.Time
+}
+
+func (e *coolingError) Error() string {
+	return "linkedin account is cooling off until " + e.until.Format(time.RFC3339)
+}
+
+// coolsDown reports whether err means the account should stay idle for a while: retrying
+// right away after LinkedIn rate limited, flagged or restricted it only makes a ban more likely.
+func coolsDown(err error) bool {
+	return errors.Is(err, scraper.ErrRateLimited) || errors.Is(err, scraper.ErrBotDetected) || errors.Is(err, scraper.ErrAccountRestricted)
+}
+
+// coolDown starts a cooldown for email's account when err calls for one, of RateLimitCooldown
+// after a rate limit and AccountCooldown otherwise. A cooldown already running is left as is,
+// so repeated failures don't keep extending it.
+func (s *Server) coolDown(email string, err error) {
+	length := s.AccountCooldown
+	if errors.Is(err, scraper.ErrRateLimited) {
+		length = s.RateLimitCooldown
+	}
+	if !coolsDown(err) || length <= 0 {
+		return
+	}
+	if _, ok := s.cooldown(email); ok {
+		return
+	}
+	c := &models.Cooldown{Email: email, Reason: err.Error(), Until: time.Now().Add(length), CreatedAt: time.Now()}
+	if err := s.Store.SaveCooldown(c); err != nil {
+		log.Printf("error while starting cooldown for %s: %v\n", email, err)
+		return
+	}
+	log.Printf("LinkedIn account %s cooling off until %s\n", email, c.Until.Format(time.RFC3339))
+}
+
+// cooldown returns the account's cooldown while it lasts. Cooldowns that can't be read
+// are logged and treated as over, the next failure starts a new one.
+func (s *Server) cooldown(email string) (*models.Cooldown, bool) {
+	c, err := s.Store.ActiveCooldown(email)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			log.Printf("error while getting cooldown for %s: %v\n", email, err)
+		}
+		return nil, false
+	}
+	return c, true
+}
+
+// writeCooling answers 503 with a Retry-After header for the end of the cooldown when err
+// is a *coolingError, and reports whether it was.
+func writeCooling(w http.ResponseWriter, err error) bool {
+	var cooling *coolingError
+	if !errors.As(err, &cooling) {
+		return false
+	}
+	setRetryAfter(w, cooling.until)
+	utils.WriteResponse(w, i18n.Format("this LinkedIn account is cooling off after LinkedIn flagged it, please try again after %s", cooling.until.Format(time.RFC3339)), http.StatusServiceUnavailable)
+	return true
+}
+
+// writeCapped answers 429 with a Retry-After header for when the account may scrape its
+// next profile when err is a *scraper.CapError, and reports whether it was.
+func writeCapped(w http.ResponseWriter, err error) bool {
+	var capped *scraper.CapError
+	if !errors.As(err, &capped) {
+		return false
+	}
+	setRetryAfter(w, capped.Until)
+	utils.WriteResponse(w, i18n.Format("this LinkedIn account reached its profile scrape limit, please try again after %s", capped.Until.Format(time.RFC3339)), http.StatusTooManyRequests)
+	return true
+}
+
+/*
+	writeLoginError answers with the status that says why LinkedIn refused email's login, and reports whether err was one it recognises.
+
+Rate limits answer 429 and flagged sessions 503, both with a Retry-After header
+for the end of the cooldown they started. Restrictions and checkpoints answer
+403, rejected credentials 401.
+*/
+func (s *Server) writeLoginError(w http.ResponseWriter, email string, err error) bool {
+	var code int
+	var msg i18n.Message
+	switch {
+	case errors.Is(err, scraper.ErrRateLimited):
+		code, msg = http.StatusTooManyRequests, i18n.Format("LinkedIn is rate limiting this account")
+	case errors.Is(err, scraper.ErrBotDetected):
+		code, msg = http.StatusServiceUnavailable, i18n.Format("LinkedIn flagged this account as automated")
+	case errors.Is(err, scraper.ErrAccountRestricted):
+		code, msg = http.StatusForbidden, i18n.Format("LinkedIn restricted this account")
+	case errors.Is(err, scraper.ErrVerificationRequired):
+		code, msg = http.StatusForbidden, i18n.Format("LinkedIn wants a security checkpoint solved for this account")
+	case errors.Is(err, scraper.ErrNotAuthenticated):
+		code, msg = http.StatusUnauthorized, i18n.Format("LinkedIn did not accept the email and password")
+	default:
+		return false
+	}
+	log.Printf("error while logging in: %v\n", err)
+	if c, ok := s.cooldown(email); ok && code != http.StatusForbidden {
+		setRetryAfter(w, c.Until)
+		msg = i18n.Format("%s, please try again after %s", msg, c.Until.Format(time.RFC3339))
+	}
+	utils.WriteResponse(w, msg, code)
+	return true
+}
+
+// setRetryAfter tells clients to retry once until has passed.
+func setRetryAfter(w http.ResponseWriter, until time.Time) {
+	w.Header().Set("Retry-After", strconv.Itoa(max(int(math.Ceil(time.Until(until).Seconds())), 1)))
+}
+
+// waitCooldown blocks until email's account is out of its cooldown.
+func (s *Server) waitCooldown(email string) {
+	for {
+		c, ok := s.cooldown(email)
+		if !ok {
+			return
+		}
+		time.Sleep(min(time.Until(c.Until), cooldownPoll))
+	}
+}
+
+// standIn returns a warm session account of one of owner's teammates that can take over
+// owner's work while owner's account cools off, or false when there is none.
+func (s *Server) standIn(owner string) (email, password string, ok bool) {
+	for _, team := range s.Teams {
+		if !slices.ContainsFunc(team.Members, func(m string) bool { return strings.EqualFold(m, owner) }) {
+			continue
+		}
+		for _, member := range team.Members {
+			if strings.EqualFold(member, owner) {
+				continue
+			}
+			ws := s.warmSession(member)
+			if ws == nil {
+				continue
+			}
+			if _, cooling := s.cooldown(member); cooling {
+				continue
+			}
+			return ws.account.Email, ws.account.Password, true
+		}
+	}
+	return "", "", false
+}
+
+// GetCooldown returns the cooldown a user's LinkedIn account is in, 404 when there is none.
+func (s *Server) GetCooldown(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	c, err := s.Store.ActiveCooldown(email)
+	if errors.Is(err, store.ErrNotFound) {
+		utils.WriteResponse(w, "account is not cooling off", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("error while getting cooldown: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	utils.WriteResponse(w, c, 200)
+}
+
+// EndCooldown lifts a cooldown early, for owners who dealt with LinkedIn themselves.
+// Paused batches resume within cooldownPoll.
+func (s *Server) EndCooldown(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	err := s.Store.DeleteCooldown(email)
+	if errors.Is(err, store.ErrNotFound) {
+		utils.WriteResponse(w, "account is not cooling off", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("error while ending cooldown: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	utils.WriteResponse(w, i18n.Format("cooldown for %s ended", email), 200)
+}
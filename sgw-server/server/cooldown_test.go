@@ -0,0 +1,188 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/config"
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// restrictOnce makes the first scraper logged in as email restricted after its first prospect.
+func restrictOnce(s *Server, email string) {
+	var stopped atomic.Bool
+	s.NewScraper = func(e, password, url string) (Scraper, error) {
+		sc, err := fake.Backend{}.NewScraper(e, password, url)
+		if e == email && stopped.CompareAndSwap(false, true) {
+			return &stoppingScraper{Scraper: sc, err: scraper.ErrAccountRestricted}, err
+		}
+		return sc, err
+	}
+}
+
+var batchURLs = []string{"https://www.linkedin.com/in/one/", "https://www.linkedin.com/in/two/", "https://www.linkedin.com/in/three/"}
+
+func TestRestrictedBatchPausesUntilCooldownEnds(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.AccountCooldown = 200 * time.Millisecond
+	restrictOnce(s, "a@x.com")
+
+	res := runTestBatch(t, ts, "a@x.com", batchURLs...)
+	if res.Status != models.BatchDone || len(res.Results) != 3 {
+		t.Fatalf("batch = %s with %d results, want done with all 3", res.Status, len(res.Results))
+	}
+	if !res.ResumeAt.IsZero() || res.Account != "" {
+		t.Errorf("finished batch still shows resumeAt %v and account %q", res.ResumeAt, res.Account)
+	}
+	var paused bool
+	for _, a := range s.activity.snapshot() {
+		paused = paused || (a.Kind == ActivityBatchPaused && a.BatchID == res.ID)
+	}
+	if !paused {
+		t.Error("no batch.paused activity recorded")
+	}
+}
+
+func TestRestrictedBatchMovesToTeammate(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.Teams = []config.Team{{Name: "growth", Members: []string{"a@x.com", "b@x.com"}}}
+	restrictOnce(s, "a@x.com")
+	s.WarmUp([]config.Account{{Email: "b@x.com", Password: "other"}}, time.Hour)
+
+	res := runTestBatch(t, ts, "a@x.com", batchURLs...)
+	if res.Status != models.BatchDone || len(res.Results) != 3 {
+		t.Fatalf("batch = %s with %d results, want done with all 3", res.Status, len(res.Results))
+	}
+	if res.Account != "b@x.com" {
+		t.Errorf("batch ran on %q, want the teammate's account", res.Account)
+	}
+	for _, p := range res.Results {
+		if p.Owner != "a@x.com" {
+			t.Errorf("prospect owned by %q, want the batch owner", p.Owner)
+		}
+	}
+}
+
+func TestCoolingAccountRefusesRequests(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.coolDown("a@x.com", scraper.ErrBotDetected)
+	// A checkpoint is left to the owner, it does not cool the account down
+	s.coolDown("b@x.com", scraper.ErrVerificationRequired)
+
+	body := &HomeReq{Email: "a@x.com", Password: "secret", LinkedinUrl: "https://www.linkedin.com/in/one/"}
+	if code := call(t, ts, http.MethodPost, "/api/home", body, nil); code != http.StatusServiceUnavailable {
+		t.Fatalf("home while cooling off: status %d, want 503", code)
+	}
+	var c models.Cooldown
+	if code := call(t, ts, http.MethodGet, "/api/cooldown?email=A@x.com", nil, &c); code != http.StatusOK || c.Until.Before(time.Now().Add(23*time.Hour)) {
+		t.Fatalf("GET cooldown: status %d, %+v", code, c)
+	}
+	if code := call(t, ts, http.MethodGet, "/api/cooldown?email=b@x.com", nil, nil); code != http.StatusNotFound {
+		t.Errorf("GET cooldown after a checkpoint: status %d, want 404", code)
+	}
+
+	if code := call(t, ts, http.MethodDelete, "/api/cooldown?email=a@x.com", nil, nil); code != http.StatusOK {
+		t.Fatalf("DELETE cooldown: status %d", code)
+	}
+	home(t, ts, "a@x.com", "https://www.linkedin.com/in/one/")
+}
+
+func TestLoginErrorStatuses(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.RateLimitCooldown = time.Minute
+	fails := map[string]error{
+		"limited@x.com":    fmt.Errorf("%w: https://www.linkedin.com/feed/ answered with status 429", scraper.ErrRateLimited),
+		"flagged@x.com":    scraper.ErrBotDetected,
+		"restricted@x.com": scraper.ErrAccountRestricted,
+		"rejected@x.com":   scraper.ErrNotAuthenticated,
+	}
+	s.NewScraper = func(email, password, url string) (Scraper, error) {
+		return nil, fails[email]
+	}
+
+	for email, want := range map[string]int{
+		"limited@x.com":    http.StatusTooManyRequests,
+		"flagged@x.com":    http.StatusServiceUnavailable,
+		"restricted@x.com": http.StatusForbidden,
+		"rejected@x.com":   http.StatusUnauthorized,
+	} {
+		data, _ := json.Marshal(&HomeReq{Email: email, Password: "secret", LinkedinUrl: "https://www.linkedin.com/in/one/"})
+		resp, err := http.Post(ts.URL+"/api/home", "application/json", bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("home as %s: status %d, want %d", email, resp.StatusCode, want)
+		}
+		if retry := resp.Header.Get("Retry-After"); (retry != "") != (want == http.StatusTooManyRequests || want == http.StatusServiceUnavailable) {
+			t.Errorf("home as %s: Retry-After %q", email, retry)
+		}
+	}
+
+	c, ok := s.cooldown("limited@x.com")
+	if !ok || time.Until(c.Until) > time.Minute {
+		t.Fatalf("rate limited account cooldown = %+v, want one of RateLimitCooldown", c)
+	}
+	if _, ok := s.cooldown("rejected@x.com"); ok {
+		t.Error("rejected credentials started a cooldown")
+	}
+}
+
+// cappingScraper fails its at-th scrape with a cap of scraper.Rate ending at until, once
+// across every scraper sharing capped.
+type cappingScraper struct {
+	*fake.Scraper
+	at     int32
+	calls  atomic.Int32
+	capped *atomic.Bool
+	until  time.Time
+}
+
+func (s *cappingScraper) ScrapeWithBudget(ctx context.Context, budget time.Duration, sections ...scraper.Section) []scraper.SectionResult {
+	if s.calls.Add(1) == s.at && s.capped.CompareAndSwap(false, true) {
+		return []scraper.SectionResult{{Section: sections[0], Err: &scraper.CapError{Until: s.until}}}
+	}
+	return s.Scraper.ScrapeWithBudget(ctx, budget, sections...)
+}
+
+func TestCappedAccountWaits(t *testing.T) {
+	s, ts := newTestServer(t)
+	var capped atomic.Bool
+	at, until := int32(1), time.Now().Add(300*time.Millisecond)
+	s.NewScraper = func(e, password, url string) (Scraper, error) {
+		sc, err := fake.Backend{}.NewScraper(e, password, url)
+		return &cappingScraper{Scraper: sc, at: at, capped: &capped, until: until}, err
+	}
+
+	body := &HomeReq{Email: "a@x.com", Password: "secret", LinkedinUrl: "https://www.linkedin.com/in/one/"}
+	if code := call(t, ts, http.MethodPost, "/api/home", body, nil); code != http.StatusTooManyRequests {
+		t.Fatalf("home past the cap: status %d, want 429", code)
+	}
+
+	// A batch reaching the cap partway waits for it, then carries on
+	capped.Store(false)
+	at, until = 2, time.Now().Add(300*time.Millisecond)
+	res := runTestBatch(t, ts, "b@x.com", batchURLs...)
+	if res.Status != models.BatchDone || len(res.Results) != 3 {
+		t.Fatalf("batch = %s with %d results, want done with all 3", res.Status, len(res.Results))
+	}
+	if time.Now().Before(until) || !capped.Load() {
+		t.Error("batch finished before the cap ended")
+	}
+	var paused bool
+	for _, a := range s.activity.snapshot() {
+		paused = paused || (a.Kind == ActivityBatchPaused && a.BatchID == res.ID)
+	}
+	if !paused {
+		t.Error("no batch.paused activity recorded")
+	}
+}
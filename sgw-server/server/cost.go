@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/openai"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+// jobCost adds up what one prospect's job spends while it runs. Enrichment sources run
+// concurrently, so it is locked. A nil jobCost records nothing, for scrapes no one pays
+// per prospect for, such as the sender's own profile.
+type jobCost struct {
+	mu      sync.Mutex
+	browser time.Duration
+	actions int
+	usage   openai.Usage
+}
+
+// scraped records the sections a scrape read; skipped ones never reached LinkedIn.
+func (c *jobCost) scraped(results []scraper.SectionResult) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range results {
+		if !r.Skipped {
+			c.browser += r.Took
+			c.actions++
+		}
+	}
+}
+
+// read records a single page read outside ScrapeWithBudget, such as a job posting.
+func (c *jobCost) read(took time.Duration) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.browser += took
+	c.actions++
+}
+
+// llm records the usage of LLM calls.
+func (c *jobCost) llm(u openai.Usage) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.usage = c.usage.Add(u)
+}
+
+// report returns what was recorded, with the LLM spend estimated at prices.
+func (c *jobCost) report(prices openai.Prices) *models.Cost {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &models.Cost{
+		BrowserSeconds:   c.browser.Seconds(),
+		LinkedInActions:  c.actions,
+		LLMCalls:         c.usage.Calls,
+		PromptTokens:     c.usage.PromptTokens,
+		CompletionTokens: c.usage.CompletionTokens,
+		EmbeddingTokens:  c.usage.EmbeddingTokens,
+		LLMDollars:       c.usage.Dollars(prices),
+	}
+}
+
+type jobCostKey struct{}
+
+// withCost returns ctx carrying cost, for the LLM calls made below code that only passes contexts on.
+func withCost(ctx context.Context, cost *jobCost) context.Context {
+	return context.WithValue(ctx, jobCostKey{}, cost)
+}
+
+// costOf returns the jobCost ctx carries, nil when it carries none.
+func costOf(ctx context.Context) *jobCost {
+	cost, _ := ctx.Value(jobCostKey{}).(*jobCost)
+	return cost
+}
+
+// SummarizeWebsite summarizes a prospect's website with the LLM that writes the messages,
+// counting it towards the cost of the job ctx belongs to. It is the enrich.Summarize of
+// the website source.
+func (s *Server) SummarizeWebsite(ctx context.Context, site, text string) (string, error) {
+	summary, usage, err := s.ProtectedLLM().SummarizeWebsite(site, text)
+	costOf(ctx).llm(usage)
+	return summary, err
+}
+
+// totalCost adds up the cost of prospects; those stored before costs were recorded add nothing.
+func totalCost(prospects []*models.Prospect) models.Cost {
+	var total models.Cost
+	for _, p := range prospects {
+		if p.Cost != nil {
+			total.Add(*p.Cost)
+		}
+	}
+	return total
+}
+
+// CampaignCost adds up what the batches a campaign sourced spent, per batch and in total.
+func (s *Server) CampaignCost(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := s.ownedCampaign(w, r.PathValue("id"), r.URL.Query().Get("email"))
+	if !ok {
+		return
+	}
+	res := &CampaignCostRes{CampaignID: campaign.ID, Batches: []BatchCost{}}
+	for _, id := range campaign.BatchIDs {
+		prospects, err := s.Store.ListBatchProspects(id)
+		if err != nil {
+			log.Printf("error while listing batch prospects: %v\n", err)
+			utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+			return
+		}
+		batch := BatchCost{BatchID: id, Prospects: len(prospects), Cost: totalCost(prospects)}
+		res.Batches = append(res.Batches, batch)
+		res.Prospects += batch.Prospects
+		res.Cost.Add(batch.Cost)
+	}
+	utils.WriteResponse(w, res, 200)
+}
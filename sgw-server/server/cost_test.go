@@ -0,0 +1,50 @@
+package server
+
+import (
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/openai"
+)
+
+func TestCostReport(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.LLMPrices = openai.Prices{PromptPerMillion: 1, CompletionPerMillion: 2}
+
+	res := runTestBatch(t, ts, "a@x.com", "https://www.linkedin.com/in/one/", "https://www.linkedin.com/in/two/")
+	if len(res.Results) != 2 {
+		t.Fatalf("batch = %+v", res)
+	}
+	var sum models.Cost
+	for _, p := range res.Results {
+		c := p.Cost
+		if c == nil || c.LinkedInActions == 0 || c.LLMCalls != 1 || c.PromptTokens == 0 || c.CompletionTokens == 0 {
+			t.Fatalf("cost of %s = %+v, want its sections and one message", p.LinkedinUrl, c)
+		}
+		if want := (float64(c.PromptTokens)*1 + float64(c.CompletionTokens)*2) / 1e6; math.Abs(c.LLMDollars-want) > 1e-12 {
+			t.Errorf("llmDollars = %v, want %v at the configured prices", c.LLMDollars, want)
+		}
+		sum.Add(*c)
+	}
+	if res.Cost != sum {
+		t.Errorf("batch cost = %+v, want the prospects' %+v", res.Cost, sum)
+	}
+
+	campaign := &models.Campaign{ID: "c1", Owner: "a@x.com", Name: "Data leads", Query: "data", BatchIDs: []string{res.ID}, CreatedAt: time.Now()}
+	if err := s.Store.SaveCampaign(campaign); err != nil {
+		t.Fatal(err)
+	}
+	var report CampaignCostRes
+	if code := call(t, ts, http.MethodGet, "/api/campaigns/c1/cost?email=a@x.com", nil, &report); code != http.StatusOK {
+		t.Fatalf("GET cost: status %d", code)
+	}
+	if report.Prospects != 2 || report.Cost != sum || len(report.Batches) != 1 || report.Batches[0].BatchID != res.ID || report.Batches[0].Cost != sum {
+		t.Errorf("campaign cost = %+v", report)
+	}
+	if code := call(t, ts, http.MethodGet, "/api/campaigns/c1/cost?email=b@x.com", nil, nil); code != http.StatusNotFound {
+		t.Errorf("other user: status %d, want 404", code)
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"github.com/hemantsharma1498/segwise-assignment/config"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+/*
+DegradationPolicy decides which sections are scraped for a prospect.
+
+Sections are always scraped, Full sections only for full scrapes (a sender
+is onboarded or an ICP filter reads details). Fallbacks then fill in for
+sections that came back thin, e.g. few posts leave little to personalise on,
+so experience and education are fetched instead. Text sections count as one
+entry when non-empty.
+*/
+type DegradationPolicy struct {
+	Sections  []scraper.Section
+	Full      []scraper.Section
+	Fallbacks []config.FallbackRule
+}
+
+// DefaultDegradationPolicy always fetches the current employer's page, and fetches articles, comments and the detail sections
+// (experience, education, skills, certifications, recommendations, volunteering, publications, patents and languages) when
+// a profile has two posts or fewer.
+var DefaultDegradationPolicy = DegradationPolicy{
+	Sections: []scraper.Section{scraper.SectionNameAndLocation, scraper.SectionPosts, scraper.SectionCompany},
+	Full: []scraper.Section{
+		scraper.SectionAbout, scraper.SectionArticles, scraper.SectionComments, scraper.SectionExperience, scraper.SectionEducation,
+		scraper.SectionSkills, scraper.SectionCertifications, scraper.SectionRecommendations, scraper.SectionVolunteering,
+		scraper.SectionPublications, scraper.SectionPatents, scraper.SectionLanguages,
+	},
+	Fallbacks: []config.FallbackRule{
+		{When: scraper.SectionPosts, Below: 3, Fetch: []scraper.Section{
+			scraper.SectionArticles, scraper.SectionComments, scraper.SectionExperience, scraper.SectionEducation,
+			scraper.SectionSkills, scraper.SectionCertifications, scraper.SectionRecommendations, scraper.SectionVolunteering,
+			scraper.SectionPublications, scraper.SectionPatents, scraper.SectionLanguages,
+		}},
+	},
+}
+
+// FirstPass returns the sections to scrape before any fallback, in page order.
+func (p DegradationPolicy) FirstPass(full bool) []scraper.Section {
+	sections := p.Sections
+	if full {
+		sections = append(append([]scraper.Section{}, p.Sections...), p.Full...)
+	}
+	return inPageOrder(sections, nil)
+}
+
+// Fallback returns the sections the rules add for a profile after the sections in done were
+// attempted, leaving those out. Rules only look at attempted sections; a failed one counts as empty.
+func (p DegradationPolicy) Fallback(profile scraper.Profile, done []scraper.Section) []scraper.Section {
+	coverage := sectionCoverage(profile)
+	var sections []scraper.Section
+	for _, rule := range p.Fallbacks {
+		if contains(done, rule.When) && coverage[rule.When] < rule.Below {
+			sections = append(sections, rule.Fetch...)
+		}
+	}
+	return inPageOrder(sections, done)
+}
+
+// inPageOrder deduplicates sections, drops those in exclude and sorts the rest by scraper.PageOrder.
+func inPageOrder(sections, exclude []scraper.Section) []scraper.Section {
+	ordered := make([]scraper.Section, 0, len(sections))
+	for _, section := range scraper.PageOrder {
+		if contains(sections, section) && !contains(exclude, section) {
+			ordered = append(ordered, section)
+		}
+	}
+	return ordered
+}
+
+func contains(sections []scraper.Section, section scraper.Section) bool {
+	for _, s := range sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/config"
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// driftBudget is generous so a slow night is not mistaken for drift; the check is not user facing.
+const driftBudget = 2 * time.Minute
+
+const driftLease = "drift-check"
+
+// StartDriftCheck scrapes a known-good profile every interval and raises a scraper.drift
+// notification when any section returns fewer entries than expected. LinkedIn markup
+// changes show up here as empty sections before users get empty results. Instances
+// sharing a store take turns, so the profile is scraped once per interval.
+func (s *Server) StartDriftCheck(account config.Account, linkedinUrl string, expect map[scraper.Section]int, interval time.Duration) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				// The lease is kept for the whole interval, so one instance checks per interval
+				ok, err := s.Store.AcquireLease(driftLease, s.InstanceID, interval)
+				if err != nil {
+					log.Printf("error while leasing the drift check: %v\n", err)
+				}
+				if ok {
+					s.checkDrift(account, linkedinUrl, expect)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+func (s *Server) checkDrift(account config.Account, linkedinUrl string, expect map[scraper.Section]int) {
+	log.Printf("Running selector drift check against %s\n", linkedinUrl)
+	sc, release, err := s.acquireScraper(account.Email, account.Password, linkedinUrl, job{name: "drift-check"})
+	if err != nil {
+		// A failed login is not markup drift, the session checks already report it
+		log.Printf("error while logging in for drift check: %v\n", err)
+		return
+	}
+	defer release()
+
+	sc.Renew(scraper.DefaultLease)
+	sc.SetProfileURL(linkedinUrl)
+	results := sc.ScrapeWithBudget(context.Background(), driftBudget, scraper.PageOrder...)
+
+	problems := driftProblems(results, sectionCoverage(sc.Profile()), expect)
+	if len(problems) == 0 {
+		log.Printf("Selector drift check passed\n")
+		return
+	}
+
+	detail := strings.Join(problems, "; ")
+	log.Printf("selector drift check failed: %s\n", detail)
+	s.notify(models.EventSelectorDrift, detail)
+}
+
+// driftProblems describes the sections that fell short of their expectation. Sections
+// expected to be empty on the drift profile are not checked, their pages may not even
+// render and a failure there says nothing about the selectors.
+func driftProblems(results []scraper.SectionResult, coverage, expect map[scraper.Section]int) []string {
+	var problems []string
+	for _, r := range results {
+		if expect[r.Section] == 0 {
+			continue
+		}
+		switch {
+		case r.Skipped:
+			problems = append(problems, fmt.Sprintf("%s skipped, scrape budget exhausted", r.Section))
+		case r.Err != nil:
+			problems = append(problems, fmt.Sprintf("%s failed: %v", r.Section, r.Err))
+		case coverage[r.Section] < expect[r.Section]:
+			problems = append(problems, fmt.Sprintf("%s has %d entries, expected at least %d", r.Section, coverage[r.Section], expect[r.Section]))
+		}
+	}
+	return problems
+}
+
+// sectionCoverage counts the entries each section produced; text sections count as one when non-empty.
+func sectionCoverage(p scraper.Profile) map[scraper.Section]int {
+	coverage := map[scraper.Section]int{
+		scraper.SectionPosts:           len(p.Posts),
+		scraper.SectionExperience:      len(p.Experience),
+		scraper.SectionEducation:       len(p.Education),
+		scraper.SectionSkills:          len(p.Skills),
+		scraper.SectionCertifications:  len(p.Certifications),
+		scraper.SectionRecommendations: len(p.Recommendations),
+		scraper.SectionVolunteering:    len(p.Volunteering),
+		scraper.SectionPublications:    len(p.Publications),
+		scraper.SectionPatents:         len(p.Patents),
+		scraper.SectionLanguages:       len(p.Languages),
+		scraper.SectionArticles:        len(p.Articles),
+		scraper.SectionComments:        len(p.Comments),
+		scraper.SectionContactInfo:     len(p.ContactInfo.Websites),
+	}
+	if p.Name != "" && p.Location != "" {
+		coverage[scraper.SectionNameAndLocation] = 1
+	}
+	if p.About != "" {
+		coverage[scraper.SectionAbout] = 1
+	}
+	if p.Company != nil && p.Company.Name != "" {
+		coverage[scraper.SectionCompany] = 1
+	}
+	return coverage
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/config"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestDriftProblemsIgnoresOptionalSections(t *testing.T) {
+	expect, err := config.ParseDriftExpectations("experience=2")
+	if err != nil {
+		t.Fatalf("ParseDriftExpectations: %v", err)
+	}
+	timeout := errors.New("context deadline exceeded")
+	results := []scraper.SectionResult{
+		{Section: scraper.SectionExperience},
+		{Section: scraper.SectionPatents, Err: timeout},
+		{Section: scraper.SectionLanguages, Skipped: true},
+		{Section: scraper.SectionCertifications},
+	}
+	coverage := map[scraper.Section]int{scraper.SectionExperience: 2}
+	if problems := driftProblems(results, coverage, expect); len(problems) != 0 {
+		t.Fatalf("optional sections reported as drift: %v", problems)
+	}
+
+	results = append(results, scraper.SectionResult{Section: scraper.SectionSkills, Err: timeout}, scraper.SectionResult{Section: scraper.SectionEducation})
+	coverage[scraper.SectionExperience] = 1
+	problems := driftProblems(results, coverage, expect)
+	if len(problems) != 3 {
+		t.Fatalf("problems = %v, want experience, skills and education", problems)
+	}
+	for i, want := range []string{"experience has 1 entries", "skills failed", "education has 0 entries"} {
+		if !strings.HasPrefix(problems[i], want) {
+			t.Errorf("problem %d = %q, want %q...", i, problems[i], want)
+		}
+	}
+}
@@ -1,13 +1,465 @@
 package server
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/background"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/breaker"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/cache"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/enrich"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/icp"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/latency"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/persona"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/redact"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/schema"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scoring"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
 type HomeReq struct {
 	Email       string `json:"email"`
 	Password    string `json:"password"`
 	LinkedinUrl string `json:"linkedinUrl"`
+	JobUrl      string `json:"jobUrl"` // Optional LinkedIn job posting the message is about
+	Goal        string `json:"goal"`   // Optional purpose of the message, posts relevant to it are referenced first
 }
 
 type HomeRes struct {
-	Msg         string   `json:"msg"`
-	ParamsUsed  []string `json:"paramsUsed"`
-	RecentPosts string   `json:"recentPosts"`
+	SchemaVersion    int               `json:"schemaVersion"`
+	Msg              string            `json:"msg"`
+	ParamsUsed       []string          `json:"paramsUsed"`
+	RecentPosts      string            `json:"recentPosts"`
+	Persona          persona.Persona   `json:"persona"`
+	SharedBackground []background.Hook `json:"sharedBackground"`
+	Sources          []enrich.Outcome  `json:"sources"` // How each source fared, LinkedIn sections included
+	// Status is "insufficient_personalization" when Msg is the generic fallback, see models.Prospect.Status
+	Status models.ProspectStatus `json:"status,omitempty"`
+}
+
+// SenderReq onboards the user's own profile; LinkedinUrl is their own profile URL.
+type SenderReq struct {
+	Email       string `json:"email"`
+	Password    string `json:"password"`
+	LinkedinUrl string `json:"linkedinUrl"`
+}
+
+type SenderRes struct {
+	LinkedinUrl string            `json:"linkedinUrl"`
+	Profile     scraper.Profile   `json:"profile"`
+	Missing     []scraper.Section `json:"missing,omitempty"` // Sections that failed to scrape, left empty in Profile
+	ScrapedAt   time.Time         `json:"scrapedAt"`
+}
+
+type ListProfilesRes struct {
+	SchemaVersion int                `json:"schemaVersion"`
+	Profiles      []*models.Prospect `json:"profiles"`
+}
+
+// BatchReq scrapes LinkedinUrls with one login and scores them against the given criteria.
+// Weights overrides the server's default scoring weights. Profiles not matching the
+// ICPFilterID filter are skipped before generation.
+type BatchReq struct {
+	Email          string           `json:"email"`
+	Password       string           `json:"password"`
+	LinkedinUrls   []string         `json:"linkedinUrls"`
+	TargetTitles   []string         `json:"targetTitles"`
+	MinCompanySize int              `json:"minCompanySize"`
+	MaxCompanySize int              `json:"maxCompanySize"`
+	Weights        *scoring.Weights `json:"weights"`
+	ICPFilterID    string           `json:"icpFilterId"`
+	JobUrl         string           `json:"jobUrl"` // Optional LinkedIn job posting every message is about
+	DryRun         bool             `json:"dryRun"` // Generate drafts only, to try a configuration on a few profiles
+	Goal           string           `json:"goal"`   // Optional purpose of the messages, posts relevant to it are referenced first
+}
+
+type CreateBatchRes struct {
+	ID     string             `json:"id"`
+	Status models.BatchStatus `json:"status"`
+}
+
+type BatchRes struct {
+	SchemaVersion int `json:"schemaVersion"`
+	*models.Batch
+	Results []*models.Prospect `json:"results"`
+	// Cost adds up the results' costs so far
+	Cost models.Cost `json:"cost"`
+}
+
+type ICPFilterReq struct {
+	Email  string     `json:"email"`
+	Name   string     `json:"name"`
+	Filter icp.Filter `json:"filter"`
+}
+
+type ListICPFiltersRes struct {
+	Filters []*models.ICPFilter `json:"filters"`
+}
+
+// CampaignReq saves a people search to source prospects from. Filters.Page is the page
+// the first sourcing run starts at.
+type CampaignReq struct {
+	Email          string                `json:"email"`
+	Name           string                `json:"name"`
+	Query          string                `json:"query"`
+	Filters        scraper.SearchFilters `json:"filters"`
+	ICPFilterID    string                `json:"icpFilterId"`
+	TargetTitles   []string              `json:"targetTitles"`
+	MinCompanySize int                   `json:"minCompanySize"`
+	MaxCompanySize int                   `json:"maxCompanySize"`
+	Weights        *scoring.Weights      `json:"weights"`
+	JobUrl         string                `json:"jobUrl"`
+	Goal           string                `json:"goal"`
+}
+
+type ListCampaignsRes struct {
+	Campaigns []*models.Campaign `json:"campaigns"`
+}
+
+// SourceCampaignReq sources Pages more search pages, 1 when unset, with the user's LinkedIn account.
+type SourceCampaignReq struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Pages    int    `json:"pages"`
+	DryRun   bool   `json:"dryRun"` // Queue a dry run batch and leave the campaign where it was
+}
+
+// SourceCampaignRes counts what a sourcing run found: Found people on the pages read, of
+// which AlreadySourced were considered by an earlier run, Skipped fell outside the ICP
+// filter's locations and Queued went into the batch BatchID, empty when none did.
+type SourceCampaignRes struct {
+	BatchID        string `json:"batchId,omitempty"`
+	Found          int    `json:"found"`
+	AlreadySourced int    `json:"alreadySourced"`
+	Skipped        int    `json:"skipped"`
+	Queued         int    `json:"queued"`
+	NextPage       int    `json:"nextPage"`
+	Exhausted      bool   `json:"exhausted"`
+}
+
+// CampaignCostRes adds up what the Prospects of the batches a campaign sourced cost, per
+// batch in the order they were sourced. Dry runs aren't part of the campaign.
+type CampaignCostRes struct {
+	CampaignID string      `json:"campaignId"`
+	Prospects  int         `json:"prospects"`
+	Cost       models.Cost `json:"cost"`
+	Batches    []BatchCost `json:"batches"`
+}
+
+type BatchCost struct {
+	BatchID   string      `json:"batchId"`
+	Prospects int         `json:"prospects"`
+	Cost      models.Cost `json:"cost"`
+}
+
+// RegenerationReq re-generates the messages of a batch, or of all the user's prospects
+// when BatchID is empty, optionally narrowed down by persona.
+// RegenerationReq regenerates the messages of a batch, of the batches a campaign sourced, or
+// of every prospect of Email when neither BatchID nor CampaignID is set.
+type RegenerationReq struct {
+	Email      string         `json:"email"`
+	BatchID    string         `json:"batchId"`
+	CampaignID string         `json:"campaignId"`
+	Filter     persona.Filter `json:"filter"`
+}
+
+type CreateRegenerationRes struct {
+	ID        string             `json:"id"`
+	Status    models.BatchStatus `json:"status"`
+	Prospects int                `json:"prospects"`
+}
+
+// ApplyRegenerationReq applies the regenerated messages of ProspectIDs, or all of them when empty.
+type ApplyRegenerationReq struct {
+	Email       string   `json:"email"`
+	ProspectIDs []string `json:"prospectIds"`
+}
+
+type ApplyRegenerationRes struct {
+	Applied int            `json:"applied"`
+	Skipped []SkippedApply `json:"skipped"`
+}
+
+type SkippedApply struct {
+	ProspectID string `json:"prospectId"`
+	Reason     string `json:"reason"`
+}
+
+// SettingsReq replaces Email's overrides of the server's generation settings; nil or empty
+// fields keep the server's value. Fallbacks use the SCRAPE_FALLBACKS syntax.
+type SettingsReq struct {
+	Email            string           `json:"email"`
+	ScoringWeights   *scoring.Weights `json:"scoringWeights"`
+	Fallbacks        string           `json:"fallbacks"`
+	NativeLanguage   *bool            `json:"nativeLanguage"`
+	PersonaLLMAssist *bool            `json:"personaLlmAssist"`
+	NotifyRoutes     string           `json:"notifyRoutes"`
+}
+
+// ConfigBundle is a user's shareable configuration, exported from one deployment and
+// imported into another. It carries no IDs, owners or profile data.
+type ConfigBundle struct {
+	Version    int               `json:"version"`
+	ExportedAt time.Time         `json:"exportedAt"`
+	ICPFilters []BundleICPFilter `json:"icpFilters"`
+	// Settings are the exporter's overrides, nil when they saved none
+	Settings  *BundleSettings  `json:"settings,omitempty"`
+	Campaigns []BundleCampaign `json:"campaigns"`
+}
+
+type BundleSettings struct {
+	ScoringWeights   *scoring.Weights `json:"scoringWeights,omitempty"`
+	Fallbacks        string           `json:"fallbacks,omitempty"`
+	NativeLanguage   *bool            `json:"nativeLanguage,omitempty"`
+	PersonaLLMAssist *bool            `json:"personaLlmAssist,omitempty"`
+}
+
+type BundleICPFilter struct {
+	Name   string     `json:"name"`
+	Filter icp.Filter `json:"filter"`
+}
+
+// BundleCampaign is a campaign's configuration, without what its sourcing runs found.
+type BundleCampaign struct {
+	Name     string                `json:"name"`
+	Query    string                `json:"query"`
+	Filters  scraper.SearchFilters `json:"filters"`
+	Criteria scoring.Criteria      `json:"criteria"`
+	JobUrl   string                `json:"jobUrl,omitempty"`
+	Goal     string                `json:"goal,omitempty"`
+	// ICPFilter names the campaign's ICP filter, one of the bundle's or the importer's own
+	ICPFilter string `json:"icpFilter,omitempty"`
+}
+
+// ImportBundleReq imports Bundle for Email; Replace overwrites filters and campaigns with
+// the same name and settings the user already saved.
+type ImportBundleReq struct {
+	Email   string       `json:"email"`
+	Bundle  ConfigBundle `json:"bundle"`
+	Replace bool         `json:"replace"`
+}
+
+type ImportBundleRes struct {
+	Imported int      `json:"imported"`
+	Skipped  []string `json:"skipped"` // Names of filters that already existed
+	// SettingsImported is false when the bundle had none or the user's own were kept
+	SettingsImported  bool     `json:"settingsImported"`
+	CampaignsImported int      `json:"campaignsImported"`
+	SkippedCampaigns  []string `json:"skippedCampaigns"` // Names of campaigns that already existed
+}
+
+type ShareLinkReq struct {
+	Email string `json:"email"`
+}
+
+type ShareLinkRes struct {
+	Url       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type VerificationRes struct {
+	Url       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type VerificationsRes struct {
+	Verifications []VerificationRes `json:"verifications"`
+}
+
+type OTPRequestRes struct {
+	Url       string    `json:"url"`
+	Method    string    `json:"method"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type OTPRequestsRes struct {
+	Requests []OTPRequestRes `json:"requests"`
+}
+
+// OTPReq is the verification code LinkedIn sent a waiting login.
+type OTPReq struct {
+	Code string `json:"code"`
+}
+
+// VerificationInputReq replays a click at X, Y (fractions of the frame), typed Text or a
+// pressed Key into a checkpoint page. Type is "click", "type" or "key".
+type VerificationInputReq struct {
+	Type string  `json:"type"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+	Text string  `json:"text"`
+	Key  string  `json:"key"`
+}
+
+// ReviewReq approves or rejects a pending message; Email must be one of the reviewers.
+type ReviewReq struct {
+	Email   string `json:"email"`
+	Approve bool   `json:"approve"`
+	Note    string `json:"note"`
+}
+
+// TimelineEntry is one thing that happened to a prospect. Ref is the batch or
+// regeneration ID it came from, Actor the reviewer for approval decisions.
+type TimelineEntry struct {
+	At      time.Time    `json:"at"`
+	Kind    TimelineKind `json:"kind"`
+	Actor   string       `json:"actor,omitempty"`
+	Ref     string       `json:"ref,omitempty"`
+	Detail  string       `json:"detail,omitempty"`
+	Message string       `json:"message,omitempty"`
+}
+
+type TimelineRes struct {
+	ProspectID  string          `json:"prospectId"`
+	LinkedinUrl string          `json:"linkedinUrl"`
+	Entries     []TimelineEntry `json:"entries"`
+}
+
+// Activity is something a user did or ran into. Owner is set when the prospect acted on
+// belongs to someone else, as for reviews.
+type Activity struct {
+	ID          uint64       `json:"id"`
+	Kind        ActivityKind `json:"kind"`
+	Actor       string       `json:"actor"`
+	Owner       string       `json:"owner,omitempty"`
+	ProspectID  string       `json:"prospectId,omitempty"`
+	BatchID     string       `json:"batchId,omitempty"`
+	LinkedinUrl string       `json:"linkedinUrl,omitempty"`
+	Detail      string       `json:"detail,omitempty"`
+	At          time.Time    `json:"at"`
+}
+
+type ActivityRes struct {
+	Activities []Activity `json:"activities"`
+}
+
+type CacheStatsRes struct {
+	Caches map[string]cache.Stats `json:"caches"`
+}
+
+// BusyRes turns work away with a 429 until RetryAfterSeconds, the estimate also in Retry-After.
+type BusyRes struct {
+	Error             string `json:"error"`
+	RetryAfterSeconds int    `json:"retryAfterSeconds"`
+}
+
+// LatencyRes is the latency of home requests (home), batch profiles (batch) and
+// regenerated messages (regeneration), by job and stage.
+type LatencyRes struct {
+	Jobs map[string]map[latency.Stage]latency.Stats `json:"jobs"`
+}
+
+// SchemasRes lists the published JSON Schemas by resource name, the profile schema
+// version they describe, and the payload versions responses and webhooks can be pinned to.
+type SchemasRes struct {
+	Schemas              map[string]string `json:"schemas"`
+	ProfileSchemaVersion int               `json:"profileSchemaVersion"`
+	PayloadVersion       int               `json:"payloadVersion"`
+	PayloadVersions      []PayloadVersion  `json:"payloadVersions"`
+}
+
+// PayloadVersion is a payload version that is still served, with when it was deprecated
+// and stops being served for old ones.
+type PayloadVersion struct {
+	Version    int        `json:"version"`
+	Deprecated *time.Time `json:"deprecated,omitempty"`
+	Sunset     *time.Time `json:"sunset,omitempty"`
+}
+
+// ValidationRes is whether a document matches its schema, and where it doesn't.
+type ValidationRes struct {
+	Valid  bool           `json:"valid"`
+	Errors []schema.Error `json:"errors"`
+}
+
+// BreakersRes is the state of circuit breakers, by the dependency they guard.
+type BreakersRes struct {
+	Breakers map[string]breaker.Stats `json:"breakers"`
+}
+
+// HealthRes is the state of the server and the schema versions of the store it runs on.
+type HealthRes struct {
+	Status               string `json:"status"`
+	SchemaVersion        int    `json:"schemaVersion,omitempty"`
+	ProfileSchemaVersion int    `json:"profileSchemaVersion"`
+}
+
+// ReadyRes is whether the instance takes traffic: warming up, ready or draining.
+type ReadyRes struct {
+	Status string `json:"status"`
+}
+
+// DrainRes is how a drain ended: drained, or timed out with Running jobs left.
+type DrainRes struct {
+	Status  string `json:"status"`
+	Running int    `json:"running,omitempty"`
+}
+
+// SearchReq runs a people search with the user's LinkedIn account. Pages pages are fetched
+// from Filters.Page on, 1 when unset.
+type SearchReq struct {
+	Email    string                `json:"email"`
+	Password string                `json:"password"`
+	Query    string                `json:"query"`
+	Filters  scraper.SearchFilters `json:"filters"`
+	Pages    int                   `json:"pages"`
+}
+
+// SearchRes lists the people found; Page is the last page fetched, pass Page+1 to continue.
+type SearchRes struct {
+	Results []scraper.SearchResult `json:"results"`
+	Page    int                    `json:"page"`
+	HasMore bool                   `json:"hasMore"`
+}
+
+// String keeps the password out of logs when a request is printed with %v.
+func (d HomeReq) String() string {
+	return fmt.Sprintf("{Email:%s Password:%s LinkedinUrl:%s}", d.Email, redact.Mask, d.LinkedinUrl)
+}
+
+// String keeps the password out of logs when a request is printed with %v.
+func (d SenderReq) String() string {
+	return fmt.Sprintf("{Email:%s Password:%s LinkedinUrl:%s}", d.Email, redact.Mask, d.LinkedinUrl)
+}
+
+// String keeps the password out of logs when a request is printed with %v.
+func (d BatchReq) String() string {
+	return fmt.Sprintf("{Email:%s Password:%s LinkedinUrls:%v ICPFilterID:%s}", d.Email, redact.Mask, d.LinkedinUrls, d.ICPFilterID)
+}
+
+// String keeps the password out of logs when a request is printed with %v.
+func (d SourceCampaignReq) String() string {
+	return fmt.Sprintf("{Email:%s Password:%s Pages:%d}", d.Email, redact.Mask, d.Pages)
+}
+
+// String keeps the password out of logs when a request is printed with %v.
+func (d SearchReq) String() string {
+	return fmt.Sprintf("{Email:%s Password:%s Query:%s Filters:%+v Pages:%d}", d.Email, redact.Mask, d.Query, d.Filters, d.Pages)
+}
+
+// SupportViewRes is what support staff see of a user, found in the audit log as AuditID.
+type SupportViewRes struct {
+	User       string              `json:"user"`
+	ViewedBy   string              `json:"viewedBy"`
+	AuditID    string              `json:"auditId"`
+	Sender     *models.Sender      `json:"sender,omitempty"`
+	Settings   *models.Settings    `json:"settings,omitempty"`
+	ICPFilters []*models.ICPFilter `json:"icpFilters"`
+	Campaigns  []*models.Campaign  `json:"campaigns"`
+	Prospects  []*models.Prospect  `json:"prospects"`
+}
+
+type AuditRes struct {
+	Entries []*models.AuditEntry `json:"entries"`
+}
+
+// ReloadRes is what Reload applied: how many selectors and page scripts replace the built-in
+// ones, of which version, and which message prompt.
+type ReloadRes struct {
+	Selectors    int       `json:"selectors"` // Named selectors and rewrites
+	Scripts      int       `json:"scripts"`
+	PagesVersion int       `json:"pagesVersion"`
+	Prompt       string    `json:"prompt"` // default or file
+	ReloadedAt   time.Time `json:"reloadedAt"`
 }
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/enrich"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/openai"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// fakeSource finds the same enrichment for every profile and fails after it.
+type fakeSource []enrich.Enrichment
+
+func (f fakeSource) Name() string { return "fake" }
+
+func (f fakeSource) Enrich(ctx context.Context, profile scraper.Profile) ([]enrich.Enrichment, error) {
+	return f, errors.New("second page unreachable")
+}
+
+// recordingLLM remembers the prospects it was asked to write for.
+type recordingLLM struct {
+	fake.LLM
+	mu        sync.Mutex
+	prospects []openai.Prospect
+}
+
+func (l *recordingLLM) GetMessage(prospect openai.Prospect) (string, openai.Usage, error) {
+	l.mu.Lock()
+	l.prospects = append(l.prospects, prospect)
+	l.mu.Unlock()
+	return l.LLM.GetMessage(prospect)
+}
+
+func TestEnrichmentIsStoredAndWrittenFrom(t *testing.T) {
+	s, ts := newTestServer(t)
+	llm := &recordingLLM{}
+	s.LLM = llm
+	found := []enrich.Enrichment{{Source: "github", URL: "https://github.com/one", Facts: []string{"Maintains kafka-lag (Go, 320 stars)"}}}
+	s.Enrichment.Sources = []enrich.Source{fakeSource(found)}
+
+	home(t, ts, "a@x.com", "https://www.linkedin.com/in/one/")
+
+	prospects, err := s.Store.ListProspects("a@x.com")
+	if err != nil || len(prospects) != 1 {
+		t.Fatalf("ListProspects = %d prospects, %v", len(prospects), err)
+	}
+	if !reflect.DeepEqual(prospects[0].Enrichment, found) {
+		t.Errorf("stored enrichment = %+v, want what the source found despite its error", prospects[0].Enrichment)
+	}
+	if len(llm.prospects) != 1 || !reflect.DeepEqual(llm.prospects[0].Enrichment, found) {
+		t.Errorf("LLM prospects = %+v, want the enrichment passed along", llm.prospects)
+	}
+
+	// The LinkedIn sections come first, then the sources outside it
+	sources := prospects[0].Sources
+	if len(sources) < 2 || sources[0] != (enrich.Outcome{Source: "linkedin:nameAndLocation", OK: true}) {
+		t.Fatalf("stored sources = %+v, want the LinkedIn sections recorded", sources)
+	}
+	if last := sources[len(sources)-1]; last.Source != "fake" || last.OK || last.Error != "fake: second page unreachable" {
+		t.Errorf("fake source outcome = %+v, want its failure recorded", last)
+	}
+}
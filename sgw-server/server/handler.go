@@ -1,15 +1,42 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/background"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/cache"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/condense"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/enrich"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/i18n"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/latency"
 	"github.com/hemantsharma1498/segwise-assignment/pkg/openai"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/payload"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/persona"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/relevance"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scoring"
 	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/topics"
 	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+	"github.com/hemantsharma1498/segwise-assignment/store"
 	"log"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 )
 
+// maxGoalLength is how long the goal of a message may be; it is a purpose, not a brief.
+const maxGoalLength = 500
+
 func (s *Server) Home(w http.ResponseWriter, r *http.Request) {
+	submitted := time.Now()
+	version, ok := responseVersion(w, r)
+	if !ok {
+		return
+	}
 	d := &HomeReq{}
 	if err := utils.DecodeReqBody(r, d); err != nil {
 		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
@@ -19,44 +46,488 @@ func (s *Server) Home(w http.ResponseWriter, r *http.Request) {
 		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
 		return
 	}
+	if d.JobUrl != "" && scraper.JobPage(d.JobUrl) == "" {
+		utils.WriteResponse(w, "jobUrl is not a LinkedIn job posting", http.StatusBadRequest)
+		return
+	}
+	if len(d.Goal) > maxGoalLength {
+		utils.WriteResponse(w, i18n.Format("goal is longer than %d characters", maxGoalLength), http.StatusBadRequest)
+		return
+	}
+
+	// Without credentials only the public profile is read, there's no session to read a posting with
+	public := d.Password == ""
+	if public && d.JobUrl != "" {
+		utils.WriteResponse(w, "jobUrl needs the LinkedIn account's password", http.StatusBadRequest)
+		return
+	}
+
+	if !public && writeBusy(w, s.admitScrape()) {
+		return
+	}
+	scraper, release, err := s.PublicScraper(d.LinkedinUrl), func() {}, error(nil)
+	if !public {
+		scraper, release, err = s.acquireScraper(d.Email, d.Password, d.LinkedinUrl, job{name: "home"})
+	}
+	if errors.Is(err, errAccountBusy) {
+		utils.WriteResponse(w, "this LinkedIn account is busy, please try again shortly", http.StatusServiceUnavailable)
+		return
+	}
+	if writeCooling(w, err) || writeOpenBreaker(w, err, i18n.Format("logging in to this LinkedIn account")) || s.writeLoginError(w, d.Email, err) {
+		return
+	}
+	if err != nil {
+		log.Printf("error while logging in: %v\n", err)
+		utils.WriteResponse(w, "could not log in to LinkedIn, please try again later", 500)
+		return
+	}
+	scraping := s.observe("home", latency.Queue, submitted)
 
-	scraper, err := scraper.NewScraper(d.Email, d.Password, d.LinkedinUrl)
+	opts := s.settingsFor(d.Email)
+	sender := s.senderFor(r.Context(), scraper, d.Email)
+	if public {
+		// A public read would replace the sender's full profile with a reduced one
+		sender = s.storedSender(d.Email)
+	}
+	cost := &jobCost{}
+	posting, err := s.jobFor(r.Context(), scraper, d.JobUrl, cost)
+	if err != nil {
+		go release()
+		s.accountStopped(d.Email, job{name: "home"}, err)
+		utils.WriteResponse(w, "could not read the job posting, please check jobUrl and try again", 500)
+		return
+	}
+	pc, err := s.scrapeProspect(r.Context(), scraper, d.LinkedinUrl, sender != nil, opts.degradation, cost)
+	go release()
+	if writeCapped(w, err) {
+		return
+	}
 	if err != nil {
-		log.Printf("error while getting posts: %v\n", err)
+		// What was scraped before LinkedIn stopped the account is still worth a message
+		s.accountStopped(d.Email, job{name: "home"}, err)
 	}
 
-	if err = scraper.GetNameAndLocation(); err != nil {
-		log.Printf("error while getting name && location: %v\n", err)
+	pc.Job, pc.Goal = posting, strings.TrimSpace(d.Goal)
+	s.enrich(&pc, cost)
+	generating := s.observe("home", latency.Scrape, scraping)
+	profile := pc.Profile
+	prospect, msg, status, err := s.generate(pc, sender, opts, cost)
+	if writeOpenBreaker(w, err, i18n.Format("writing messages")) {
+		return
+	}
+	if err != nil {
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
 	}
-	if err = scraper.GetRecentPosts(); err != nil {
-		log.Printf("error while getting posts: %v\n", err)
+	s.observe("home", latency.Generate, generating)
+	s.observe("home", latency.Total, submitted)
+	s.saveProspect(&models.Prospect{
+		Owner:       d.Email,
+		LinkedinUrl: d.LinkedinUrl,
+		Profile:     profile,
+		Persona:     *prospect.Persona,
+		Enrichment:  pc.Enrichment,
+		Sources:     pc.Sources,
+		Job:         posting,
+		Goal:        pc.Goal,
+		Message:     msg,
+		Status:      status,
+		Score:       scoring.Score(profile, scoring.Criteria{Weights: opts.weights}),
+		Cost:        cost.report(s.LLMPrices),
+	})
+
+	paramsUsed := utils.GetUsedParams(profile)
+
+	jsonPosts, err := json.Marshal(profile.Posts)
+	if err != nil {
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
 	}
+	res := &HomeRes{SchemaVersion: payload.Current, Msg: msg, ParamsUsed: paramsUsed, RecentPosts: string(jsonPosts), Persona: *prospect.Persona, SharedBackground: prospect.SharedBackground, Sources: pc.Sources, Status: status}
+	s.checkResponse("message", res)
+	writeVersioned(w, version, res, 200)
+}
 
-	//If posts are less than 2, get user information
-	if len(scraper.Profile.Posts) <= 2 {
-		if err := scraper.GetExperiences(); err != nil {
-			log.Printf("error while getting experiences: %v\n", err)
+// ListProfiles returns the prospects scraped by a user, best score first. Passing
+// titles re-scores them against those target titles; seniority and function filter
+// by persona, topics by what the prospects posted about.
+func (s *Server) ListProfiles(w http.ResponseWriter, r *http.Request) {
+	version, ok := responseVersion(w, r)
+	if !ok {
+		return
+	}
+	email := r.URL.Query().Get("email")
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	prospects, err := s.Store.ListProspects(email)
+	if err != nil {
+		log.Printf("error while listing prospects: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+
+	filter := persona.Filter{}
+	for _, v := range splitQuery(r, "seniority") {
+		filter.Seniorities = append(filter.Seniorities, persona.Seniority(v))
+	}
+	for _, v := range splitQuery(r, "function") {
+		filter.Functions = append(filter.Functions, persona.Function(v))
+	}
+	matching := make([]*models.Prospect, 0, len(prospects))
+	for _, p := range prospects {
+		// Drafts are seen in their dry run batch
+		if !p.Draft && filter.Match(p.Persona) {
+			matching = append(matching, p)
 		}
-		if err := scraper.GetEducation(); err != nil {
-			log.Printf("error while getting education: %v\n", err)
-			utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+	}
+	prospects = matching
+
+	if wanted := splitQuery(r, "topics"); len(wanted) > 0 {
+		matching := make([]*models.Prospect, 0, len(prospects))
+		for _, p := range prospects {
+			if topics.Match(p.Profile.Topics, wanted) {
+				matching = append(matching, p)
+			}
+		}
+		prospects = matching
+	}
+
+	if titles := splitQuery(r, "titles"); len(titles) > 0 {
+		criteria := scoring.Criteria{Weights: s.settingsFor(email).weights, TargetTitles: titles}
+		for _, p := range prospects {
+			p.Score = scoring.Score(p.Profile, criteria)
 		}
 	}
-	go scraper.Close()
+	sortByScore(prospects)
+	for _, p := range prospects {
+		s.checkResponse("prospect", p)
+	}
+	writeVersioned(w, version, &ListProfilesRes{SchemaVersion: payload.Current, Profiles: prospects}, 200)
+}
 
-	msg, err := openai.GetMessage(*scraper.Profile, s.OpenAIApiKey)
+func (s *Server) Sender(w http.ResponseWriter, r *http.Request) {
+	d := &SenderReq{}
+	if err := utils.DecodeReqBody(r, d); err != nil {
+		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
+		return
+	}
+	if !utils.ValidEmail(d.Email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+
+	if writeBusy(w, s.admitScrape()) {
+		return
+	}
+	scraper, release, err := s.acquireScraper(d.Email, d.Password, d.LinkedinUrl, job{name: "sender"})
+	if errors.Is(err, errAccountBusy) {
+		utils.WriteResponse(w, "this LinkedIn account is busy, please try again shortly", http.StatusServiceUnavailable)
+		return
+	}
+	if writeCooling(w, err) || writeOpenBreaker(w, err, i18n.Format("logging in to this LinkedIn account")) || s.writeLoginError(w, d.Email, err) {
+		return
+	}
+	if err != nil {
+		log.Printf("error while logging in: %v\n", err)
+		utils.WriteResponse(w, "could not log in to LinkedIn, please try again later", 500)
+		return
+	}
+	defer release()
+
+	sender, err := s.scrapeSender(r.Context(), scraper, d.Email, d.LinkedinUrl)
+	if writeCapped(w, err) {
+		return
+	}
 	if err != nil {
 		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
 		return
 	}
+	utils.WriteResponse(w, &SenderRes{LinkedinUrl: sender.LinkedinUrl, Profile: sender.Profile, Missing: sender.Missing, ScrapedAt: sender.ScrapedAt}, 200)
+}
+
+// scrapeProspect scrapes the sections used for generation from linkedinUrl with an
+// already logged in scraper, within s.ScrapeBudget. policy picks the sections and the
+// fallbacks for thin ones. Failed and skipped sections are logged, left empty and recorded
+// in the context's Sources. full also fetches the policy's Full sections, for shared
+// background and ICP matching. The sections read are recorded in cost. The error is set
+// when LinkedIn stopped the account at a checkpoint or restricted it, or the account
+// reached a cap of scraper.Rate, the profile then holds what was scraped before.
+func (s *Server) scrapeProspect(ctx context.Context, sc Scraper, linkedinUrl string, full bool, policy DegradationPolicy, cost *jobCost) (enrich.ProspectContext, error) {
+	sc.SetProfileURL(linkedinUrl)
+	deadline := time.Now().Add(s.ScrapeBudget)
+
+	sections := policy.FirstPass(full)
+	if len(s.Enrichment.Sources) > 0 {
+		// Enrichment sources read the websites it lists
+		sections = inPageOrder(append(sections, scraper.SectionContactInfo), nil)
+	}
+	results := sc.ScrapeWithBudget(ctx, time.Until(deadline), sections...)
+	if fallback := policy.Fallback(sc.Profile(), sections); len(fallback) > 0 {
+		results = append(results, sc.ScrapeWithBudget(ctx, time.Until(deadline), fallback...)...)
+	}
+	cost.scraped(results)
+	var stopped error
+	for _, r := range results {
+		if r.Skipped {
+			log.Printf("skipped %s for %s, scrape budget exhausted\n", r.Section, linkedinUrl)
+		} else if r.Err != nil && !errors.Is(r.Err, scraper.ErrNotPublic) {
+			log.Printf("error while getting %s: %v\n", r.Section, r.Err)
+			var capped *scraper.CapError
+			if _, ok := accountEvent(r.Err); (ok || errors.As(r.Err, &capped)) && stopped == nil {
+				stopped = r.Err
+			}
+		}
+	}
+	profile := sc.Profile()
+	profile.Topics = topics.Extract(profile)
+	profile.LastActiveAt = profile.LastActivity()
+	return enrich.ProspectContext{Profile: profile, Sources: enrich.LinkedInOutcomes(results)}, stopped
+}
+
+// enrich asks the sources of s.Enrichment what they know about a scraped profile, adding
+// what they found and how each fared to pc. Failing sources are logged, a message is still
+// worth writing from what the others found. Websites summarized count towards cost.
+func (s *Server) enrich(pc *enrich.ProspectContext, cost *jobCost) {
+	if len(s.Enrichment.Sources) == 0 {
+		return
+	}
+	enrichments, outcomes, err := s.Enrichment.Run(withCost(context.Background(), cost), pc.Profile)
+	if err != nil {
+		log.Printf("error while enriching %s: %v\n", pc.Profile.Name, err)
+	}
+	pc.Enrichment = enrichments
+	pc.Sources = append(pc.Sources, outcomes...)
+}
 
-	paramsUsed := utils.GetUsedParams(*scraper.Profile)
+// jobFor scrapes the job posting at jobURL with an already logged in scraper, or returns
+// nil when no jobURL was given. Reading it is recorded in cost.
+func (s *Server) jobFor(ctx context.Context, sc Scraper, jobURL string, cost *jobCost) (*scraper.Job, error) {
+	if jobURL == "" {
+		return nil, nil
+	}
+	start := time.Now()
+	posting, err := sc.GetJob(ctx, jobURL)
+	cost.read(time.Since(start))
+	if err != nil {
+		log.Printf("error while getting job %s: %v\n", jobURL, err)
+	}
+	return posting, err
+}
 
-	jsonPosts, err := json.Marshal(scraper.Profile.Posts)
+// generate classifies a scraped profile and asks OpenAI for a connect message from its
+// context, the profile, its enrichment and the job it is about, with the owner's settings,
+// returning the prompt input alongside the message. Profiles with fewer than MinSignals
+// details get the generic fallback message instead, with the status saying so, and
+// OpenAI isn't asked. The LLM calls are recorded in cost.
+func (s *Server) generate(pc enrich.ProspectContext, sender *models.Sender, opts settings, cost *jobCost) (openai.Prospect, string, models.ProspectStatus, error) {
+	profile := pc.Profile
+	p, err := persona.ClassifyWithAssist(profile, s.personaAssist(opts.personaAssist, cost))
 	if err != nil {
-		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		log.Printf("error while classifying persona: %v\n", err)
+	}
+
+	// Persona, language and shared background are read off the whole profile, only the prompt is condensed
+	prospect := openai.Prospect{Persona: &p, Enrichment: pc.Enrichment, Job: pc.Job, Activity: openai.Recency(profile, time.Now()), Goal: pc.Goal}
+	if sender != nil {
+		prospect.Sender = openai.NewSenderPersona(sender.Profile)
+		prospect.SharedBackground = background.Shared(sender.Profile, profile)
+	}
+	if s.insufficient(pc, prospect.SharedBackground) {
+		prospect.Profile = profile
+		return prospect, fallbackMessage(pc, sender), models.ProspectInsufficientPersonalization, nil
+	}
+	prompt := profile
+	prompt.Posts = s.promptPosts(profile.Posts, pc.Goal, cost)
+	prospect.Profile = condense.Profile(prompt, s.PromptBudget)
+	if opts.nativeLanguage {
+		prospect.Language = openai.NativeLanguage(profile)
+	}
+
+	msg, usage, err := s.ProtectedLLM().GetMessage(prospect)
+	cost.llm(usage)
+	return prospect, msg, "", err
+}
+
+// promptPosts returns the posts a message may be written from: those within PostMaxAge,
+// ordered by relevance to goal when there is one. Posts that can't be embedded are ranked
+// by keywords instead. The embeddings are recorded in cost.
+func (s *Server) promptPosts(posts []scraper.Post, goal string, cost *jobCost) []scraper.Post {
+	posts = relevance.Recent(posts, s.PostMaxAge, time.Now())
+	if goal == "" || len(posts) < 2 || s.PostRanking == relevance.RankOff {
+		return posts
+	}
+	if s.PostRanking == relevance.RankEmbedding {
+		texts := []string{goal}
+		for _, p := range posts {
+			texts = append(texts, relevance.Text(p))
+		}
+		vectors, usage, err := s.ProtectedLLM().Embed(texts)
+		cost.llm(usage)
+		if err == nil && len(vectors) != len(texts) {
+			err = fmt.Errorf("got %d embeddings for %d texts", len(vectors), len(texts))
+		}
+		if err == nil {
+			return relevance.Rank(posts, relevance.SimilarityScores(vectors[0], vectors[1:]))
+		}
+		log.Printf("error while embedding posts, ranking them by keywords instead: %v\n", err)
+	}
+	return relevance.Rank(posts, relevance.KeywordScores(posts, goal))
+}
+
+func (s *Server) saveProspect(prospect *models.Prospect) {
+	id, err := utils.GenerateID()
+	if err != nil {
+		log.Printf("error while generating prospect id: %v\n", err)
+		return
+	}
+	prospect.ID = id
+	prospect.ScrapedAt = time.Now()
+	s.awaitApproval(prospect)
+	if err := s.Store.SaveProspect(prospect); err != nil {
+		log.Printf("error while saving prospect: %v\n", err)
 		return
 	}
-	res := &HomeRes{Msg: msg, ParamsUsed: paramsUsed, RecentPosts: string(jsonPosts)}
+
+	activity := Activity{Kind: ActivityMessageGenerated, Actor: prospect.Owner, ProspectID: prospect.ID, BatchID: prospect.BatchID, LinkedinUrl: prospect.LinkedinUrl}
+	switch {
+	case prospect.SkipReason != "":
+		activity.Kind, activity.Detail = ActivityProspectSkipped, prospect.SkipReason
+	case prospect.Error != "" || prospect.Message == "":
+		activity.Kind, activity.Detail = ActivityMessageFailed, prospect.Error
+	case prospect.Status != "":
+		activity.Detail = string(prospect.Status)
+	}
+	s.record(activity)
+}
+
+// splitQuery returns the comma separated values of a query parameter.
+func splitQuery(r *http.Request, name string) []string {
+	var values []string
+	for _, v := range strings.Split(r.URL.Query().Get(name), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func sortByScore(prospects []*models.Prospect) {
+	sort.SliceStable(prospects, func(i, j int) bool {
+		return prospects[i].Score.Total > prospects[j].Score.Total
+	})
+}
+
+// senderFor returns the stored sender profile for email, re-scraping it with the
+// already logged in scraper once it is older than models.SenderRefreshInterval.
+// It returns nil when the user has not onboarded a sender profile.
+func (s *Server) senderFor(ctx context.Context, sc Scraper, email string) *models.Sender {
+	sender := s.storedSender(email)
+	if sender == nil || !sender.NeedsRefresh() {
+		return sender
+	}
+
+	refreshed, err := s.scrapeSender(ctx, sc, email, sender.LinkedinUrl)
+	if err != nil {
+		log.Printf("error while refreshing sender, using stale profile: %v\n", err)
+		return sender
+	}
+	return refreshed
+}
+
+// storedSender returns the sender persona stored for email as it is, nil when there is none.
+func (s *Server) storedSender(email string) *models.Sender {
+	sender, err := s.Store.GetSender(email)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			log.Printf("error while getting sender: %v\n", err)
+		}
+		return nil
+	}
+	return sender
+}
+
+// senderSections are the sections of the user's own profile stored as their sender persona.
+var senderSections = []scraper.Section{
+	scraper.SectionNameAndLocation, scraper.SectionAbout, scraper.SectionExperience, scraper.SectionEducation,
+	scraper.SectionSkills, scraper.SectionCertifications, scraper.SectionRecommendations, scraper.SectionVolunteering,
+	scraper.SectionPublications, scraper.SectionPatents, scraper.SectionLanguages, scraper.SectionArticles, scraper.SectionComments,
+}
+
+// scrapeSender scrapes every section of the user's own profile and stores it as their sender
+// persona. Sections that fail are logged and recorded as Missing, only a failed name fails it.
+func (s *Server) scrapeSender(ctx context.Context, sc Scraper, email, linkedinUrl string) (*models.Sender, error) {
+	sc.SetProfileURL(linkedinUrl)
+	profile, err := sc.ScrapeAll(ctx, senderSections...)
+	var failed scraper.SectionErrors
+	if err != nil && !errors.As(err, &failed) {
+		log.Printf("error while getting sender: %v\n", err)
+		return nil, err
+	}
+	if err := failed.Failed(scraper.SectionNameAndLocation); err != nil {
+		log.Printf("error while getting sender name && location: %v\n", err)
+		return nil, err
+	}
+	if len(failed) > 0 {
+		log.Printf("error while getting sender sections, storing the rest: %v\n", failed)
+	}
+
+	sender := &models.Sender{Email: email, LinkedinUrl: linkedinUrl, Profile: profile, Missing: failed.Sections(), ScrapedAt: time.Now()}
+	if err := s.Store.SaveSender(sender); err != nil {
+		log.Printf("error while saving sender: %v\n", err)
+		return nil, err
+	}
+	return sender, nil
+}
+
+// personaAssist returns the LLM fallback of the persona classifier when enabled, recording
+// its calls in cost; cached answers cost nothing.
+func (s *Server) personaAssist(enabled bool, cost *jobCost) persona.Assist {
+	if !enabled {
+		return nil
+	}
+	classify := func(profile scraper.Profile) (persona.Persona, error) {
+		p, usage, err := s.ProtectedLLM().ClassifyPersona(profile)
+		cost.llm(usage)
+		return p, err
+	}
+	return func(profile scraper.Profile) (persona.Persona, error) {
+		// Assist only runs for titles the rules can't place, and those repeat ("Partner", "Member of Technical Staff")
+		title := strings.ToLower(persona.Classify(profile).Title)
+		if title == "" {
+			return classify(profile)
+		}
+		if p, ok := s.personaCache.Get(title); ok {
+			return p, nil
+		}
+		p, err := classify(profile)
+		if err == nil {
+			s.personaCache.Add(title, p)
+		}
+		return p, err
+	}
+}
+
+// Health reports whether the store can be read, with its schema version, for load
+// balancers and upgrades. A store another instance upgraded past this instance's
+// version can't be read, the health check fails until this instance is upgraded too.
+func (s *Server) Health(w http.ResponseWriter, r *http.Request) {
+	res := &HealthRes{Status: "ok", ProfileSchemaVersion: scraper.ProfileSchemaVersion}
+	version, err := s.Store.SchemaVersion()
+	if err != nil {
+		log.Printf("error while reading store for health check: %v\n", err)
+		res.Status = "store unavailable"
+		utils.WriteResponse(w, res, http.StatusServiceUnavailable)
+		return
+	}
+	res.SchemaVersion = version
 	utils.WriteResponse(w, res, 200)
 }
+
+// CacheStats reports hit and miss counters for the in-memory caches.
+func (s *Server) CacheStats(w http.ResponseWriter, r *http.Request) {
+	utils.WriteResponse(w, &CacheStatsRes{Caches: map[string]cache.Stats{
+		"persona": s.personaCache.Stats(),
+	}}, 200)
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestScrapeProspectStopsWithTheRequest(t *testing.T) {
+	s, _ := newTestServer(t)
+	sc, err := fake.Backend{SectionLatency: 10 * time.Second}.NewScraper("a@x.com", "secret", "")
+	if err != nil {
+		t.Fatalf("NewScraper: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	pc, _ := s.scrapeProspect(ctx, sc, "https://www.linkedin.com/in/someone/", true, DefaultDegradationPolicy, nil)
+	if took := time.Since(start); took > 5*time.Second {
+		t.Fatalf("scrape took %v after the request's deadline", took)
+	}
+	if len(pc.Sources) == 0 {
+		t.Fatal("no sources recorded")
+	}
+	for _, source := range pc.Sources {
+		if source.OK || source.Skipped || !strings.Contains(source.Error, context.DeadlineExceeded.Error()) {
+			t.Errorf("source %+v, want it stopped by the request's deadline", source)
+		}
+	}
+}
+
+// brokenScraper fails the sections of broken, scraping the rest as usual.
+type brokenScraper struct {
+	*fake.Scraper
+	broken map[scraper.Section]error
+}
+
+func (b *brokenScraper) ScrapeAll(ctx context.Context, sections ...scraper.Section) (scraper.Profile, error) {
+	profile, _ := b.Scraper.ScrapeAll(ctx, sections...)
+	var results []scraper.SectionResult
+	for _, section := range sections {
+		results = append(results, scraper.SectionResult{Section: section, Err: b.broken[section]})
+	}
+	return profile, scraper.Errors(results)
+}
+
+func TestScrapeSenderRecordsMissingSections(t *testing.T) {
+	s, _ := newTestServer(t)
+	sc, err := fake.Backend{}.NewScraper("a@x.com", "secret", "")
+	if err != nil {
+		t.Fatalf("NewScraper: %v", err)
+	}
+	url := fake.ProfileURL(fake.Profiles[0])
+	broken := &brokenScraper{Scraper: sc, broken: map[scraper.Section]error{scraper.SectionSkills: errors.New("timed out")}}
+
+	sender, err := s.scrapeSender(context.Background(), broken, "a@x.com", url)
+	if err != nil {
+		t.Fatalf("scrapeSender: %v", err)
+	}
+	if !reflect.DeepEqual(sender.Missing, []scraper.Section{scraper.SectionSkills}) || sender.Profile.Name != fake.Profiles[0].Name || len(sender.Profile.Experience) == 0 {
+		t.Errorf("sender = missing %v, name %q, %d roles; want everything but the skills", sender.Missing, sender.Profile.Name, len(sender.Profile.Experience))
+	}
+	if stored := s.storedSender("a@x.com"); stored == nil || len(stored.Missing) != 1 {
+		t.Errorf("stored sender = %+v, want the missing section recorded", stored)
+	}
+
+	broken.broken = map[scraper.Section]error{scraper.SectionNameAndLocation: errors.New("timed out")}
+	if _, err := s.scrapeSender(context.Background(), broken, "b@x.com", url); err == nil {
+		t.Error("scrapeSender stored a sender without a name")
+	}
+}
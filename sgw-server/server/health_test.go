@@ -0,0 +1,20 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+func TestHealthReportsSchemaVersions(t *testing.T) {
+	_, ts := newTestServer(t)
+	var res HealthRes
+	if code := call(t, ts, http.MethodGet, "/api/health", nil, &res); code != http.StatusOK {
+		t.Fatalf("GET /api/health: status %d", code)
+	}
+	if res.Status != "ok" || res.SchemaVersion != store.SchemaVersion || res.ProfileSchemaVersion != scraper.ProfileSchemaVersion {
+		t.Errorf("health = %+v", res)
+	}
+}
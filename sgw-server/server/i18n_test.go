@@ -0,0 +1,107 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/i18n"
+)
+
+// getIn sends a GET accepting lang and returns the response with its body read.
+func getIn(t *testing.T, ts *httptest.Server, path, lang string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, ts.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept-Language", lang)
+	res, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return res, string(body)
+}
+
+func TestResponsesFollowAcceptLanguage(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	res, body := getIn(t, ts, "/api/stats/breakers?email=nope", "es-MX,es;q=0.9,en;q=0.5")
+	var msg string
+	if err := json.Unmarshal([]byte(body), &msg); err != nil {
+		t.Fatalf("decode %s: %v", body, err)
+	}
+	if msg != "correo electrónico no válido" || res.Header.Get("Content-Language") != "es" {
+		t.Errorf("Spanish error = %q, Content-Language %q", msg, res.Header.Get("Content-Language"))
+	}
+	if _, body := getIn(t, ts, "/api/stats/breakers?email=nope", "ja"); body != "\"invalid email\"\n" {
+		t.Errorf("unsupported language answered %s, want English", body)
+	}
+
+	// Pages are rendered in the reader's language, the message itself is left as written
+	s.PublicBaseURL = "https://segwise.example.com"
+	home(t, ts, "a@x.com", "https://www.linkedin.com/in/one/")
+	var link ShareLinkRes
+	call(t, ts, http.MethodPost, "/api/prospects/"+s.activity.snapshot()[0].ProspectID+"/share", &ShareLinkReq{Email: "a@x.com"}, &link)
+	page := strings.TrimPrefix(link.Url, "https://segwise.example.com")
+	if _, html := getIn(t, ts, page, "de"); !strings.Contains(html, `<html lang="de">`) || !strings.Contains(html, "Vorgeschlagene Nachricht für") {
+		t.Errorf("German share page:\n%s", html)
+	}
+	if _, html := getIn(t, ts, page, ""); !strings.Contains(html, `<html lang="en">`) || !strings.Contains(html, "Suggested message for") {
+		t.Errorf("default share page:\n%s", html)
+	}
+	if res, text := getIn(t, ts, "/m/bogus", "fr"); res.StatusCode != http.StatusNotFound || !strings.Contains(text, "Lien de partage introuvable.") {
+		t.Errorf("French missing link: status %d, %q", res.StatusCode, text)
+	}
+}
+
+// Messages the server writes as literals, to responses and page templates.
+var literalMessages = regexp.MustCompile("(?:utils\\.WriteResponse\\(w, |i18n\\.Format\\(|i18n\\.T\\(lang, |\\{\\{t )(\"(?:[^\"\\\\]|\\\\.)*\"|`[^`]*`)")
+
+func TestMessagesAreTranslated(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := 0
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range literalMessages.FindAllStringSubmatch(string(src), -1) {
+			msg, err := strconv.Unquote(m[1])
+			if err != nil {
+				// A template literal inside a raw string, quoted as templates quote
+				msg = strings.Trim(m[1], `"`)
+			}
+			if msg == "%s" {
+				continue
+			}
+			seen++
+			for _, lang := range i18n.Languages[1:] {
+				if i18n.T(lang, msg) == msg {
+					t.Errorf("%s: %q has no %s translation", file, msg, lang)
+				}
+			}
+		}
+	}
+	if seen < 50 {
+		t.Errorf("found %d messages, the pattern no longer matches how they are written", seen)
+	}
+}
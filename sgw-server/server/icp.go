@@ -0,0 +1,85 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+func (s *Server) CreateICPFilter(w http.ResponseWriter, r *http.Request) {
+	d := &ICPFilterReq{}
+	if err := utils.DecodeReqBody(r, d); err != nil {
+		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
+		return
+	}
+	if !utils.ValidEmail(d.Email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(d.Name) == "" {
+		utils.WriteResponse(w, "name is required", http.StatusBadRequest)
+		return
+	}
+
+	id, err := utils.GenerateID()
+	if err != nil {
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	filter := &models.ICPFilter{ID: id, Owner: d.Email, Name: d.Name, Filter: d.Filter, CreatedAt: time.Now()}
+	if err := s.Store.SaveICPFilter(filter); err != nil {
+		log.Printf("error while saving icp filter: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	utils.WriteResponse(w, filter, http.StatusCreated)
+}
+
+func (s *Server) ListICPFilters(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	filters, err := s.Store.ListICPFilters(email)
+	if err != nil {
+		log.Printf("error while listing icp filters: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	utils.WriteResponse(w, &ListICPFiltersRes{Filters: filters}, 200)
+}
+
+func (s *Server) DeleteICPFilter(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.ownedICPFilter(w, r.PathValue("id"), r.URL.Query().Get("email")); !ok {
+		return
+	}
+	if err := s.Store.DeleteICPFilter(r.PathValue("id")); err != nil {
+		log.Printf("error while deleting icp filter: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ownedICPFilter loads an ICP filter belonging to email, writing the error response
+// and returning false when it doesn't exist or belongs to someone else.
+func (s *Server) ownedICPFilter(w http.ResponseWriter, id, email string) (*models.ICPFilter, bool) {
+	filter, err := s.Store.GetICPFilter(id)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Printf("error while getting icp filter: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return nil, false
+	}
+	if err != nil || !strings.EqualFold(filter.Owner, email) {
+		utils.WriteResponse(w, "icp filter not found", http.StatusNotFound)
+		return nil, false
+	}
+	return filter, true
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+)
+
+const testJobURL = "https://www.linkedin.com/jobs/view/senior-data-engineer-at-moonfrog-labs-4012345678/?refId=abc"
+
+func TestHomeWritesAboutTheJob(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	var res HomeRes
+	req := &HomeReq{Email: "a@x.com", Password: "secret", LinkedinUrl: "https://www.linkedin.com/in/one/", JobUrl: testJobURL}
+	if code := call(t, ts, http.MethodPost, "/api/home", req, &res); code != http.StatusOK {
+		t.Fatalf("POST /api/home: status %d", code)
+	}
+	if !strings.Contains(res.Msg, "Senior Data Engineer role at Moonfrog Labs") {
+		t.Errorf("message %q is not about the job", res.Msg)
+	}
+
+	prospects, err := s.Store.ListProspects("a@x.com")
+	if err != nil || len(prospects) != 1 {
+		t.Fatalf("ListProspects = %d prospects, %v", len(prospects), err)
+	}
+	if job := prospects[0].Job; job == nil || job.URL != "https://www.linkedin.com/jobs/view/4012345678/" || len(job.HiringTeam) != 1 {
+		t.Errorf("stored job = %+v", job)
+	}
+
+	req.JobUrl = "https://www.linkedin.com/in/not-a-job/"
+	if code := call(t, ts, http.MethodPost, "/api/home", req, nil); code != http.StatusBadRequest {
+		t.Errorf("profile URL as jobUrl: status %d, want 400", code)
+	}
+}
+
+func TestBatchWritesEveryMessageAboutTheJob(t *testing.T) {
+	_, ts := newTestServer(t)
+
+	var created CreateBatchRes
+	req := &BatchReq{Email: "a@x.com", Password: "secret", LinkedinUrls: []string{"https://www.linkedin.com/in/one/", "https://www.linkedin.com/in/two/"}, JobUrl: testJobURL}
+	if code := call(t, ts, http.MethodPost, "/api/batches", req, &created); code != http.StatusAccepted {
+		t.Fatalf("POST /api/batches: status %d", code)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	var batch BatchRes
+	for batch.Batch == nil || batch.Status != models.BatchDone {
+		if time.Now().After(deadline) {
+			t.Fatal("batch not done after 5s")
+		}
+		if code := call(t, ts, http.MethodGet, "/api/batches/"+created.ID+"?email=a@x.com", nil, &batch); code != http.StatusOK {
+			t.Fatalf("GET batch: status %d", code)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if batch.JobURL != testJobURL || len(batch.Results) != 2 {
+		t.Fatalf("batch = %+v", batch)
+	}
+	for _, p := range batch.Results {
+		if p.Job == nil || p.Job.Title != "Senior Data Engineer" || !strings.Contains(p.Message, "Senior Data Engineer") {
+			t.Errorf("prospect %s: job %+v, message %q", p.LinkedinUrl, p.Job, p.Message)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/latency"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+// observe records that a stage of job has taken since start, and returns now for the next stage to start from.
+func (s *Server) observe(job string, stage latency.Stage, start time.Time) time.Time {
+	now := time.Now()
+	s.latencies.Observe(job, stage, now.Sub(start))
+	return now
+}
+
+// LatencyStats reports percentiles of how long jobs took on this instance, from submission
+// until their message was ready and by stage, over their latest runs.
+func (s *Server) LatencyStats(w http.ResponseWriter, r *http.Request) {
+	utils.WriteResponse(w, &LatencyRes{Jobs: s.latencies.Stats()}, 200)
+}
+
+// Metrics exposes the job latencies in the Prometheus text format, as summaries labeled with
+// job and stage, and the browsers in use against their limit, which autoscalers scale on.
+func (s *Server) Metrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := s.latencies.WritePrometheus(w, "segwise_job_latency_seconds", "How long jobs took from submission until their message was ready, by stage."); err != nil {
+		log.Printf("error while writing metrics: %v\n", err)
+	}
+	s.load.mu.Lock()
+	browsers, limit := s.load.browsers, s.load.pressureLimit
+	s.load.mu.Unlock()
+	if limit == 0 {
+		limit = s.MaxBrowsers
+	}
+	fmt.Fprintf(w, "# HELP segwise_browsers_in_use Scrapers requests and batches hold.\n# TYPE segwise_browsers_in_use gauge\nsegwise_browsers_in_use %d\n", browsers)
+	fmt.Fprintf(w, "# HELP segwise_browser_limit Scrapers that may be in use, lowered while memory is low; 0 doesn't limit.\n# TYPE segwise_browser_limit gauge\nsegwise_browser_limit %d\n", limit)
+}
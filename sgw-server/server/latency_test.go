@@ -0,0 +1,42 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/latency"
+)
+
+func TestLatencyStats(t *testing.T) {
+	_, ts := newTestServer(t)
+	home(t, ts, "a@x.com", "https://www.linkedin.com/in/one/")
+	runTestBatch(t, ts, "a@x.com", "https://www.linkedin.com/in/one/", "https://www.linkedin.com/in/two/")
+
+	var res LatencyRes
+	if code := call(t, ts, http.MethodGet, "/api/stats/latency", nil, &res); code != http.StatusOK {
+		t.Fatalf("GET /api/stats/latency: status %d", code)
+	}
+	for _, stage := range []latency.Stage{latency.Queue, latency.Scrape, latency.Generate, latency.Total} {
+		if got := res.Jobs["home"][stage].Count; got != 1 {
+			t.Errorf("home %s observed %d times, want 1", stage, got)
+		}
+		if got := res.Jobs["batch"][stage].Count; got != 2 {
+			t.Errorf("batch %s observed %d times, want once per profile", stage, got)
+		}
+	}
+	if total, scrape := res.Jobs["batch"][latency.Total], res.Jobs["batch"][latency.Scrape]; total.MaxMs < scrape.MaxMs {
+		t.Errorf("batch total %+v is shorter than its scrape %+v", total, scrape)
+	}
+
+	metrics, err := ts.Client().Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer metrics.Body.Close()
+	body, _ := io.ReadAll(metrics.Body)
+	if !strings.Contains(string(body), `segwise_job_latency_seconds_count{job="batch",stage="total"} 2`) {
+		t.Errorf("/metrics has no batch total count:\n%s", body)
+	}
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+const (
+	// leaseTTL bounds how long the work of an instance that died blocks the others.
+	leaseTTL = time.Minute
+	// accountWait is how long a request waits for a LinkedIn account that is in use.
+	accountWait = 2 * time.Minute
+	// accountPoll is how often a waiting request retries the account lease.
+	accountPoll = 250 * time.Millisecond
+)
+
+// errAccountBusy is returned by acquireScraper when the account stayed in use for accountWait.
+var errAccountBusy = errors.New("linkedin account is in use by another request")
+
+// newInstanceID names this process as a lease holder; the hostname makes it recognisable in the store.
+func newInstanceID() string {
+	host, _ := os.Hostname()
+	id, err := utils.GenerateID()
+	if err != nil {
+		// Only has to differ from the other instances sharing the store
+		id = fmt.Sprintf("%d-%d", os.Getpid(), time.Now().UnixNano())
+	}
+	return host + "-" + id
+}
+
+// holdLease acquires the named lease for holder and keeps renewing it until the returned
+// function releases it. ok is false when another holder has it.
+func (s *Server) holdLease(name, holder string) (release func(), ok bool, err error) {
+	ok, err = s.Store.AcquireLease(name, holder, leaseTTL)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(leaseTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if ok, err := s.Store.AcquireLease(name, holder, leaseTTL); err != nil || !ok {
+					log.Printf("error while renewing lease %s (held: %t): %v\n", name, ok, err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		if err := s.Store.ReleaseLease(name, holder); err != nil {
+			log.Printf("error while releasing lease %s: %v\n", name, err)
+		}
+	}, true, nil
+}
+
+// holdAccount waits up to accountWait for the LinkedIn account's lease, so an account is
+// only used by one request at a time across every instance sharing the store.
+func (s *Server) holdAccount(email string) (func(), error) {
+	token, err := utils.GenerateID()
+	if err != nil {
+		return nil, err
+	}
+	holder := s.InstanceID + "/" + token
+	deadline := time.Now().Add(accountWait)
+	for {
+		release, ok, err := s.holdLease(accountLease(email), holder)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return release, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, errAccountBusy
+		}
+		time.Sleep(accountPoll)
+	}
+}
+
+func accountLease(email string) string {
+	return "account:" + key(email)
+}
+
+func batchLease(id string) string {
+	return "batch:" + id
+}
+
+func regenerationLease(id string) string {
+	return "regeneration:" + id
+}
+
+func campaignLease(id string) string {
+	return "campaign:" + id
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+// sharedStores opens n stores on one file, as instances sharing a volume would.
+func sharedStores(t *testing.T, n int) []*store.Store {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "segwise.json")
+	stores := make([]*store.Store, n)
+	for i := range stores {
+		st, err := store.NewStore(path)
+		if err != nil {
+			t.Fatalf("NewStore: %v", err)
+		}
+		t.Cleanup(func() { st.Close() })
+		stores[i] = st
+	}
+	return stores
+}
+
+func TestRecoverInterruptedSkipsLeasedWork(t *testing.T) {
+	stores := sharedStores(t, 2)
+	running, restarted := InitServer("unused", stores[0]), InitServer("unused", stores[1])
+
+	release, ok, err := running.holdLease(batchLease("live"), running.InstanceID)
+	if err != nil || !ok {
+		t.Fatalf("holdLease = %t, %v", ok, err)
+	}
+	defer release()
+	for _, id := range []string{"live", "orphan"} {
+		if err := running.Store.SaveBatch(&models.Batch{ID: id, Owner: "a@x.com", Status: models.BatchRunning}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := restarted.RecoverInterrupted(); err != nil {
+		t.Fatalf("RecoverInterrupted: %v", err)
+	}
+	if b, _ := restarted.Store.GetBatch("live"); b.Status != models.BatchRunning {
+		t.Errorf("a batch another instance is running was marked %s", b.Status)
+	}
+	if b, _ := restarted.Store.GetBatch("orphan"); b.Status != models.BatchFailed {
+		t.Errorf("an orphaned batch is %s, want failed", b.Status)
+	}
+}
+
+func TestAccountUsedByOneInstanceAtATime(t *testing.T) {
+	stores := sharedStores(t, 2)
+	a, b := InitServer("unused", stores[0]), InitServer("unused", stores[1])
+
+	releaseA, err := a.holdAccount("A@x.com")
+	if err != nil {
+		t.Fatalf("holdAccount: %v", err)
+	}
+	acquired := make(chan time.Time)
+	go func() {
+		releaseB, err := b.holdAccount("a@x.com")
+		if err != nil {
+			t.Error(err)
+			close(acquired)
+			return
+		}
+		acquired <- time.Now()
+		releaseB()
+	}()
+
+	time.Sleep(2 * accountPoll)
+	releasedAt := time.Now()
+	releaseA()
+	if at := <-acquired; at.Before(releasedAt) {
+		t.Error("the second instance got the account while the first held it")
+	}
+}
+
+func TestRelayRunsOnOneInstance(t *testing.T) {
+	stores := sharedStores(t, 2)
+	hook, url := newWebhook(t)
+	var servers []*Server
+	for _, st := range stores {
+		s := InitServer("unused", st)
+		s.WebhookURL = url
+		servers = append(servers, s)
+	}
+
+	var wg sync.WaitGroup
+	for _, s := range servers {
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(s *Server) {
+				defer wg.Done()
+				s.notify(models.EventSelectorDrift, "experience has 0 entries")
+			}(s)
+		}
+	}
+	wg.Wait()
+	for _, s := range servers {
+		stop := s.StartRelay(5 * time.Millisecond)
+		defer stop()
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(hook.received()) < 20 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	received := hook.received()
+	if len(received) != 20 {
+		t.Fatalf("%d distinct events delivered, want 20", len(received))
+	}
+	for id, n := range received {
+		if n != 1 {
+			t.Errorf("event %s delivered %d times", id, n)
+		}
+	}
+}
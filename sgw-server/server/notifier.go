@@ -0,0 +1,244 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+
+	"github.com/hemantsharma1498/segwise-assignment/config"
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/payload"
+)
+
+/*
+	Notifier delivers events to one notification channel.
+
+The outbox relay calls Notify per queued entry and retries it with a backoff
+until it returns nil, so Notify may be called again for an event that was
+already delivered; receivers that care deduplicate on the event ID. A new
+channel, such as Microsoft Teams, implements Notifier, adds its destination
+to models.Destinations so routes can name it, and is registered in
+Server.Notifiers.
+*/
+type Notifier interface {
+	Notify(ctx context.Context, event models.Event) error
+}
+
+// DefaultNotifyRoutes send account events to every channel, including the account alert
+// webhook, and every other event to every channel but the account alert webhook.
+var DefaultNotifyRoutes = []config.Route{
+	{Event: "account.*", Channels: []models.Destination{models.DestinationWebhook, models.DestinationSlack, models.DestinationAccountAlert, models.DestinationEmail}},
+	{Event: "*", Channels: []models.Destination{models.DestinationWebhook, models.DestinationSlack, models.DestinationEmail}},
+}
+
+// notifiers returns the channels events can be delivered to, those set up by URL and
+// Server.Notifiers, which take precedence.
+func (s *Server) notifiers() map[models.Destination]Notifier {
+	n := map[models.Destination]Notifier{}
+	if s.WebhookURL != "" {
+		n[models.DestinationWebhook] = &webhookNotifier{url: s.WebhookURL, version: s.payloadVersion(models.DestinationWebhook)}
+	}
+	if s.SlackWebhookURL != "" {
+		n[models.DestinationSlack] = &slackNotifier{url: s.SlackWebhookURL}
+	}
+	if s.AccountAlertURL != "" {
+		n[models.DestinationAccountAlert] = &webhookNotifier{url: s.AccountAlertURL, version: s.payloadVersion(models.DestinationAccountAlert)}
+	}
+	for d, notifier := range s.Notifiers {
+		n[d] = notifier
+	}
+	return n
+}
+
+// webhookNotifier posts events as JSON in the payload version the receiver pinned.
+type webhookNotifier struct {
+	url     string
+	version int
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, event models.Event) error {
+	event.SchemaVersion = payload.Current
+	raw, err := json.Marshal(event)
+	if err == nil {
+		raw, err = payload.Downgrade(raw, n.version)
+	}
+	if err != nil {
+		return err
+	}
+	return postEvent(ctx, n.url, raw, event, func(h http.Header) { payload.SetHeaders(h, n.version) })
+}
+
+// slackNotifier posts events to a Slack incoming webhook as a line of text.
+type slackNotifier struct {
+	url string
+}
+
+func (n *slackNotifier) Notify(ctx context.Context, event models.Event) error {
+	raw, err := json.Marshal(map[string]string{"text": slackText(event)})
+	if err != nil {
+		return err
+	}
+	return postEvent(ctx, n.url, raw, event, func(http.Header) {})
+}
+
+func postEvent(ctx context.Context, url string, raw []byte, event models.Event, headers func(http.Header)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	headers(req.Header)
+	req.Header.Set("X-Segwise-Event-Id", event.ID)
+	if event.Priority != "" {
+		req.Header.Set("X-Segwise-Priority", event.Priority)
+	}
+
+	res, err := outboxClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("destination responded with %s", res.Status)
+	}
+	return nil
+}
+
+/*
+	EmailNotifier mails events to their owner through an SMTP server.
+
+Events without an owner, such as selector drift, have no one to mail and are
+never routed to email, Notify drops any it is given. The connection is
+upgraded with STARTTLS when the server offers it, and authenticates with
+Username and Password when Username is set.
+*/
+type EmailNotifier struct {
+	// Addr is the SMTP server's host:port
+	Addr     string
+	From     string
+	Username string
+	Password string
+
+	// send replaces the SMTP conversation in tests
+	send func(ctx context.Context, to string, msg []byte) error
+}
+
+func (n *EmailNotifier) Notify(ctx context.Context, event models.Event) error {
+	if event.Owner == "" {
+		return nil
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\nTo: %s\r\nSubject: %s\r\n", n.From, event.Owner, eventSubject(event))
+	fmt.Fprintf(&msg, "Message-ID: <%s@segwise>\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n", event.ID)
+	if event.Priority == models.PriorityHigh {
+		msg.WriteString("Importance: high\r\n")
+	}
+	fmt.Fprintf(&msg, "\r\n%s\r\n", eventText(event))
+
+	if n.send != nil {
+		return n.send(ctx, event.Owner, msg.Bytes())
+	}
+	return n.sendSMTP(ctx, event.Owner, msg.Bytes())
+}
+
+func (n *EmailNotifier) sendSMTP(ctx context.Context, to string, msg []byte) error {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", n.Addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	host, _, _ := net.SplitHostPort(n.Addr)
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if n.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", n.Username, n.Password, host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(n.From); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func slackText(e models.Event) string {
+	switch e.Kind {
+	case models.EventAccountOTPRequired:
+		return ":key: " + eventText(e)
+	case models.EventAccountCheckpoint, models.EventAccountRestricted, models.EventAccountBotDetected:
+		return ":rotating_light: " + eventText(e)
+	}
+	return eventText(e)
+}
+
+func eventText(e models.Event) string {
+	switch e.Kind {
+	case models.EventBatchFailed:
+		return fmt.Sprintf("Batch %s for %s failed: %s", e.BatchID, e.Owner, e.Error)
+	case models.EventSelectorDrift:
+		return "LinkedIn markup may have changed, the drift check profile came back incomplete: " + e.Error
+	case models.EventAccountCheckpoint:
+		if e.VerifyURL != "" {
+			return fmt.Sprintf("LinkedIn stopped %s at a security checkpoint during %s, solve it from your browser before %s: %s", e.Owner, jobText(e), e.VerifyBy.Format("15:04 MST"), e.VerifyURL)
+		}
+		return fmt.Sprintf("LinkedIn stopped %s at a security checkpoint during %s, log in by hand to clear it: %s", e.Owner, jobText(e), e.Error)
+	case models.EventAccountRestricted:
+		return fmt.Sprintf("LinkedIn restricted %s during %s, the account is cooling off and its batches are paused: %s", e.Owner, jobText(e), e.Error)
+	case models.EventAccountOTPRequired:
+		return fmt.Sprintf("%s during %s of %s, POST it as {\"code\": \"...\"} to %s before %s", e.Error, jobText(e), e.Owner, e.VerifyURL, e.VerifyBy.Format("15:04 MST"))
+	case models.EventAccountBotDetected:
+		return fmt.Sprintf("LinkedIn flagged %s as automated during %s, the account is cooling off and its batches are paused: %s", e.Owner, jobText(e), e.Error)
+	}
+	return fmt.Sprintf("Batch %s for %s is done, %d prospects scraped", e.BatchID, e.Owner, e.Prospects)
+}
+
+func eventSubject(e models.Event) string {
+	switch e.Kind {
+	case models.EventBatchDone:
+		return "Batch " + e.BatchID + " is done"
+	case models.EventBatchFailed:
+		return "Batch " + e.BatchID + " failed"
+	case models.EventSelectorDrift:
+		return "LinkedIn markup may have changed"
+	case models.EventAccountOTPRequired:
+		return "LinkedIn needs a verification code for " + e.Owner
+	}
+	// Account events, the ones an owner has to act on
+	return "Action needed: LinkedIn stopped " + e.Owner
+}
+
+func jobText(e models.Event) string {
+	if e.BatchID != "" {
+		return "batch " + e.BatchID
+	}
+	return e.Job
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/openapi"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/payload"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+var (
+	ownerEmail = openapi.Param{Name: "email", Required: true, Description: "The user whose data it is"}
+	busy       = reflect.TypeFor[BusyRes]()
+)
+
+// apiRoutes are the JSON operations the router registers, as the OpenAPI document and
+// the generated client describe them. The share, verification, SMS and metrics endpoints
+// answer with HTML, forms or text and are left out.
+var apiRoutes = []openapi.Route{
+	{Method: http.MethodPost, Path: "/api/home", ID: "home", Summary: "scrapes a prospect and generates the connect message", Request: reflect.TypeFor[HomeReq](), Response: reflect.TypeFor[HomeRes](), Busy: busy},
+	{Method: http.MethodPost, Path: "/api/sender", ID: "sender", Summary: "scrapes the user's own profile", Request: reflect.TypeFor[SenderReq](), Response: reflect.TypeFor[SenderRes](), Busy: busy},
+	{Method: http.MethodPost, Path: "/api/search", ID: "searchPeople", Summary: "runs a people search with the user's LinkedIn account", Request: reflect.TypeFor[SearchReq](), Response: reflect.TypeFor[SearchRes](), Busy: busy},
+	{Method: http.MethodGet, Path: "/api/profiles", ID: "listProfiles", Summary: "lists the user's prospects", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[ListProfilesRes]()},
+	{Method: http.MethodPost, Path: "/api/batches", ID: "createBatch", Summary: "starts scraping and scoring a batch of profiles", Request: reflect.TypeFor[BatchReq](), Response: reflect.TypeFor[CreateBatchRes](), Status: http.StatusAccepted, Busy: busy},
+	{Method: http.MethodGet, Path: "/api/batches/{id}", ID: "getBatch", Summary: "returns a batch and the prospects it scraped", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[BatchRes]()},
+	{Method: http.MethodPost, Path: "/api/icp-filters", ID: "createICPFilter", Summary: "saves an ICP filter", Request: reflect.TypeFor[ICPFilterReq](), Response: reflect.TypeFor[models.ICPFilter](), Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/api/icp-filters", ID: "listICPFilters", Summary: "lists the user's ICP filters", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[ListICPFiltersRes]()},
+	{Method: http.MethodDelete, Path: "/api/icp-filters/{id}", ID: "deleteICPFilter", Summary: "deletes an ICP filter", Query: []openapi.Param{ownerEmail}, Status: http.StatusNoContent},
+	{Method: http.MethodPost, Path: "/api/campaigns", ID: "createCampaign", Summary: "saves a people search to source prospects from", Request: reflect.TypeFor[CampaignReq](), Response: reflect.TypeFor[models.Campaign](), Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/api/campaigns", ID: "listCampaigns", Summary: "lists the user's campaigns", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[ListCampaignsRes]()},
+	{Method: http.MethodGet, Path: "/api/campaigns/{id}", ID: "getCampaign", Summary: "returns a campaign", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[models.Campaign]()},
+	{Method: http.MethodPost, Path: "/api/campaigns/{id}/source", ID: "sourceCampaign", Summary: "sources more search pages of a campaign", Request: reflect.TypeFor[SourceCampaignReq](), Response: reflect.TypeFor[SourceCampaignRes](), Busy: busy},
+	{Method: http.MethodGet, Path: "/api/campaigns/{id}/cost", ID: "getCampaignCost", Summary: "adds up what the campaign's batches cost", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[CampaignCostRes]()},
+	{Method: http.MethodGet, Path: "/api/settings", ID: "getSettings", Summary: "returns the user's saved overrides of the generation settings", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[models.Settings]()},
+	{Method: http.MethodPut, Path: "/api/settings", ID: "saveSettings", Summary: "replaces the user's overrides of the generation settings", Request: reflect.TypeFor[SettingsReq](), Response: reflect.TypeFor[models.Settings]()},
+	{Method: http.MethodGet, Path: "/api/cooldown", ID: "getCooldown", Summary: "returns the cooldown the user's LinkedIn account is in", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[models.Cooldown]()},
+	{Method: http.MethodDelete, Path: "/api/cooldown", ID: "endCooldown", Summary: "lifts the cooldown of the user's LinkedIn account early", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[string]()},
+	{Method: http.MethodGet, Path: "/api/config-bundle", ID: "exportBundle", Summary: "exports the user's configuration", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[ConfigBundle]()},
+	{Method: http.MethodPost, Path: "/api/config-bundle", ID: "importBundle", Summary: "imports a configuration exported from another deployment", Request: reflect.TypeFor[ImportBundleReq](), Response: reflect.TypeFor[ImportBundleRes]()},
+	{Method: http.MethodPost, Path: "/api/regenerations", ID: "createRegeneration", Summary: "starts re-generating the messages of a batch or of all the user's prospects", Request: reflect.TypeFor[RegenerationReq](), Response: reflect.TypeFor[CreateRegenerationRes](), Status: http.StatusAccepted},
+	{Method: http.MethodGet, Path: "/api/regenerations/{id}", ID: "getRegeneration", Summary: "returns a regeneration and the messages it generated", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[models.Regeneration]()},
+	{Method: http.MethodPost, Path: "/api/regenerations/{id}/apply", ID: "applyRegeneration", Summary: "replaces prospects' messages with the regenerated ones", Request: reflect.TypeFor[ApplyRegenerationReq](), Response: reflect.TypeFor[ApplyRegenerationRes]()},
+	{Method: http.MethodPost, Path: "/api/prospects/{id}/share", ID: "createShareLink", Summary: "signs a link to a prospect's message", Request: reflect.TypeFor[ShareLinkReq](), Response: reflect.TypeFor[ShareLinkRes](), Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/api/prospects/{id}/timeline", ID: "prospectTimeline", Summary: "returns what happened to a prospect", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[TimelineRes]()},
+	{Method: http.MethodPost, Path: "/api/prospects/{id}/review", ID: "reviewProspect", Summary: "approves or rejects a pending message", Request: reflect.TypeFor[ReviewReq](), Response: reflect.TypeFor[models.Prospect]()},
+	{Method: http.MethodGet, Path: "/api/verifications", ID: "listVerifications", Summary: "lists the checkpoints the user's logins wait at", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[VerificationsRes]()},
+	{Method: http.MethodGet, Path: "/api/otp", ID: "listOTPRequests", Summary: "lists the logins waiting for a verification code", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[OTPRequestsRes]()},
+	{Method: http.MethodPost, Path: "/api/otp/{token}", ID: "submitOTP", Summary: "hands a verification code to the login waiting for it", Request: reflect.TypeFor[OTPReq](), Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/api/approvals", ID: "listApprovals", Summary: "lists the messages waiting for a reviewer", Query: []openapi.Param{{Name: "email", Required: true, Description: "The reviewer"}, {Name: "owner", Description: "Only this user's messages"}}, Response: reflect.TypeFor[ListProfilesRes]()},
+	{Method: http.MethodGet, Path: "/api/teams/{team}/activity", ID: "teamActivity", Summary: "returns what a team's members recently did", Query: []openapi.Param{{Name: "email", Required: true, Description: "A member of the team"}}, Response: reflect.TypeFor[ActivityRes]()},
+	{Method: http.MethodGet, Path: "/api/stats/caches", ID: "cacheStats", Summary: "returns the hit rates of the caches", Response: reflect.TypeFor[CacheStatsRes]()},
+	{Method: http.MethodGet, Path: "/api/stats/breakers", ID: "breakerStats", Summary: "returns the state of the circuit breakers", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[BreakersRes]()},
+	{Method: http.MethodGet, Path: "/api/stats/latency", ID: "latencyStats", Summary: "returns the latency percentiles of jobs by stage", Response: reflect.TypeFor[LatencyRes]()},
+	{Method: http.MethodGet, Path: "/api/schemas", ID: "listSchemas", Summary: "lists the published JSON Schemas", Response: reflect.TypeFor[SchemasRes]()},
+	{Method: http.MethodGet, Path: "/api/schemas/{name}", ID: "getSchema", Summary: "returns a published JSON Schema", Response: reflect.TypeFor[map[string]any]()},
+	{Method: http.MethodPost, Path: "/api/schemas/{name}/validate", ID: "validateDocument", Summary: "validates a document against a published JSON Schema", Request: reflect.TypeFor[map[string]any](), Response: reflect.TypeFor[ValidationRes]()},
+	{Method: http.MethodGet, Path: "/api/support/users/{email}", ID: "viewAsUser", Summary: "shows support staff a user's data, recording the view in the audit log", Query: []openapi.Param{{Name: "staff", Required: true, Description: "The support staff member viewing"}, {Name: "reason", Required: true, Description: "Why, e.g. a ticket number"}}, Response: reflect.TypeFor[SupportViewRes]()},
+	{Method: http.MethodGet, Path: "/api/audit", ID: "listAudit", Summary: "returns the audit log", Query: []openapi.Param{ownerEmail}, Response: reflect.TypeFor[AuditRes]()},
+	{Method: http.MethodPost, Path: "/api/reload", ID: "reload", Summary: "reads the selectors and message prompt files again", Query: []openapi.Param{{Name: "email", Required: true, Description: "The support staff member reloading"}}, Response: reflect.TypeFor[ReloadRes]()},
+	{Method: http.MethodGet, Path: "/api/health", ID: "health", Summary: "reports whether the store can be read, with its schema version", Response: reflect.TypeFor[HealthRes]()},
+	{Method: http.MethodGet, Path: "/api/ready", ID: "ready", Summary: "reports whether the instance is warmed up and takes traffic, for readiness probes", Response: reflect.TypeFor[ReadyRes]()},
+	{Method: http.MethodPost, Path: "/api/drain", ID: "drain", Summary: "stops the instance taking on work and waits for what it runs, from the instance itself only", Response: reflect.TypeFor[DrainRes]()},
+	{Method: http.MethodGet, Path: "/api/openapi.json", ID: "getOpenAPI", Summary: "returns this document", Response: reflect.TypeFor[map[string]any]()},
+}
+
+// OpenAPI describes the API as an OpenAPI document, the one clients are generated from
+// with make sdk. Its version is the payload version responses are written in.
+func OpenAPI() *openapi.Document {
+	return openapi.New(openapi.Info{
+		Title:       "Segwise",
+		Version:     strconv.Itoa(payload.Current),
+		Description: "Scrapes LinkedIn profiles and generates connect messages. Errors are JSON strings; " + payload.Header + " pins the version of versioned responses.",
+	}, apiRoutes)
+}
+
+// GetOpenAPI returns the OpenAPI document, for generating clients in other languages.
+func (s *Server) GetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	doc := OpenAPI()
+	doc.Servers = []openapi.Server{{URL: s.PublicBaseURL}}
+	utils.WriteResponse(w, doc, 200)
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/client"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/openapi"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/payload"
+)
+
+func TestAPIRoutesAreRegistered(t *testing.T) {
+	s, _ := newTestServer(t)
+	for _, route := range apiRoutes {
+		_, pattern := s.Router.Handler(httptest.NewRequest(route.Method, route.Path, nil))
+		if pattern != route.Path {
+			t.Errorf("%s %s is described but the router matches it to %q", route.Method, route.Path, pattern)
+		}
+	}
+}
+
+func TestClientIsGenerated(t *testing.T) {
+	doc := OpenAPI()
+	src, err := openapi.GoClient(doc, openapi.GoOptions{Package: "client", VersionHeader: payload.Header})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, _ := json.MarshalIndent(doc, "", "  ")
+	for file, want := range map[string][]byte{"client.go": src, "openapi.json": append(spec, '\n')} {
+		got, err := os.ReadFile("../client/" + file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("client/%s is out of date, run make sdk", file)
+		}
+	}
+}
+
+func TestClient(t *testing.T) {
+	_, ts := newTestServer(t)
+	c := client.New(ts.URL)
+	ctx := context.Background()
+
+	res, err := c.Home(ctx, &client.HomeReq{Email: "a@x.com", Password: "secret", LinkedinURL: "https://www.linkedin.com/in/one/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Msg == "" || res.SchemaVersion != payload.Current {
+		t.Errorf("home = %+v", res)
+	}
+	profiles, err := c.ListProfiles(ctx, "a@x.com")
+	if err != nil || len(profiles.Profiles) != 1 || profiles.Profiles[0].Profile.Name == "" {
+		t.Fatalf("profiles = %+v, %v", profiles, err)
+	}
+
+	_, err = c.GetCooldown(ctx, "a@x.com")
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "account is not cooling off" {
+		t.Errorf("cooldown of an account that isn't cooling off: %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+// otpCode is a verification code as LinkedIn sends them, also found in the text of an SMS.
+var otpCode = regexp.MustCompile(`\b\d{4,8}\b`)
+
+// otpRequest is a login waiting for the verification code posted to /api/otp/{token}.
+type otpRequest struct {
+	token     string
+	email     string
+	method    scraper.OTPMethod
+	expiresAt time.Time
+	code      chan string
+}
+
+// OTPCode waits for the verification code LinkedIn sent req's login to be posted to
+// /api/otp/{token} or texted to the SMS webhook, and alerts the owner with the link. It
+// gives up after scraper.VerificationTimeout. It is meant for scraper.OTP, behind the
+// providers that answer codes without anyone's help.
+func (s *Server) OTPCode(ctx context.Context, req scraper.OTPRequest) (string, error) {
+	token, err := utils.GenerateID()
+	if err != nil {
+		return "", err
+	}
+	o := &otpRequest{token: token, email: req.Email, method: req.Method, expiresAt: time.Now().Add(scraper.VerificationTimeout), code: make(chan string, 1)}
+	s.otpMu.Lock()
+	s.otps[token] = o
+	s.otpMu.Unlock()
+	defer func() {
+		s.otpMu.Lock()
+		delete(s.otps, token)
+		s.otpMu.Unlock()
+	}()
+
+	log.Printf("LinkedIn asked %s for a verification code sent by %s, waiting for it to be posted\n", req.Email, req.Method)
+	s.queueAlert(models.Event{
+		Kind:      models.EventAccountOTPRequired,
+		Owner:     req.Email,
+		Job:       "login",
+		Error:     fmt.Sprintf("LinkedIn asked for the verification code it sent by %s", req.Method),
+		VerifyURL: s.PublicBaseURL + "/api/otp/" + token,
+		VerifyBy:  o.expiresAt,
+	})
+
+	timer := time.NewTimer(time.Until(o.expiresAt))
+	defer timer.Stop()
+	select {
+	case code := <-o.code:
+		return code, nil
+	case <-timer.C:
+		return "", fmt.Errorf("no verification code was posted within %s", scraper.VerificationTimeout)
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// ListOTPRequests returns the links logins of a user's account are waiting for a
+// verification code at, so the frontend can ask for it while a login hangs.
+func (s *Server) ListOTPRequests(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+
+	res := &OTPRequestsRes{Requests: []OTPRequestRes{}}
+	s.otpMu.Lock()
+	for _, o := range s.otps {
+		if strings.EqualFold(o.email, email) {
+			res.Requests = append(res.Requests, OTPRequestRes{Url: s.PublicBaseURL + "/api/otp/" + o.token, Method: string(o.method), ExpiresAt: o.expiresAt})
+		}
+	}
+	s.otpMu.Unlock()
+	sort.Slice(res.Requests, func(i, j int) bool {
+		return res.Requests[i].ExpiresAt.Before(res.Requests[j].ExpiresAt)
+	})
+	utils.WriteResponse(w, res, 200)
+}
+
+// SubmitOTP hands the verification code in the body to the login waiting at the request's token.
+func (s *Server) SubmitOTP(w http.ResponseWriter, r *http.Request) {
+	d := &OTPReq{}
+	if err := utils.DecodeReqBody(r, d); err != nil {
+		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
+		return
+	}
+	code := strings.TrimSpace(d.Code)
+	if otpCode.FindString(code) != code {
+		utils.WriteResponse(w, "code must be 4 to 8 digits", http.StatusBadRequest)
+		return
+	}
+
+	s.otpMu.Lock()
+	o, ok := s.otps[r.PathValue("token")]
+	s.otpMu.Unlock()
+	if !ok || !s.deliverOTP(o, code) {
+		utils.WriteResponse(w, "verification code request not found", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// OTPSMS is the webhook an SMS provider such as Twilio forwards the texts sent to the
+// accounts' phones to. The code in the form's Body goes to the oldest login waiting for
+// a texted code of the account OTPPhones lists the To number for.
+func (s *Server) OTPSMS(w http.ResponseWriter, r *http.Request) {
+	secret := r.URL.Query().Get("secret")
+	if s.OTPSMSSecret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(s.OTPSMSSecret)) != 1 {
+		utils.WriteResponse(w, "invalid secret", http.StatusUnauthorized)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		utils.WriteResponse(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	code := otpCode.FindString(r.PostForm.Get("Body"))
+	if code == "" {
+		utils.WriteResponse(w, "the text has no verification code", http.StatusBadRequest)
+		return
+	}
+
+	to := phoneDigits(r.PostForm.Get("To"))
+	var waiting []*otpRequest
+	s.otpMu.Lock()
+	for _, o := range s.otps {
+		if o.method == scraper.OTPSMS && to != "" && phoneDigits(s.phoneOf(o.email)) == to {
+			waiting = append(waiting, o)
+		}
+	}
+	s.otpMu.Unlock()
+	sort.Slice(waiting, func(i, j int) bool { return waiting[i].expiresAt.Before(waiting[j].expiresAt) })
+	for _, o := range waiting {
+		if s.deliverOTP(o, code) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	utils.WriteResponse(w, "no login is waiting for a code sent to this number", http.StatusNotFound)
+}
+
+// deliverOTP hands code to o's login, reporting false when it already has one or gave up.
+func (s *Server) deliverOTP(o *otpRequest, code string) bool {
+	if time.Now().After(o.expiresAt) {
+		return false
+	}
+	select {
+	case o.code <- code:
+		log.Printf("Verification code for %s received\n", o.email)
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Server) phoneOf(email string) string {
+	for e, phone := range s.OTPPhones {
+		if strings.EqualFold(e, email) {
+			return phone
+		}
+	}
+	return ""
+}
+
+// phoneDigits drops the formatting of a phone number, so "+1 (555) 010-2030" matches "+15550102030".
+func phoneDigits(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
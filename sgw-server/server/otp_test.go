@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// waitOTP starts a login waiting for a code and returns the link it is waiting at.
+func waitOTP(t *testing.T, s *Server, email string, method scraper.OTPMethod) (string, <-chan string) {
+	t.Helper()
+	codes := make(chan string, 1)
+	go func() {
+		code, err := s.OTPCode(context.Background(), scraper.OTPRequest{Email: email, Method: method, Attempt: 1})
+		if err != nil {
+			code = "error: " + err.Error()
+		}
+		codes <- code
+	}()
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.otpMu.Lock()
+		for _, o := range s.otps {
+			if o.email == email && o.method == method {
+				s.otpMu.Unlock()
+				return s.PublicBaseURL + "/api/otp/" + o.token, codes
+			}
+		}
+		s.otpMu.Unlock()
+		if time.Now().After(deadline) {
+			t.Fatal("no login waiting for a code")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSubmitOTP(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.PublicBaseURL = ts.URL
+	_, hookURL := newAlertHook(t)
+	s.AccountAlertURL = hookURL
+	link, codes := waitOTP(t, s, "a@x.com", scraper.OTPEmail)
+
+	var list OTPRequestsRes
+	if code := call(t, ts, http.MethodGet, "/api/otp?email=A@x.com", nil, &list); code != http.StatusOK || len(list.Requests) != 1 || list.Requests[0].Url != link || list.Requests[0].Method != "email" {
+		t.Fatalf("otp requests: status %d, %+v", code, list)
+	}
+	due, _ := s.Store.DueOutbox(time.Now())
+	if len(due) != 1 || due[0].Event.Kind != models.EventAccountOTPRequired || due[0].Event.VerifyURL != link {
+		t.Fatalf("alerts = %+v, want one otp-required alert with the link", due)
+	}
+
+	path := strings.TrimPrefix(link, ts.URL)
+	if code := call(t, ts, http.MethodPost, path, &OTPReq{Code: "12ab"}, nil); code != http.StatusBadRequest {
+		t.Errorf("a code that is not digits: status %d, want 400", code)
+	}
+	if code := call(t, ts, http.MethodPost, path, &OTPReq{Code: " 482913 "}, nil); code != http.StatusNoContent {
+		t.Fatalf("code: status %d, want 204", code)
+	}
+	if got := <-codes; got != "482913" {
+		t.Errorf("login got %q", got)
+	}
+	if code := call(t, ts, http.MethodPost, path, &OTPReq{Code: "482913"}, nil); code != http.StatusNotFound {
+		t.Errorf("a code for a login that has one: status %d, want 404", code)
+	}
+}
+
+func TestOTPSMS(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.OTPSMSSecret = "hook-secret"
+	s.OTPPhones = map[string]string{"a@x.com": "+1 (555) 010-2030", "b@x.com": "+15550109999"}
+	_, codes := waitOTP(t, s, "a@x.com", scraper.OTPSMS)
+
+	text := func(secret, to, body string) int {
+		t.Helper()
+		res, err := ts.Client().PostForm(ts.URL+"/api/otp/sms?secret="+secret, url.Values{"To": {to}, "Body": {body}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res.StatusCode
+	}
+	if code := text("wrong", "+15550102030", "Your LinkedIn verification code is 771204."); code != http.StatusUnauthorized {
+		t.Errorf("wrong secret: status %d, want 401", code)
+	}
+	if code := text("hook-secret", "+15550109999", "Your LinkedIn verification code is 771204."); code != http.StatusNotFound {
+		t.Errorf("another account's number: status %d, want 404", code)
+	}
+	if code := text("hook-secret", "+15550102030", "Your LinkedIn verification code is 771204."); code != http.StatusNoContent {
+		t.Fatalf("text: status %d, want 204", code)
+	}
+	if got := <-codes; got != "771204" {
+		t.Errorf("login got %q", got)
+	}
+}
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/config"
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+const (
+	// outboxMinBackoff is the wait after the first failed delivery; it doubles per attempt up to outboxMaxBackoff.
+	outboxMinBackoff = 30 * time.Second
+	outboxMaxBackoff = time.Hour
+	// outboxMaxAttempts failed deliveries dead-letter an entry, about two days at the maximum backoff
+	outboxMaxAttempts = 50
+
+	relayLease = "outbox-relay"
+)
+
+// notifyTimeout bounds one delivery, the relay retries it after its backoff.
+const notifyTimeout = 30 * time.Second
+
+var outboxClient = &http.Client{Timeout: 10 * time.Second}
+
+// finishBatch saves a batch in its final state together with the notifications about it.
+func (s *Server) finishBatch(batch *models.Batch) {
+	batch.CompletedAt = time.Now()
+
+	entries, err := s.outboxEntries(batch)
+	if err != nil {
+		// The batch result matters more than its notification
+		log.Printf("error while queueing notifications for batch %s: %v\n", batch.ID, err)
+	}
+
+	if err := s.Store.SaveBatchWithOutbox(batch, entries); err != nil {
+		log.Printf("error while saving batch %s: %v\n", batch.ID, err)
+	}
+
+	kind := ActivityBatchDone
+	if batch.Status == models.BatchFailed {
+		kind = ActivityBatchFailed
+	}
+	s.record(Activity{Kind: kind, Actor: batch.Owner, BatchID: batch.ID, Detail: batch.Error})
+}
+
+func (s *Server) outboxEntries(batch *models.Batch) ([]*models.OutboxEntry, error) {
+	kind := models.EventBatchDone
+	if batch.Status == models.BatchFailed {
+		kind = models.EventBatchFailed
+	}
+	if batch.DryRun || len(s.destinationsFor(kind, batch.Owner)) == 0 {
+		return nil, nil
+	}
+	event, err := s.batchEvent(batch)
+	if err != nil {
+		return nil, err
+	}
+	return s.entriesFor(event)
+}
+
+// notify queues an event that is not tied to a stored change.
+func (s *Server) notify(kind models.EventKind, detail string) {
+	if len(s.destinationsFor(kind, "")) == 0 {
+		return
+	}
+	id, err := utils.GenerateID()
+	if err != nil {
+		log.Printf("error while queueing %s notification: %v\n", kind, err)
+		return
+	}
+	s.queueEvent(models.Event{ID: id, Kind: kind, Error: detail, CreatedAt: time.Now()})
+}
+
+// queueEvent puts an event in the outbox for every destination that receives it.
+func (s *Server) queueEvent(event models.Event) {
+	entries, err := s.entriesFor(event)
+	if err == nil {
+		err = s.Store.EnqueueOutbox(entries)
+	}
+	if err != nil {
+		log.Printf("error while queueing %s notification: %v\n", event.Kind, err)
+	}
+}
+
+// entriesFor creates one outbox entry per destination receiving the event.
+func (s *Server) entriesFor(event models.Event) ([]*models.OutboxEntry, error) {
+	destinations := s.destinationsFor(event.Kind, event.Owner)
+	entries := make([]*models.OutboxEntry, 0, len(destinations))
+	for _, d := range destinations {
+		id, err := utils.GenerateID()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &models.OutboxEntry{ID: id, Destination: d, Event: event, NextAttemptAt: event.CreatedAt})
+	}
+	return entries, nil
+}
+
+func (s *Server) batchEvent(batch *models.Batch) (models.Event, error) {
+	id, err := utils.GenerateID()
+	if err != nil {
+		return models.Event{}, err
+	}
+	prospects, err := s.Store.ListBatchProspects(batch.ID)
+	if err != nil {
+		return models.Event{}, err
+	}
+	event := models.Event{
+		ID:        id,
+		Kind:      models.EventBatchDone,
+		Owner:     batch.Owner,
+		BatchID:   batch.ID,
+		Prospects: len(prospects),
+		CreatedAt: batch.CompletedAt,
+	}
+	if batch.Status == models.BatchFailed {
+		event.Kind = models.EventBatchFailed
+		event.Error = batch.Error
+	}
+	return event, nil
+}
+
+/*
+	destinationsFor returns the configured destinations receiving events of kind about owner.
+
+The routes tried are owner's own, those of the first of owner's teams with
+routes, then the server's; the first route matching kind decides, so a user's
+"batch.done=email" sends finished batches by email but leaves every other
+event to their team and the server. Events without an owner aren't mailed.
+*/
+func (s *Server) destinationsFor(kind models.EventKind, owner string) []models.Destination {
+	var routes []config.Route
+	if owner != "" {
+		routes = append(routes, s.settingsFor(owner).routes...)
+		for _, team := range s.Teams {
+			if teamRoutes, ok := s.TeamNotifyRoutes[strings.ToLower(team.Name)]; ok && slices.ContainsFunc(team.Members, func(m string) bool { return strings.EqualFold(m, owner) }) {
+				routes = append(routes, teamRoutes...)
+				break
+			}
+		}
+	}
+	routes = append(routes, s.NotifyRoutes...)
+
+	i := slices.IndexFunc(routes, func(r config.Route) bool { return r.Matches(kind) })
+	if i < 0 {
+		return nil
+	}
+	notifiers := s.notifiers()
+	var d []models.Destination
+	for _, channel := range routes[i].Channels {
+		if _, ok := notifiers[channel]; !ok || (channel == models.DestinationEmail && owner == "") || slices.Contains(d, channel) {
+			continue
+		}
+		d = append(d, channel)
+	}
+	return d
+}
+
+// StartRelay delivers queued outbox entries every interval until the returned function is called.
+// Delivery is at least once: an entry is only removed after its destination accepted it, so a
+// crash in between resends it. Webhook receivers can deduplicate on the X-Segwise-Event-Id header.
+// Entries that failed outboxMaxAttempts times are dead-lettered instead of retried forever.
+// Of the instances sharing a store, only the one holding the relay lease delivers.
+func (s *Server) StartRelay(interval time.Duration) func() {
+	// Outlives a few missed ticks so the lease does not hop between instances
+	ttl := max(3*interval, leaseTTL)
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if ok, err := s.Store.AcquireLease(relayLease, s.InstanceID, ttl); err != nil {
+				log.Printf("error while leasing the outbox relay: %v\n", err)
+			} else if ok {
+				s.relayOutbox()
+			}
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+func (s *Server) relayOutbox() {
+	entries, err := s.Store.DueOutbox(time.Now())
+	if err != nil {
+		log.Printf("error while reading outbox: %v\n", err)
+		return
+	}
+	for _, entry := range entries {
+		if err := s.deliver(entry); err != nil {
+			entry.Attempts++
+			entry.LastError = err.Error()
+			entry.NextAttemptAt = time.Now().Add(backoff(entry.Attempts))
+			log.Printf("error while delivering %s event %s (attempt %d): %v\n", entry.Destination, entry.Event.ID, entry.Attempts, err)
+			if entry.Attempts >= outboxMaxAttempts {
+				entry.DeadAt = time.Now()
+				log.Printf("giving up on %s event %s after %d attempts, it stays in the outbox as dead\n", entry.Destination, entry.Event.ID, entry.Attempts)
+			}
+			if err := s.Store.SaveOutboxEntry(entry); err != nil {
+				log.Printf("error while saving outbox entry %s: %v\n", entry.ID, err)
+			}
+			continue
+		}
+		if err := s.Store.DeleteOutboxEntry(entry.ID); err != nil {
+			log.Printf("error while deleting outbox entry %s: %v\n", entry.ID, err)
+		}
+	}
+}
+
+func (s *Server) deliver(entry *models.OutboxEntry) error {
+	notifier, ok := s.notifiers()[entry.Destination]
+	if !ok {
+		return fmt.Errorf("%s destination is no longer configured", entry.Destination)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+	return notifier.Notify(ctx, entry.Event)
+}
+
+func backoff(attempts int) time.Duration {
+	wait := outboxMinBackoff
+	for i := 1; i < attempts && wait < outboxMaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > outboxMaxBackoff {
+		wait = outboxMaxBackoff
+	}
+	return wait
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/config"
+	"github.com/hemantsharma1498/segwise-assignment/models"
+)
+
+// webhook records the event IDs POSTed to it.
+type webhook struct {
+	mu     sync.Mutex
+	events map[string]int
+}
+
+func newWebhook(t *testing.T) (*webhook, string) {
+	t.Helper()
+	h := &webhook{events: map[string]int{}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		h.events[r.Header.Get("X-Segwise-Event-Id")]++
+		h.mu.Unlock()
+	}))
+	t.Cleanup(srv.Close)
+	return h, srv.URL
+}
+
+func (h *webhook) received() map[string]int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	copied := make(map[string]int, len(h.events))
+	for id, n := range h.events {
+		copied[id] = n
+	}
+	return copied
+}
+
+func TestRelayDeliversConcurrentNotificationsOnce(t *testing.T) {
+	s, _ := newTestServer(t)
+	hook, url := newWebhook(t)
+	s.WebhookURL = url
+	stop := s.StartRelay(5 * time.Millisecond)
+	defer stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.notify(models.EventSelectorDrift, "experience has 0 entries")
+		}()
+	}
+	wg.Wait()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(hook.received()) < 20 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	received := hook.received()
+	if len(received) != 20 {
+		t.Fatalf("%d distinct events delivered, want 20", len(received))
+	}
+	for id, n := range received {
+		if n != 1 {
+			t.Errorf("event %s delivered %d times", id, n)
+		}
+	}
+	if due, _ := s.Store.DueOutbox(time.Now().Add(time.Hour)); len(due) != 0 {
+		t.Errorf("%d entries left in the outbox", len(due))
+	}
+}
+
+func TestRelayDeadLettersAfterMaxAttempts(t *testing.T) {
+	s, _ := newTestServer(t)
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	s.WebhookURL = failing.URL
+
+	entry := &models.OutboxEntry{ID: "e1", Destination: models.DestinationWebhook, Event: models.Event{ID: "ev1", Kind: models.EventBatchDone}, Attempts: outboxMaxAttempts - 2}
+	if err := s.Store.EnqueueOutbox([]*models.OutboxEntry{entry}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.relayOutbox()
+	if dead, _ := s.Store.DeadOutbox(); len(dead) != 0 {
+		t.Fatalf("dead-lettered after %d attempts, want %d", outboxMaxAttempts-1, outboxMaxAttempts)
+	}
+	due, _ := s.Store.DueOutbox(time.Now().Add(outboxMaxBackoff))
+	if len(due) != 1 {
+		t.Fatalf("%d entries due after the backoff, want 1", len(due))
+	}
+
+	// Skip the backoff instead of waiting for it
+	due[0].NextAttemptAt = time.Time{}
+	if err := s.Store.SaveOutboxEntry(due[0]); err != nil {
+		t.Fatal(err)
+	}
+	s.relayOutbox()
+	dead, _ := s.Store.DeadOutbox()
+	if len(dead) != 1 || dead[0].Attempts != outboxMaxAttempts || dead[0].LastError == "" {
+		t.Fatalf("dead entries = %+v", dead)
+	}
+	if due, _ := s.Store.DueOutbox(time.Now().Add(24 * time.Hour)); len(due) != 0 {
+		t.Errorf("a dead entry is still being retried")
+	}
+}
+
+func TestRecoverInterruptedFailsOrphanedWork(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.WebhookURL = "http://127.0.0.1:0/unused"
+
+	running := &models.Batch{ID: "b1", Owner: "a@x.com", LinkedinUrls: []string{"https://www.linkedin.com/in/one/"}, Status: models.BatchRunning}
+	done := &models.Batch{ID: "b2", Owner: "a@x.com", Status: models.BatchDone}
+	for _, b := range []*models.Batch{running, done} {
+		if err := s.Store.SaveBatch(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Store.SaveRegeneration(&models.Regeneration{ID: "r1", Owner: "a@x.com", Status: models.BatchPending}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.RecoverInterrupted(); err != nil {
+		t.Fatalf("RecoverInterrupted: %v", err)
+	}
+	if b, _ := s.Store.GetBatch("b1"); b.Status != models.BatchFailed || b.Error == "" || b.CompletedAt.IsZero() {
+		t.Errorf("interrupted batch = %+v", b)
+	}
+	if b, _ := s.Store.GetBatch("b2"); b.Status != models.BatchDone {
+		t.Errorf("finished batch changed to %s", b.Status)
+	}
+	if r, _ := s.Store.GetRegeneration("r1"); r.Status != models.BatchFailed {
+		t.Errorf("interrupted regeneration is %s", r.Status)
+	}
+	due, _ := s.Store.DueOutbox(time.Now())
+	if len(due) != 1 || due[0].Event.Kind != models.EventBatchFailed || due[0].Event.BatchID != "b1" {
+		t.Errorf("queued notifications = %+v", due)
+	}
+}
+
+func TestNotifyRoutes(t *testing.T) {
+	s, ts := newTestServer(t)
+	_, url := newWebhook(t)
+	s.WebhookURL, s.SlackWebhookURL = url, url
+	var mailed []string
+	s.Notifiers[models.DestinationEmail] = &EmailNotifier{From: "sgw@x.com", send: func(_ context.Context, to string, msg []byte) error {
+		mailed = append(mailed, to+"\n"+string(msg))
+		return nil
+	}}
+	s.Teams = []config.Team{{Name: "Growth", Members: []string{"a@x.com", "b@x.com"}}}
+	s.TeamNotifyRoutes = map[string][]config.Route{"growth": {{Event: "account.*", Channels: []models.Destination{models.DestinationSlack}}}}
+	if code := call(t, ts, http.MethodPut, "/api/settings", &SettingsReq{Email: "b@x.com", NotifyRoutes: "batch.done=email"}, nil); code != http.StatusOK {
+		t.Fatalf("PUT /api/settings: status %d", code)
+	}
+	if code := call(t, ts, http.MethodPut, "/api/settings", &SettingsReq{Email: "b@x.com", NotifyRoutes: "batch.done=pager"}, nil); code != http.StatusBadRequest {
+		t.Errorf("PUT /api/settings with an unknown channel: status %d, want 400", code)
+	}
+
+	all := []models.Destination{models.DestinationWebhook, models.DestinationSlack, models.DestinationEmail}
+	cases := []struct {
+		kind  models.EventKind
+		owner string
+		want  []models.Destination
+	}{
+		{models.EventBatchDone, "b@x.com", []models.Destination{models.DestinationEmail}},
+		{models.EventBatchFailed, "b@x.com", all},
+		{models.EventAccountRestricted, "b@x.com", []models.Destination{models.DestinationSlack}},
+		{models.EventBatchDone, "c@x.com", all},
+		{models.EventSelectorDrift, "", all[:2]},
+	}
+	for _, c := range cases {
+		if got := s.destinationsFor(c.kind, c.owner); !slices.Equal(got, c.want) {
+			t.Errorf("destinationsFor(%s, %q) = %v, want %v", c.kind, c.owner, got, c.want)
+		}
+	}
+	if s.NotifyRoutes, _ = config.ParseRoutes("*=none"); s.destinationsFor(models.EventSelectorDrift, "") != nil {
+		t.Error("*=none still sends selector drift somewhere")
+	}
+
+	event := models.Event{ID: "ev1", Kind: models.EventBatchDone, Owner: "b@x.com", BatchID: "b1", Prospects: 3}
+	if err := s.deliver(&models.OutboxEntry{Destination: models.DestinationEmail, Event: event}); err != nil {
+		t.Fatal(err)
+	}
+	if len(mailed) != 1 || !strings.HasPrefix(mailed[0], "b@x.com\nFrom: sgw@x.com\r\n") || !strings.Contains(mailed[0], "Subject: Batch b1 is done\r\n") || !strings.Contains(mailed[0], "3 prospects scraped") {
+		t.Errorf("mailed %q", mailed)
+	}
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cgroupRoot is where the container's cgroup files are mounted, a directory of fakes in tests.
+var cgroupRoot = "/sys/fs/cgroup"
+
+/*
+	memoryUsage returns the share of the container's memory limit in use, and false when
+	the container has no limit or its cgroup can't be read.
+
+Page cache the kernel can reclaim, counted as inactive_file, is left out: Chrome's
+disk cache would otherwise read as pressure long before the container is at
+risk of being killed. cgroup v2 is read, v1 when v2 isn't mounted.
+*/
+func memoryUsage() (float64, bool) {
+	v2 := []string{"memory.current", "memory.max", "memory.stat", "inactive_file"}
+	v1 := []string{"memory/memory.usage_in_bytes", "memory/memory.limit_in_bytes", "memory/memory.stat", "total_inactive_file"}
+	for _, files := range [][]string{v2, v1} {
+		used, err := readBytes(files[0])
+		if err != nil {
+			continue
+		}
+		limit, err := readBytes(files[1])
+		// v2 writes max and v1 a number near the largest int64 for no limit
+		if err != nil || limit <= 0 || limit > 1<<60 {
+			return 0, false
+		}
+		used -= statValue(files[2], files[3])
+		return float64(max(used, 0)) / float64(limit), true
+	}
+	return 0, false
+}
+
+func readBytes(name string) (int64, error) {
+	raw, err := os.ReadFile(filepath.Join(cgroupRoot, name))
+	if err != nil {
+		return 0, err
+	}
+	v := strings.TrimSpace(string(raw))
+	if v == "max" {
+		return 0, nil
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+// statValue returns the value of key in a memory.stat file, 0 when it has none.
+func statValue(name, key string) int64 {
+	raw, err := os.ReadFile(filepath.Join(cgroupRoot, name))
+	if err != nil {
+		return 0
+	}
+	lines := bufio.NewScanner(bytes.NewReader(raw))
+	for lines.Scan() {
+		if k, v, ok := strings.Cut(lines.Text(), " "); ok && k == key {
+			n, _ := strconv.ParseInt(v, 10, 64)
+			return n
+		}
+	}
+	return 0
+}
+
+/*
+	StartPressureWatch checks the container's memory every interval and shrinks the
+	browser pool while more than threshold of its limit is in use.
+
+Under pressure admitScrape admits half the browsers it did before the pressure
+started, at least one, so requests turned away are retried on instances with
+room, which the autoscaler adds, instead of the container being OOM killed with
+every browser's scrape. The pool grows back once usage drops below threshold.
+It stops when the returned func is called; without a memory limit it never
+shrinks the pool.
+*/
+func (s *Server) StartPressureWatch(threshold float64, interval time.Duration) func() {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		defer ticker.Stop()
+		for {
+			s.checkPressure(threshold)
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+func (s *Server) checkPressure(threshold float64) {
+	used, ok := memoryUsage()
+	pressured := ok && used > threshold
+	s.load.mu.Lock()
+	defer s.load.mu.Unlock()
+	switch {
+	case pressured && s.load.pressureLimit == 0:
+		limit := s.load.browsers
+		if s.MaxBrowsers > 0 {
+			limit = s.MaxBrowsers
+		}
+		s.load.pressureLimit = max(limit/2, 1)
+		log.Printf("Memory is %.0f%% used, shrinking the browser pool to %d\n", used*100, s.load.pressureLimit)
+	case !pressured && s.load.pressureLimit > 0:
+		s.load.pressureLimit = 0
+		log.Printf("Memory is back to %.0f%% used, growing the browser pool again\n", used*100)
+	}
+}
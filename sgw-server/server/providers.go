@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/openai"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/persona"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// Scraper is the part of *scraper.Scraper the server drives, so a fake can stand in for a real browser.
+type Scraper interface {
+	SetProfileURL(linkedInURL string)
+	ScrapeWithBudget(ctx context.Context, budget time.Duration, sections ...scraper.Section) []scraper.SectionResult
+	ScrapeAll(ctx context.Context, sections ...scraper.Section) (scraper.Profile, error)
+	GetNameAndLocation(ctx context.Context) error
+	GetAbout(ctx context.Context) error
+	GetExperiences(ctx context.Context) error
+	GetEducation(ctx context.Context) error
+	GetSkills(ctx context.Context) error
+	GetCertifications(ctx context.Context) error
+	GetRecommendations(ctx context.Context) error
+	GetVolunteering(ctx context.Context) error
+	GetPublications(ctx context.Context) error
+	GetPatents(ctx context.Context) error
+	GetLanguages(ctx context.Context) error
+	GetArticles(ctx context.Context) error
+	GetRecentComments(ctx context.Context) error
+	GetCompany(ctx context.Context) error
+	GetContactInfo(ctx context.Context) error
+	GetJob(ctx context.Context, jobURL string) (*scraper.Job, error)
+	SearchPeople(ctx context.Context, query string, filters scraper.SearchFilters) (scraper.SearchPage, error)
+	Profile() scraper.Profile
+	Renew(lease time.Duration)
+	Ping() error
+	Relogin() error
+	Close()
+}
+
+// Verification is the part of *scraper.Verification the verification pages drive.
+type Verification interface {
+	Frame() ([]byte, bool)
+	Click(x, y float64) error
+	Type(text string) error
+	Press(key string) error
+	Done() <-chan struct{}
+}
+
+// ScraperFactory logs in to LinkedIn and returns a scraper positioned at linkedInURL.
+type ScraperFactory func(email, password, linkedInURL string) (Scraper, error)
+
+// PublicScraperFactory returns a scraper of the public profile at linkedInURL, for requests
+// without LinkedIn credentials.
+type PublicScraperFactory func(linkedInURL string) Scraper
+
+// LLM generates connect messages, resolves personas the title rules can't,
+// summarizes the personal websites prospects list and embeds posts to rank them by a
+// goal. Every call reports the tokens it used, which prospects' costs are estimated from.
+type LLM interface {
+	GetMessage(prospect openai.Prospect) (string, openai.Usage, error)
+	ClassifyPersona(profile scraper.Profile) (persona.Persona, openai.Usage, error)
+	SummarizeWebsite(site, text string) (string, openai.Usage, error)
+	Embed(texts []string) ([][]float64, openai.Usage, error)
+}
+
+func newChromeScraper(email, password, linkedInURL string) (Scraper, error) {
+	return scraper.NewScraper(email, password, linkedInURL)
+}
+
+func newPublicScraper(linkedInURL string) Scraper {
+	return scraper.NewPublicScraper(linkedInURL)
+}
+
+type openAILLM struct {
+	apiKey string
+}
+
+func (o openAILLM) GetMessage(prospect openai.Prospect) (string, openai.Usage, error) {
+	return openai.GetMessage(prospect, o.apiKey)
+}
+
+func (o openAILLM) ClassifyPersona(profile scraper.Profile) (persona.Persona, openai.Usage, error) {
+	return openai.ClassifyPersona(profile, o.apiKey)
+}
+
+func (o openAILLM) SummarizeWebsite(site, text string) (string, openai.Usage, error) {
+	return openai.SummarizeWebsite(site, text, o.apiKey)
+}
+
+func (o openAILLM) Embed(texts []string) ([][]float64, openai.Usage, error) {
+	return openai.Embed(texts, o.apiKey)
+}
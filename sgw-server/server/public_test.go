@@ -0,0 +1,31 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+)
+
+func TestHomeWithoutPasswordReadsPublicProfile(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.NewScraper = func(email, password, url string) (Scraper, error) {
+		t.Errorf("logged in to LinkedIn as %s without a password", email)
+		return nil, errors.New("no password")
+	}
+	priya := fake.Profiles[0]
+
+	var res HomeRes
+	if code := call(t, ts, http.MethodPost, "/api/home", &HomeReq{Email: "a@x.com", LinkedinUrl: fake.ProfileURL(priya)}, &res); code != http.StatusOK {
+		t.Fatalf("POST /api/home without a password: status %d", code)
+	}
+	if res.Msg == "" {
+		t.Error("no message generated from the public profile")
+	}
+
+	req := &HomeReq{Email: "a@x.com", LinkedinUrl: fake.ProfileURL(priya), JobUrl: "https://www.linkedin.com/jobs/view/4012345678/"}
+	if code := call(t, ts, http.MethodPost, "/api/home", req, nil); code != http.StatusBadRequest {
+		t.Errorf("POST /api/home with a jobUrl and no password: status %d, want 400", code)
+	}
+}
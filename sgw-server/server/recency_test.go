@@ -0,0 +1,30 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+)
+
+func TestDormantProspectHooksOnExperience(t *testing.T) {
+	_, ts := newTestServer(t)
+	arjun := fake.Profiles[3]
+	home(t, ts, "a@x.com", fake.ProfileURL(arjun))
+
+	var res ListProfilesRes
+	call(t, ts, http.MethodGet, "/api/profiles?email=a@x.com", nil, &res)
+	if len(res.Profiles) != 1 {
+		t.Fatalf("got %d prospects, want 1", len(res.Profiles))
+	}
+	p := res.Profiles[0]
+	// The dated comment is newer than the articles
+	if want := time.Date(2024, time.October, 2, 0, 0, 0, 0, time.UTC); !p.Profile.LastActiveAt.Equal(want) {
+		t.Errorf("LastActiveAt = %v, want %v", p.Profile.LastActiveAt, want)
+	}
+	if !strings.Contains(p.Message, "your work as Co-Founder & CEO at Pixelforge") {
+		t.Errorf("message to a dormant prospect = %q, want it to hook on their experience", p.Message)
+	}
+}
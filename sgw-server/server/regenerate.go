@@ -0,0 +1,276 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/diff"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/enrich"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/latency"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+// CreateRegeneration re-generates messages for a user's stored prospects in the background,
+// from their stored profiles. Nothing is replaced until the result is applied.
+func (s *Server) CreateRegeneration(w http.ResponseWriter, r *http.Request) {
+	d := &RegenerationReq{}
+	if err := utils.DecodeReqBody(r, d); err != nil {
+		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
+		return
+	}
+	if !utils.ValidEmail(d.Email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+
+	if d.BatchID != "" && d.CampaignID != "" {
+		utils.WriteResponse(w, "batchId and campaignId can't both be set", http.StatusBadRequest)
+		return
+	}
+
+	var prospects []*models.Prospect
+	var err error
+	switch {
+	case d.CampaignID != "":
+		campaign, ok := s.ownedCampaign(w, d.CampaignID, d.Email)
+		if !ok {
+			return
+		}
+		for _, id := range campaign.BatchIDs {
+			var batch []*models.Prospect
+			if batch, err = s.Store.ListBatchProspects(id); err != nil {
+				break
+			}
+			prospects = append(prospects, batch...)
+		}
+	case d.BatchID != "":
+		var batch *models.Batch
+		batch, err = s.Store.GetBatch(d.BatchID)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			log.Printf("error while getting batch: %v\n", err)
+			utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+			return
+		}
+		if err != nil || !strings.EqualFold(batch.Owner, d.Email) {
+			utils.WriteResponse(w, "batch not found", http.StatusNotFound)
+			return
+		}
+		prospects, err = s.Store.ListBatchProspects(batch.ID)
+	default:
+		prospects, err = s.Store.ListProspects(d.Email)
+	}
+	if err != nil {
+		log.Printf("error while listing prospects: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+
+	id, err := utils.GenerateID()
+	if err != nil {
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	regen := &models.Regeneration{
+		ID:         id,
+		Owner:      d.Email,
+		BatchID:    d.BatchID,
+		CampaignID: d.CampaignID,
+		Filter:     d.Filter,
+		Status:     models.BatchPending,
+		CreatedAt:  time.Now(),
+	}
+	for _, p := range prospects {
+		// ICP-skipped prospects never had a message to improve on, and drafts are left
+		// out of the user's prospects unless the batches they are in are regenerated
+		if p.SkipReason != "" || (p.Draft && d.BatchID == "" && d.CampaignID == "") || !d.Filter.Match(p.Persona) {
+			continue
+		}
+		regen.Items = append(regen.Items, models.RegenerationItem{ProspectID: p.ID, LinkedinUrl: p.LinkedinUrl, OldMessage: p.Message})
+	}
+	if len(regen.Items) == 0 {
+		utils.WriteResponse(w, "no prospects match", http.StatusBadRequest)
+		return
+	}
+	if !s.life.begin() {
+		writeBusy(w, errDraining)
+		return
+	}
+	releaseLease, _, err := s.holdLease(regenerationLease(regen.ID), s.InstanceID)
+	if err != nil {
+		s.life.end()
+		log.Printf("error while leasing regeneration: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	if err := s.Store.SaveRegeneration(regen); err != nil {
+		releaseLease()
+		s.life.end()
+		log.Printf("error while saving regeneration: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+
+	// runRegeneration owns the regeneration from here on
+	res := &CreateRegenerationRes{ID: regen.ID, Status: regen.Status, Prospects: len(regen.Items)}
+	go func() {
+		defer s.life.end()
+		s.runRegeneration(regen, releaseLease)
+	}()
+	utils.WriteResponse(w, res, http.StatusAccepted)
+}
+
+// GetRegeneration returns a regeneration with the old and new message and their diff per prospect.
+func (s *Server) GetRegeneration(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	regen, err := s.Store.GetRegeneration(r.PathValue("id"))
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Printf("error while getting regeneration: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	if err != nil || !strings.EqualFold(regen.Owner, email) {
+		utils.WriteResponse(w, "regeneration not found", http.StatusNotFound)
+		return
+	}
+	utils.WriteResponse(w, regen, 200)
+}
+
+// ApplyRegeneration replaces stored messages with regenerated ones, for the chosen
+// prospects or all of them. Prospects whose message changed since the regeneration
+// started are skipped rather than overwritten.
+func (s *Server) ApplyRegeneration(w http.ResponseWriter, r *http.Request) {
+	d := &ApplyRegenerationReq{}
+	if err := utils.DecodeReqBody(r, d); err != nil {
+		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
+		return
+	}
+	regen, err := s.Store.GetRegeneration(r.PathValue("id"))
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Printf("error while getting regeneration: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	if err != nil || !strings.EqualFold(regen.Owner, d.Email) {
+		utils.WriteResponse(w, "regeneration not found", http.StatusNotFound)
+		return
+	}
+	if regen.Status != models.BatchDone {
+		utils.WriteResponse(w, "regeneration is still running", http.StatusConflict)
+		return
+	}
+
+	chosen := map[string]bool{}
+	for _, id := range d.ProspectIDs {
+		chosen[id] = true
+	}
+	res := &ApplyRegenerationRes{Skipped: []SkippedApply{}}
+	var prospects []*models.Prospect
+	for i := range regen.Items {
+		item := &regen.Items[i]
+		if len(chosen) > 0 && !chosen[item.ProspectID] {
+			continue
+		}
+		skip := func(reason string) {
+			res.Skipped = append(res.Skipped, SkippedApply{ProspectID: item.ProspectID, Reason: reason})
+		}
+		switch {
+		case item.Applied:
+			skip("already applied")
+			continue
+		case item.Error != "" || item.NewMessage == "":
+			skip("regeneration failed")
+			continue
+		}
+
+		prospect, err := s.Store.GetProspect(item.ProspectID)
+		if errors.Is(err, store.ErrNotFound) {
+			skip("prospect no longer exists")
+			continue
+		}
+		if err != nil {
+			log.Printf("error while getting prospect: %v\n", err)
+			utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+			return
+		}
+		if prospect.Message != item.OldMessage {
+			skip("message changed since the regeneration started")
+			continue
+		}
+		prospect.Message, prospect.Status = item.NewMessage, item.Status
+		prospect.Error = ""
+		// An approval was for the old message
+		s.awaitApproval(prospect)
+		prospects = append(prospects, prospect)
+		item.Applied = true
+		item.AppliedAt = time.Now()
+	}
+
+	if err := s.Store.SaveRegenerationWithProspects(regen, prospects); err != nil {
+		log.Printf("error while applying regeneration: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	res.Applied = len(prospects)
+	if res.Applied > 0 {
+		s.record(Activity{Kind: ActivityRegenerationApplied, Actor: regen.Owner, BatchID: regen.BatchID, Detail: fmt.Sprintf("%d messages", res.Applied)})
+	}
+	utils.WriteResponse(w, res, 200)
+}
+
+func (s *Server) runRegeneration(regen *models.Regeneration, releaseLease func()) {
+	defer releaseLease()
+	regen.Status = models.BatchRunning
+	s.saveRegeneration(regen)
+
+	sender, err := s.Store.GetSender(regen.Owner)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Printf("error while getting sender for regeneration %s: %v\n", regen.ID, err)
+	}
+
+	opts := s.settingsFor(regen.Owner)
+	for i := range regen.Items {
+		item := &regen.Items[i]
+		prospect, err := s.Store.GetProspect(item.ProspectID)
+		if err != nil {
+			item.Error = err.Error()
+			continue
+		}
+		pc := enrich.ProspectContext{Profile: prospect.Profile, Enrichment: prospect.Enrichment, Job: prospect.Job, Goal: prospect.Goal, Sources: prospect.Sources}
+		// Nothing is scraped, the profile was stored with the prospect
+		generating := s.observe("regeneration", latency.Queue, regen.CreatedAt)
+		cost := &jobCost{}
+		_, msg, status, err := s.generate(pc, sender, opts, cost)
+		regen.Cost.Add(*cost.report(s.LLMPrices))
+		if err != nil {
+			log.Printf("error while regenerating message for %s: %v\n", item.LinkedinUrl, err)
+			item.Error = err.Error()
+		} else {
+			s.observe("regeneration", latency.Generate, generating)
+			s.observe("regeneration", latency.Total, regen.CreatedAt)
+		}
+		item.NewMessage, item.Status = msg, status
+		item.Diff = diff.Words(item.OldMessage, msg)
+		// Saved per prospect so the diff view fills in while the rest are generated
+		s.saveRegeneration(regen)
+	}
+
+	regen.Status = models.BatchDone
+	regen.CompletedAt = time.Now()
+	s.saveRegeneration(regen)
+}
+
+func (s *Server) saveRegeneration(regen *models.Regeneration) {
+	if err := s.Store.SaveRegeneration(regen); err != nil {
+		log.Printf("error while saving regeneration %s: %v\n", regen.ID, err)
+	}
+}
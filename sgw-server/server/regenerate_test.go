@@ -0,0 +1,78 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+)
+
+func TestGetRegenerationOnlyForOwner(t *testing.T) {
+	_, ts := newTestServer(t)
+	home(t, ts, "a@x.com", "https://www.linkedin.com/in/one/")
+
+	var created CreateRegenerationRes
+	if code := call(t, ts, http.MethodPost, "/api/regenerations", &RegenerationReq{Email: "a@x.com"}, &created); code != http.StatusAccepted {
+		t.Fatalf("POST /api/regenerations: status %d", code)
+	}
+	if created.Prospects != 1 {
+		t.Fatalf("regenerating %d prospects, want 1", created.Prospects)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	var regen models.Regeneration
+	for regen.Status != models.BatchDone {
+		if time.Now().After(deadline) {
+			t.Fatalf("regeneration still %s after 5s", regen.Status)
+		}
+		if code := call(t, ts, http.MethodGet, "/api/regenerations/"+created.ID+"?email=a@x.com", nil, &regen); code != http.StatusOK {
+			t.Fatalf("GET as owner: status %d", code)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if len(regen.Items) != 1 || regen.Items[0].NewMessage == "" {
+		t.Errorf("items = %+v", regen.Items)
+	}
+
+	if code := call(t, ts, http.MethodGet, "/api/regenerations/"+created.ID+"?email=b@x.com", nil, nil); code != http.StatusNotFound {
+		t.Errorf("other user: status %d, want 404", code)
+	}
+	if code := call(t, ts, http.MethodGet, "/api/regenerations/"+created.ID, nil, nil); code != http.StatusBadRequest {
+		t.Errorf("no email: status %d, want 400", code)
+	}
+}
+
+func TestRegenerateCampaign(t *testing.T) {
+	s, ts := newTestServer(t)
+	sourced := runTestBatch(t, ts, "a@x.com", "https://www.linkedin.com/in/one/", "https://www.linkedin.com/in/two/")
+	runTestBatch(t, ts, "a@x.com", "https://www.linkedin.com/in/three/")
+	var created CreateBatchRes
+	req := &BatchReq{Email: "a@x.com", Password: "secret", LinkedinUrls: []string{"https://www.linkedin.com/in/four/"}, DryRun: true}
+	if code := call(t, ts, http.MethodPost, "/api/batches", req, &created); code != http.StatusAccepted {
+		t.Fatalf("POST dry run batch: status %d", code)
+	}
+	dryRun := waitTestBatch(t, ts, "a@x.com", created.ID)
+
+	campaign := &models.Campaign{ID: "c1", Owner: "a@x.com", Name: "Data leads", Query: "data", BatchIDs: []string{sourced.ID, dryRun.ID}, CreatedAt: time.Now()}
+	if err := s.Store.SaveCampaign(campaign); err != nil {
+		t.Fatal(err)
+	}
+
+	// The campaign's batches only, its dry run's drafts included
+	var regen CreateRegenerationRes
+	if code := call(t, ts, http.MethodPost, "/api/regenerations", &RegenerationReq{Email: "a@x.com", CampaignID: "c1"}, &regen); code != http.StatusAccepted {
+		t.Fatalf("POST /api/regenerations: status %d", code)
+	}
+	if regen.Prospects != 3 {
+		t.Errorf("regenerating %d prospects, want the campaign's 3", regen.Prospects)
+	}
+
+	if code := call(t, ts, http.MethodPost, "/api/regenerations", &RegenerationReq{Email: "b@x.com", CampaignID: "c1"}, nil); code != http.StatusNotFound {
+		t.Errorf("other user's campaign: status %d, want 404", code)
+	}
+	both := &RegenerationReq{Email: "a@x.com", CampaignID: "c1", BatchID: sourced.ID}
+	if code := call(t, ts, http.MethodPost, "/api/regenerations", both, nil); code != http.StatusBadRequest {
+		t.Errorf("batchId and campaignId: status %d, want 400", code)
+	}
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/relevance"
+)
+
+func TestGoalRanksPosts(t *testing.T) {
+	s, ts := newTestServer(t)
+	priya := fake.ProfileURL(fake.Profiles[0])
+	message := func(email, goal string) *ListProfilesRes {
+		t.Helper()
+		if code := call(t, ts, http.MethodPost, "/api/home", &HomeReq{Email: email, Password: "secret", LinkedinUrl: priya, Goal: goal}, nil); code != http.StatusOK {
+			t.Fatalf("POST /api/home: status %d", code)
+		}
+		var res ListProfilesRes
+		call(t, ts, http.MethodGet, "/api/profiles?email="+email, nil, &res)
+		if len(res.Profiles) != 1 {
+			t.Fatalf("got %d prospects, want 1", len(res.Profiles))
+		}
+		return &res
+	}
+
+	if p := message("a@x.com", "").Profiles[0]; !strings.Contains(p.Message, "We cut our daily batch window") {
+		t.Errorf("message without a goal = %q, want the latest post", p.Message)
+	}
+	p := message("b@x.com", "Hiring data engineers").Profiles[0]
+	if !strings.Contains(p.Message, "Hiring two senior data engineers") || p.Goal != "Hiring data engineers" {
+		t.Errorf("message for hiring = %q, goal %q, want the hiring post", p.Message, p.Goal)
+	}
+
+	s.PostRanking = relevance.RankEmbedding
+	p = message("c@x.com", "late events in churn models").Profiles[0]
+	if !strings.Contains(p.Message, "Hot take: most churn models") {
+		t.Errorf("message by embeddings = %q, want the churn post", p.Message)
+	}
+	if p.Cost == nil || p.Cost.EmbeddingTokens == 0 || p.Cost.LLMCalls != 2 {
+		t.Errorf("cost = %+v, want the embeddings counted", p.Cost)
+	}
+
+	long := &HomeReq{Email: "d@x.com", Password: "secret", LinkedinUrl: priya, Goal: strings.Repeat("a", maxGoalLength+1)}
+	if code := call(t, ts, http.MethodPost, "/api/home", long, nil); code != http.StatusBadRequest {
+		t.Errorf("overlong goal: status %d, want 400", code)
+	}
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/i18n"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/openai"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+// Reload reads SelectorsFile and PromptFile again and applies them: the selectors, page
+// scripts and rewrites to the pages scrapes open from then on, the prompt to messages
+// written from then on, so neither needs a restart that drains running batches. Both
+// files are read before either is applied, and a file that can't be read, parsed or
+// applied changes nothing. An unset file goes back to the built-in pages or prompt.
+func (s *Server) Reload() (*ReloadRes, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	pages, err := readPages(s.SelectorsFile)
+	if err != nil {
+		return nil, err
+	}
+	prompt, err := readPrompt(s.PromptFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := scraper.SetPages(pages); err != nil {
+		return nil, fmt.Errorf("selectors file %s can't be applied: %w", s.SelectorsFile, err)
+	}
+	openai.SetMessagePrompt(prompt)
+
+	res := &ReloadRes{Prompt: "default", PagesVersion: scraper.PagesVersion(), ReloadedAt: time.Now()}
+	if pages != nil {
+		res.Selectors, res.Scripts = len(pages.Selectors)+len(pages.Rewrites), len(pages.Scripts)
+	}
+	if prompt != "" {
+		res.Prompt = "file"
+	}
+	log.Printf("Reloaded %d selectors and %d page scripts and the %s message prompt\n", res.Selectors, res.Scripts, res.Prompt)
+	return res, nil
+}
+
+// ReloadOnHangup calls Reload whenever the process gets a SIGHUP, until the returned
+// func is called. A failed reload is logged and keeps what was applied before.
+func (s *Server) ReloadOnHangup() func() {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-hup:
+				if _, err := s.Reload(); err != nil {
+					log.Printf("error while reloading on SIGHUP: %v\n", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(hup)
+		close(done)
+	}
+}
+
+/*
+	readPages reads the override of the built-in page scripts and selectors, nil when
+	file is unset.
+
+The file is a scraper.Pages object, with the version of the built-in pages it
+was written for, or a JSON object of the selectors to rewrite to the ones
+replacing them, as files were before scripts and selectors had names.
+*/
+func readPages(file string) (*scraper.Pages, error) {
+	if file == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read selectors: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("selectors file %s is not a JSON object: %w", file, err)
+	}
+	pages := &scraper.Pages{Version: scraper.PagesVersion()}
+	if _, versioned := fields["version"]; versioned {
+		err = json.Unmarshal(data, pages)
+	} else {
+		err = json.Unmarshal(data, &pages.Rewrites)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("selectors file %s is neither page scripts and selectors nor a JSON object of selector to new selector: %w", file, err)
+	}
+	for old, selector := range pages.Rewrites {
+		if strings.TrimSpace(old) == "" || strings.TrimSpace(selector) == "" {
+			return nil, fmt.Errorf("selectors file %s rewrites %q to %q, neither may be empty", file, old, selector)
+		}
+	}
+	return pages, nil
+}
+
+// readPrompt reads the message prompt, "" for openai.DefaultMessagePrompt.
+func readPrompt(file string) (string, error) {
+	if file == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read prompt: %w", err)
+	}
+	prompt := strings.TrimSpace(string(data))
+	if prompt == "" {
+		return "", errors.New("prompt file " + file + " is empty")
+	}
+	return prompt, nil
+}
+
+// ReloadConfig reloads the selectors and the message prompt for support staff, the
+// operators who fix them when LinkedIn changes its pages.
+func (s *Server) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	if !s.isSupportStaff(r, r.URL.Query().Get("email")) {
+		utils.WriteResponse(w, "only support staff can reload the configuration", http.StatusForbidden)
+		return
+	}
+	res, err := s.Reload()
+	if err != nil {
+		log.Printf("error while reloading: %v\n", err)
+		utils.WriteResponse(w, i18n.Format("%s, nothing was reloaded", err), http.StatusUnprocessableEntity)
+		return
+	}
+	utils.WriteResponse(w, res, 200)
+}
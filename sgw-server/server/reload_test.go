@@ -0,0 +1,71 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/openai"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestReload(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.SupportStaff, s.SupportToken = []string{"support@x.com"}, "staff-secret"
+	staff := http.Header{SupportTokenHeader: {"staff-secret"}}
+	dir := t.TempDir()
+	s.SelectorsFile, s.PromptFile = filepath.Join(dir, "selectors.json"), filepath.Join(dir, "prompt.txt")
+	t.Cleanup(func() {
+		scraper.SetPages(nil)
+		openai.SetMessagePrompt("")
+	})
+	write := func(file, data string) {
+		if err := os.WriteFile(file, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(s.SelectorsFile, `{".pvs-list__paged-list-item": ".new-item"}`)
+	write(s.PromptFile, "Write a one line connect message.\n")
+
+	if code := callWith(t, ts, http.MethodPost, "/api/reload?email=a@x.com", staff, nil, nil); code != http.StatusForbidden {
+		t.Errorf("reload by non-staff: status %d, want 403", code)
+	}
+	if code := call(t, ts, http.MethodPost, "/api/reload?email=support@x.com", nil, nil); code != http.StatusForbidden {
+		t.Errorf("reload by a staff email without the token: status %d, want 403", code)
+	}
+	var res ReloadRes
+	if code := callWith(t, ts, http.MethodPost, "/api/reload?email=support@x.com", staff, nil, &res); code != http.StatusOK {
+		t.Fatalf("reload: status %d", code)
+	}
+	if res.Selectors != 1 || res.Prompt != "file" || openai.MessagePrompt() != "Write a one line connect message." {
+		t.Errorf("reload = %+v, prompt %q", res, openai.MessagePrompt())
+	}
+
+	// Named selectors and scripts written for this build's pages, and not for another's
+	write(s.SelectorsFile, fmt.Sprintf(`{"version": %d, "selectors": {"list.entry": ".new-item"}, "scripts": {"lead-about": "''"}}`, scraper.PagesVersion()))
+	if res, err := s.Reload(); err != nil || res.Selectors != 1 || res.Scripts != 1 || scraper.Selector("list.entry") != ".new-item" {
+		t.Errorf("reload of named selectors = %+v, %v, list.entry %q", res, err, scraper.Selector("list.entry"))
+	}
+	write(s.SelectorsFile, fmt.Sprintf(`{"version": %d, "selectors": {"list.entry": ".newer-item"}}`, scraper.PagesVersion()+1))
+	if _, err := s.Reload(); err == nil || scraper.Selector("list.entry") != ".new-item" {
+		t.Errorf("reload of another version's pages = %v, list.entry %q", err, scraper.Selector("list.entry"))
+	}
+
+	// A broken selectors file keeps the prompt that was applied with the working one
+	write(s.SelectorsFile, `[".pvs-list__paged-list-item"]`)
+	write(s.PromptFile, "Write a haiku.")
+	if code := callWith(t, ts, http.MethodPost, "/api/reload?email=support@x.com", staff, nil, nil); code != http.StatusUnprocessableEntity {
+		t.Errorf("reload of a broken file: status %d, want 422", code)
+	}
+	if got := openai.MessagePrompt(); got != "Write a one line connect message." {
+		t.Errorf("prompt after a failed reload = %q", got)
+	}
+
+	// Unset files go back to what is built in
+	s.SelectorsFile, s.PromptFile = "", ""
+	if res, err := s.Reload(); err != nil || res.Selectors != 0 || openai.MessagePrompt() != openai.DefaultMessagePrompt {
+		t.Errorf("reload without files = %+v, %v", res, err)
+	}
+}
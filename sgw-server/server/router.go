@@ -14,4 +14,329 @@ func (s *Server) Routes() {
 		}
 		s.Home(w, r)
 	})))
+	s.Router.HandleFunc("/api/sender", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.Sender(w, r)
+	})))
+	s.Router.HandleFunc("/api/search", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.SearchPeople(w, r)
+	})))
+	s.Router.HandleFunc("/api/profiles", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.ListProfiles(w, r)
+	})))
+	s.Router.HandleFunc("/api/batches", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.CreateBatch(w, r)
+	})))
+	s.Router.HandleFunc("/api/batches/{id}", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.GetBatch(w, r)
+	})))
+	s.Router.HandleFunc("/api/icp-filters", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			s.CreateICPFilter(w, r)
+		case http.MethodGet:
+			s.ListICPFilters(w, r)
+		default:
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		}
+	})))
+	s.Router.HandleFunc("/api/icp-filters/{id}", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.DeleteICPFilter(w, r)
+	})))
+	s.Router.HandleFunc("/api/campaigns", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			s.CreateCampaign(w, r)
+		case http.MethodGet:
+			s.ListCampaigns(w, r)
+		default:
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		}
+	})))
+	s.Router.HandleFunc("/api/campaigns/{id}", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.GetCampaign(w, r)
+	})))
+	s.Router.HandleFunc("/api/campaigns/{id}/source", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.SourceCampaign(w, r)
+	})))
+	s.Router.HandleFunc("/api/campaigns/{id}/cost", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.CampaignCost(w, r)
+	})))
+	s.Router.HandleFunc("/api/settings", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.GetSettings(w, r)
+		case http.MethodPut:
+			s.SaveSettings(w, r)
+		default:
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		}
+	})))
+	s.Router.HandleFunc("/api/cooldown", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.GetCooldown(w, r)
+		case http.MethodDelete:
+			s.EndCooldown(w, r)
+		default:
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		}
+	})))
+	s.Router.HandleFunc("/api/config-bundle", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.ExportBundle(w, r)
+		case http.MethodPost:
+			s.ImportBundle(w, r)
+		default:
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		}
+	})))
+	s.Router.HandleFunc("/api/regenerations", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.CreateRegeneration(w, r)
+	})))
+	s.Router.HandleFunc("/api/regenerations/{id}", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.GetRegeneration(w, r)
+	})))
+	s.Router.HandleFunc("/api/regenerations/{id}/apply", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.ApplyRegeneration(w, r)
+	})))
+	s.Router.HandleFunc("/api/prospects/{id}/share", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.CreateShareLink(w, r)
+	})))
+	s.Router.HandleFunc("/api/prospects/{id}/timeline", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.ProspectTimeline(w, r)
+	})))
+	s.Router.HandleFunc("/m/{token}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.SharedMessage(w, r)
+	})
+	s.Router.HandleFunc("/api/verifications", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.ListVerifications(w, r)
+	})))
+	s.Router.HandleFunc("/api/otp", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.ListOTPRequests(w, r)
+	})))
+	s.Router.HandleFunc("/api/otp/{token}", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.SubmitOTP(w, r)
+	})))
+	// Called by the SMS provider, not browsers
+	s.Router.HandleFunc("/api/otp/sms", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.OTPSMS(w, r)
+	})
+	s.Router.HandleFunc("/verify/{token}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.VerificationPage(w, r)
+	})
+	s.Router.HandleFunc("/verify/{token}/frame", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.VerificationFrame(w, r)
+	})
+	s.Router.HandleFunc("/verify/{token}/input", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.VerificationInput(w, r)
+	})
+	s.Router.HandleFunc("/api/approvals", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.ListApprovals(w, r)
+	})))
+	s.Router.HandleFunc("/api/prospects/{id}/review", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.ReviewProspect(w, r)
+	})))
+	s.Router.HandleFunc("/api/teams/{team}/activity", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.TeamActivity(w, r)
+	})))
+	s.Router.HandleFunc("/api/stats/caches", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.CacheStats(w, r)
+	})))
+	s.Router.HandleFunc("/api/stats/breakers", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.BreakerStats(w, r)
+	})))
+	s.Router.HandleFunc("/api/stats/latency", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.LatencyStats(w, r)
+	})))
+	s.Router.HandleFunc("/api/schemas", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.ListSchemas(w, r)
+	})))
+	s.Router.HandleFunc("/api/schemas/{name}", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.GetSchema(w, r)
+	})))
+	s.Router.HandleFunc("/api/schemas/{name}/validate", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.ValidateDocument(w, r)
+	})))
+	s.Router.HandleFunc("/api/openapi.json", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.GetOpenAPI(w, r)
+	})))
+	s.Router.HandleFunc("/api/support/users/{email}", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.ViewAsUser(w, r)
+	})))
+	s.Router.HandleFunc("/api/audit", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.ListAudit(w, r)
+	})))
+	s.Router.HandleFunc("/api/reload", utils.WithCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.ReloadConfig(w, r)
+	})))
+	s.Router.HandleFunc("/api/health", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.Health(w, r)
+	})
+	s.Router.HandleFunc("/api/ready", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.Ready(w, r)
+	})
+	// GET as well, the only method a preStop httpGet hook sends
+	s.Router.HandleFunc("/api/drain", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.DrainInstance(w, r)
+	})
+	s.Router.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.Metrics(w, r)
+	})
 }
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+// errDraining turns work away while the instance drains before it stops.
+var errDraining = errors.New("this instance is shutting down")
+
+/*
+	lifecycle is where the instance is between starting and stopping, for orchestrators
+	such as Kubernetes routing traffic to it.
+
+An instance is not ready until MarkReady, after its browsers are warmed up, and
+stops being ready when it starts draining. Draining instances refuse new batches,
+regenerations and scrapes, and Drain waits for the ones running. It is safe for
+concurrent use.
+*/
+type lifecycle struct {
+	mu       sync.Mutex
+	ready    bool
+	draining bool
+	jobs     int           // Batches and regenerations running in the background
+	changed  chan struct{} // Closed and replaced whenever a job ends
+}
+
+func newLifecycle() *lifecycle {
+	return &lifecycle{changed: make(chan struct{})}
+}
+
+// begin counts a background job, false without counting it while draining.
+func (l *lifecycle) begin() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.draining {
+		return false
+	}
+	l.jobs++
+	return true
+}
+
+func (l *lifecycle) end() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.jobs--
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+func (l *lifecycle) isDraining() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.draining
+}
+
+// MarkReady makes /api/ready succeed, once the browsers are warmed up and interrupted
+// work is recovered.
+func (s *Server) MarkReady() {
+	s.life.mu.Lock()
+	defer s.life.mu.Unlock()
+	s.life.ready = true
+	log.Printf("Ready for traffic\n")
+}
+
+/*
+	Drain stops the instance taking on work and waits for what it runs to finish.
+
+Batches, regenerations and requests holding browsers are waited for until ctx
+ends, which returns how many are left; a batch cut off is failed as interrupted
+by whichever instance starts next. Drain may be called more than once, by the
+preStop hook and the SIGTERM following it.
+*/
+func (s *Server) Drain(ctx context.Context) (int, error) {
+	s.life.mu.Lock()
+	if !s.life.draining {
+		s.life.draining = true
+		log.Printf("Draining, waiting for %d running batches and regenerations\n", s.life.jobs)
+	}
+	s.life.mu.Unlock()
+	for {
+		s.life.mu.Lock()
+		jobs, changed := s.life.jobs, s.life.changed
+		s.life.mu.Unlock()
+		s.load.mu.Lock()
+		browsers := s.load.browsers
+		s.load.mu.Unlock()
+		if jobs == 0 && browsers == 0 {
+			return 0, nil
+		}
+		// Browsers are released without ending a job, so they are polled
+		select {
+		case <-ctx.Done():
+			return jobs + browsers, ctx.Err()
+		case <-changed:
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
+
+// Ready answers 200 once MarkReady was called, 503 while warming up and draining, for
+// readiness probes. Unlike Health it doesn't touch the store, so it is cheap to poll.
+func (s *Server) Ready(w http.ResponseWriter, r *http.Request) {
+	s.life.mu.Lock()
+	ready, draining := s.life.ready, s.life.draining
+	s.life.mu.Unlock()
+	switch {
+	case draining:
+		utils.WriteResponse(w, &ReadyRes{Status: "draining"}, http.StatusServiceUnavailable)
+	case !ready:
+		utils.WriteResponse(w, &ReadyRes{Status: "warming up"}, http.StatusServiceUnavailable)
+	default:
+		utils.WriteResponse(w, &ReadyRes{Status: "ready"}, 200)
+	}
+}
+
+// DrainInstance drains the instance for a preStop hook, answering once the running work
+// finished or DrainTimeout passed. Only requests from the instance itself may drain it.
+func (s *Server) DrainInstance(w http.ResponseWriter, r *http.Request) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if ip := net.ParseIP(host); err != nil || ip == nil || !ip.IsLoopback() {
+		utils.WriteResponse(w, "only the instance itself can drain it", http.StatusForbidden)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), s.DrainTimeout)
+	defer cancel()
+	left, err := s.Drain(ctx)
+	res := &DrainRes{Status: "drained"}
+	if err != nil {
+		log.Printf("error while draining, %d jobs left: %v\n", left, err)
+		res.Status, res.Running = "timed out", left
+	}
+	utils.WriteResponse(w, res, 200)
+}
+
+// Start serves the API on port until the process gets a SIGTERM or SIGINT, then drains
+// for up to DrainTimeout and stops once the open requests are answered.
+func (m *Server) Start(port string) error {
+	log.Printf("Starting auction server at address: %s\n", port)
+	srv := &http.Server{Addr: ":" + port, Handler: m.Router}
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
+	defer signal.Stop(stop)
+
+	served := make(chan error, 1)
+	go func() { served <- srv.ListenAndServe() }()
+	select {
+	case err := <-served:
+		return err
+	case sig := <-stop:
+		log.Printf("Got %s, shutting down\n", sig)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), m.DrainTimeout)
+	defer cancel()
+	if left, err := m.Drain(ctx); err != nil {
+		log.Printf("error while draining, stopping with %d jobs left: %v\n", left, err)
+	}
+	// What is left of the timeout lets requests still being answered finish
+	if err := srv.Shutdown(ctx); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadyAndDrain(t *testing.T) {
+	s, ts := newTestServer(t)
+	var ready ReadyRes
+	if code := call(t, ts, http.MethodGet, "/api/ready", nil, nil); code != http.StatusServiceUnavailable {
+		t.Errorf("ready while warming up: status %d, want 503", code)
+	}
+	s.MarkReady()
+	if code := call(t, ts, http.MethodGet, "/api/ready", nil, &ready); code != 200 || ready.Status != "ready" {
+		t.Errorf("ready once marked: status %d, %+v", code, ready)
+	}
+
+	// A request holding a browser keeps the drain waiting
+	_, release, err := s.acquireScraper("b@x.com", "secret", "", job{name: "home"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if left, err := s.Drain(ctx); left != 1 || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("drain with a browser held = %d, %v, want 1 left", left, err)
+	}
+	if code := call(t, ts, http.MethodGet, "/api/ready", nil, nil); code != http.StatusServiceUnavailable {
+		t.Errorf("ready while draining: status %d, want 503", code)
+	}
+	res, _ := postBusy(t, ts, "/api/batches", &BatchReq{Email: "a@x.com", Password: "secret", LinkedinUrls: []string{"https://www.linkedin.com/in/one/"}})
+	if res.StatusCode != http.StatusServiceUnavailable || res.Header.Get("Retry-After") == "" {
+		t.Errorf("batch while draining: status %d, Retry-After %q, want 503 with a Retry-After", res.StatusCode, res.Header.Get("Retry-After"))
+	}
+	if code := call(t, ts, http.MethodPost, "/api/home", &HomeReq{Email: "a@x.com", Password: "secret", LinkedinUrl: "https://www.linkedin.com/in/one/"}, nil); code != http.StatusServiceUnavailable {
+		t.Errorf("home while draining: status %d, want 503", code)
+	}
+
+	release()
+	var drained DrainRes
+	if code := call(t, ts, http.MethodGet, "/api/drain", nil, &drained); code != 200 || drained.Status != "drained" {
+		t.Errorf("preStop drain: status %d, %+v", code, drained)
+	}
+	// Only the instance itself may drain it
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/drain", nil)
+	req.RemoteAddr = "192.0.2.1:40000"
+	s.Router.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("drain from another host: status %d, want 403", w.Code)
+	}
+}
+
+func TestMemoryPressure(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.MaxBrowsers = 4
+	root := t.TempDir()
+	old := cgroupRoot
+	cgroupRoot = root
+	t.Cleanup(func() { cgroupRoot = old })
+	cgroup := func(current, limit, inactive string) {
+		t.Helper()
+		for name, v := range map[string]string{"memory.current": current, "memory.max": limit, "memory.stat": "anon 1\ninactive_file " + inactive + "\n"} {
+			if err := os.WriteFile(filepath.Join(root, name), []byte(v+"\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	cgroup("900", "1000", "0")
+	s.checkPressure(0.85)
+	for _, email := range []string{"b@x.com", "c@x.com"} {
+		if _, _, err := s.acquireScraper(email, "secret", "", job{name: "home"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var busy *busyError
+	if err := s.admitScrape(); !errors.As(err, &busy) {
+		t.Errorf("admitScrape with 2 of 4 browsers in use under pressure = %v, want busy", err)
+	}
+
+	// Reclaimable page cache isn't pressure, and neither is memory without a limit
+	cgroup("900", "1000", "200")
+	s.checkPressure(0.85)
+	if err := s.admitScrape(); err != nil {
+		t.Errorf("admitScrape once memory is back = %v", err)
+	}
+	cgroup("900", "max", "0")
+	s.checkPressure(0.85)
+	if err := s.admitScrape(); err != nil {
+		t.Errorf("admitScrape without a memory limit = %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+// SessionScraper is a Scraper whose cookies can be saved, so later scrapers log in without the login form.
+type SessionScraper interface {
+	Session() (*scraper.Session, error)
+}
+
+// RestoreFactory returns a scraper logged in with a saved session and positioned at linkedInURL.
+type RestoreFactory func(session *scraper.Session, email, password, linkedInURL string) (Scraper, error)
+
+func restoreChromeScraper(session *scraper.Session, email, password, linkedInURL string) (Scraper, error) {
+	return scraper.NewScraperFromSession(session, email, password, linkedInURL)
+}
+
+// login returns a scraper logged in as email. The account's saved session is tried first,
+// so most scrapes skip the login form LinkedIn puts checkpoints in front of; without one,
+// or when LinkedIn no longer accepts it, the scraper logs in through the form and its
+// session is saved for next time. While the account's logins keep failing it fails fast
+// with a *breaker.OpenError.
+func (s *Server) login(email, password, linkedinUrl string) (Scraper, error) {
+	done, err := s.Breakers.Get(loginBreaker(email)).Allow()
+	if err != nil {
+		return nil, err
+	}
+	sc, err := s.loginWithSession(email, password, linkedinUrl)
+	done(loginFailure(err))
+	return sc, err
+}
+
+func (s *Server) loginWithSession(email, password, linkedinUrl string) (Scraper, error) {
+	if !s.SaveSessions {
+		return s.NewScraper(email, password, linkedinUrl)
+	}
+	if session := s.savedSession(email, password); session != nil {
+		sc, err := s.RestoreScraper(session, email, password, linkedinUrl)
+		if err == nil {
+			return sc, nil
+		}
+		// Logging in through the form would only make a flagged account worse
+		if errors.Is(err, scraper.ErrBotDetected) || errors.Is(err, scraper.ErrAccountRestricted) {
+			return nil, err
+		}
+		log.Printf("error while restoring the session of %s, logging in: %v\n", email, err)
+		if errors.Is(err, scraper.ErrNotAuthenticated) {
+			s.forgetSession(email)
+		}
+	}
+
+	sc, err := s.NewScraper(email, password, linkedinUrl)
+	if err != nil {
+		return nil, err
+	}
+	s.saveSession(sc, email, password)
+	return sc, nil
+}
+
+// savedSession opens the account's saved session, nil when there is none or password doesn't open it.
+func (s *Server) savedSession(email, password string) *scraper.Session {
+	saved, err := s.Store.GetSession(email)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			log.Printf("error while getting saved session: %v\n", err)
+		}
+		return nil
+	}
+	raw, err := utils.Open(utils.CreateHash(password, saved.Salt), saved.Sealed)
+	if err != nil {
+		// Saved with another password; the next login replaces it
+		return nil
+	}
+	var session scraper.Session
+	if err := json.Unmarshal(raw, &session); err != nil {
+		log.Printf("error while decoding saved session: %v\n", err)
+		return nil
+	}
+	return &session
+}
+
+// saveSession seals the cookies of sc with password and stores them as the account's
+// session. Scrapers that can't give their cookies, such as fakes, are left alone.
+func (s *Server) saveSession(sc Scraper, email, password string) {
+	ss, ok := sc.(SessionScraper)
+	if !s.SaveSessions || !ok {
+		return
+	}
+	session, err := ss.Session()
+	if err != nil {
+		log.Printf("error while reading the session of %s: %v\n", email, err)
+		return
+	}
+	raw, err := json.Marshal(session)
+	if err != nil {
+		log.Printf("error while encoding session: %v\n", err)
+		return
+	}
+	salt, err := utils.GenerateSalt()
+	if err != nil {
+		log.Printf("error while generating session salt: %v\n", err)
+		return
+	}
+	sealed, err := utils.Seal(utils.CreateHash(password, salt), raw)
+	if err != nil {
+		log.Printf("error while sealing session: %v\n", err)
+		return
+	}
+	err = s.Store.SaveSession(&models.Session{Email: email, Salt: salt, Sealed: sealed, SavedAt: time.Now()})
+	if err != nil {
+		log.Printf("error while saving session: %v\n", err)
+	}
+}
+
+func (s *Server) forgetSession(email string) {
+	if err := s.Store.DeleteSession(email); err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Printf("error while deleting saved session: %v\n", err)
+	}
+}
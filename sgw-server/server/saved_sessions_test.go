@@ -0,0 +1,98 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// cookieScraper is a fake scraper with a cookie jar, as a logged in Chrome has.
+type cookieScraper struct {
+	Scraper
+	liAt string
+}
+
+func (c *cookieScraper) Session() (*scraper.Session, error) {
+	return &scraper.Session{Cookies: []scraper.Cookie{{Name: "li_at", Value: c.liAt, Domain: ".www.linkedin.com", Path: "/"}}}, nil
+}
+
+func TestLoginsReuseTheSavedSession(t *testing.T) {
+	s, ts := newTestServer(t)
+	backend := fake.Backend{}
+	var logins, restores []string
+	s.NewScraper = func(email, password, url string) (Scraper, error) {
+		logins = append(logins, password)
+		sc, _ := backend.NewScraper(email, password, url)
+		return &cookieScraper{Scraper: sc, liAt: "token-" + password}, nil
+	}
+	var restoreErr error
+	s.RestoreScraper = func(session *scraper.Session, email, password, url string) (Scraper, error) {
+		restores = append(restores, session.LiAt())
+		if restoreErr != nil {
+			return nil, restoreErr
+		}
+		sc, _ := backend.NewScraper(email, password, url)
+		return &cookieScraper{Scraper: sc, liAt: session.LiAt()}, nil
+	}
+	request := func(password string) {
+		t.Helper()
+		req := &HomeReq{Email: "a@x.com", Password: password, LinkedinUrl: "https://www.linkedin.com/in/priya/"}
+		if code := call(t, ts, http.MethodPost, "/api/home", req, nil); code != http.StatusOK {
+			t.Fatalf("POST /api/home: status %d", code)
+		}
+	}
+
+	request("secret")
+	saved, err := s.Store.GetSession("a@x.com")
+	if err != nil {
+		t.Fatalf("GetSession after the first login: %v", err)
+	}
+	if bytes.Contains(saved.Sealed, []byte("token-secret")) {
+		t.Error("the saved session holds the li_at cookie in the clear")
+	}
+
+	request("secret")
+	if fmt.Sprint(logins) != "[secret]" || fmt.Sprint(restores) != "[token-secret]" {
+		t.Fatalf("second request: logins %v, restores %v; want the saved session restored", logins, restores)
+	}
+
+	// Another password can't open the session and logs in through the form
+	request("other")
+	if fmt.Sprint(logins) != "[secret other]" || len(restores) != 1 {
+		t.Fatalf("other password: logins %v, restores %v", logins, restores)
+	}
+
+	// An expired session is replaced by a fresh login's
+	restoreErr = fmt.Errorf("failed to restore session: %w", scraper.ErrNotAuthenticated)
+	request("other")
+	restoreErr = nil
+	request("other")
+	if fmt.Sprint(logins) != "[secret other other]" || fmt.Sprint(restores) != "[token-secret token-other token-other]" {
+		t.Fatalf("expired session: logins %v, restores %v", logins, restores)
+	}
+}
+
+func TestSaveSessionsOffAlwaysLogsIn(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.SaveSessions = false
+	backend := fake.Backend{}
+	logins := 0
+	s.NewScraper = func(email, password, url string) (Scraper, error) {
+		logins++
+		sc, _ := backend.NewScraper(email, password, url)
+		return &cookieScraper{Scraper: sc, liAt: "token"}, nil
+	}
+	for range 2 {
+		home(t, ts, "a@x.com", "https://www.linkedin.com/in/priya/")
+	}
+	if logins != 2 {
+		t.Errorf("logins = %d, want 2", logins)
+	}
+	if _, err := s.Store.GetSession("a@x.com"); err == nil {
+		t.Error("a session was saved with SaveSessions off")
+	}
+}
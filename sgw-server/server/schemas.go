@@ -0,0 +1,111 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/i18n"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/payload"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/schema"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+// maxValidateBody bounds the documents /api/schemas/{name}/validate reads.
+const maxValidateBody = 5 << 20
+
+// schemas are the published resource contracts, by name: a scraped profile, the message
+// /api/home answers with, the stored prospect /api/profiles and batches list, and the
+// events webhooks receive.
+var schemas = map[string]*schema.Schema{
+	"profile":  schema.For(reflect.TypeFor[scraper.Profile](), "LinkedIn profile"),
+	"message":  schema.For(reflect.TypeFor[HomeRes](), "Generated connect message"),
+	"prospect": schema.For(reflect.TypeFor[models.Prospect](), "Prospect with its profile and message"),
+	"event":    schema.For(reflect.TypeFor[models.Event](), "Webhook event"),
+}
+
+func (s *Server) schemaURL(name string) string {
+	return s.PublicBaseURL + "/api/schemas/" + name
+}
+
+// ListSchemas returns where each published schema is.
+func (s *Server) ListSchemas(w http.ResponseWriter, r *http.Request) {
+	res := &SchemasRes{Schemas: map[string]string{}, ProfileSchemaVersion: scraper.ProfileSchemaVersion, PayloadVersion: payload.Current, PayloadVersions: []PayloadVersion{}}
+	for name := range schemas {
+		res.Schemas[name] = s.schemaURL(name)
+	}
+	for _, v := range payload.Versions {
+		if payload.Supported(v.Number, time.Now()) != nil {
+			continue
+		}
+		pv := PayloadVersion{Version: v.Number}
+		if !v.Deprecated.IsZero() {
+			pv.Deprecated, pv.Sunset = &v.Deprecated, &v.Sunset
+		}
+		res.PayloadVersions = append(res.PayloadVersions, pv)
+	}
+	sort.Slice(res.PayloadVersions, func(i, j int) bool { return res.PayloadVersions[i].Version < res.PayloadVersions[j].Version })
+	utils.WriteResponse(w, res, 200)
+}
+
+// GetSchema returns a resource's JSON Schema (draft 2020-12).
+func (s *Server) GetSchema(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	published, ok := schemas[name]
+	if !ok {
+		utils.WriteResponse(w, "schema not found", http.StatusNotFound)
+		return
+	}
+	res := *published
+	res.ID = s.schemaURL(name)
+	w.Header().Set("Content-Type", "application/schema+json")
+	w.WriteHeader(200)
+	json.NewEncoder(w).Encode(&res)
+}
+
+// ValidateDocument checks the JSON document in the body against a resource's schema,
+// for integrators testing what they send or store against the contract.
+func (s *Server) ValidateDocument(w http.ResponseWriter, r *http.Request) {
+	published, ok := schemas[r.PathValue("name")]
+	if !ok {
+		utils.WriteResponse(w, "schema not found", http.StatusNotFound)
+		return
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxValidateBody))
+	if err != nil {
+		utils.WriteResponse(w, "body is larger than 5MB", http.StatusRequestEntityTooLarge)
+		return
+	}
+	errs, err := published.Validate(data)
+	if err != nil {
+		utils.WriteResponse(w, i18n.Format("body is not a JSON document: %s", err), http.StatusBadRequest)
+		return
+	}
+	utils.WriteResponse(w, &ValidationRes{Valid: len(errs) == 0, Errors: errs}, 200)
+}
+
+// checkResponse validates v against the named schema with ValidateResponses set, and logs
+// where the response breaks the published contract. The response is sent either way.
+func (s *Server) checkResponse(name string, v any) {
+	if !s.ValidateResponses {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("error while validating %s response: %v\n", name, err)
+		return
+	}
+	errs, err := schemas[name].Validate(data)
+	if err != nil {
+		log.Printf("error while validating %s response: %v\n", name, err)
+	}
+	for _, e := range errs {
+		log.Printf("error while validating %s response: %v\n", name, e)
+	}
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+)
+
+// validate posts doc to the schema's validation endpoint.
+func validate(t *testing.T, ts *httptest.Server, name string, doc []byte) ValidationRes {
+	t.Helper()
+	res, err := ts.Client().Post(ts.URL+"/api/schemas/"+name+"/validate", "application/json", bytes.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("POST /api/schemas/%s/validate: status %d", name, res.StatusCode)
+	}
+	var out ValidationRes
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestSchemasDescribeResponses(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.PublicBaseURL = ts.URL
+	var list SchemasRes
+	if code := call(t, ts, http.MethodGet, "/api/schemas", nil, &list); code != http.StatusOK || len(list.Schemas) != 4 {
+		t.Fatalf("GET /api/schemas: status %d, %+v", code, list)
+	}
+	res, err := ts.Client().Get(list.Schemas["profile"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var published map[string]any
+	json.NewDecoder(res.Body).Decode(&published)
+	res.Body.Close()
+	if published["$id"] != s.PublicBaseURL+"/api/schemas/profile" || res.Header.Get("Content-Type") != "application/schema+json" {
+		t.Errorf("profile schema served as %q with $id %v", res.Header.Get("Content-Type"), published["$id"])
+	}
+
+	// What the server sends is valid against what it publishes
+	body, _ := json.Marshal(&HomeReq{Email: "a@x.com", Password: "secret", LinkedinUrl: "https://www.linkedin.com/in/one/"})
+	homeRes, err := ts.Client().Post(ts.URL+"/api/home", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, _ := io.ReadAll(homeRes.Body)
+	homeRes.Body.Close()
+	if got := validate(t, ts, "message", message); !got.Valid {
+		t.Errorf("the /api/home response is invalid: %+v", got.Errors)
+	}
+	var prospects struct {
+		Profiles []json.RawMessage `json:"profiles"`
+	}
+	listRes, err := ts.Client().Get(ts.URL + "/api/profiles?email=a@x.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	json.NewDecoder(listRes.Body).Decode(&prospects)
+	listRes.Body.Close()
+	if len(prospects.Profiles) != 1 {
+		t.Fatalf("%d prospects, want 1", len(prospects.Profiles))
+	}
+	if got := validate(t, ts, "prospect", prospects.Profiles[0]); !got.Valid {
+		t.Errorf("the listed prospect is invalid: %+v", got.Errors)
+	}
+	var prospect models.Prospect
+	json.Unmarshal(prospects.Profiles[0], &prospect)
+	profile, _ := json.Marshal(prospect.Profile)
+	if got := validate(t, ts, "profile", profile); !got.Valid {
+		t.Errorf("the stored profile is invalid: %+v", got.Errors)
+	}
+
+	got := validate(t, ts, "profile", []byte(`{"Name": 3, "Experience": [{"title": "CTO", "seniority": "c-level"}]}`))
+	if got.Valid || len(got.Errors) == 0 || got.Errors[0].Path != "" || !strings.Contains(got.Errors[0].Message, "is missing") {
+		t.Errorf("broken profile = %+v, want it invalid with what is missing", got)
+	}
+	if code := call(t, ts, http.MethodPost, "/api/schemas/profile/validate", "not json", nil); code != http.StatusOK {
+		// A JSON string is a document too, just not a profile
+		t.Errorf("validating a string: status %d", code)
+	}
+	if code := call(t, ts, http.MethodGet, "/api/schemas/invoice", nil, nil); code != http.StatusNotFound {
+		t.Errorf("unknown schema: status %d, want 404", code)
+	}
+}
+
+func TestValidateResponsesLogsViolations(t *testing.T) {
+	s, _ := newTestServer(t)
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	s.checkResponse("prospect", &models.Prospect{})
+	if logged.Len() != 0 {
+		t.Fatalf("logged without ValidateResponses: %s", logged.String())
+	}
+	s.ValidateResponses = true
+	s.checkResponse("prospect", &models.Prospect{})
+	if logged.Len() != 0 {
+		t.Errorf("a valid prospect logged: %s", logged.String())
+	}
+	s.checkResponse("profile", map[string]any{"Name": 3})
+	if !strings.Contains(logged.String(), "error while validating profile response: /Name: is an integer, want string") {
+		t.Errorf("logged %q", logged.String())
+	}
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/i18n"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+// maxSearchPages caps how many pages of people search results one request may fetch.
+const maxSearchPages = 10
+
+// SearchPeople runs a LinkedIn people search with the user's account and returns the
+// profiles found on d.Pages pages from d.Filters.Page on.
+func (s *Server) SearchPeople(w http.ResponseWriter, r *http.Request) {
+	d := &SearchReq{}
+	if err := utils.DecodeReqBody(r, d); err != nil {
+		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
+		return
+	}
+	if !utils.ValidEmail(d.Email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	if d.Pages == 0 {
+		d.Pages = 1
+	}
+	if d.Pages < 0 || d.Pages > maxSearchPages {
+		utils.WriteResponse(w, i18n.Format("pages must be between 1 and %d", maxSearchPages), http.StatusBadRequest)
+		return
+	}
+	if _, err := scraper.SearchURL(d.Query, d.Filters); err != nil {
+		utils.WriteResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if writeBusy(w, s.admitScrape()) {
+		return
+	}
+	sc, release, err := s.acquireScraper(d.Email, d.Password, "", job{name: "search"})
+	if errors.Is(err, errAccountBusy) {
+		utils.WriteResponse(w, "this LinkedIn account is busy, please try again shortly", http.StatusServiceUnavailable)
+		return
+	}
+	if writeCooling(w, err) || writeOpenBreaker(w, err, i18n.Format("logging in to this LinkedIn account")) || s.writeLoginError(w, d.Email, err) {
+		return
+	}
+	if err != nil {
+		log.Printf("error while logging in: %v\n", err)
+		utils.WriteResponse(w, "could not log in to LinkedIn, please try again later", 500)
+		return
+	}
+
+	found, err := s.searchPeople(r.Context(), sc, d.Query, d.Filters, d.Pages)
+	release()
+	if err != nil {
+		s.accountStopped(d.Email, job{name: "search"}, err)
+		if found.Page == 0 {
+			utils.WriteResponse(w, "could not search LinkedIn, please try again later", 500)
+			return
+		}
+	}
+	utils.WriteResponse(w, &SearchRes{Results: found.Results, Page: found.Page, HasMore: found.HasMore}, 200)
+}
+
+// searchPeople fetches up to pages pages of people search results from filters.Page on
+// with an already logged in scraper, dropping people listed twice. When a page fails the
+// pages before it are returned with the error; Page is the last page fetched, 0 for none.
+func (s *Server) searchPeople(ctx context.Context, sc Scraper, query string, filters scraper.SearchFilters, pages int) (scraper.SearchPage, error) {
+	found := scraper.SearchPage{Results: []scraper.SearchResult{}}
+	seen := map[string]bool{}
+	first := max(filters.Page, 1)
+	for filters.Page = first; filters.Page < first+pages && filters.Page <= scraper.MaxSearchPage; filters.Page++ {
+		sc.Renew(scraper.DefaultLease)
+		page, err := sc.SearchPeople(ctx, query, filters)
+		if err != nil {
+			log.Printf("error while searching people, page %d: %v\n", filters.Page, err)
+			return found, err
+		}
+		for _, r := range page.Results {
+			if !seen[r.URL] {
+				seen[r.URL] = true
+				found.Results = append(found.Results, r)
+			}
+		}
+		found.Page, found.HasMore = page.Page, page.HasMore
+		if !page.HasMore {
+			break
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestSearchPeople(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	var res SearchRes
+	req := &SearchReq{Email: "a@x.com", Password: "secret", Query: "data", Filters: scraper.SearchFilters{Company: "moonfrog"}}
+	if code := call(t, ts, http.MethodPost, "/api/search", req, &res); code != http.StatusOK {
+		t.Fatalf("POST /api/search: status %d", code)
+	}
+	if len(res.Results) != 1 || res.Results[0].Name != "Priya Raman" || res.Page != 1 || res.HasMore {
+		t.Fatalf("results = %+v", res)
+	}
+
+	// Results scrape back to the person listed
+	home(t, ts, "a@x.com", res.Results[0].URL)
+	prospects, err := s.Store.ListProspects("a@x.com")
+	if err != nil || len(prospects) != 1 || prospects[0].Profile.Name != "Priya Raman" {
+		t.Errorf("scraped %+v, %v; want Priya Raman", prospects, err)
+	}
+
+	for name, bad := range map[string]*SearchReq{
+		"nothing to search": {Email: "a@x.com", Password: "secret"},
+		"too many pages":    {Email: "a@x.com", Password: "secret", Query: "data", Pages: maxSearchPages + 1},
+		"unknown network":   {Email: "a@x.com", Password: "secret", Query: "data", Filters: scraper.SearchFilters{Network: []string{"2nd"}}},
+	} {
+		if code := call(t, ts, http.MethodPost, "/api/search", bad, nil); code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", name, code)
+		}
+	}
+}
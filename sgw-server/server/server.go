@@ -3,23 +3,203 @@ package server
 import (
 	"log"
 	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/config"
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/breaker"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/cache"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/condense"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/enrich"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/latency"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/openai"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/persona"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/relevance"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scoring"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/sharelink"
+	"github.com/hemantsharma1498/segwise-assignment/store"
 )
 
 type Server struct {
 	Router       *http.ServeMux
 	OpenAIApiKey string
-}
+	Store        *store.Store
+	// PersonaLLMAssist lets the persona classifier fall back to OpenAI
+	// when the title-based rules can't decide.
+	PersonaLLMAssist bool
+	// ScoringWeights are the prospect scoring weights used unless a batch overrides them.
+	ScoringWeights scoring.Weights
+	// ScrapeBudget is the time allowed for scraping a single prospect; low-priority
+	// sections are skipped rather than letting the whole scrape time out.
+	ScrapeBudget time.Duration
+	// PromptBudget is the size of profile JSON, in bytes, long profiles are condensed to
+	// before a message is written; 0 hands the model every profile whole.
+	PromptBudget int
+	// PostMaxAge leaves posts older than it out of the prompt, 0 keeps them all; PostRanking
+	// orders the rest by relevance to the goal of the message, by keywords or embeddings.
+	PostMaxAge  time.Duration
+	PostRanking string
+	// MinSignals is how many details, such as an About, a post or a role, a profile needs
+	// before a message is written from it; thinner ones get a generic fallback message
+	// flagged as insufficient_personalization. 0 writes to every profile.
+	MinSignals int
+	// Degradation decides which sections are scraped per prospect and what
+	// replaces sections that come back thin.
+	Degradation DegradationPolicy
+	// WebhookURL and SlackWebhookURL receive events through the outbox relay, by default
+	// all of them; see DefaultNotifyRoutes.
+	WebhookURL      string
+	SlackWebhookURL string
+	// AccountAlertURL receives by default only the high-priority checkpoint and
+	// restriction events, which also go to WebhookURL and SlackWebhookURL.
+	AccountAlertURL string
+	// Notifiers are the channels besides the URLs above events are delivered to, such as
+	// email, and replace the URLs' channels when they set the same destination.
+	Notifiers map[models.Destination]Notifier
+	// NotifyRoutes decide which channels receive which events, after the routes users
+	// save in their settings and TeamNotifyRoutes of their team, keyed by lowercased name.
+	NotifyRoutes     []config.Route
+	TeamNotifyRoutes map[string][]config.Route
+	// AccountCooldown is how long a LinkedIn account stays idle after LinkedIn flagged it
+	// as automated or restricted it. Its batches pause or move to a teammate's warm session.
+	AccountCooldown time.Duration
+	// RateLimitCooldown is how long an account stays idle after LinkedIn rate limited it.
+	RateLimitCooldown time.Duration
+	// PublicBaseURL is where users reach the server, share links point below it.
+	PublicBaseURL string
+	// ShareLinks signs /m/{token} links to generated messages, valid for ShareLinkTTL.
+	// The default random key invalidates links on restart.
+	ShareLinks   *sharelink.Signer
+	ShareLinkTTL time.Duration
+	// RequireApproval marks generated messages pending until one of Reviewers approves them.
+	// Reviewers prove who they are with their ReviewerTokens entry, by lowercased email, in
+	// the ReviewerTokenHeader of their requests.
+	RequireApproval bool
+	Reviewers       []string
+	ReviewerTokens  map[string]string
+	// SupportStaff may view any user's data through /api/support/users/{email}, each view audit
+	// logged, proving who they are with SupportToken in the SupportTokenHeader of their requests.
+	SupportStaff []string
+	SupportToken string
+	// Enrichment runs the sources that add what pages outside LinkedIn say about prospects,
+	// such as the websites in their contact info. Contact info is only scraped when there are any.
+	Enrichment enrich.Pipeline
+	// NativeLanguageMessages writes messages in the language a prospect lists as native,
+	// instead of English.
+	NativeLanguageMessages bool
+	// Teams decides whose activity each user sees in /api/teams/{team}/activity.
+	Teams []config.Team
+	// InstanceID identifies this process in the leases it takes in the store, which
+	// keep instances sharing a store from processing the same work.
+	InstanceID string
+	// Breakers make OpenAI calls and LinkedIn logins fail fast with 503s while they keep
+	// failing, instead of piling up requests waiting on them. See ProtectedLLM and login.
+	Breakers *breaker.Set
+	// MaxQueueDepth is how many profiles of running batches may wait to be scraped before
+	// new batches are turned away with a 429, and MaxBrowsers how many scrapers may be in
+	// use before new scrapes and batches are; 0 doesn't limit. Both count this instance only.
+	MaxQueueDepth int
+	MaxBrowsers   int
+	// ValidateResponses checks profile, message and prospect responses against their
+	// published JSON Schemas and logs where they don't match, a debug mode for catching
+	// changes that break the contract integrators code against.
+	ValidateResponses bool
+	// OTPSMSSecret guards the /api/otp/sms webhook an SMS provider forwards texts to, and
+	// OTPPhones maps the numbers they are sent to onto the accounts they are for.
+	OTPSMSSecret string
+	OTPPhones    map[string]string
+	// WebhookPayloadVersion and AccountAlertPayloadVersion pin the payload version events
+	// are delivered to WebhookURL and AccountAlertURL in; 0 sends payload.Current.
+	WebhookPayloadVersion      int
+	AccountAlertPayloadVersion int
+	// SelectorsFile and PromptFile are read again by Reload, on SIGHUP or /api/reload, so
+	// selectors LinkedIn broke and the message prompt are fixed without a restart.
+	SelectorsFile string
+	PromptFile    string
+	// LLMPrices estimate the LLM spend recorded in prospects' costs from the tokens used.
+	LLMPrices openai.Prices
+	// DrainTimeout is how long Drain waits for running batches and regenerations when the
+	// instance is stopped, below the orchestrator's grace period before it kills the process.
+	DrainTimeout time.Duration
 
-func InitServer(OpenAIApiKey string) *Server {
-	s := &Server{Router: http.NewServeMux(), OpenAIApiKey: OpenAIApiKey}
-	s.Routes()
-	return s
+	// NewScraper and LLM default to Chrome and OpenAI; tools such as cmd/loadtest swap in fakes.
+	NewScraper ScraperFactory
+	LLM        LLM
+	// PublicScraper reads logged out profile pages for requests without a password.
+	PublicScraper PublicScraperFactory
+	// SaveSessions keeps the cookies of each account's logged in browser in the store, sealed
+	// with the account's password, and RestoreScraper logs later scrapers in with them instead
+	// of the login form, which is where LinkedIn raises its checkpoints.
+	SaveSessions   bool
+	RestoreScraper RestoreFactory
+
+	warmMu sync.Mutex
+	warm   map[string]*warmSession
+
+	activity *activityFeed
+	load     *load
+	life     *lifecycle
+
+	alertMu sync.Mutex
+	alerted map[string]time.Time // Last account alert per account and kind
+
+	verifyMu      sync.Mutex
+	verifications map[string]*verification // Checkpoints being solved remotely, by token
+
+	otpMu sync.Mutex
+	otps  map[string]*otpRequest // Logins waiting for a verification code, by token
+
+	reloadMu sync.Mutex // Keeps a SIGHUP and /api/reload from applying files half each
+
+	// personaCache keeps LLM persona answers by lowercased title
+	personaCache *cache.LRU[string, persona.Persona]
+
+	// latencies times home requests, batch profiles and regenerations by stage
+	latencies *latency.Recorder
 }
 
-func (m *Server) Start(port string) error {
-	log.Printf("Starting auction server at address: %s\n", port)
-	if err := http.ListenAndServe(":"+port, m.Router); err != nil {
-		return err
+func InitServer(OpenAIApiKey string, store *store.Store) *Server {
+	shareLinks, err := sharelink.NewRandom()
+	if err != nil {
+		log.Panicf("Failed to create share link key: %s\n", err)
 	}
-	return nil
+	s := &Server{
+		Router:            http.NewServeMux(),
+		OpenAIApiKey:      OpenAIApiKey,
+		Store:             store,
+		ScoringWeights:    scoring.DefaultWeights,
+		ScrapeBudget:      90 * time.Second,
+		PromptBudget:      condense.DefaultBudget,
+		PostRanking:       relevance.RankKeyword,
+		MinSignals:        DefaultMinSignals,
+		Degradation:       DefaultDegradationPolicy,
+		Notifiers:         map[models.Destination]Notifier{},
+		NotifyRoutes:      DefaultNotifyRoutes,
+		PublicBaseURL:     "http://localhost:3100",
+		AccountCooldown:   24 * time.Hour,
+		RateLimitCooldown: time.Hour,
+		ShareLinks:        shareLinks,
+		ShareLinkTTL:      24 * time.Hour,
+		NewScraper:        newChromeScraper,
+		LLM:               openAILLM{apiKey: OpenAIApiKey},
+		PublicScraper:     newPublicScraper,
+		SaveSessions:      true,
+		RestoreScraper:    restoreChromeScraper,
+		InstanceID:        newInstanceID(),
+		Breakers:          breaker.NewSet(breaker.DefaultThreshold, breaker.DefaultCooldown),
+		LLMPrices:         openai.DefaultPrices,
+		DrainTimeout:      25 * time.Second,
+		warm:              map[string]*warmSession{},
+		activity:          newActivityFeed(),
+		load:              newLoad(),
+		life:              newLifecycle(),
+		alerted:           map[string]time.Time{},
+		verifications:     map[string]*verification{},
+		otps:              map[string]*otpRequest{},
+		personaCache:      cache.New[string, persona.Persona](1024),
+		latencies:         latency.New(latency.DefaultWindow),
+	}
+	s.Routes()
+	return s
 }
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+// newTestServer returns a server on a fresh store in t.TempDir that scrapes with
+// fake.Backend, logged in or not, and writes with fake.LLM, so no browser, LinkedIn
+// login or OpenAI key is needed. The HTTP server is closed when the test ends.
+func newTestServer(t *testing.T) (*Server, *httptest.Server) {
+	t.Helper()
+	st, err := store.NewStore(filepath.Join(t.TempDir(), "segwise.json"))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { st.Close() })
+
+	s := InitServer("unused", st)
+	backend := fake.Backend{}
+	s.NewScraper = func(email, password, url string) (Scraper, error) {
+		return backend.NewScraper(email, password, url)
+	}
+	s.PublicScraper = func(url string) Scraper {
+		sc, _ := backend.NewScraper("", "", url)
+		return sc
+	}
+	s.LLM = &fake.LLM{}
+
+	ts := httptest.NewServer(s.Router)
+	t.Cleanup(ts.Close)
+	return s, ts
+}
+
+// call sends body as JSON (when not nil) and decodes the response into out (when not nil).
+func call(t *testing.T, ts *httptest.Server, method, path string, body, out any) int {
+	t.Helper()
+	return callWith(t, ts, method, path, nil, body, out)
+}
+
+// callWith is call sending header too.
+func callWith(t *testing.T, ts *httptest.Server, method, path string, header http.Header, body, out any) int {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("marshal %s %s: %v", method, path, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, ts.URL+path, reader)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	res, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer res.Body.Close()
+	if out != nil && res.StatusCode < 300 {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			t.Fatalf("decode %s %s: %v", method, path, err)
+		}
+	}
+	return res.StatusCode
+}
+
+// home generates a message for url as email through POST /api/home.
+func home(t *testing.T, ts *httptest.Server, email, url string) {
+	t.Helper()
+	if code := call(t, ts, http.MethodPost, "/api/home", &HomeReq{Email: email, Password: "secret", LinkedinUrl: url}, nil); code != http.StatusOK {
+		t.Fatalf("POST /api/home as %s: status %d", email, code)
+	}
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/config"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/breaker"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// warmSession is a pre-authenticated scraper for a configured account. mu is held
+// for as long as a request or keep-alive ping uses the scraper.
+type warmSession struct {
+	mu      sync.Mutex
+	account config.Account
+	scraper Scraper
+}
+
+// WarmUp logs the accounts in and pings their feed every pingInterval to keep the
+// sessions alive. Accounts that fail to log in are skipped and fall back to a fresh
+// login per request.
+func (s *Server) WarmUp(accounts []config.Account, pingInterval time.Duration) {
+	for _, account := range accounts {
+		log.Printf("Warming up LinkedIn session for %s\n", account.Email)
+		sc, err := s.login(account.Email, account.Password, "")
+		if err != nil {
+			log.Printf("error while warming up %s: %v\n", account.Email, err)
+			s.recordLoginFailure(account.Email, job{name: "warm-up"}, err)
+			continue
+		}
+		ws := &warmSession{account: account, scraper: sc}
+		s.warmMu.Lock()
+		s.warm[key(account.Email)] = ws
+		s.warmMu.Unlock()
+		go s.keepAlive(ws, pingInterval)
+	}
+}
+
+func (s *Server) keepAlive(ws *warmSession, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		// Pings are activity too, a cooling account is left alone
+		if _, ok := s.cooldown(ws.account.Email); ok {
+			continue
+		}
+		// A request using the account keeps the session alive anyway
+		releaseAccount, ok, err := s.holdLease(accountLease(ws.account.Email), s.InstanceID+"/keep-alive")
+		if err != nil || !ok {
+			if err != nil {
+				log.Printf("error while leasing %s for keep-alive: %v\n", ws.account.Email, err)
+			}
+			continue
+		}
+		ws.mu.Lock()
+		ws.scraper.Renew(time.Minute)
+		err = ws.scraper.Ping()
+		if errors.Is(err, scraper.ErrNotAuthenticated) {
+			log.Printf("warm session for %s expired, logging in again\n", ws.account.Email)
+			if err = ws.scraper.Relogin(); err == nil {
+				s.saveSession(ws.scraper, ws.account.Email, ws.account.Password)
+			}
+		}
+		if err != nil {
+			log.Printf("warm session for %s lost, restarting browser: %v\n", ws.account.Email, err)
+			s.accountStopped(ws.account.Email, job{name: "keep-alive"}, err)
+			ws.scraper.Close()
+			sc, err := s.login(ws.account.Email, ws.account.Password, "")
+			if err != nil {
+				log.Printf("error while re-warming %s: %v\n", ws.account.Email, err)
+				s.recordLoginFailure(ws.account.Email, job{name: "keep-alive"}, err)
+				s.dropWarmSession(ws.account.Email)
+				ws.mu.Unlock()
+				releaseAccount()
+				return
+			}
+			ws.scraper = sc
+		}
+		ws.mu.Unlock()
+		releaseAccount()
+	}
+}
+
+// acquireScraper returns a logged in scraper pointed at linkedinUrl and the function
+// that gives it back. Warm sessions are reused when the credentials match the
+// configured account; otherwise a fresh scraper is logged in, with the account's saved
+// session when it has one, and closed on release.
+// The account is used by one request at a time across instances, a busy account
+// fails with errAccountBusy after accountWait, one cooling off fails with a *coolingError
+// and one whose logins keep failing with a *breaker.OpenError.
+// j is reported if LinkedIn stops the login.
+func (s *Server) acquireScraper(email, password, linkedinUrl string, j job) (Scraper, func(), error) {
+	if c, ok := s.cooldown(email); ok {
+		return nil, nil, &coolingError{until: c.Until}
+	}
+	// Without a warm session the request would wait for the account only to fail its login
+	if s.warmSession(email) == nil {
+		if err := s.Breakers.Get(loginBreaker(email)).Err(); err != nil {
+			return nil, nil, err
+		}
+	}
+	releaseAccount, err := s.holdAccount(email)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ws := s.warmSession(email); ws != nil {
+		ws.mu.Lock()
+		if subtle.ConstantTimeCompare([]byte(ws.account.Password), []byte(password)) == 1 && s.warmSession(email) == ws {
+			ws.scraper.Renew(scraper.DefaultLease)
+			ws.scraper.SetProfileURL(linkedinUrl)
+			return ws.scraper, s.load.hold(func() {
+				ws.mu.Unlock()
+				releaseAccount()
+			}), nil
+		}
+		ws.mu.Unlock()
+	}
+
+	sc, err := s.login(email, password, linkedinUrl)
+	if err != nil {
+		releaseAccount()
+		if !errors.Is(err, breaker.ErrOpen) {
+			s.recordLoginFailure(email, j, err)
+		}
+		return nil, nil, err
+	}
+	return sc, s.load.hold(func() {
+		// LinkedIn refreshes cookies while scraping, the next login starts from the latest
+		s.saveSession(sc, email, password)
+		sc.Close()
+		releaseAccount()
+	}), nil
+}
+
+func (s *Server) warmSession(email string) *warmSession {
+	s.warmMu.Lock()
+	defer s.warmMu.Unlock()
+	return s.warm[key(email)]
+}
+
+func (s *Server) dropWarmSession(email string) {
+	s.warmMu.Lock()
+	defer s.warmMu.Unlock()
+	delete(s.warm, key(email))
+}
+
+func key(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
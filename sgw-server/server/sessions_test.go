@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/config"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// exclusiveScraper fails the test when two requests scrape with it at the same time.
+type exclusiveScraper struct {
+	Scraper
+	t     *testing.T
+	inUse atomic.Bool
+}
+
+func (e *exclusiveScraper) ScrapeWithBudget(ctx context.Context, budget time.Duration, sections ...scraper.Section) []scraper.SectionResult {
+	if !e.inUse.CompareAndSwap(false, true) {
+		e.t.Error("two requests scraped with the same warm session at once")
+		return e.Scraper.ScrapeWithBudget(ctx, budget, sections...)
+	}
+	defer e.inUse.Store(false)
+	return e.Scraper.ScrapeWithBudget(ctx, budget, sections...)
+}
+
+func TestWarmSessionConcurrentRequests(t *testing.T) {
+	s, ts := newTestServer(t)
+	backend := fake.Backend{SectionLatency: time.Millisecond}
+	var logins atomic.Int32
+	s.NewScraper = func(email, password, url string) (Scraper, error) {
+		logins.Add(1)
+		sc, err := backend.NewScraper(email, password, url)
+		if err != nil {
+			return nil, err
+		}
+		return &exclusiveScraper{Scraper: sc, t: t}, nil
+	}
+	s.WarmUp([]config.Account{{Email: "a@x.com", Password: "secret"}}, time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := &HomeReq{Email: "A@x.com", Password: "secret", LinkedinUrl: fmt.Sprintf("https://www.linkedin.com/in/p%d/", i)}
+			if code := call(t, ts, http.MethodPost, "/api/home", req, nil); code != http.StatusOK {
+				t.Errorf("POST /api/home: status %d", code)
+			}
+		}(i)
+	}
+	// A wrong password never gets the warm session
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		req := &HomeReq{Email: "a@x.com", Password: "wrong", LinkedinUrl: "https://www.linkedin.com/in/other/"}
+		if code := call(t, ts, http.MethodPost, "/api/home", req, nil); code != http.StatusOK {
+			t.Errorf("POST /api/home with another password: status %d", code)
+		}
+	}()
+	wg.Wait()
+
+	if n := logins.Load(); n != 2 {
+		t.Errorf("%d logins, want the warm-up and one for the other password", n)
+	}
+	prospects, err := s.Store.ListProspects("a@x.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prospects) != 11 {
+		t.Errorf("%d prospects stored, want 11", len(prospects))
+	}
+	for _, p := range prospects {
+		if p.Profile.Name == "" || p.Message == "" {
+			t.Errorf("prospect %s has profile %q and message %q", p.LinkedinUrl, p.Profile.Name, p.Message)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/config"
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scoring"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+// settings are what generation runs with for one user: the server's settings with the
+// user's saved overrides applied.
+type settings struct {
+	weights        scoring.Weights
+	degradation    DegradationPolicy
+	nativeLanguage bool
+	personaAssist  bool
+	// routes are the user's own notification routes, tried before their team's and the server's
+	routes []config.Route
+}
+
+// settingsFor returns the settings in effect for email. Overrides that can't be read are
+// logged and the server's settings used instead.
+func (s *Server) settingsFor(email string) settings {
+	effective := settings{
+		weights:        s.ScoringWeights,
+		degradation:    s.Degradation,
+		nativeLanguage: s.NativeLanguageMessages,
+		personaAssist:  s.PersonaLLMAssist,
+	}
+	saved, err := s.Store.GetSettings(email)
+	if errors.Is(err, store.ErrNotFound) {
+		return effective
+	}
+	if err != nil {
+		log.Printf("error while getting settings for %s: %v\n", email, err)
+		return effective
+	}
+	if saved.ScoringWeights != nil {
+		effective.weights = *saved.ScoringWeights
+	}
+	if saved.Fallbacks != "" {
+		// Validated when saved, an error means the rules changed since
+		if rules, err := config.ParseFallbacks(saved.Fallbacks); err != nil {
+			log.Printf("error while parsing fallbacks for %s: %v\n", email, err)
+		} else {
+			effective.degradation.Fallbacks = rules
+		}
+	}
+	if saved.NotifyRoutes != "" {
+		if routes, err := config.ParseRoutes(saved.NotifyRoutes); err != nil {
+			log.Printf("error while parsing notify routes for %s: %v\n", email, err)
+		} else {
+			effective.routes = routes
+		}
+	}
+	if saved.NativeLanguage != nil {
+		effective.nativeLanguage = *saved.NativeLanguage
+	}
+	if saved.PersonaLLMAssist != nil {
+		effective.personaAssist = *saved.PersonaLLMAssist
+	}
+	return effective
+}
+
+// validateSettings checks overrides before they are saved.
+func validateSettings(weights *scoring.Weights, fallbacks, routes string) error {
+	if w := weights; w != nil && (w.TitleMatch < 0 || w.CompanySize < 0 || w.RecentActivity < 0 || w.OpenToWork < 0) {
+		return errors.New("scoring weights can't be negative")
+	}
+	if fallbacks != "" {
+		if _, err := config.ParseFallbacks(fallbacks); err != nil {
+			return fmt.Errorf("invalid fallbacks: %w", err)
+		}
+	}
+	if routes != "" {
+		if _, err := config.ParseRoutes(routes); err != nil {
+			return fmt.Errorf("invalid notify routes: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetSettings returns a user's saved overrides, empty when they saved none.
+func (s *Server) GetSettings(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	saved, err := s.Store.GetSettings(email)
+	if errors.Is(err, store.ErrNotFound) {
+		saved, err = &models.Settings{Owner: email}, nil
+	}
+	if err != nil {
+		log.Printf("error while getting settings: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	utils.WriteResponse(w, saved, 200)
+}
+
+// SaveSettings replaces a user's overrides; fields left out fall back to the server's settings.
+func (s *Server) SaveSettings(w http.ResponseWriter, r *http.Request) {
+	d := &SettingsReq{}
+	if err := utils.DecodeReqBody(r, d); err != nil {
+		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
+		return
+	}
+	if !utils.ValidEmail(d.Email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	if err := validateSettings(d.ScoringWeights, d.Fallbacks, d.NotifyRoutes); err != nil {
+		utils.WriteResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	saved := &models.Settings{
+		Owner:            d.Email,
+		ScoringWeights:   d.ScoringWeights,
+		Fallbacks:        d.Fallbacks,
+		NativeLanguage:   d.NativeLanguage,
+		PersonaLLMAssist: d.PersonaLLMAssist,
+		NotifyRoutes:     d.NotifyRoutes,
+		UpdatedAt:        time.Now(),
+	}
+	if err := s.Store.SaveSettings(saved); err != nil {
+		log.Printf("error while saving settings: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	utils.WriteResponse(w, saved, 200)
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scoring"
+)
+
+func TestSettingsOverrideTheServerDefaults(t *testing.T) {
+	s, ts := newTestServer(t)
+	// One post makes the default fallback fetch experience and the other detail sections
+	backend := fake.Backend{Posts: 1}
+	s.NewScraper = func(email, password, url string) (Scraper, error) {
+		return backend.NewScraper(email, password, url)
+	}
+	weights := &scoring.Weights{RecentActivity: 1}
+	if code := call(t, ts, http.MethodPut, "/api/settings", &SettingsReq{Email: "a@x.com", ScoringWeights: weights, Fallbacks: "none"}, nil); code != http.StatusOK {
+		t.Fatalf("PUT /api/settings: status %d", code)
+	}
+
+	home(t, ts, "a@x.com", "https://www.linkedin.com/in/one/")
+	home(t, ts, "b@x.com", "https://www.linkedin.com/in/one/")
+
+	var own, other ListProfilesRes
+	call(t, ts, http.MethodGet, "/api/profiles?email=a@x.com", nil, &own)
+	call(t, ts, http.MethodGet, "/api/profiles?email=b@x.com", nil, &other)
+	if len(own.Profiles) != 1 || len(other.Profiles) != 1 {
+		t.Fatalf("got %d and %d prospects, want one each", len(own.Profiles), len(other.Profiles))
+	}
+	if p := own.Profiles[0]; p.Profile.Experience != nil || p.Score.Total != 20 {
+		t.Errorf("with overrides: experience %v, score %v; want no fallback and only recent activity scored", p.Profile.Experience, p.Score.Total)
+	}
+	if p := other.Profiles[0]; p.Profile.Experience == nil {
+		t.Error("without overrides the default fallback did not fetch experience")
+	}
+
+	for _, weights := range []scoring.Weights{{TitleMatch: -1}, {CompanySize: -1}} {
+		if code := call(t, ts, http.MethodPut, "/api/settings", &SettingsReq{Email: "a@x.com", ScoringWeights: &weights}, nil); code != http.StatusBadRequest {
+			t.Errorf("negative weight %+v: status %d, want 400", weights, code)
+		}
+	}
+}
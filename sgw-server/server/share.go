@@ -0,0 +1,170 @@
+package server
+
+import (
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/background"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/i18n"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/persona"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/sharelink"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+// shareHookPostWords caps the post excerpt shown as a hook on the share page.
+const shareHookPostWords = 20
+
+// sharePage is rendered through localized, its Funcs translate into the reader's language.
+var sharePage = template.Must(template.New("share").Funcs(i18n.Funcs(i18n.Default)).Parse(`<!DOCTYPE html>
+<html lang="{{lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{t "Suggested message for %s" .Name}}</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 40rem; margin: 2rem auto; padding: 0 1rem; color: #1d2226; }
+blockquote { margin: 1rem 0; padding: 1rem; background: #f3f6f8; border-left: 4px solid #0a66c2; white-space: pre-wrap; }
+small { color: #666; }
+</style>
+</head>
+<body>
+<h1>{{t "Suggested message for %s" .Name}}</h1>
+{{if .Headline}}<p>{{.Headline}}</p>{{end}}
+<blockquote>{{.Message}}</blockquote>
+{{if .Hooks}}<h2>{{t "Why this message"}}</h2>
+<ul>{{range .Hooks}}<li>{{.}}</li>{{end}}</ul>{{end}}
+<p><a href="{{.LinkedinUrl}}" rel="noopener noreferrer">{{t "View profile on LinkedIn"}}</a></p>
+<p><small>{{t "This link expires %s." (.ExpiresAt.Format "Jan 2, 2006 15:04 MST")}}</small></p>
+</body>
+</html>
+`))
+
+type sharePageData struct {
+	Name        string
+	Headline    string
+	Message     string
+	Hooks       []string
+	LinkedinUrl string
+	ExpiresAt   time.Time
+}
+
+// CreateShareLink signs a link to a prospect's generated message that a colleague can
+// open without logging in, until s.ShareLinkTTL passes.
+func (s *Server) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+	d := &ShareLinkReq{}
+	if err := utils.DecodeReqBody(r, d); err != nil {
+		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
+		return
+	}
+	prospect, err := s.Store.GetProspect(r.PathValue("id"))
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Printf("error while getting prospect: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	if err != nil || !strings.EqualFold(prospect.Owner, d.Email) {
+		utils.WriteResponse(w, "prospect not found", http.StatusNotFound)
+		return
+	}
+	if prospect.Draft {
+		utils.WriteResponse(w, "prospect is a draft of a dry run batch, drafts can't be shared", http.StatusConflict)
+		return
+	}
+	if prospect.Message == "" {
+		utils.WriteResponse(w, "prospect has no generated message", http.StatusBadRequest)
+		return
+	}
+
+	expires := time.Now().Add(s.ShareLinkTTL)
+	// Never built from the Host header, a spoofed one would hand out links to another site
+	url := s.PublicBaseURL + "/m/" + s.ShareLinks.Sign(prospect.ID, expires)
+	utils.WriteResponse(w, &ShareLinkRes{Url: url, ExpiresAt: expires}, http.StatusCreated)
+}
+
+// SharedMessage renders the message a share link points to, with the hooks it was built on.
+func (s *Server) SharedMessage(w http.ResponseWriter, r *http.Request) {
+	lang := utils.Localize(w, r)
+	id, expires, err := s.ShareLinks.Verify(r.PathValue("token"), time.Now())
+	if errors.Is(err, sharelink.ErrExpired) {
+		http.Error(w, i18n.T(lang, "This share link has expired, ask for a new one."), http.StatusGone)
+		return
+	}
+	if err != nil {
+		http.Error(w, i18n.T(lang, "Share link not found."), http.StatusNotFound)
+		return
+	}
+	prospect, err := s.Store.GetProspect(id)
+	if errors.Is(err, store.ErrNotFound) {
+		http.Error(w, i18n.T(lang, "Share link not found."), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("error while getting shared prospect: %v\n", err)
+		http.Error(w, i18n.T(lang, "server encountered an error, please try again later"), 500)
+		return
+	}
+
+	data := sharePageData{
+		Name:        prospect.Profile.Name,
+		Message:     prospect.Message,
+		Hooks:       s.shareHooks(prospect, lang),
+		LinkedinUrl: prospect.LinkedinUrl,
+		ExpiresAt:   expires,
+	}
+	if len(prospect.Profile.Experience) > 0 {
+		exp := prospect.Profile.Experience[0]
+		company, _, _ := strings.Cut(exp.Company, "·")
+		data.Headline = strings.TrimSpace(exp.Title + " at " + strings.TrimSpace(company))
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	if err := localized(sharePage, lang).Execute(w, data); err != nil {
+		log.Printf("error while rendering share page: %v\n", err)
+	}
+}
+
+// shareHooks lists what the message could draw on, strongest first, as the prompt ranks them,
+// in lang. Shared backgrounds are described in English, as pkg/background words them.
+func (s *Server) shareHooks(prospect *models.Prospect, lang string) []string {
+	var hooks []string
+	sender, err := s.Store.GetSender(prospect.Owner)
+	if err == nil {
+		for _, h := range background.Shared(sender.Profile, prospect.Profile) {
+			hooks = append(hooks, h.Detail)
+		}
+	}
+	if posts := prospect.Profile.Posts; len(posts) > 0 {
+		// The post that drew the most engagement, the latest one on a tie
+		top := posts[0]
+		for _, p := range posts[1:] {
+			if p.Engagement() > top.Engagement() {
+				top = p
+			}
+		}
+		words := strings.Fields(top.Content)
+		excerpt := strings.Join(words[:min(len(words), shareHookPostWords)], " ")
+		if len(words) > shareHookPostWords {
+			excerpt += "..."
+		}
+		hooks = append(hooks, i18n.Format("Recent post: \"%s\"", excerpt).In(lang))
+	}
+	if p := prospect.Persona; p.Seniority != persona.SeniorityUnknown && p.Seniority != "" {
+		hooks = append(hooks, i18n.Format("Persona: %s, %s", p.Seniority, p.Function).In(lang))
+	}
+	return hooks
+}
+
+// localized returns a copy of page whose Funcs translate into lang, leaving page as it was
+// for requests in other languages.
+func localized(page *template.Template, lang string) *template.Template {
+	return template.Must(page.Clone()).Funcs(i18n.Funcs(lang))
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestShareLinkIgnoresHostHeader(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.PublicBaseURL = "https://segwise.example.com"
+	home(t, ts, "a@x.com", "https://www.linkedin.com/in/one/")
+	prospectID := s.activity.snapshot()[0].ProspectID
+
+	body, _ := json.Marshal(&ShareLinkReq{Email: "a@x.com"})
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/api/prospects/"+prospectID+"/share", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Host = "evil.example.net"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	res, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var link ShareLinkRes
+	if err := json.NewDecoder(res.Body).Decode(&link); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !strings.HasPrefix(link.Url, "https://segwise.example.com/m/") {
+		t.Fatalf("share link = %q, want it below PUBLIC_BASE_URL", link.Url)
+	}
+
+	page := strings.TrimPrefix(link.Url, "https://segwise.example.com")
+	if code := call(t, ts, http.MethodGet, page, nil, nil); code != http.StatusOK {
+		t.Errorf("GET %s: status %d", page, code)
+	}
+}
+
+func TestShareHooksQuoteTheMostEngagedPost(t *testing.T) {
+	s, _ := newTestServer(t)
+	prospect := &models.Prospect{Owner: "a@x.com", Profile: scraper.Profile{Posts: []scraper.Post{
+		{Content: "Latest but quiet", Reactions: 2},
+		{Content: "Older and popular", Reactions: 90, Comments: 14},
+	}}}
+	hooks := s.shareHooks(prospect, "en")
+	if len(hooks) != 1 || !strings.Contains(hooks[0], "Older and popular") {
+		t.Errorf("hooks = %q, want the popular post quoted", hooks)
+	}
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/background"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/enrich"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/relevance"
+)
+
+// DefaultMinSignals is how many details a profile needs to be written to personally.
+const DefaultMinSignals = 2
+
+/*
+signals counts the details of a prospect a message can be personalised on.
+
+Each counts one: a non-empty About, every post within PostMaxAge, article,
+comment, role, received recommendation, volunteer entry, publication and patent,
+every enrichment and every background shared with the sender. Headlines,
+education, skills and the like are left out, they read the same on thousands of
+profiles and make for the templated notes prospects see through.
+*/
+func (s *Server) signals(pc enrich.ProspectContext, shared []background.Hook) int {
+	p := pc.Profile
+	n := len(relevance.Recent(p.Posts, s.PostMaxAge, time.Now())) + len(p.Articles) + len(p.Comments) + len(p.Experience) +
+		len(p.Volunteering) + len(p.Publications) + len(p.Patents) + len(pc.Enrichment) + len(shared)
+	if strings.TrimSpace(p.About) != "" {
+		n++
+	}
+	for _, r := range p.Recommendations {
+		if !r.Given {
+			n++
+		}
+	}
+	return n
+}
+
+// insufficient reports whether a prospect has fewer than s.MinSignals details to write from.
+func (s *Server) insufficient(pc enrich.ProspectContext, shared []background.Hook) bool {
+	return s.MinSignals > 0 && s.signals(pc, shared) < s.MinSignals
+}
+
+// fallbackMessage is the generic connect note sent in place of a personal one, flagged as
+// models.ProspectInsufficientPersonalization; it claims nothing the profile didn't say.
+func fallbackMessage(pc enrich.ProspectContext, sender *models.Sender) string {
+	first, _, _ := strings.Cut(strings.TrimSpace(pc.Profile.Name), " ")
+	if first == "" {
+		first = "there"
+	}
+	msg := fmt.Sprintf("Hi %s, I'd like to add you to my network. Would love to connect.", first)
+	if job := pc.Job; job != nil && job.Title != "" && job.Company != "" {
+		msg = fmt.Sprintf("Hi %s, I'm reaching out about the %s role at %s. Would love to connect.", first, job.Title, job.Company)
+	}
+	if sender != nil && sender.Profile.Name != "" {
+		msg += " - " + sender.Profile.Name
+	}
+	return msg
+}
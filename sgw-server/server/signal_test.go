@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+func TestThinProfileGetsFallbackMessage(t *testing.T) {
+	s, ts := newTestServer(t)
+	// No About, no posts and a single role
+	thin := scraper.Profile{Name: "Sam Ortiz", Headline: "Analyst", Experience: []scraper.Experience{{Title: "Analyst", Company: "Acme"}}}
+	backend := fake.Backend{Profiles: []scraper.Profile{thin}}
+	s.NewScraper = func(email, password, url string) (Scraper, error) {
+		return backend.NewScraper(email, password, url)
+	}
+	url := fake.ProfileURL(thin)
+
+	var res HomeRes
+	if code := call(t, ts, http.MethodPost, "/api/home", &HomeReq{Email: "a@x.com", Password: "secret", LinkedinUrl: url}, &res); code != http.StatusOK {
+		t.Fatalf("POST /api/home: status %d", code)
+	}
+	if res.Status != models.ProspectInsufficientPersonalization || !strings.HasPrefix(res.Msg, "Hi Sam, I'd like to add you to my network.") {
+		t.Errorf("thin profile: status %q, message %q, want the flagged fallback", res.Status, res.Msg)
+	}
+	var list ListProfilesRes
+	call(t, ts, http.MethodGet, "/api/profiles?email=a@x.com", nil, &list)
+	if len(list.Profiles) != 1 {
+		t.Fatalf("got %d prospects, want 1", len(list.Profiles))
+	}
+	if p := list.Profiles[0]; p.Status != models.ProspectInsufficientPersonalization || p.Cost == nil || p.Cost.LLMCalls != 0 {
+		t.Errorf("stored prospect: status %q, cost %+v, want it flagged and no message written", p.Status, p.Cost)
+	}
+
+	s.MinSignals = 0
+	res = HomeRes{}
+	call(t, ts, http.MethodPost, "/api/home", &HomeReq{Email: "b@x.com", Password: "secret", LinkedinUrl: url}, &res)
+	if res.Status != "" || !strings.Contains(res.Msg, "your work as Analyst at Acme") {
+		t.Errorf("without a threshold: status %q, message %q, want one written from the role", res.Status, res.Msg)
+	}
+}
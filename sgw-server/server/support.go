@@ -0,0 +1,121 @@
+package server
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+// AuditSupportView is the audit action of support staff viewing a user's data.
+const AuditSupportView = "support.view"
+
+// SupportTokenHeader carries the SupportToken staff prove who they are with, their email
+// alone being something anyone can type.
+const SupportTokenHeader = "X-Support-Token"
+
+// ViewAsUser shows support staff what a user sees, their sender profile, settings, ICP
+// filters, campaigns and prospects, so they can reproduce an issue without the user's
+// password. It is read-only, and the user's saved LinkedIn session is never part of it.
+// Every view is written to the audit log, with the reason staff gave, before anything is
+// returned; a view that can't be recorded is refused.
+func (s *Server) ViewAsUser(w http.ResponseWriter, r *http.Request) {
+	staff, user := r.URL.Query().Get("staff"), r.PathValue("email")
+	reason := strings.TrimSpace(r.URL.Query().Get("reason"))
+	if !s.isSupportStaff(r, staff) {
+		utils.WriteResponse(w, "only support staff can view other users", http.StatusForbidden)
+		return
+	}
+	if !utils.ValidEmail(user) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	if reason == "" {
+		utils.WriteResponse(w, "reason is required, it is recorded in the audit log", http.StatusBadRequest)
+		return
+	}
+
+	id, err := utils.GenerateID()
+	if err != nil {
+		log.Printf("error while generating audit entry id: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	entry := &models.AuditEntry{ID: id, Actor: staff, Action: AuditSupportView, Subject: user, Reason: reason, At: time.Now()}
+	if err := s.Store.AppendAudit(entry); err != nil {
+		log.Printf("error while recording support view: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	log.Printf("%s viewed the data of %s: %s\n", staff, user, reason)
+
+	view := &SupportViewRes{User: user, ViewedBy: staff, AuditID: id}
+	if view.Sender, err = s.Store.GetSender(user); errors.Is(err, store.ErrNotFound) {
+		err = nil
+	}
+	if err == nil {
+		if view.Settings, err = s.Store.GetSettings(user); errors.Is(err, store.ErrNotFound) {
+			err = nil
+		}
+	}
+	if err == nil {
+		view.ICPFilters, err = s.Store.ListICPFilters(user)
+	}
+	if err == nil {
+		view.Campaigns, err = s.Store.ListCampaigns(user)
+	}
+	if err == nil {
+		view.Prospects, err = s.Store.ListProspects(user)
+	}
+	if err != nil {
+		log.Printf("error while reading the data of %s for support: %v\n", user, err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	sort.Slice(view.Campaigns, func(i, j int) bool { return view.Campaigns[i].CreatedAt.After(view.Campaigns[j].CreatedAt) })
+	sort.Slice(view.Prospects, func(i, j int) bool { return view.Prospects[i].ScrapedAt.After(view.Prospects[j].ScrapedAt) })
+	utils.WriteResponse(w, view, 200)
+}
+
+// ListAudit returns the audit log, newest first: support staff sending the support token
+// see every entry, other users who looked at their data.
+func (s *Server) ListAudit(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	of := email
+	if s.isSupportStaff(r, email) {
+		of = ""
+	}
+	entries, err := s.Store.ListAudit(of)
+	if err != nil {
+		log.Printf("error while listing audit entries: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	utils.WriteResponse(w, &AuditRes{Entries: entries}, 200)
+}
+
+// isSupportStaff reports whether email is one of SupportStaff and r carries the support
+// token. Without a SupportToken nobody is.
+func (s *Server) isSupportStaff(r *http.Request, email string) bool {
+	token := r.Header.Get(SupportTokenHeader)
+	if s.SupportToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.SupportToken)) != 1 || !utils.ValidEmail(email) {
+		return false
+	}
+	for _, staff := range s.SupportStaff {
+		if strings.EqualFold(staff, email) {
+			return true
+		}
+	}
+	return false
+}
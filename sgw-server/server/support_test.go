@@ -0,0 +1,56 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestViewAsUser(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.SupportStaff, s.SupportToken = []string{"support@x.com", "ops@x.com"}, "staff-secret"
+	staff := http.Header{SupportTokenHeader: {"staff-secret"}}
+	home(t, ts, "a@x.com", "https://www.linkedin.com/in/priya/")
+	view := "/api/support/users/a@x.com?staff=support@x.com&reason=" + url.QueryEscape("ticket 42: empty messages")
+
+	if code := callWith(t, ts, http.MethodGet, "/api/support/users/a@x.com?staff=b@x.com&reason=curious", staff, nil, nil); code != http.StatusForbidden {
+		t.Errorf("view as non-staff: status %d, want 403", code)
+	}
+	// A staff email is something anyone can type, the token is what proves it
+	for _, header := range []http.Header{nil, {SupportTokenHeader: {"guessed"}}} {
+		if code := callWith(t, ts, http.MethodGet, view, header, nil, nil); code != http.StatusForbidden {
+			t.Errorf("view with token %q: status %d, want 403", header.Get(SupportTokenHeader), code)
+		}
+	}
+	if code := callWith(t, ts, http.MethodGet, "/api/support/users/a@x.com?staff=support@x.com", staff, nil, nil); code != http.StatusBadRequest {
+		t.Errorf("view without a reason: status %d, want 400", code)
+	}
+	var seen SupportViewRes
+	if code := callWith(t, ts, http.MethodGet, view, staff, nil, &seen); code != http.StatusOK {
+		t.Fatalf("GET %s: status %d", view, code)
+	}
+	if len(seen.Prospects) != 1 || seen.Prospects[0].Message == "" || seen.AuditID == "" {
+		t.Fatalf("view = %+v, want the user's prospect and its audit entry", seen)
+	}
+
+	// The user sees who looked and why, staff see every entry
+	var audit AuditRes
+	if code := call(t, ts, http.MethodGet, "/api/audit?email=a@x.com", nil, &audit); code != http.StatusOK {
+		t.Fatalf("GET /api/audit: status %d", code)
+	}
+	if len(audit.Entries) != 1 || audit.Entries[0].ID != seen.AuditID || audit.Entries[0].Actor != "support@x.com" || audit.Entries[0].Reason != "ticket 42: empty messages" {
+		t.Fatalf("audit of a@x.com = %+v", audit.Entries)
+	}
+	if code := call(t, ts, http.MethodGet, "/api/audit?email=b@x.com", nil, &audit); code != http.StatusOK || len(audit.Entries) != 0 {
+		t.Errorf("audit of b@x.com = %+v (status %d), want none", audit.Entries, code)
+	}
+	if code := callWith(t, ts, http.MethodGet, "/api/audit?email=support@x.com", staff, nil, &audit); code != http.StatusOK || len(audit.Entries) != 1 {
+		t.Errorf("audit as staff = %+v (status %d), want every entry", audit.Entries, code)
+	}
+	if code := call(t, ts, http.MethodGet, "/api/audit?email=ops@x.com", nil, &audit); code != http.StatusOK || len(audit.Entries) != 0 {
+		t.Errorf("audit as staff without the token = %+v (status %d), want only their own", audit.Entries, code)
+	}
+	if code := callWith(t, ts, http.MethodPost, view, staff, nil, nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("POST %s: status %d, want read-only", view, code)
+	}
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+	"github.com/hemantsharma1498/segwise-assignment/store"
+)
+
+type TimelineKind string
+
+const (
+	TimelineBatchQueued        TimelineKind = "batch.queued"
+	TimelineProfileScraped     TimelineKind = "profile.scraped"
+	TimelineProspectSkipped    TimelineKind = "prospect.skipped"
+	TimelineMessageFailed      TimelineKind = "message.failed"
+	TimelineMessageGenerated   TimelineKind = "message.generated"
+	TimelineMessageRegenerated TimelineKind = "message.regenerated"
+	TimelineApprovalRequested  TimelineKind = "approval.requested"
+	TimelineMessageApproved    TimelineKind = "message.approved"
+	TimelineMessageRejected    TimelineKind = "message.rejected"
+)
+
+// ProspectTimeline returns everything known about one prospect, oldest first: the batch
+// it was queued in, its scrape, each message it was given and the approval decision.
+func (s *Server) ProspectTimeline(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+	prospect, err := s.Store.GetProspect(r.PathValue("id"))
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Printf("error while getting prospect: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	if err != nil || !strings.EqualFold(prospect.Owner, email) {
+		utils.WriteResponse(w, "prospect not found", http.StatusNotFound)
+		return
+	}
+	regens, err := s.Store.ListRegenerations(email)
+	if err != nil {
+		log.Printf("error while listing regenerations: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+
+	entries := []TimelineEntry{}
+	if prospect.BatchID != "" {
+		batch, err := s.Store.GetBatch(prospect.BatchID)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			log.Printf("error while getting batch: %v\n", err)
+		}
+		if err == nil {
+			entries = append(entries, TimelineEntry{At: batch.CreatedAt, Kind: TimelineBatchQueued, Ref: batch.ID, Detail: fmt.Sprintf("%d profiles", len(batch.LinkedinUrls))})
+		}
+	}
+
+	sections := utils.GetUsedParams(prospect.Profile)
+	sort.Strings(sections)
+	entries = append(entries, TimelineEntry{At: prospect.ScrapedAt, Kind: TimelineProfileScraped, Detail: strings.Join(sections, ", ")})
+
+	// Only the latest message is stored on the prospect, earlier ones are the Old side of
+	// the regenerations applied to it
+	var applied []TimelineEntry
+	var earliest time.Time
+	original := prospect.Message
+	for _, regen := range regens {
+		for _, item := range regen.Items {
+			if item.ProspectID != prospect.ID || !item.Applied {
+				continue
+			}
+			at := item.AppliedAt
+			if at.IsZero() {
+				at = regen.CompletedAt
+			}
+			applied = append(applied, TimelineEntry{At: at, Kind: TimelineMessageRegenerated, Ref: regen.ID, Message: item.NewMessage})
+			if len(applied) == 1 || at.Before(earliest) {
+				earliest, original = at, item.OldMessage
+			}
+		}
+	}
+	switch {
+	case prospect.SkipReason != "":
+		entries = append(entries, TimelineEntry{At: prospect.ScrapedAt, Kind: TimelineProspectSkipped, Detail: prospect.SkipReason})
+	case original != "":
+		entries = append(entries, TimelineEntry{At: prospect.ScrapedAt, Kind: TimelineMessageGenerated, Message: original})
+	case prospect.Error != "":
+		entries = append(entries, TimelineEntry{At: prospect.ScrapedAt, Kind: TimelineMessageFailed, Detail: prospect.Error})
+	}
+	entries = append(entries, applied...)
+
+	if a := prospect.Approval; a != nil {
+		if !a.RequestedAt.IsZero() {
+			entries = append(entries, TimelineEntry{At: a.RequestedAt, Kind: TimelineApprovalRequested})
+		}
+		switch a.Status {
+		case models.ApprovalApproved:
+			entries = append(entries, TimelineEntry{At: a.ReviewedAt, Kind: TimelineMessageApproved, Actor: a.Reviewer, Detail: a.Note})
+		case models.ApprovalRejected:
+			entries = append(entries, TimelineEntry{At: a.ReviewedAt, Kind: TimelineMessageRejected, Actor: a.Reviewer, Detail: a.Note})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].At.Before(entries[j].At) })
+	utils.WriteResponse(w, &TimelineRes{ProspectID: prospect.ID, LinkedinUrl: prospect.LinkedinUrl, Entries: entries}, 200)
+}
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/fake"
+)
+
+func TestProfilesFilterByTopic(t *testing.T) {
+	_, ts := newTestServer(t)
+	priya, daniel := fake.Profiles[0], fake.Profiles[1]
+	home(t, ts, "a@x.com", fake.ProfileURL(priya))
+	home(t, ts, "a@x.com", fake.ProfileURL(daniel))
+
+	var all, matching ListProfilesRes
+	call(t, ts, http.MethodGet, "/api/profiles?email=a@x.com", nil, &all)
+	call(t, ts, http.MethodGet, "/api/profiles?email=a@x.com&topics=Bengaluru", nil, &matching)
+	if len(all.Profiles) != 2 {
+		t.Fatalf("got %d prospects, want 2", len(all.Profiles))
+	}
+	if len(matching.Profiles) != 1 || matching.Profiles[0].Profile.Name != priya.Name {
+		t.Errorf("prospects posting about Bengaluru = %+v, want only %s", matching.Profiles, priya.Name)
+	}
+}
@@ -0,0 +1,262 @@
+package server
+
+import (
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/i18n"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+// maxVerificationText caps the text a single input request types into a checkpoint page.
+const maxVerificationText = 256
+
+// verificationPage is rendered through localized, like sharePage.
+var verificationPage = template.Must(template.New("verify").Funcs(i18n.Funcs(i18n.Default)).Parse(`<!DOCTYPE html>
+<html lang="{{lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{t "LinkedIn security check for %s" .Email}}</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 80rem; margin: 2rem auto; padding: 0 1rem; color: #1d2226; }
+#screen { display: block; max-width: 100%; min-height: 10rem; border: 1px solid #ccc; cursor: pointer; background: #f3f6f8; }
+small { color: #666; }
+</style>
+</head>
+<body>
+<h1>{{t "LinkedIn security check for %s" .Email}}</h1>
+<p id="status">{{t "Click and type on the page below as you would on LinkedIn. Once LinkedIn lets the account through, the scrape carries on by itself."}}</p>
+<img id="screen" alt="{{t "LinkedIn security checkpoint"}}">
+<p><small>{{t "This check gives up %s." (.ExpiresAt.Format "Jan 2, 2006 15:04 MST")}}</small></p>
+<script>
+const base = location.pathname;
+const screen = document.getElementById('screen');
+const status = document.getElementById('status');
+const keys = ['Enter', 'Backspace', 'Tab', 'Escape', 'Delete', 'ArrowLeft', 'ArrowRight', 'ArrowUp', 'ArrowDown'];
+let ended = false;
+let queue = Promise.resolve();
+
+function end() {
+    ended = true;
+    status.textContent = {{t "This security check has ended. If LinkedIn accepted it, the scrape carries on by itself."}};
+}
+
+async function refresh() {
+    if (ended) return;
+    try {
+        const res = await fetch(base + '/frame', { cache: 'no-store' });
+        if (res.status === 404) return end();
+        if (res.status === 200) {
+            const old = screen.src;
+            screen.src = URL.createObjectURL(await res.blob());
+            if (old) URL.revokeObjectURL(old);
+        }
+    } catch (e) {}
+    setTimeout(refresh, 500);
+}
+
+// Inputs are sent one at a time so keystrokes reach the page in order
+function send(input) {
+    queue = queue.then(() => fetch(base + '/input', {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify(input)
+    })).then(res => { if (res.status === 404) end(); }).catch(() => {});
+}
+
+screen.addEventListener('click', e => {
+    if (ended) return;
+    send({ type: 'click', x: e.offsetX / screen.clientWidth, y: e.offsetY / screen.clientHeight });
+});
+document.addEventListener('keydown', e => {
+    if (ended || e.ctrlKey || e.metaKey || e.altKey) return;
+    if (e.key.length === 1) {
+        send({ type: 'type', text: e.key });
+    } else if (keys.includes(e.key)) {
+        send({ type: 'key', key: e.key });
+    } else {
+        return;
+    }
+    e.preventDefault();
+});
+refresh();
+</script>
+</body>
+</html>
+`))
+
+// verification is a checkpoint waiting to be solved from the verification page at /verify/{token}.
+type verification struct {
+	Verification
+	token     string
+	email     string
+	expiresAt time.Time
+}
+
+// StartVerification publishes a checkpoint LinkedIn stopped email's headless login at, so
+// it can be solved from a browser until expiresAt, and alerts the owner with the link. The
+// link only works until v is done. It is meant for scraper.RemoteVerification.
+func (s *Server) StartVerification(email string, expiresAt time.Time, v Verification) {
+	token, err := utils.GenerateID()
+	if err != nil {
+		log.Printf("error while starting verification for %s: %v\n", email, err)
+		return
+	}
+	s.verifyMu.Lock()
+	s.verifications[token] = &verification{Verification: v, token: token, email: email, expiresAt: expiresAt}
+	s.verifyMu.Unlock()
+	go func() {
+		<-v.Done()
+		s.verifyMu.Lock()
+		delete(s.verifications, token)
+		s.verifyMu.Unlock()
+	}()
+
+	// Never built from the Host header, a spoofed one would hand the checkpoint to another site
+	url := s.PublicBaseURL + "/verify/" + token
+	log.Printf("LinkedIn stopped %s at a security checkpoint, waiting for it to be solved remotely\n", email)
+	if len(s.destinationsFor(models.EventAccountCheckpoint, email)) == 0 {
+		return
+	}
+	// Counts as the checkpoint alert, so a login that gives up on it doesn't alert again
+	s.alertMu.Lock()
+	s.alerted[key(email)+" "+string(models.EventAccountCheckpoint)] = time.Now()
+	s.alertMu.Unlock()
+	s.queueAlert(models.Event{
+		Kind:      models.EventAccountCheckpoint,
+		Owner:     email,
+		Job:       "login",
+		Error:     scraper.ErrVerificationRequired.Error(),
+		VerifyURL: url,
+		VerifyBy:  expiresAt,
+	})
+}
+
+// ListVerifications returns the links to the checkpoints a user's account is waiting at,
+// so the frontend can offer them while a login hangs.
+func (s *Server) ListVerifications(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if !utils.ValidEmail(email) {
+		utils.WriteResponse(w, "invalid email", http.StatusBadRequest)
+		return
+	}
+
+	res := &VerificationsRes{Verifications: []VerificationRes{}}
+	s.verifyMu.Lock()
+	for _, v := range s.verifications {
+		if strings.EqualFold(v.email, email) {
+			res.Verifications = append(res.Verifications, VerificationRes{Url: s.PublicBaseURL + "/verify/" + v.token, ExpiresAt: v.expiresAt})
+		}
+	}
+	s.verifyMu.Unlock()
+	sort.Slice(res.Verifications, func(i, j int) bool {
+		return res.Verifications[i].ExpiresAt.Before(res.Verifications[j].ExpiresAt)
+	})
+	utils.WriteResponse(w, res, 200)
+}
+
+// VerificationPage renders the page a checkpoint is solved from.
+func (s *Server) VerificationPage(w http.ResponseWriter, r *http.Request) {
+	v, ok := s.pendingVerification(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	data := struct {
+		Email     string
+		ExpiresAt time.Time
+	}{v.email, v.expiresAt}
+	if err := localized(verificationPage, utils.Language(w)).Execute(w, data); err != nil {
+		log.Printf("error while rendering verification page: %v\n", err)
+	}
+}
+
+// VerificationFrame returns the latest JPEG frame of a checkpoint page, or no content
+// before the first one arrives.
+func (s *Server) VerificationFrame(w http.ResponseWriter, r *http.Request) {
+	v, ok := s.pendingVerification(w, r)
+	if !ok {
+		return
+	}
+	frame, ok := v.Frame()
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.Write(frame)
+}
+
+// VerificationInput replays a click, typed text or a key press into a checkpoint page.
+func (s *Server) VerificationInput(w http.ResponseWriter, r *http.Request) {
+	v, ok := s.pendingVerification(w, r)
+	if !ok {
+		return
+	}
+	d := &VerificationInputReq{}
+	if err := utils.DecodeReqBody(r, d); err != nil {
+		utils.WriteResponse(w, "Encountered an error. Please try again", http.StatusInternalServerError)
+		return
+	}
+
+	var err error
+	switch d.Type {
+	case "click":
+		if d.X < 0 || d.X > 1 || d.Y < 0 || d.Y > 1 {
+			utils.WriteResponse(w, "x and y must be between 0 and 1", http.StatusBadRequest)
+			return
+		}
+		err = v.Click(d.X, d.Y)
+	case "type":
+		if d.Text == "" || len(d.Text) > maxVerificationText {
+			utils.WriteResponse(w, "text must be between 1 and 256 bytes", http.StatusBadRequest)
+			return
+		}
+		err = v.Type(d.Text)
+	case "key":
+		err = v.Press(d.Key)
+	default:
+		utils.WriteResponse(w, `type must be one of "click", "type" or "key"`, http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, scraper.ErrUnsupportedKey) {
+		utils.WriteResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, scraper.ErrVerificationEnded) {
+		utils.WriteResponse(w, "verification not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("error while replaying verification input: %v\n", err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pendingVerification looks up the checkpoint named by the request's token. Pages and frames
+// show the user's LinkedIn session, so they are never cached or leaked through the referrer.
+// It also picks the language the page and its responses answer in.
+func (s *Server) pendingVerification(w http.ResponseWriter, r *http.Request) (*verification, bool) {
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	lang := utils.Localize(w, r)
+	s.verifyMu.Lock()
+	v, ok := s.verifications[r.PathValue("token")]
+	s.verifyMu.Unlock()
+	if !ok || time.Now().After(v.expiresAt) {
+		http.Error(w, i18n.T(lang, "Verification not found."), http.StatusNotFound)
+		return nil, false
+	}
+	return v, true
+}
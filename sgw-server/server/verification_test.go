@@ -0,0 +1,111 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+// fakeVerification records the input replayed into it.
+type fakeVerification struct {
+	mu    sync.Mutex
+	input []string
+	done  chan struct{}
+}
+
+func newFakeVerification() *fakeVerification {
+	return &fakeVerification{done: make(chan struct{})}
+}
+
+func (v *fakeVerification) Frame() ([]byte, bool) { return []byte("jpeg"), true }
+
+func (v *fakeVerification) Click(x, y float64) error {
+	return v.record(fmt.Sprintf("click %.1f,%.1f", x, y))
+}
+
+func (v *fakeVerification) Type(text string) error { return v.record("type " + text) }
+
+func (v *fakeVerification) Press(key string) error {
+	if key != "Enter" {
+		return fmt.Errorf("%w %q", scraper.ErrUnsupportedKey, key)
+	}
+	return v.record("key " + key)
+}
+
+func (v *fakeVerification) Done() <-chan struct{} { return v.done }
+
+func (v *fakeVerification) record(input string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.input = append(v.input, input)
+	return nil
+}
+
+func (v *fakeVerification) replayed() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]string(nil), v.input...)
+}
+
+func TestRemoteVerification(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.PublicBaseURL = ts.URL
+	_, hookURL := newAlertHook(t)
+	s.AccountAlertURL = hookURL
+	v := newFakeVerification()
+	s.StartVerification("a@x.com", time.Now().Add(time.Minute), v)
+
+	var list VerificationsRes
+	if code := call(t, ts, http.MethodGet, "/api/verifications?email=A@x.com", nil, &list); code != http.StatusOK || len(list.Verifications) != 1 {
+		t.Fatalf("verifications: status %d, %+v", code, list)
+	}
+	page := strings.TrimPrefix(list.Verifications[0].Url, ts.URL)
+	due, _ := s.Store.DueOutbox(time.Now())
+	if len(due) != 1 || due[0].Event.Kind != models.EventAccountCheckpoint || due[0].Event.VerifyURL != list.Verifications[0].Url {
+		t.Fatalf("alerts = %+v, want one checkpoint alert with the link", due)
+	}
+
+	res, err := ts.Client().Get(ts.URL + page + "/frame")
+	if err != nil {
+		t.Fatal(err)
+	}
+	frame, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK || string(frame) != "jpeg" || res.Header.Get("Cache-Control") != "private, no-store" {
+		t.Errorf("frame: status %d, %q, Cache-Control %q", res.StatusCode, frame, res.Header.Get("Cache-Control"))
+	}
+
+	for _, in := range []VerificationInputReq{{Type: "click", X: 0.5, Y: 0.2}, {Type: "type", Text: "123456"}, {Type: "key", Key: "Enter"}} {
+		if code := call(t, ts, http.MethodPost, page+"/input", &in, nil); code != http.StatusNoContent {
+			t.Errorf("input %+v: status %d", in, code)
+		}
+	}
+	for _, in := range []VerificationInputReq{{Type: "click", X: 1.5}, {Type: "key", Key: "F5"}, {Type: "scroll"}} {
+		if code := call(t, ts, http.MethodPost, page+"/input", &in, nil); code != http.StatusBadRequest {
+			t.Errorf("input %+v: status %d, want 400", in, code)
+		}
+	}
+	if got := fmt.Sprint(v.replayed()); got != "[click 0.5,0.2 type 123456 key Enter]" {
+		t.Errorf("replayed %s", got)
+	}
+
+	// The link dies with the verification
+	close(v.done)
+	deadline := time.Now().Add(time.Second)
+	for call(t, ts, http.MethodGet, page, nil, nil) != http.StatusNotFound {
+		if time.Now().After(deadline) {
+			t.Fatal("verification page still served after it ended")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if code := call(t, ts, http.MethodGet, "/verify/not-a-token/frame", nil, nil); code != http.StatusNotFound {
+		t.Errorf("unknown token: status %d, want 404", code)
+	}
+}
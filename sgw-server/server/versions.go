@@ -0,0 +1,54 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/payload"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/utils"
+)
+
+// responseVersion is the payload version a request pinned with X-Segwise-Schema-Version,
+// payload.Current without one. Versions that aren't supported are answered with a 400,
+// before any work is done for the request.
+func responseVersion(w http.ResponseWriter, r *http.Request) (int, bool) {
+	v, err := payload.Parse(r.Header.Get(payload.Header), time.Now())
+	if err != nil {
+		utils.WriteResponse(w, err.Error(), http.StatusBadRequest)
+		return 0, false
+	}
+	return v, true
+}
+
+// writeVersioned writes res, whose schemaVersion is payload.Current, as version v, with
+// the headers naming v and, when it is deprecated, its sunset.
+func writeVersioned(w http.ResponseWriter, v int, res any, httpStatus int) {
+	doc, err := json.Marshal(res)
+	if err == nil {
+		doc, err = payload.Downgrade(doc, v)
+	}
+	if err != nil {
+		log.Printf("error while writing version %d response: %v\n", v, err)
+		utils.WriteResponse(w, "server encountered an error, please try again later", 500)
+		return
+	}
+	payload.SetHeaders(w.Header(), v)
+	w.Header().Add("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(httpStatus)
+	w.Write(append(doc, '\n'))
+}
+
+// payloadVersion is the version a webhook destination receives events in.
+func (s *Server) payloadVersion(d models.Destination) int {
+	v := s.WebhookPayloadVersion
+	if d == models.DestinationAccountAlert {
+		v = s.AccountAlertPayloadVersion
+	}
+	if v == 0 {
+		return payload.Current
+	}
+	return v
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/payload"
+)
+
+func TestResponseVersions(t *testing.T) {
+	_, ts := newTestServer(t)
+	home(t, ts, "a@x.com", "https://www.linkedin.com/in/one/")
+
+	get := func(version string) (*http.Response, map[string]any) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/api/profiles?email=a@x.com", nil)
+		if version != "" {
+			req.Header.Set(payload.Header, version)
+		}
+		res, err := ts.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		var body map[string]any
+		json.NewDecoder(res.Body).Decode(&body)
+		return res, body
+	}
+
+	res, body := get("")
+	if body["schemaVersion"] != float64(payload.Current) || res.Header.Get(payload.Header) != "2" || res.Header.Get("Deprecation") != "" {
+		t.Errorf("unpinned: %s %v, Deprecation %q", res.Header.Get(payload.Header), body["schemaVersion"], res.Header.Get("Deprecation"))
+	}
+	res, body = get("1")
+	if _, ok := body["schemaVersion"]; ok || res.Header.Get(payload.Header) != "1" {
+		t.Errorf("pinned to 1: %s, %v", res.Header.Get(payload.Header), body)
+	}
+	if res.Header.Get("Deprecation") != "@1791936000" || res.Header.Get("Sunset") != "Wed, 14 Apr 2027 00:00:00 GMT" {
+		t.Errorf("Deprecation %q, Sunset %q", res.Header.Get("Deprecation"), res.Header.Get("Sunset"))
+	}
+	if len(body["profiles"].([]any)) != 1 {
+		t.Errorf("pinned to 1: %v, want the prospect", body)
+	}
+	if res, _ := get("9"); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown version: status %d, want 400", res.StatusCode)
+	}
+}
+
+func TestWebhookPayloadVersion(t *testing.T) {
+	s, _ := newTestServer(t)
+	type delivery struct {
+		headers http.Header
+		body    []byte
+	}
+	deliveries := make(chan delivery, 2)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		deliveries <- delivery{r.Header, body}
+	}))
+	defer hook.Close()
+	s.WebhookURL, s.AccountAlertURL = hook.URL, hook.URL
+	s.AccountAlertPayloadVersion = 1
+	event := models.Event{ID: "ev1", Kind: models.EventAccountRestricted, Owner: "a@x.com"}
+
+	if err := s.deliver(&models.OutboxEntry{Destination: models.DestinationWebhook, Event: event}); err != nil {
+		t.Fatal(err)
+	}
+	d := <-deliveries
+	headers, body := d.headers, d.body
+	if !bytes.Contains(body, []byte(`"schemaVersion":2`)) || headers.Get(payload.Header) != "2" {
+		t.Errorf("webhook got %s, version %q", body, headers.Get(payload.Header))
+	}
+	if err := s.deliver(&models.OutboxEntry{Destination: models.DestinationAccountAlert, Event: event}); err != nil {
+		t.Fatal(err)
+	}
+	d = <-deliveries
+	headers, body = d.headers, d.body
+	if bytes.Contains(body, []byte("schemaVersion")) || headers.Get(payload.Header) != "1" || headers.Get("Sunset") == "" {
+		t.Errorf("account alert pinned to 1 got %s, version %q, Sunset %q", body, headers.Get(payload.Header), headers.Get("Sunset"))
+	}
+	var got models.Event
+	if err := json.Unmarshal(body, &got); err != nil || got.ID != "ev1" || got.Owner != "a@x.com" {
+		t.Errorf("version 1 event = %+v, %v", got, err)
+	}
+}
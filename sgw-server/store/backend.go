@@ -0,0 +1,136 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// backend persists the store's records as one JSON document. Stores keep the records in
+// memory and write the whole document back on every change, so a backend only needs to
+// keep instances sharing it from writing at the same time and tell them when the
+// document changed under them.
+type backend interface {
+	// lock blocks until no other instance can write, until unlock.
+	lock() error
+	unlock()
+	// read returns the document, nil when none was written yet.
+	read() ([]byte, error)
+	// changed reports whether the document was written since it was last read or written here.
+	changed() (bool, error)
+	// write replaces the document. Callers hold the lock.
+	write(raw []byte) error
+	close() error
+}
+
+/*
+	ParseDSN tells which backend a store DSN selects.
+
+Parameters:
+  - dsn: "sqlite:<path>" or "sqlite:///<absolute path>" for SQLite, a "postgres://" or
+    "postgresql://" URL for Postgres, anything else is the path of a JSON file
+
+Returns:
+  - string: "file", "sqlite" or "postgres"
+  - string: The file path, SQLite database path or Postgres URL
+  - error: An error naming what is wrong with dsn, never its password
+*/
+func ParseDSN(dsn string) (string, string, error) {
+	dsn = strings.TrimSpace(dsn)
+	scheme, rest, found := strings.Cut(dsn, ":")
+	switch {
+	case dsn == "":
+		return "", "", errors.New("is empty")
+	case found && scheme == "sqlite":
+		path := strings.TrimPrefix(rest, "//")
+		if path == "" {
+			return "", "", errors.New("is a SQLite DSN without a database path")
+		}
+		return "sqlite", path, nil
+	case found && (scheme == "postgres" || scheme == "postgresql"):
+		return "postgres", dsn, nil
+	case found && strings.HasPrefix(rest, "//"):
+		return "", "", fmt.Errorf("has scheme %q, expected sqlite or postgres", scheme)
+	}
+	return "file", dsn, nil
+}
+
+// fileBackend keeps the document in a JSON file, locked with flock on a lock file next to it.
+type fileBackend struct {
+	path  string
+	lockf *os.File    // Held with flock while writing
+	seen  os.FileInfo // The file last read or written
+}
+
+func openFile(path string) (*fileBackend, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	lockf, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &fileBackend{path: path, lockf: lockf}, nil
+}
+
+func (f *fileBackend) lock() error {
+	return lockFile(f.lockf)
+}
+
+func (f *fileBackend) unlock() {
+	unlockFile(f.lockf)
+}
+
+// read stats the file before reading it, so a file replaced in between is read again next time.
+func (f *fileBackend) read() ([]byte, error) {
+	info, err := os.Stat(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+	f.seen = info
+	return raw, nil
+}
+
+// changed compares the file with the one last seen. Inode numbers are reused and
+// timestamps are coarse, so this is only good enough for reads; writes always reload.
+func (f *fileBackend) changed() (bool, error) {
+	info, err := os.Stat(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	unchanged := f.seen != nil && os.SameFile(f.seen, info) && f.seen.ModTime().Equal(info.ModTime()) && f.seen.Size() == info.Size()
+	return !unchanged, nil
+}
+
+// write replaces the file with a renamed temporary file, so readers without the lock never see half a file.
+func (f *fileBackend) write(raw []byte) error {
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		return err
+	}
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return err
+	}
+	f.seen = info
+	return nil
+}
+
+func (f *fileBackend) close() error {
+	return f.lockf.Close()
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package store
+
+import "os"
+
+// lockFile is a no-op; platforms without flock only support a single instance per store.
+func lockFile(f *os.File) error {
+	return nil
+}
+
+func unlockFile(f *os.File) {}
@@ -0,0 +1,235 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/topics"
+)
+
+// SchemaVersion is the layout of the store file this version reads and writes. Changing
+// the layout in a way older files don't simply decode into, such as renaming or
+// reshaping a collection, means bumping it and appending to migrations.
+const SchemaVersion = 1
+
+// Migration upgrades a store file from schema version Version-1 to Version.
+type Migration struct {
+	Version     int
+	Description string
+	up          func(file map[string]json.RawMessage) error
+}
+
+// migrations[i] upgrades the store file from schema version i to i+1. They run in order
+// on every file that is behind, before the profile migrations.
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "record the schema version in the store file",
+		// Files written before versioning already have the version 1 layout
+		up: func(file map[string]json.RawMessage) error { return nil },
+	},
+}
+
+// MigrationReport is what migrating a store file changed.
+type MigrationReport struct {
+	From, To int         // Store schema versions before and after
+	Ran      []Migration // Store migrations run, From+1 to To
+	Profiles int         // Stored profiles upgraded to scraper.ProfileSchemaVersion
+}
+
+// Changed reports whether the file had to be rewritten.
+func (r MigrationReport) Changed() bool {
+	return len(r.Ran) > 0 || r.Profiles > 0
+}
+
+// profileMigrations[i] upgrades a stored profile from schema version i to i+1. Adding
+// a section to scraper.Profile that older records can't simply leave empty means
+// bumping scraper.ProfileSchemaVersion and appending a migration here.
+var profileMigrations = []func(profile map[string]any) error{
+	// 0 -> 1: profiles stored before versioning could hold null sections,
+	// consumers iterate them so they become empty lists.
+	func(profile map[string]any) error {
+		for _, section := range []string{"Experience", "Education", "Posts"} {
+			if profile[section] == nil {
+				profile[section] = []any{}
+			}
+		}
+		return nil
+	},
+	// 1 -> 2: topics are derived from the posts, articles and comments at scrape time,
+	// profiles stored before get theirs so topic filters don't pass them over.
+	func(profile map[string]any) error {
+		p, err := decodeProfile(profile)
+		if err != nil {
+			return err
+		}
+		if t := topics.Extract(p); t != nil {
+			profile["Topics"] = t
+		}
+		return nil
+	},
+	// 2 -> 3: the last activity is derived from the dated posts, articles and comments at
+	// scrape time, profiles stored before would read as never active.
+	func(profile map[string]any) error {
+		p, err := decodeProfile(profile)
+		if err != nil {
+			return err
+		}
+		if last := p.LastActivity(); !last.IsZero() {
+			profile["LastActiveAt"] = last
+		}
+		return nil
+	},
+	// 3 -> 4: websites moved into the contact info next to the email, Twitter handle and birthday.
+	func(profile map[string]any) error {
+		if websites, ok := profile["Websites"]; ok {
+			if websites != nil {
+				profile["ContactInfo"] = map[string]any{"websites": websites}
+			}
+			delete(profile, "Websites")
+		}
+		return nil
+	},
+}
+
+func init() {
+	if len(migrations) != SchemaVersion {
+		panic(fmt.Sprintf("store: %d migrations for schema version %d", len(migrations), SchemaVersion))
+	}
+	if len(profileMigrations) != scraper.ProfileSchemaVersion {
+		panic(fmt.Sprintf("store: %d profile migrations for schema version %d", len(profileMigrations), scraper.ProfileSchemaVersion))
+	}
+}
+
+// decodeProfile reads a stored profile as it is after the migrations that ran so far.
+func decodeProfile(profile map[string]any) (scraper.Profile, error) {
+	var p scraper.Profile
+	raw, err := json.Marshal(profile)
+	if err != nil {
+		return p, err
+	}
+	err = json.Unmarshal(raw, &p)
+	return p, err
+}
+
+// profileCollections are the top level store keys whose records embed a scraped profile.
+var profileCollections = []string{"senders", "prospects"}
+
+// migrate upgrades a raw store file to SchemaVersion and every stored profile in it to
+// scraper.ProfileSchemaVersion.
+func migrate(raw []byte) ([]byte, error) {
+	migrated, _, err := migrateFile(raw)
+	return migrated, err
+}
+
+func migrateFile(raw []byte) ([]byte, MigrationReport, error) {
+	report := MigrationReport{To: SchemaVersion}
+	var file map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, report, err
+	}
+
+	if v, ok := file["schemaVersion"]; ok {
+		if err := json.Unmarshal(v, &report.From); err != nil {
+			return nil, report, fmt.Errorf("failed to read the schema version: %w", err)
+		}
+	}
+	if report.From > SchemaVersion {
+		return nil, report, fmt.Errorf("store schema version %d is newer than supported version %d, upgrade segwise", report.From, SchemaVersion)
+	}
+	for _, m := range migrations[report.From:] {
+		if err := m.up(file); err != nil {
+			return nil, report, fmt.Errorf("failed to run store migration %d (%s): %w", m.Version, m.Description, err)
+		}
+		report.Ran = append(report.Ran, m)
+	}
+	file["schemaVersion"] = json.RawMessage(strconv.Itoa(SchemaVersion))
+
+	for _, collection := range profileCollections {
+		if len(file[collection]) == 0 {
+			continue
+		}
+		var records map[string]map[string]any
+		if err := json.Unmarshal(file[collection], &records); err != nil {
+			return nil, report, fmt.Errorf("failed to read %s: %w", collection, err)
+		}
+		for id, record := range records {
+			upgraded, err := migrateRecord(record)
+			if err != nil {
+				return nil, report, fmt.Errorf("failed to migrate %s/%s: %w", collection, id, err)
+			}
+			if upgraded {
+				report.Profiles++
+			}
+		}
+		migrated, err := json.Marshal(records)
+		if err != nil {
+			return nil, report, err
+		}
+		file[collection] = migrated
+	}
+	migrated, err := json.Marshal(file)
+	return migrated, report, err
+}
+
+// Migrate brings the store file up to SchemaVersion, with every stored profile at
+// scraper.ProfileSchemaVersion, and reports what it changed. Reads migrate the file in
+// memory anyway and writes store it migrated; Migrate, run at startup, makes an upgrade
+// stick at once, so records nobody writes are not migrated again on every load and an
+// instance left on an older version fails on the upgraded file instead of misreading it.
+// The file is only rewritten when it was behind.
+func (s *Store) Migrate() (MigrationReport, error) {
+	if err := s.lock(); err != nil {
+		return MigrationReport{}, err
+	}
+	defer s.unlock()
+	raw, err := s.b.read()
+	if err != nil {
+		return MigrationReport{}, err
+	}
+	if len(raw) == 0 {
+		return MigrationReport{From: SchemaVersion, To: SchemaVersion}, nil
+	}
+	_, report, err := migrateFile(raw)
+	if err != nil || !report.Changed() {
+		return report, err
+	}
+	// lock loaded the migrated records, writing them is the upgrade
+	return report, s.flush()
+}
+
+// SchemaVersion returns the schema version of the store file, failing when it can't be read.
+func (s *Store) SchemaVersion() (int, error) {
+	if err := s.rlock(); err != nil {
+		return 0, err
+	}
+	defer s.mu.RUnlock()
+	return s.data.SchemaVersion, nil
+}
+
+// migrateRecord upgrades the profile of record, reporting whether it was behind.
+func migrateRecord(record map[string]any) (bool, error) {
+	version := 0
+	if v, ok := record["profileVersion"].(float64); ok {
+		version = int(v)
+	}
+	if version > scraper.ProfileSchemaVersion {
+		return false, fmt.Errorf("profile schema version %d is newer than supported version %d", version, scraper.ProfileSchemaVersion)
+	}
+	upgraded := version < scraper.ProfileSchemaVersion
+
+	profile, _ := record["profile"].(map[string]any)
+	if profile == nil {
+		profile = map[string]any{}
+	}
+	for ; version < scraper.ProfileSchemaVersion; version++ {
+		if err := profileMigrations[version](profile); err != nil {
+			return false, err
+		}
+	}
+	record["profile"] = profile
+	record["profileVersion"] = version
+	return upgraded, nil
+}
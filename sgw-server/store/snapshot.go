@@ -0,0 +1,35 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// Snapshot returns every record in the store as the store's document holds them, for backups.
+func (s *Store) Snapshot() ([]byte, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	return json.Marshal(s.data)
+}
+
+// Restore replaces every record in the store with those of snapshot, which may come from
+// an older version. Other instances sharing the store see the restored records on their
+// next access. Nothing is replaced when snapshot can't be read.
+func (s *Store) Restore(snapshot []byte) error {
+	if len(bytes.TrimSpace(snapshot)) == 0 {
+		return errors.New("the snapshot is empty")
+	}
+	restored, err := decode(snapshot)
+	if err != nil {
+		return err
+	}
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	s.data = restored
+	return s.flush()
+}
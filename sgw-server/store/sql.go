@@ -0,0 +1,144 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
+)
+
+// sqlBackend keeps the document in the single row of a segwise_store table, with a
+// version counting its writes. Writers lock the table in a transaction that the write
+// commits; readers compare the version with the one they last saw.
+type sqlBackend struct {
+	db       *sql.DB
+	tx       *sql.Tx // Open while locked
+	postgres bool
+	version  int64 // Of the document last read or written, 0 when there was none
+}
+
+// sqliteOptions make writers take the database lock when they begin, waiting for each
+// other rather than failing, and let reads go on while one writes.
+const sqliteOptions = "_txlock=immediate&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)"
+
+func openSQL(driver, source string) (*sqlBackend, error) {
+	b := &sqlBackend{postgres: driver == "postgres"}
+	create := `CREATE TABLE IF NOT EXISTS segwise_store (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		version INTEGER NOT NULL,
+		data BLOB NOT NULL
+	)`
+	var err error
+	if b.postgres {
+		create = strings.Replace(create, "BLOB", "BYTEA", 1)
+		b.db, err = sql.Open("pgx", source)
+	} else {
+		if err := os.MkdirAll(filepath.Dir(source), 0o700); err != nil {
+			return nil, err
+		}
+		if strings.Contains(source, "?") {
+			source += "&" + sqliteOptions
+		} else {
+			source += "?" + sqliteOptions
+		}
+		b.db, err = sql.Open("sqlite", source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := b.db.Exec(create); err != nil {
+		b.db.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *sqlBackend) lock() error {
+	tx, err := b.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+	// SQLite begins immediate transactions, which lock the database already
+	if b.postgres {
+		if _, err := tx.Exec("LOCK TABLE segwise_store IN EXCLUSIVE MODE"); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	b.tx = tx
+	return nil
+}
+
+// unlock rolls back the transaction of a lock no write committed.
+func (b *sqlBackend) unlock() {
+	if b.tx != nil {
+		b.tx.Rollback()
+		b.tx = nil
+	}
+}
+
+func (b *sqlBackend) read() ([]byte, error) {
+	var version int64
+	var raw []byte
+	err := b.queryRow("SELECT version, data FROM segwise_store WHERE id = 1").Scan(&version, &raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	b.version = version
+	return raw, nil
+}
+
+func (b *sqlBackend) changed() (bool, error) {
+	var version int64
+	err := b.queryRow("SELECT version FROM segwise_store WHERE id = 1").Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return version != b.version, nil
+}
+
+// write stores the document and commits the lock's transaction, so a failed commit fails the write.
+func (b *sqlBackend) write(raw []byte) error {
+	query := `INSERT INTO segwise_store (id, version, data) VALUES (1, 1, ?)
+		ON CONFLICT (id) DO UPDATE SET version = segwise_store.version + 1, data = excluded.data
+		RETURNING version`
+	if b.postgres {
+		query = strings.Replace(query, "?", "$1", 1)
+	}
+	var version int64
+	if err := b.queryRow(query, raw).Scan(&version); err != nil {
+		return err
+	}
+	if b.tx != nil {
+		err := b.tx.Commit()
+		b.tx = nil
+		if err != nil {
+			return err
+		}
+	}
+	b.version = version
+	return nil
+}
+
+// queryRow queries in the lock's transaction while there is one.
+func (b *sqlBackend) queryRow(query string, args ...any) *sql.Row {
+	if b.tx != nil {
+		return b.tx.QueryRow(query, args...)
+	}
+	return b.db.QueryRow(query, args...)
+}
+
+func (b *sqlBackend) close() error {
+	return b.db.Close()
+}
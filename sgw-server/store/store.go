@@ -0,0 +1,866 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hemantsharma1498/segwise-assignment/models"
+	"github.com/hemantsharma1498/segwise-assignment/pkg/scraper"
+)
+
+var ErrNotFound = errors.New("record not found")
+
+// data is the on-disk layout of the store file.
+type data struct {
+	SchemaVersion int `json:"schemaVersion"`
+
+	Senders   map[string]*models.Sender       `json:"senders"`
+	Prospects map[string]*models.Prospect     `json:"prospects"`
+	Batches   map[string]*models.Batch        `json:"batches"`
+	ICPs      map[string]*models.ICPFilter    `json:"icpFilters"`
+	Outbox    map[string]*models.OutboxEntry  `json:"outbox"`
+	Regens    map[string]*models.Regeneration `json:"regenerations"`
+	Leases    map[string]*models.Lease        `json:"leases"`
+	Settings  map[string]*models.Settings     `json:"settings"`
+	Cooldowns map[string]*models.Cooldown     `json:"cooldowns"`
+	Campaigns map[string]*models.Campaign     `json:"campaigns"`
+	Sessions  map[string]*models.Session      `json:"sessions"`
+	Audit     map[string]*models.AuditEntry   `json:"audit"`
+	Scrapes   map[string][]time.Time          `json:"scrapes"`
+}
+
+func newData() *data {
+	return &data{
+		SchemaVersion: SchemaVersion,
+
+		Senders:   map[string]*models.Sender{},
+		Prospects: map[string]*models.Prospect{},
+		Batches:   map[string]*models.Batch{},
+		ICPs:      map[string]*models.ICPFilter{},
+		Outbox:    map[string]*models.OutboxEntry{},
+		Regens:    map[string]*models.Regeneration{},
+		Leases:    map[string]*models.Lease{},
+		Settings:  map[string]*models.Settings{},
+		Cooldowns: map[string]*models.Cooldown{},
+		Campaigns: map[string]*models.Campaign{},
+		Sessions:  map[string]*models.Session{},
+		Audit:     map[string]*models.AuditEntry{},
+		Scrapes:   map[string][]time.Time{},
+	}
+}
+
+// Store is a thread-safe store of JSON records. Every write persists the whole
+// document, which is fine for the handful of records a single deployment keeps.
+//
+// The document is kept in a JSON file, a SQLite database or Postgres, see Open.
+// Several server instances may share it, e.g. a file on a shared volume or one
+// database. Writes hold the backend's exclusive lock and apply the change to the
+// document as it is, so no instance overwrites another's writes; reads reload the
+// document when it changed since this instance last saw it.
+// Instances coordinate work through leases, see AcquireLease.
+type Store struct {
+	mu   sync.RWMutex
+	b    backend
+	data *data
+}
+
+// NewStore opens the store kept in the JSON file at path.
+func NewStore(path string) (*Store, error) {
+	b, err := openFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return newStore(b)
+}
+
+/*
+	Open opens the store a DSN selects, see ParseDSN.
+
+SQLite suits a single host with no database to run; Postgres suits team
+deployments with several instances. Either keeps the same JSON document the
+file does, in the single row of a segwise_store table it creates.
+
+Parameters:
+  - dsn: "sqlite:<path>", a "postgres://" URL or the path of a JSON file
+
+Returns:
+  - *Store: The store
+  - error: Any error reading dsn or connecting
+*/
+func Open(dsn string) (*Store, error) {
+	driver, source, err := ParseDSN(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("store DSN %w", err)
+	}
+	var b backend
+	if driver == "file" {
+		b, err = openFile(source)
+	} else {
+		b, err = openSQL(driver, source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newStore(b)
+}
+
+func newStore(b backend) (*Store, error) {
+	s := &Store{b: b, data: newData()}
+	if err := s.lock(); err != nil {
+		b.close()
+		return nil, err
+	}
+	defer s.unlock()
+	raw, err := b.read()
+	if err == nil && raw == nil {
+		// Written once so other instances see the document from the start
+		err = s.flush()
+	}
+	if err != nil {
+		b.close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// load reads the document into data. Callers must hold mu.
+func (s *Store) load() error {
+	raw, err := s.b.read()
+	if err != nil {
+		return err
+	}
+	loaded, err := decode(raw)
+	if err != nil {
+		return err
+	}
+	s.data = loaded
+	return nil
+}
+
+// decode reads a document written by any version, empty when raw is.
+func decode(raw []byte) (*data, error) {
+	var err error
+	loaded := newData()
+	if len(raw) > 0 {
+		if raw, err = migrate(raw); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, loaded); err != nil {
+			return nil, err
+		}
+	}
+	// Records missing from older files decode as nil maps
+	defaults := newData()
+	if loaded.Senders == nil {
+		loaded.Senders = defaults.Senders
+	}
+	if loaded.Prospects == nil {
+		loaded.Prospects = defaults.Prospects
+	}
+	if loaded.Batches == nil {
+		loaded.Batches = defaults.Batches
+	}
+	if loaded.ICPs == nil {
+		loaded.ICPs = defaults.ICPs
+	}
+	if loaded.Outbox == nil {
+		loaded.Outbox = defaults.Outbox
+	}
+	if loaded.Regens == nil {
+		loaded.Regens = defaults.Regens
+	}
+	if loaded.Leases == nil {
+		loaded.Leases = defaults.Leases
+	}
+	if loaded.Settings == nil {
+		loaded.Settings = defaults.Settings
+	}
+	if loaded.Cooldowns == nil {
+		loaded.Cooldowns = defaults.Cooldowns
+	}
+	if loaded.Campaigns == nil {
+		loaded.Campaigns = defaults.Campaigns
+	}
+	if loaded.Sessions == nil {
+		loaded.Sessions = defaults.Sessions
+	}
+	if loaded.Audit == nil {
+		loaded.Audit = defaults.Audit
+	}
+	if loaded.Scrapes == nil {
+		loaded.Scrapes = defaults.Scrapes
+	}
+	return loaded, nil
+}
+
+// lock takes mu and the backend's lock and loads other instances' writes; unlock releases both.
+func (s *Store) lock() error {
+	s.mu.Lock()
+	if err := s.b.lock(); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	// Under the lock nobody else writes, so the document read is the one written over
+	if err := s.load(); err != nil {
+		s.b.unlock()
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (s *Store) unlock() {
+	s.b.unlock()
+	s.mu.Unlock()
+}
+
+// rlock read-locks mu after loading writes other instances made since the last access.
+// Backends replace the document atomically, so no backend lock is needed.
+func (s *Store) rlock() error {
+	s.mu.RLock()
+	changed, err := s.b.changed()
+	if err == nil && !changed {
+		return nil
+	}
+	s.mu.RUnlock()
+
+	s.mu.Lock()
+	if changed, err = s.b.changed(); err == nil && changed {
+		err = s.load()
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	s.mu.RLock()
+	return nil
+}
+
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.close()
+}
+
+func (s *Store) GetSender(email string) (*models.Sender, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	sender, ok := s.data.Senders[key(email)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *sender
+	return &copied, nil
+}
+
+func (s *Store) SaveSender(sender *models.Sender) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	copied := *sender
+	copied.ProfileVersion = scraper.ProfileSchemaVersion
+	s.data.Senders[key(sender.Email)] = &copied
+	return s.flush()
+}
+
+// GetSettings returns a user's saved settings, ErrNotFound when they never saved any.
+func (s *Store) GetSettings(owner string) (*models.Settings, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	settings, ok := s.data.Settings[key(owner)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copySettings(settings), nil
+}
+
+func (s *Store) SaveSettings(settings *models.Settings) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	s.data.Settings[key(settings.Owner)] = copySettings(settings)
+	return s.flush()
+}
+
+// copySettings copies settings without sharing the overrides they point to.
+func copySettings(settings *models.Settings) *models.Settings {
+	copied := *settings
+	if w := settings.ScoringWeights; w != nil {
+		weights := *w
+		copied.ScoringWeights = &weights
+	}
+	if b := settings.NativeLanguage; b != nil {
+		v := *b
+		copied.NativeLanguage = &v
+	}
+	if b := settings.PersonaLLMAssist; b != nil {
+		v := *b
+		copied.PersonaLLMAssist = &v
+	}
+	return &copied
+}
+
+func (s *Store) SaveProspect(prospect *models.Prospect) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	copied := *prospect
+	copied.ProfileVersion = scraper.ProfileSchemaVersion
+	s.data.Prospects[prospect.ID] = &copied
+	return s.flush()
+}
+
+func (s *Store) GetProspect(id string) (*models.Prospect, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	prospect, ok := s.data.Prospects[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *prospect
+	return &copied, nil
+}
+
+// ListProspects returns every prospect scraped by owner.
+func (s *Store) ListProspects(owner string) ([]*models.Prospect, error) {
+	return s.filterProspects(func(p *models.Prospect) bool { return key(p.Owner) == key(owner) })
+}
+
+// ListBatchProspects returns the prospects scraped as part of a batch.
+func (s *Store) ListBatchProspects(batchID string) ([]*models.Prospect, error) {
+	return s.filterProspects(func(p *models.Prospect) bool { return p.BatchID == batchID })
+}
+
+// ListAwaitingApproval returns the prospects whose message is pending approval.
+func (s *Store) ListAwaitingApproval() ([]*models.Prospect, error) {
+	return s.filterProspects(func(p *models.Prospect) bool {
+		return p.Approval != nil && p.Approval.Status == models.ApprovalPending
+	})
+}
+
+func (s *Store) filterProspects(match func(*models.Prospect) bool) ([]*models.Prospect, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	prospects := make([]*models.Prospect, 0)
+	for _, p := range s.data.Prospects {
+		if match(p) {
+			copied := *p
+			prospects = append(prospects, &copied)
+		}
+	}
+	return prospects, nil
+}
+
+func (s *Store) GetBatch(id string) (*models.Batch, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	batch, ok := s.data.Batches[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *batch
+	return &copied, nil
+}
+
+func (s *Store) SaveBatch(batch *models.Batch) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	copied := *batch
+	s.data.Batches[batch.ID] = &copied
+	return s.flush()
+}
+
+// ListUnfinishedBatches returns the batches that are still pending, running or paused.
+func (s *Store) ListUnfinishedBatches() ([]*models.Batch, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	batches := make([]*models.Batch, 0)
+	for _, b := range s.data.Batches {
+		if b.Status == models.BatchPending || b.Status == models.BatchRunning || b.Status == models.BatchPaused {
+			copied := *b
+			batches = append(batches, &copied)
+		}
+	}
+	return batches, nil
+}
+
+// SaveBatchWithOutbox saves a batch and queues outbox entries in the same write,
+// so a crash can never persist one without the other.
+func (s *Store) SaveBatchWithOutbox(batch *models.Batch, entries []*models.OutboxEntry) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	copied := *batch
+	s.data.Batches[batch.ID] = &copied
+	for _, e := range entries {
+		entry := *e
+		s.data.Outbox[e.ID] = &entry
+	}
+	return s.flush()
+}
+
+// EnqueueOutbox queues outbox entries that are not tied to another record.
+func (s *Store) EnqueueOutbox(entries []*models.OutboxEntry) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	for _, e := range entries {
+		entry := *e
+		s.data.Outbox[e.ID] = &entry
+	}
+	return s.flush()
+}
+
+// DueOutbox returns the live outbox entries whose next attempt is not after now, oldest event first.
+func (s *Store) DueOutbox(now time.Time) ([]*models.OutboxEntry, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	entries := make([]*models.OutboxEntry, 0)
+	for _, e := range s.data.Outbox {
+		if e.DeadAt.IsZero() && !e.NextAttemptAt.After(now) {
+			copied := *e
+			entries = append(entries, &copied)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Event.CreatedAt.Before(entries[j].Event.CreatedAt) })
+	return entries, nil
+}
+
+// DeadOutbox returns the outbox entries that delivery was given up on, oldest event first.
+func (s *Store) DeadOutbox() ([]*models.OutboxEntry, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	entries := make([]*models.OutboxEntry, 0)
+	for _, e := range s.data.Outbox {
+		if !e.DeadAt.IsZero() {
+			copied := *e
+			entries = append(entries, &copied)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Event.CreatedAt.Before(entries[j].Event.CreatedAt) })
+	return entries, nil
+}
+
+func (s *Store) SaveOutboxEntry(entry *models.OutboxEntry) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	if _, ok := s.data.Outbox[entry.ID]; !ok {
+		return ErrNotFound
+	}
+	copied := *entry
+	s.data.Outbox[entry.ID] = &copied
+	return s.flush()
+}
+
+func (s *Store) DeleteOutboxEntry(id string) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	if _, ok := s.data.Outbox[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.data.Outbox, id)
+	return s.flush()
+}
+
+func (s *Store) GetRegeneration(id string) (*models.Regeneration, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	regen, ok := s.data.Regens[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *regen
+	copied.Items = append([]models.RegenerationItem(nil), regen.Items...)
+	return &copied, nil
+}
+
+// ListRegenerations returns an owner's regenerations in no particular order.
+func (s *Store) ListRegenerations(owner string) ([]*models.Regeneration, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	regens := make([]*models.Regeneration, 0)
+	for _, r := range s.data.Regens {
+		if key(r.Owner) == key(owner) {
+			copied := *r
+			copied.Items = append([]models.RegenerationItem(nil), r.Items...)
+			regens = append(regens, &copied)
+		}
+	}
+	return regens, nil
+}
+
+// ListUnfinishedRegenerations returns the regenerations that are still pending or running.
+func (s *Store) ListUnfinishedRegenerations() ([]*models.Regeneration, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	regens := make([]*models.Regeneration, 0)
+	for _, r := range s.data.Regens {
+		if r.Status == models.BatchPending || r.Status == models.BatchRunning {
+			copied := *r
+			copied.Items = append([]models.RegenerationItem(nil), r.Items...)
+			regens = append(regens, &copied)
+		}
+	}
+	return regens, nil
+}
+
+func (s *Store) SaveRegeneration(regen *models.Regeneration) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	s.putRegeneration(regen)
+	return s.flush()
+}
+
+// SaveRegenerationWithProspects saves a regeneration and the prospects its messages were
+// applied to in the same write, so an item is never marked applied without its prospect.
+func (s *Store) SaveRegenerationWithProspects(regen *models.Regeneration, prospects []*models.Prospect) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	s.putRegeneration(regen)
+	for _, p := range prospects {
+		copied := *p
+		copied.ProfileVersion = scraper.ProfileSchemaVersion
+		s.data.Prospects[p.ID] = &copied
+	}
+	return s.flush()
+}
+
+func (s *Store) putRegeneration(regen *models.Regeneration) {
+	copied := *regen
+	copied.Items = append([]models.RegenerationItem(nil), regen.Items...)
+	s.data.Regens[regen.ID] = &copied
+}
+
+func (s *Store) GetICPFilter(id string) (*models.ICPFilter, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	filter, ok := s.data.ICPs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *filter
+	return &copied, nil
+}
+
+func (s *Store) ListICPFilters(owner string) ([]*models.ICPFilter, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	filters := make([]*models.ICPFilter, 0)
+	for _, f := range s.data.ICPs {
+		if key(f.Owner) == key(owner) {
+			copied := *f
+			filters = append(filters, &copied)
+		}
+	}
+	return filters, nil
+}
+
+func (s *Store) SaveICPFilter(filter *models.ICPFilter) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	copied := *filter
+	s.data.ICPs[filter.ID] = &copied
+	return s.flush()
+}
+
+func (s *Store) DeleteICPFilter(id string) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	if _, ok := s.data.ICPs[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.data.ICPs, id)
+	return s.flush()
+}
+
+func (s *Store) GetCampaign(id string) (*models.Campaign, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	campaign, ok := s.data.Campaigns[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyCampaign(campaign), nil
+}
+
+func (s *Store) ListCampaigns(owner string) ([]*models.Campaign, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	campaigns := make([]*models.Campaign, 0)
+	for _, c := range s.data.Campaigns {
+		if key(c.Owner) == key(owner) {
+			campaigns = append(campaigns, copyCampaign(c))
+		}
+	}
+	return campaigns, nil
+}
+
+func (s *Store) SaveCampaign(campaign *models.Campaign) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	s.data.Campaigns[campaign.ID] = copyCampaign(campaign)
+	return s.flush()
+}
+
+func copyCampaign(campaign *models.Campaign) *models.Campaign {
+	copied := *campaign
+	copied.Filters.Network = append([]string(nil), campaign.Filters.Network...)
+	copied.Filters.Locations = append([]string(nil), campaign.Filters.Locations...)
+	copied.Criteria.TargetTitles = append([]string(nil), campaign.Criteria.TargetTitles...)
+	copied.Sourced = append([]string(nil), campaign.Sourced...)
+	copied.BatchIDs = append([]string(nil), campaign.BatchIDs...)
+	return &copied
+}
+
+// AcquireLease gives holder the named lease, e.g. "batch:<id>", for ttl and reports whether
+// it got it: it does not while another holder's lease is unexpired. Holders call it again
+// before the lease runs out to keep it. Leases rely on the instances' clocks agreeing to
+// well within ttl.
+func (s *Store) AcquireLease(name, holder string, ttl time.Duration) (bool, error) {
+	if err := s.lock(); err != nil {
+		return false, err
+	}
+	defer s.unlock()
+	now := time.Now()
+	if l, ok := s.data.Leases[name]; ok && l.Holder != holder && l.ExpiresAt.After(now) {
+		return false, nil
+	}
+	s.data.Leases[name] = &models.Lease{Name: name, Holder: holder, ExpiresAt: now.Add(ttl)}
+	return true, s.flush()
+}
+
+// ReleaseLease gives up holder's lease early. Leases held by someone else are left alone.
+func (s *Store) ReleaseLease(name, holder string) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	if l, ok := s.data.Leases[name]; !ok || l.Holder != holder {
+		return nil
+	}
+	delete(s.data.Leases, name)
+	return s.flush()
+}
+
+// ActiveLease returns the named lease if it has not expired, ErrNotFound otherwise.
+func (s *Store) ActiveLease(name string) (*models.Lease, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	l, ok := s.data.Leases[name]
+	if !ok || !l.ExpiresAt.After(time.Now()) {
+		return nil, ErrNotFound
+	}
+	copied := *l
+	return &copied, nil
+}
+
+// SaveCooldown starts or replaces the cooldown of cooldown.Email's account.
+func (s *Store) SaveCooldown(cooldown *models.Cooldown) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	copied := *cooldown
+	s.data.Cooldowns[key(cooldown.Email)] = &copied
+	return s.flush()
+}
+
+// ActiveCooldown returns the account's cooldown if it has not ended, ErrNotFound otherwise.
+func (s *Store) ActiveCooldown(email string) (*models.Cooldown, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	c, ok := s.data.Cooldowns[key(email)]
+	if !ok || !c.Until.After(time.Now()) {
+		return nil, ErrNotFound
+	}
+	copied := *c
+	return &copied, nil
+}
+
+// DeleteCooldown ends an account's cooldown early, ErrNotFound when there was none.
+func (s *Store) DeleteCooldown(email string) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	if _, ok := s.data.Cooldowns[key(email)]; !ok {
+		return ErrNotFound
+	}
+	delete(s.data.Cooldowns, key(email))
+	return s.flush()
+}
+
+// TakeScrape counts a profile scraped by account at now against rate's caps, see
+// scraper.ScrapeCounter. Nothing is written when a cap turns it away.
+func (s *Store) TakeScrape(account string, now time.Time, rate scraper.ScrapeRate) (time.Time, error) {
+	if err := s.lock(); err != nil {
+		return time.Time{}, err
+	}
+	defer s.unlock()
+	recent := scraper.Recent(s.data.Scrapes[key(account)], now)
+	next := rate.Next(recent, now)
+	if next.After(now) {
+		return next, nil
+	}
+	s.data.Scrapes[key(account)] = append(recent, now)
+	return next, s.flush()
+}
+
+// SaveSession stores or replaces the saved session of session.Email's account.
+func (s *Store) SaveSession(session *models.Session) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	copied := *session
+	s.data.Sessions[key(session.Email)] = &copied
+	return s.flush()
+}
+
+// GetSession returns the account's saved session, ErrNotFound when there is none.
+func (s *Store) GetSession(email string) (*models.Session, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	session, ok := s.data.Sessions[key(email)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *session
+	return &copied, nil
+}
+
+// ListSessions returns every saved session, by account email.
+func (s *Store) ListSessions() ([]*models.Session, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	var sessions []*models.Session
+	for _, session := range s.data.Sessions {
+		copied := *session
+		sessions = append(sessions, &copied)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return key(sessions[i].Email) < key(sessions[j].Email) })
+	return sessions, nil
+}
+
+// DeleteSession forgets the account's saved session, ErrNotFound when there was none.
+func (s *Store) DeleteSession(email string) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	if _, ok := s.data.Sessions[key(email)]; !ok {
+		return ErrNotFound
+	}
+	delete(s.data.Sessions, key(email))
+	return s.flush()
+}
+
+// AppendAudit records an audit entry. Entries are never changed or deleted.
+func (s *Store) AppendAudit(entry *models.AuditEntry) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+	if _, ok := s.data.Audit[entry.ID]; ok {
+		return fmt.Errorf("audit entry %s already exists", entry.ID)
+	}
+	copied := *entry
+	s.data.Audit[entry.ID] = &copied
+	return s.flush()
+}
+
+// ListAudit returns the audit entries email is the actor or subject of, every entry when
+// email is empty, newest first.
+func (s *Store) ListAudit(email string) ([]*models.AuditEntry, error) {
+	if err := s.rlock(); err != nil {
+		return nil, err
+	}
+	defer s.mu.RUnlock()
+	entries := make([]*models.AuditEntry, 0)
+	for _, e := range s.data.Audit {
+		if email == "" || key(e.Actor) == key(email) || key(e.Subject) == key(email) {
+			copied := *e
+			entries = append(entries, &copied)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].At.After(entries[j].At) })
+	return entries, nil
+}
+
+// flush writes the whole store to the backend, which keeps a crash mid-write from leaving
+// a partial document behind. Callers hold the lock.
+func (s *Store) flush() error {
+	raw, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return s.b.write(raw)
+}
+
+func key(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}